@@ -0,0 +1,46 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectAbbreviation returns the configured abbreviation for a project, matched by ID, by the ID recorded for
+// the name it was configured under, or by name
+func (m model) projectAbbreviation(projectID, projectName string) (string, bool) {
+	if m.config == nil {
+		return "", false
+	}
+	if abbreviation, ok := m.config.ProjectAbbreviations[projectID]; ok {
+		return abbreviation, true
+	}
+	references := m.config.references().of(referenceProject)
+	for name, abbreviation := range m.config.ProjectAbbreviations {
+		if references[strings.ToLower(name)] == projectID {
+			return abbreviation, true
+		}
+	}
+	for name, abbreviation := range m.config.ProjectAbbreviations {
+		if _, recorded := references[strings.ToLower(name)]; !recorded && strings.EqualFold(name, projectName) {
+			return abbreviation, true
+		}
+	}
+	return "", false
+}
+
+// projectColumnText returns the project name to show in a column of the given width
+// Names that don't fit use their configured abbreviation, or are truncated otherwise
+func (m model) projectColumnText(projectID string, width int) string {
+	projectName := m.client.GetProjectName(projectID)
+	if lipgloss.Width(projectName) <= width {
+		return projectName
+	}
+	if abbreviation, ok := m.projectAbbreviation(projectID, projectName); ok && lipgloss.Width(abbreviation) <= width {
+		return abbreviation
+	}
+	if width <= 3 {
+		return projectName[:max(width, 0)]
+	}
+	return projectName[:width-3] + "..."
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// agendaStartHour and agendaEndHour bound the day laid out by the agenda, widened to fit earlier or later tasks
+const (
+	agendaStartHour = 8
+	agendaEndHour   = 18
+)
+
+// agendaMinGap is the shortest free time listed as a gap in the agenda
+const agendaMinGap = 15 * time.Minute
+
+// agendaBlock is a task with a due time, taking up its duration on the agenda
+type agendaBlock struct {
+	task  TodoistTask
+	start time.Time
+	// end equals start for tasks without a duration
+	end time.Time
+	// hasDuration is false for tasks due at a time without a duration
+	hasDuration bool
+	// overlaps names the other tasks booked during this one
+	overlaps []string
+}
+
+// agendaLoadedMsg is sent with today's timed tasks laid out for the agenda
+type agendaLoadedMsg struct {
+	blocks []agendaBlock
+	// untimed is the number of tasks due today without a time
+	untimed int
+}
+
+// taskDuration returns how long a task is estimated to take
+// Todoist counts durations in minutes or days; zero or unknown durations count as none
+func taskDuration(task TodoistTask) (time.Duration, bool) {
+	if task.Duration == nil || task.Duration.Amount <= 0 {
+		return 0, false
+	}
+	switch task.Duration.Unit {
+	case "minute":
+		return time.Duration(task.Duration.Amount) * time.Minute, true
+	case "day":
+		return time.Duration(task.Duration.Amount) * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// formatDuration writes a duration briefly, e.g. "45m", "1h", "1h30m" or "2d"
+func formatDuration(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%02dm", hours, minutes)
+	}
+}
+
+// agendaBlocks lays out the tasks due at a time on the given day in start order, noting which overlap
+// Returns the number of tasks due that day without a time too, which have no place on the agenda
+func agendaBlocks(tasks []TodoistTask, day time.Time) ([]agendaBlock, int) {
+	date := day.Format("2006-01-02")
+	var blocks []agendaBlock
+	untimed := 0
+	for _, task := range tasks {
+		start, ok := parseDueTime(task.Due)
+		if !ok {
+			if task.Due != nil && task.Due.Date == date {
+				untimed++
+			}
+			continue
+		}
+		start = start.In(todayLocation())
+		if start.Format("2006-01-02") != date {
+			continue
+		}
+		duration, hasDuration := taskDuration(task)
+		blocks = append(blocks, agendaBlock{task: task, start: start, end: start.Add(duration), hasDuration: hasDuration})
+	}
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return blocks[i].start.Before(blocks[j].start)
+	})
+
+	// Two tasks overlap when each starts before the other ends; tasks without a duration take no time
+	for i := range blocks {
+		for j := range blocks {
+			if i != j && blocks[i].start.Before(blocks[j].end) && blocks[j].start.Before(blocks[i].end) {
+				blocks[i].overlaps = append(blocks[i].overlaps, blocks[j].task.Content)
+			}
+		}
+	}
+	return blocks, untimed
+}
+
+// agendaHours returns the first and last hour the agenda shows: the working day, widened to fit the tasks
+func agendaHours(blocks []agendaBlock) (int, int) {
+	first, last := agendaStartHour, agendaEndHour
+	for _, block := range blocks {
+		first = min(first, block.start.Hour())
+		// The hour a task ends in, rounded up; tasks running past midnight fill the rest of the day
+		endHour := block.start.Hour() + 1
+		if block.end.Format("2006-01-02") != block.start.Format("2006-01-02") {
+			endHour = 24
+		} else if block.end.Hour() >= endHour {
+			endHour = block.end.Hour()
+			if block.end.Minute() > 0 {
+				endHour++
+			}
+		}
+		last = max(last, endHour)
+	}
+	return first, last
+}
+
+// agendaGaps returns the free stretches of at least agendaMinGap between from and to
+func agendaGaps(blocks []agendaBlock, from, to time.Time) [][2]time.Time {
+	var gaps [][2]time.Time
+	free := from
+	for _, block := range blocks {
+		if block.start.Sub(free) >= agendaMinGap && block.start.Before(to) {
+			gaps = append(gaps, [2]time.Time{free, block.start})
+		}
+		if block.end.After(free) {
+			free = block.end
+		}
+	}
+	if to.Sub(free) >= agendaMinGap {
+		gaps = append(gaps, [2]time.Time{free, to})
+	}
+	return gaps
+}
+
+// bookedTime returns the time taken up by the blocks, counting overlapping stretches once
+func bookedTime(blocks []agendaBlock) time.Duration {
+	var booked time.Duration
+	var covered time.Time
+	for _, block := range blocks {
+		start := block.start
+		if start.Before(covered) {
+			start = covered
+		}
+		if block.end.After(start) {
+			booked += block.end.Sub(start)
+		}
+		if block.end.After(covered) {
+			covered = block.end
+		}
+	}
+	return booked
+}
+
+// loadAgenda creates a command that lays out today's cached tasks for the agenda
+func loadAgenda(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load agenda: %w", err))
+		}
+		blocks, untimed := agendaBlocks(tasks, dayNow())
+		return agendaLoadedMsg{blocks: blocks, untimed: untimed}
+	})
+}
+
+// openAgenda shows the agenda and lays out today's tasks from the cache
+func (m *model) openAgenda() tea.Cmd {
+	m.showingAgenda = true
+	m.agenda = agendaLoadedMsg{}
+	return loadAgenda(m.cache)
+}
+
+// renderAgendaBlock writes a task's line on the agenda: its times, content, duration and project
+// A warning about overlapping tasks goes on its own line, starting with indent
+func (m model) renderAgendaBlock(block agendaBlock, indent string) string {
+	times := block.start.Format("15:04")
+	length := "no duration"
+	if block.hasDuration {
+		times += "–" + block.end.Format("15:04")
+		length = formatDuration(block.end.Sub(block.start))
+	}
+	line := fmt.Sprintf("%-11s %s (%s) ", times, block.task.Content, length) + projectStyle.Render(m.client.GetProjectName(block.task.ProjectID))
+	if len(block.overlaps) > 0 {
+		line += "\n" + indent + strings.Repeat(" ", 12) + staleStyle.MarginLeft(0).Render("⚠ overlaps "+strings.Join(block.overlaps, ", "))
+	}
+	return line
+}
+
+// renderAgenda creates the agenda screen laying out today's timed tasks by hour, with the free time between them
+func (m model) renderAgenda() string {
+	var content strings.Builder
+	today, now := dayNow(), time.Now().In(todayLocation())
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🕒 Agenda: " + today.Format("Monday, Jan 2")))
+	content.WriteString("\n\n")
+
+	blocks := m.agenda.blocks
+	first, last := agendaHours(blocks)
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+	free := lipgloss.NewStyle().Faint(true)
+
+	// One row per hour, with the tasks starting in it; the rail shows whether the hour is booked
+	next := 0
+	for hour := first; hour < last; hour++ {
+		from, to := day.Add(time.Duration(hour)*time.Hour), day.Add(time.Duration(hour+1)*time.Hour)
+		booked := false
+		for _, block := range blocks {
+			if block.start.Before(to) && block.end.After(from) || (!block.hasDuration && !block.start.Before(from) && block.start.Before(to)) {
+				booked = true
+				break
+			}
+		}
+		rail := free.Render("┊")
+		if booked {
+			rail = popupFieldStyle.Render("┃")
+		}
+		label := from.Format("15:04")
+		if hour == now.Hour() && now.Format("2006-01-02") == today.Format("2006-01-02") {
+			label = popupFieldStyle.Render(label)
+		}
+
+		started := false
+		for next < len(blocks) && blocks[next].start.Before(to) {
+			prefix := label + " " + rail + " "
+			if started {
+				prefix = "      " + rail + " "
+			}
+			content.WriteString(prefix + m.renderAgendaBlock(blocks[next], "      "+rail+" ") + "\n")
+			started = true
+			next++
+		}
+		if !started {
+			content.WriteString(label + " " + rail + "\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Summary of the day
+	dayStart, dayEnd := day.Add(time.Duration(first)*time.Hour), day.Add(time.Duration(last)*time.Hour)
+	overlaps := 0
+	for _, block := range blocks {
+		if len(block.overlaps) > 0 {
+			overlaps++
+		}
+	}
+	booked := bookedTime(blocks)
+	content.WriteString(popupFieldStyle.Render("Booked: "))
+	content.WriteString(fmt.Sprintf("%s • free %s", formatDuration(booked), formatDuration(dayEnd.Sub(dayStart)-booked)))
+	if overlaps > 0 {
+		content.WriteString(staleStyle.MarginLeft(0).Render(fmt.Sprintf(" • %d tasks overlap", overlaps)))
+	}
+	content.WriteString("\n")
+	if gaps := agendaGaps(blocks, dayStart, dayEnd); len(gaps) > 0 {
+		var spans []string
+		for _, gap := range gaps {
+			spans = append(spans, gap[0].Format("15:04")+"–"+gap[1].Format("15:04"))
+		}
+		content.WriteString(popupFieldStyle.Render("Gaps: "))
+		content.WriteString(strings.Join(spans, ", "))
+		content.WriteString("\n")
+	}
+	if m.agenda.untimed > 0 {
+		content.WriteString(projectStyle.Render(fmt.Sprintf("%d more due today without a time", m.agenda.untimed)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("ESC: close")
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleAgendaInput handles keyboard input when the agenda is visible
+func (m model) handleAgendaInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "escape" || m.keys.action(keyContextMain, msg.String()) == actionAgenda {
+		// Close the agenda, also with the key that opened it
+		m.showingAgenda = false
+	}
+	return m, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// AnnounceConfig configures how selection changes are passed on to screen readers and Braille displays
+type AnnounceConfig struct {
+	// Command is run with the selected task's summary on stdin, e.g. ["spd-say", "-e"]
+	Command []string `json:"command,omitempty"`
+	// Title sets the terminal window title to the summary, which many screen readers announce
+	Title bool `json:"title,omitempty"`
+}
+
+// enabled reports whether any announcement channel is configured
+func (a *AnnounceConfig) enabled() bool {
+	return a != nil && (len(a.Command) > 0 || a.Title)
+}
+
+// Update handles a message and announces the selected task when the selection changed
+// Keys are translated for the terminal first; in the tutorial, it also moves on to the next step once the current one is done
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer tracer.observeUpdate(msg, time.Now())
+	if key, ok := msg.(tea.KeyMsg); ok {
+		msg = m.keys.translate(key)
+	}
+	updated, cmd := m.update(msg)
+	next, ok := updated.(model)
+	if !ok {
+		return updated, cmd
+	}
+	if step := next.advanceTutorial(m, msg); step != nil {
+		cmd = tea.Batch(cmd, step)
+	}
+	if announce := next.announceSelection(); announce != nil {
+		return next, tea.Batch(cmd, announce)
+	}
+	return next, cmd
+}
+
+// selectionSummary describes the selected task in plain words, without styling or symbols
+func (m model) selectionSummary() string {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return ""
+	}
+	task := m.allTasks[m.selectedIndex]
+
+	// Priority 4 in the API is shown as P1
+	parts := []string{task.Content, fmt.Sprintf("priority %d", 5-task.Priority)}
+	if task.Due != nil {
+		due := task.Due.String
+		if due == "" {
+			due = task.Due.Date
+		}
+		if isTaskOverdue(task) {
+			parts = append(parts, "overdue "+due)
+		} else {
+			parts = append(parts, "due "+due)
+		}
+	}
+	parts = append(parts, "project "+m.client.GetProjectName(task.ProjectID))
+	if len(task.Labels) > 0 {
+		parts = append(parts, "labels "+strings.Join(task.Labels, ", "))
+	}
+	if m.completedTasks[task.ID] {
+		parts = append(parts, "completed")
+	}
+	if m.markedTasks[task.ID] {
+		parts = append(parts, "selected")
+	}
+	parts = append(parts, fmt.Sprintf("%d of %d", m.selectedIndex+1, len(m.allTasks)))
+	return strings.Join(parts, ", ")
+}
+
+// announceSelection returns a command passing the selected task's summary to the configured channels
+// Returns nil when announcements are disabled or the summary hasn't changed since the last one
+func (m *model) announceSelection() tea.Cmd {
+	if m.config == nil || !m.config.Announce.enabled() {
+		return nil
+	}
+	summary := m.selectionSummary()
+	if summary == "" || summary == m.lastAnnounced {
+		return nil
+	}
+	m.lastAnnounced = summary
+
+	var cmds []tea.Cmd
+	if m.config.Announce.Title {
+		cmds = append(cmds, tea.SetWindowTitle(summary))
+	}
+	if command := m.config.Announce.Command; len(command) > 0 {
+		cmds = append(cmds, runAnnounceCommand(command, summary))
+	}
+	return tea.Batch(cmds...)
+}
+
+// runAnnounceCommand creates a command that runs the announce command with the summary on stdin
+func runAnnounceCommand(command []string, summary string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		cmd := exec.Command(command[0], command[1:]...)
+		cmd.Stdin = strings.NewReader(summary + "\n")
+		// A failing announcer shouldn't interrupt navigation, like desktop notifications
+		_ = cmd.Run()
+		return nil
+	})
+}
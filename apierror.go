@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxErrorBodySize caps how much of an error response is read, since only its message is of interest
+const maxErrorBodySize = 64 << 10
+
+// APIError is an error response from Todoist
+// Todoist answers with a JSON body like {"error": "Invalid token", "error_tag": "AUTH_INVALID_TOKEN", "http_code": 401},
+// or just a line of text from some endpoints and proxies
+type APIError struct {
+	// Status is the HTTP status of the response, or the http_code of a failed Sync API command
+	Status int `json:"http_code"`
+	// Code is Todoist's numeric error code, 0 when none was sent
+	Code int `json:"error_code"`
+	// ErrorTag is Todoist's name for the error, e.g. "AUTH_INVALID_TOKEN", "" when none was sent
+	ErrorTag string `json:"error_tag"`
+	// Message is the human readable error message
+	Message string `json:"error"`
+	// RetryAfter is how long Todoist asked to wait before trying again, 0 when it didn't say
+	RetryAfter time.Duration `json:"-"`
+}
+
+// Error describes the error with Todoist's message and what identifies it
+func (e *APIError) Error() string {
+	var details []string
+	if e.ErrorTag != "" {
+		details = append(details, e.ErrorTag)
+	}
+	if e.Code != 0 {
+		details = append(details, fmt.Sprintf("code %d", e.Code))
+	}
+	if e.Status != 0 {
+		details = append(details, fmt.Sprintf("status %d", e.Status))
+	}
+
+	message := e.Message
+	if message == "" {
+		message = "API request failed"
+	}
+	if len(details) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+}
+
+// newAPIError reads the error response Todoist answered a request with
+// The body is read but not closed, which is left to the caller as for successful responses
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err := json.Unmarshal(body, apiErr); err != nil {
+		// Not JSON, so the body is the message itself
+		*apiErr = APIError{Message: strings.TrimSpace(string(body))}
+	}
+	// The status of the response wins over the one in the body, which describes the same failure
+	apiErr.Status = resp.StatusCode
+	if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		apiErr.RetryAfter = after
+	}
+	return apiErr
+}
+
+// Todoist error tags translated into advice by friendlyError
+const (
+	errorTagInvalidToken = "AUTH_INVALID_TOKEN"
+	errorTagRateLimited  = "LIMITS_REACHED"
+	errorTagItemNotFound = "ITEM_NOT_FOUND"
+)
+
+// friendlyError describes an error for the interface, turning the common Todoist errors into what to do about them
+// Other errors, including API errors without advice, are described as they are
+func friendlyError(err error) string {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err.Error()
+	}
+
+	switch {
+	case apiErr.ErrorTag == errorTagInvalidToken || apiErr.Status == http.StatusUnauthorized:
+		return "Todoist rejected the API token. Check TODOIST_TOKEN, or the token of your profile, and restart"
+	case apiErr.Status == http.StatusForbidden:
+		return "Todoist refused access. The token may lack permission for this, or it belongs to someone else's project"
+	case apiErr.ErrorTag == errorTagRateLimited || apiErr.Status == http.StatusTooManyRequests:
+		if apiErr.RetryAfter > 0 {
+			return fmt.Sprintf("Too many requests to Todoist. Try again in %s", apiErr.RetryAfter.Round(time.Second))
+		}
+		return "Too many requests to Todoist. Wait a minute and try again"
+	case apiErr.ErrorTag == errorTagItemNotFound || apiErr.Status == http.StatusNotFound:
+		return "Not found in Todoist: it was probably completed or deleted elsewhere. Press r to refresh"
+	case apiErr.Status >= http.StatusInternalServerError:
+		return fmt.Sprintf("Todoist is having trouble (status %d). Try again in a moment", apiErr.Status)
+	}
+	return err.Error()
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// apiURL returns the server requests are sent to instead of api.todoist.com, or "" for Todoist itself
+// TODOIST_API_URL comes first, then the active profile's api_url, then the top-level one
+func (c *Config) apiURL() string {
+	if root := os.Getenv(todoistAPIURLEnv); root != "" {
+		return root
+	}
+	if profile := c.activeProfile(); profile != nil && profile.APIURL != "" {
+		return profile.APIURL
+	}
+	if c == nil {
+		return ""
+	}
+	return c.APIURL
+}
+
+// validateAPIURL checks that a server URL is an absolute http or https URL; "" is valid and means Todoist itself
+func validateAPIURL(root string) error {
+	if root == "" {
+		return nil
+	}
+	parsed, err := url.Parse(root)
+	if err != nil {
+		return fmt.Errorf("failed to parse %q: %w", root, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q must be an http or https URL like https://todoist.example.com", root)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("%q must not have a query or fragment", root)
+	}
+	return nil
+}
+
+// validateAPIURLs checks the server URLs of TODOIST_API_URL, the config and its profiles
+func validateAPIURLs(c *Config) error {
+	if err := validateAPIURL(os.Getenv(todoistAPIURLEnv)); err != nil {
+		return fmt.Errorf("%s: %w", todoistAPIURLEnv, err)
+	}
+	if err := validateAPIURL(c.APIURL); err != nil {
+		return fmt.Errorf("api_url: %w", err)
+	}
+	for _, profile := range c.Profiles {
+		if err := validateAPIURL(profile.APIURL); err != nil {
+			return fmt.Errorf("api_url of profile %q: %w", profile.Name, err)
+		}
+	}
+	return nil
+}
+
+// setAPIURL sends the client's requests to another server, keeping Todoist's paths below it
+// An empty URL leaves the client talking to api.todoist.com
+func (c *TodoistClient) setAPIURL(root string) {
+	root = strings.TrimSuffix(root, "/")
+	if root == "" {
+		return
+	}
+	c.apiBase = root + "/rest/v2"
+	c.syncAPIBase = root + "/sync/v9"
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultRefreshInterval is how often tasks and projects are re-fetched when no interval is configured
+const defaultRefreshInterval = 5 * time.Minute
+
+// autoRefreshTickMsg is sent by the auto-refresh ticker with the time of the tick
+type autoRefreshTickMsg time.Time
+
+// parseRefreshInterval reads a refresh interval such as "5m" or "90s"; "0" or "off" disables auto-refresh
+// An empty value uses the default interval
+func parseRefreshInterval(value string) (time.Duration, error) {
+	switch value {
+	case "":
+		return defaultRefreshInterval, nil
+	case "0", "off":
+		return 0, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid refresh interval %q: %w", value, err)
+	}
+	if interval < 0 {
+		return 0, fmt.Errorf("invalid refresh interval %q: must not be negative", value)
+	}
+	if interval > 0 && interval < time.Minute {
+		// Refreshing more often would eat into the API rate limit
+		return 0, fmt.Errorf("invalid refresh interval %q: must be at least 1m", value)
+	}
+	return interval, nil
+}
+
+// scheduleAutoRefresh returns a command that fires the next auto-refresh tick, or nil when disabled
+func scheduleAutoRefresh(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return autoRefreshTickMsg(t)
+	})
+}
+
+// handleAutoRefreshTick re-fetches tasks and projects in the background and schedules the next tick
+// A refresh already in progress, or a view still loading, is left to finish instead
+func (m *model) handleAutoRefreshTick() tea.Cmd {
+	next := scheduleAutoRefresh(m.refreshInterval)
+	if m.loading || m.refreshingInBackground || m.cache == nil {
+		return next
+	}
+	m.refreshingInBackground = true
+	return tea.Batch(refreshCacheInBackground(m.requests.replace(requestGroupRefresh), m.client, m.cache), next)
+}
+
+// selectedTaskID returns the ID of the selected task, or an empty string when nothing is selected
+func (m model) selectedTaskID() string {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return ""
+	}
+	return m.allTasks[m.selectedIndex].ID
+}
+
+// restoreSelection selects a task again after the list was replaced, closing its popup if it's gone
+func (m *model) restoreSelection(taskID string) {
+	if taskID == "" {
+		return
+	}
+	for i, task := range m.allTasks {
+		if task.ID == taskID {
+			m.selectedIndex = i
+			return
+		}
+	}
+	m.showingPopup = false
+}
+
+// renderLastRefreshed shows when tasks were last fetched from Todoist, and how often that happens
+func (m model) renderLastRefreshed() string {
+	if m.lastTasksSync.IsZero() {
+		return ""
+	}
+	text := "⟳ Last refreshed " + m.lastTasksSync.Format("15:04:05")
+	if m.refreshInterval > 0 {
+		text += fmt.Sprintf(" • auto-refresh every %s", formatRefreshInterval(m.refreshInterval))
+	}
+	return loadingStyle.Render(text)
+}
+
+// formatRefreshInterval renders an interval without trailing zero units, e.g. "5m" rather than "5m0s"
+func formatRefreshInterval(interval time.Duration) string {
+	switch {
+	case interval%time.Hour == 0:
+		return fmt.Sprintf("%dh", interval/time.Hour)
+	case interval%time.Minute == 0:
+		return fmt.Sprintf("%dm", interval/time.Minute)
+	default:
+		return interval.String()
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxBulkPreview is the number of selected tasks listed in the bulk confirmation
+const maxBulkPreview = 10
+
+// Bulk actions available in visual-select mode
+const (
+	bulkComplete   = "complete"
+	bulkDelete     = "delete"
+	bulkReschedule = "reschedule"
+	bulkMove       = "move"
+	// bulkAddLabel and bulkRemoveLabel add or remove the label in bulkArg
+	bulkAddLabel    = "label"
+	bulkRemoveLabel = "unlabel"
+)
+
+// bulkAppliedMsg is sent when a bulk action has been applied to the selected tasks
+type bulkAppliedMsg struct {
+	action string
+	// count is the number of tasks the action was applied to
+	count int
+	// failed is the number of tasks Todoist refused the action for, with err the reason
+	failed int
+	err    error
+}
+
+// bulkSyncCommands builds one Sync API command per task for a bulk action
+// arg is the due string when rescheduling and the project ID when moving
+func bulkSyncCommands(action string, taskIDs []string, arg string) []syncCommand {
+	var commands []syncCommand
+	for _, id := range taskIDs {
+		switch action {
+		case bulkComplete:
+			commands = append(commands, newSyncCommand("item_close", map[string]any{"id": id}))
+		case bulkDelete:
+			commands = append(commands, newSyncCommand("item_delete", map[string]any{"id": id}))
+		case bulkReschedule:
+			commands = append(commands, newSyncCommand("item_update", map[string]any{"id": id, "due": map[string]any{"string": arg}}))
+		case bulkMove:
+			commands = append(commands, newSyncCommand("item_move", map[string]any{"id": id, "project_id": arg}))
+		}
+	}
+	return commands
+}
+
+// RemoveTasks drops tasks from the cache after they were completed or deleted
+func (c *CacheDB) RemoveTasks(taskIDs []string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, id := range taskIDs {
+		if _, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// getTasksInBatches fetches the given active tasks, at most maxSyncCommands at a time to keep the URLs short
+func getTasksInBatches(ctx context.Context, client *TodoistClient, ids []string) ([]TodoistTask, error) {
+	var tasks []TodoistTask
+	for start := 0; start < len(ids); start += maxSyncCommands {
+		batch, err := client.GetTasksByIDs(ctx, ids[start:min(start+maxSyncCommands, len(ids))])
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, batch...)
+	}
+	return tasks, nil
+}
+
+// applyBulkAction creates a command that applies a bulk action through the Sync API, as few requests as Todoist allows
+// The cache is updated afterwards for the tasks the action succeeded for, so the reloaded view shows the result
+// right away; the others are reported as failed
+func applyBulkAction(ctx context.Context, client *TodoistClient, cache *CacheDB, action string, taskIDs []string, arg string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		commands := bulkSyncCommands(action, taskIDs, arg)
+		if isBulkLabelAction(action) {
+			// Label changes replace the whole list, so start from the tasks' current labels
+			tasks, err := getTasksInBatches(ctx, client, taskIDs)
+			if err != nil {
+				return errorMsg(err)
+			}
+			commands = bulkLabelCommands(action, tasks, arg)
+		}
+
+		// Every command acts on a single task, named by its id argument
+		failedCommands, batchErr := client.runSyncCommandBatches(ctx, commands)
+		failedTasks := make(map[string]bool)
+		var reason error
+		for _, command := range commands {
+			if err := failedCommands[command.UUID]; err != nil {
+				id, _ := command.Args["id"].(string)
+				failedTasks[id] = true
+				reason = err
+			}
+		}
+		var succeeded []string
+		for _, id := range taskIDs {
+			if !failedTasks[id] {
+				succeeded = append(succeeded, id)
+			}
+		}
+		if len(succeeded) == 0 && reason != nil {
+			if batchErr != nil {
+				reason = batchErr
+			}
+			return errorMsg(fmt.Errorf("failed to %s %d task(s): %w", action, len(taskIDs), reason))
+		}
+
+		if action == bulkComplete || action == bulkDelete {
+			if err := cache.RemoveTasks(succeeded); err != nil {
+				return errorMsg(fmt.Errorf("failed to update cache: %w", err))
+			}
+		} else if len(succeeded) > 0 {
+			// Fetch the changed tasks to cache their new due date or project
+			tasks, err := getTasksInBatches(ctx, client, succeeded)
+			if err != nil {
+				return errorMsg(err)
+			}
+			for _, task := range tasks {
+				_ = cache.SaveTask(task)
+			}
+		}
+		return bulkAppliedMsg{action: action, count: len(succeeded), failed: len(failedTasks), err: reason}
+	})
+}
+
+// bulkOperations turns a bulk action into one queued operation per task, for use while offline
+func (m model) bulkOperations(action string, taskIDs []string, arg string) []pendingOperation {
+	var ops []pendingOperation
+	for _, id := range taskIDs {
+		summary := m.taskSummary(id)
+		switch action {
+		case bulkComplete:
+			ops = append(ops, newOperation(opComplete, id, summary, operationPayload{}))
+		case bulkDelete:
+			ops = append(ops, newOperation(opDelete, id, summary, operationPayload{}))
+		case bulkReschedule:
+			update := UpdateTaskRequest{DueString: arg}
+			ops = append(ops, newOperation(opUpdate, id, summary, operationPayload{Update: &update}))
+		case bulkMove:
+			ops = append(ops, newOperation(opUpdate, id, summary, operationPayload{Update: &UpdateTaskRequest{}, ProjectID: arg}))
+		case bulkAddLabel, bulkRemoveLabel:
+			task, _ := m.localTask(id)
+			if labels, changed := withLabel(task.Labels, arg, action == bulkAddLabel); changed {
+				ops = append(ops, newOperation(opUpdate, id, summary, operationPayload{Update: &UpdateTaskRequest{Labels: &labels}}))
+			}
+		}
+	}
+	return ops
+}
+
+// markedTaskIDs returns the IDs of the selected tasks in list order
+func (m model) markedTaskIDs() []string {
+	var ids []string
+	for _, task := range m.tasks {
+		if m.markedTasks[task.ID] {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}
+
+// hasMarkedTasks reports whether visual-select mode has any tasks selected
+func (m model) hasMarkedTasks() bool {
+	return m.visualMode && len(m.markedTaskIDs()) > 0
+}
+
+// exitVisualMode leaves visual-select mode and clears the selection
+func (m *model) exitVisualMode() {
+	m.visualMode = false
+	m.markedTasks = nil
+}
+
+// confirmBulk asks for confirmation before applying a bulk action to the selected tasks
+// label describes the argument, such as the new due date or project name
+func (m *model) confirmBulk(action, arg, label string) {
+	m.showingBulkConfirm = true
+	m.bulkAction = action
+	m.bulkArg = arg
+	m.bulkLabel = label
+}
+
+// renderVisualStatus shows the visual-select mode and its keys
+func (m model) renderVisualStatus() string {
+	if !m.visualMode {
+		return ""
+	}
+	return staleStyle.Render(fmt.Sprintf("▣ VISUAL — %d selected • space: toggle • e: complete • t: reschedule • p: move • @: label • y: copy • %s: delete • v/ESC: exit",
+		len(m.markedTaskIDs()), getDeleteShortcutText()))
+}
+
+// renderBulkConfirm creates the popup confirming a bulk action
+func (m model) renderBulkConfirm() string {
+	var content strings.Builder
+	ids := m.markedTaskIDs()
+
+	// Popup title describing the action
+	var title string
+	switch m.bulkAction {
+	case bulkComplete:
+		title = fmt.Sprintf("✅ Complete %d task(s)?", len(ids))
+	case bulkDelete:
+		title = fmt.Sprintf("🗑️ Delete %d task(s)?", len(ids))
+	case bulkReschedule:
+		title = fmt.Sprintf("📆 Reschedule %d task(s) to %q?", len(ids), m.bulkLabel)
+	case bulkMove:
+		title = fmt.Sprintf("📁 Move %d task(s) to %s?", len(ids), m.bulkLabel)
+	case bulkAddLabel:
+		title = fmt.Sprintf("🏷️ Add %s to %d task(s)?", m.bulkLabel, len(ids))
+	case bulkRemoveLabel:
+		title = fmt.Sprintf("🏷️ Remove %s from %d task(s)?", m.bulkLabel, len(ids))
+	}
+	content.WriteString(popupTitleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	// Selected tasks
+	for i, id := range ids {
+		if i == maxBulkPreview {
+			content.WriteString(fmt.Sprintf("…and %d more\n", len(ids)-maxBulkPreview))
+			break
+		}
+		content.WriteString("• " + m.taskSummary(id) + "\n")
+	}
+	content.WriteString("\n")
+
+	if m.bulkAction == bulkDelete {
+		content.WriteString(errorStyle.MarginLeft(0).Render("This cannot be undone."))
+		content.WriteString("\n\n")
+	}
+
+	// Instructions
+	content.WriteString("y: confirm • n/ESC: cancel")
+
+	// Calculate popup size and position
+	maxWidth := 60
+	if m.width < 70 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleBulkConfirmInput handles keyboard input when the bulk confirmation is visible
+func (m model) handleBulkConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		ids := m.markedTaskIDs()
+		action, arg := m.bulkAction, m.bulkArg
+		m.showingBulkConfirm = false
+		m.exitVisualMode()
+
+		// Queue each change while offline
+		if m.offline {
+			var cmds []tea.Cmd
+			for _, op := range m.bulkOperations(action, ids, arg) {
+				cmds = append(cmds, queueOperation(m.cache, op))
+				if action == bulkComplete || action == bulkDelete {
+					m.removeTask(op.TaskID)
+				}
+			}
+			return m, tea.Sequence(cmds...)
+		}
+		m.loading = true
+		return m, applyBulkAction(m.requests.base(), m.client, m.cache, action, ids, arg)
+	case "n", "N", "esc", "escape":
+		// Keep the selection and go back to it
+		m.showingBulkConfirm = false
+	}
+	return m, nil
+}
+
+// handleVisualInput handles keyboard input in visual-select mode
+// Keys without a bulk meaning, such as navigation, fall through to the main view
+func (m model) handleVisualInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if cmd := m.refuseInReadOnly(keyContextVisual, msg); cmd != nil {
+		return m, cmd
+	}
+	switch m.keys.action(keyContextVisual, msg.String()) {
+	case actionSelect, actionBack:
+		// Leave visual-select mode
+		m.exitVisualMode()
+		return m, nil
+	case actionMark:
+		// Toggle the selected task and move on to the next one
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			if m.markedTasks == nil {
+				m.markedTasks = make(map[string]bool)
+			}
+			id := m.allTasks[m.selectedIndex].ID
+			if m.markedTasks[id] {
+				delete(m.markedTasks, id)
+			} else {
+				m.markedTasks[id] = true
+			}
+			if m.selectedIndex < len(m.allTasks)-1 {
+				m.selectedIndex++
+				m.hScroll = 0
+			}
+		}
+		return m, nil
+	case actionComplete:
+		if m.hasMarkedTasks() {
+			m.confirmBulk(bulkComplete, "", "")
+			return m, nil
+		}
+	case actionReschedule:
+		// Pick a due date for all selected tasks
+		if m.hasMarkedTasks() {
+			m.openReschedule(TodoistTask{})
+			return m, nil
+		}
+	case actionMove:
+		// Pick a project to move all selected tasks to
+		if m.hasMarkedTasks() {
+			m.openProjectPicker()
+			m.projectPickerMove = true
+			return m, nil
+		}
+	case actionLabel:
+		// Pick a label to add to or remove from all selected tasks
+		if m.hasMarkedTasks() {
+			m.openBulkLabel()
+			return m, nil
+		}
+	case actionCopy:
+		// Copy all selected tasks, or the one under the cursor when none are selected
+		return m, m.copySelectedSnippet()
+	}
+	return m.handleMainViewInput(msg)
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// bulkLabelVisibleRows is the number of label suggestions listed in the bulk label popup
+const bulkLabelVisibleRows = 8
+
+// isBulkLabelAction reports whether a bulk action adds or removes a label
+func isBulkLabelAction(action string) bool {
+	return action == bulkAddLabel || action == bulkRemoveLabel
+}
+
+// withLabel returns a task's labels with the label added or removed, and whether that changed anything
+func withLabel(labels []string, label string, add bool) ([]string, bool) {
+	var result []string
+	found := false
+	for _, existing := range labels {
+		if strings.EqualFold(existing, label) {
+			found = true
+			if !add {
+				continue
+			}
+		}
+		result = append(result, existing)
+	}
+	if add && !found {
+		result = append(result, label)
+	}
+	if result == nil {
+		// An empty list, not null, clears all labels
+		result = []string{}
+	}
+	return result, found != add
+}
+
+// bulkLabelCommands builds one item_update per task whose labels change
+// The Sync API replaces the whole label list, so the tasks' current labels are needed
+func bulkLabelCommands(action string, tasks []TodoistTask, label string) []syncCommand {
+	var commands []syncCommand
+	for _, task := range tasks {
+		labels, changed := withLabel(task.Labels, label, action == bulkAddLabel)
+		if changed {
+			commands = append(commands, newSyncCommand("item_update", map[string]any{"id": task.ID, "labels": labels}))
+		}
+	}
+	return commands
+}
+
+// localTask returns a loaded task of the current view by ID
+func (m model) localTask(taskID string) (TodoistTask, bool) {
+	for _, task := range m.tasks {
+		if task.ID == taskID {
+			return task, true
+		}
+	}
+	return TodoistTask{}, false
+}
+
+// openBulkLabel shows the popup for adding or removing a label on the selected tasks
+func (m *model) openBulkLabel() {
+	m.showingBulkLabel = true
+	m.bulkLabelRemove = false
+	m.bulkLabelQuery = ""
+	m.bulkLabelIdx = 0
+}
+
+// bulkLabelChoices returns the labels offered in the bulk label popup, matching the query
+// Adding offers the query itself first when no label has that name; removing only offers the labels some selected task has
+func (m model) bulkLabelChoices() []TodoistLabel {
+	if !m.bulkLabelRemove {
+		matches := fuzzySearchLabels(m.labels, m.bulkLabelQuery)
+		for _, label := range matches {
+			if strings.EqualFold(label.Name, m.bulkLabelQuery) {
+				return matches
+			}
+		}
+		if m.bulkLabelQuery != "" {
+			matches = append([]TodoistLabel{{Name: m.bulkLabelQuery}}, matches...)
+		}
+		return matches
+	}
+
+	seen := make(map[string]bool)
+	var present []TodoistLabel
+	for _, id := range m.markedTaskIDs() {
+		task, _ := m.localTask(id)
+		for _, name := range task.Labels {
+			if !seen[name] {
+				seen[name] = true
+				present = append(present, TodoistLabel{Name: name})
+			}
+		}
+	}
+	sort.Slice(present, func(i, j int) bool { return present[i].Name < present[j].Name })
+	return fuzzySearchLabels(present, m.bulkLabelQuery)
+}
+
+// renderBulkLabel creates the popup for picking the label to add to or remove from the selected tasks
+func (m model) renderBulkLabel() string {
+	var content strings.Builder
+
+	// Popup title with the current mode
+	if m.bulkLabelRemove {
+		content.WriteString(popupTitleStyle.Render(fmt.Sprintf("🏷️ Remove a Label from %d Task(s)", len(m.markedTaskIDs()))))
+	} else {
+		content.WriteString(popupTitleStyle.Render(fmt.Sprintf("🏷️ Add a Label to %d Task(s)", len(m.markedTaskIDs()))))
+	}
+	content.WriteString("\n\n")
+
+	// Label query with cursor
+	content.WriteString(popupFieldStyle.Render("Label: @"))
+	content.WriteString(m.bulkLabelQuery + "│")
+	content.WriteString("\n\n")
+
+	// Show a window of matching labels around the selection
+	choices := m.bulkLabelChoices()
+	if len(choices) == 0 {
+		if m.bulkLabelRemove {
+			content.WriteString("No matching labels on the selected tasks")
+		} else {
+			content.WriteString("No labels yet, type a name")
+		}
+		content.WriteString("\n")
+	} else {
+		start := 0
+		if m.bulkLabelIdx >= bulkLabelVisibleRows {
+			start = m.bulkLabelIdx - bulkLabelVisibleRows + 1
+		}
+		end := min(start+bulkLabelVisibleRows, len(choices))
+		for i := start; i < end; i++ {
+			text := "@" + choices[i].Name
+			if choices[i].ID == "" && !m.bulkLabelRemove {
+				text += " (new)"
+			}
+			if i == m.bulkLabelIdx {
+				content.WriteString(lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor).Render("→ " + text))
+			} else {
+				content.WriteString("  " + lipgloss.NewStyle().Foreground(m.labelColor(choices[i].Name)).Render(text))
+			}
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	if m.bulkLabelRemove {
+		content.WriteString("Type: search • ↑/↓: select • Enter: remove • Tab: add instead • ESC: cancel")
+	} else {
+		content.WriteString("Type: search or new label • ↑/↓: select • Enter: add • Tab: remove instead • ESC: cancel")
+	}
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleBulkLabelInput handles keyboard input when the bulk label popup is visible
+func (m model) handleBulkLabelInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	choices := m.bulkLabelChoices()
+	switch msg.String() {
+	case "esc", "escape":
+		// Back to the selection without changing anything
+		m.showingBulkLabel = false
+	case "tab":
+		// Switch between adding and removing
+		m.bulkLabelRemove = !m.bulkLabelRemove
+		m.bulkLabelIdx = 0
+	case "up":
+		if len(choices) > 0 {
+			m.bulkLabelIdx = (m.bulkLabelIdx + len(choices) - 1) % len(choices)
+		}
+	case "down":
+		if len(choices) > 0 {
+			m.bulkLabelIdx = (m.bulkLabelIdx + 1) % len(choices)
+		}
+	case "backspace":
+		if len(m.bulkLabelQuery) > 0 {
+			m.bulkLabelQuery = m.bulkLabelQuery[:len(m.bulkLabelQuery)-1]
+			m.bulkLabelIdx = 0
+		}
+	case "enter":
+		// Use the highlighted label, which is the typed name when adding a new one
+		if m.bulkLabelIdx >= len(choices) {
+			return m, nil
+		}
+		name := choices[m.bulkLabelIdx].Name
+		m.showingBulkLabel = false
+		if m.bulkLabelRemove {
+			m.confirmBulk(bulkRemoveLabel, name, "@"+name)
+		} else {
+			m.confirmBulk(bulkAddLabel, name, "@"+name)
+		}
+	default:
+		// Label names can't contain spaces or commas
+		if len(msg.String()) == 1 && msg.String() != "\x1b" && msg.String() != " " && msg.String() != "," {
+			if msg.String() != "@" || m.bulkLabelQuery != "" {
+				m.bulkLabelQuery += msg.String()
+			}
+			m.bulkLabelIdx = 0
+		}
+	}
+	return m, nil
+}
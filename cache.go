@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"sort"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -16,16 +18,26 @@ import (
 // CacheDB handles SQLite caching for tasks and projects
 type CacheDB struct {
 	db *sql.DB
+	// tempDir is the directory of a throwaway cache, removed when it is closed
+	tempDir string
 }
 
 // cacheRefreshedMsg is sent when cache has been refreshed with new data
 type cacheRefreshedMsg struct {
 	tasks    []TodoistTask
 	projects []TodoistProject
+	labels   []TodoistLabel
+	sections []TodoistSection
+	// failed holds why each resource that couldn't be refreshed failed, keyed by resource name
+	failed map[string]error
+	// unchanged holds the resources Todoist answered with 304 Not Modified, which keep what's shown
+	unchanged map[string]bool
+	// renamed holds the projects and labels renamed since the last refresh, new names keyed by old name
+	renamed map[referenceKind]map[string]string
 }
 
-// NewCacheDB creates and initializes a new cache database
-func NewCacheDB() (*CacheDB, error) {
+// NewCacheDB creates and initializes the cache database of a profile, or the default one for ""
+func NewCacheDB(profile string) (*CacheDB, error) {
 	// Create cache directory in user's cache dir
 	cacheDir, err := os.UserCacheDir()
 	if err != nil {
@@ -42,7 +54,11 @@ func NewCacheDB() (*CacheDB, error) {
 		return nil, fmt.Errorf("failed to create cache directory: %w", err)
 	}
 
-	dbPath := filepath.Join(appCacheDir, "cache.db")
+	return openCacheDB(filepath.Join(appCacheDir, cacheFileName(profile)))
+}
+
+// openCacheDB opens the cache database at the given path, creating and migrating its tables
+func openCacheDB(dbPath string) (*CacheDB, error) {
 	db, err := sql.Open("sqlite3", dbPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
@@ -53,12 +69,19 @@ func NewCacheDB() (*CacheDB, error) {
 		_ = db.Close()
 		return nil, fmt.Errorf("failed to create tables: %w", err)
 	}
+	if err := cache.migrate(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate tables: %w", err)
+	}
 
 	return cache, nil
 }
 
 // Close closes the database connection
 func (c *CacheDB) Close() error {
+	if c.tempDir != "" {
+		defer func() { _ = os.RemoveAll(c.tempDir) }()
+	}
 	if c.db != nil {
 		return c.db.Close()
 	}
@@ -101,7 +124,119 @@ func (c *CacheDB) createTables() error {
 		updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
 	);`
 
-	for _, sql := range []string{tasksSQL, projectsSQL, metadataSQL} {
+	// Watched tasks table, holding the last seen state of each watched task
+	watchedSQL := `
+	CREATE TABLE IF NOT EXISTS watched_tasks (
+		task_id TEXT PRIMARY KEY,
+		comment_count INTEGER,
+		assignee TEXT,
+		due TEXT,
+		changed BOOLEAN DEFAULT 0,
+		watched_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Pending operations table, queueing task changes made while offline
+	pendingSQL := `
+	CREATE TABLE IF NOT EXISTS pending_operations (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		kind TEXT NOT NULL,
+		task_id TEXT NOT NULL DEFAULT '',
+		summary TEXT NOT NULL DEFAULT '',
+		payload TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL,
+		error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Parked tasks table, hiding tasks locally until a date
+	parkedSQL := `
+	CREATE TABLE IF NOT EXISTS parked_tasks (
+		task_id TEXT PRIMARY KEY,
+		content TEXT NOT NULL DEFAULT '',
+		hidden_until TEXT NOT NULL,
+		parked_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Task links table, holding both directions of each local link between tasks
+	linksSQL := `
+	CREATE TABLE IF NOT EXISTS task_links (
+		task_id TEXT NOT NULL,
+		related_id TEXT NOT NULL,
+		related_content TEXT NOT NULL DEFAULT '',
+		related_project_id TEXT NOT NULL DEFAULT '',
+		linked_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (task_id, related_id)
+	);`
+
+	// Overdue history table, one count of overdue tasks per day for the stats trend
+	overdueSQL := `
+	CREATE TABLE IF NOT EXISTS overdue_history (
+		day TEXT PRIMARY KEY,
+		count INTEGER NOT NULL
+	);`
+
+	// Completion history table, the tasks completed each day for the history screen
+	completionsSQL := `
+	CREATE TABLE IF NOT EXISTS completion_history (
+		task_id TEXT NOT NULL,
+		day TEXT NOT NULL,
+		content TEXT NOT NULL DEFAULT '',
+		project_id TEXT NOT NULL DEFAULT '',
+		completed_at TEXT NOT NULL,
+		PRIMARY KEY (task_id, day)
+	);`
+
+	// Plan history table, the tasks that were on the today list each day
+	planSQL := `
+	CREATE TABLE IF NOT EXISTS plan_history (
+		day TEXT NOT NULL,
+		task_id TEXT NOT NULL,
+		content TEXT NOT NULL DEFAULT '',
+		project_id TEXT NOT NULL DEFAULT '',
+		priority INTEGER NOT NULL DEFAULT 1,
+		PRIMARY KEY (day, task_id)
+	);`
+
+	// Imported issues table, the Jira and GitLab issues already mirrored as tasks
+	issuesSQL := `
+	CREATE TABLE IF NOT EXISTS imported_issues (
+		issue_key TEXT PRIMARY KEY,
+		task_id TEXT NOT NULL,
+		imported_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`
+
+	// Pomodoros table, the pomodoros completed for each task
+	pomodorosSQL := `
+	CREATE TABLE IF NOT EXISTS pomodoros (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id TEXT NOT NULL,
+		content TEXT NOT NULL DEFAULT '',
+		started_at TEXT NOT NULL,
+		completed_at TEXT NOT NULL
+	);`
+
+	// Time entries table, the stretches of time tracked on tasks; ended_at is empty while one runs
+	timeEntriesSQL := `
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		task_id TEXT NOT NULL,
+		content TEXT NOT NULL DEFAULT '',
+		project_id TEXT NOT NULL DEFAULT '',
+		started_at TEXT NOT NULL,
+		ended_at TEXT NOT NULL DEFAULT ''
+	);`
+
+	// Known names table, the last seen name of every project and label by ID to detect renames against
+	// It's kept apart from the cached projects so a resync doesn't forget them
+	knownNamesSQL := `
+	CREATE TABLE IF NOT EXISTS known_names (
+		kind TEXT NOT NULL,
+		id TEXT NOT NULL,
+		name TEXT NOT NULL,
+		PRIMARY KEY (kind, id)
+	);`
+
+	for _, sql := range []string{tasksSQL, projectsSQL, metadataSQL, watchedSQL, pendingSQL, parkedSQL, linksSQL, overdueSQL, completionsSQL, planSQL, issuesSQL, pomodorosSQL, timeEntriesSQL, knownNamesSQL} {
 		if _, err := c.db.Exec(sql); err != nil {
 			return err
 		}
@@ -110,6 +245,96 @@ func (c *CacheDB) createTables() error {
 	return nil
 }
 
+// migrate adds columns introduced after a table was first created
+func (c *CacheDB) migrate() error {
+	migrations := []struct {
+		table, column, definition string
+	}{
+		// Full task JSON, so fields without a dedicated column survive offline
+		{"tasks", "task_json", "TEXT"},
+		// Parent of completed subtasks, for the checklist progress of their parents
+		{"completion_history", "parent_id", "TEXT NOT NULL DEFAULT ''"},
+		// Which project is the Inbox, since it may not be named Inbox or come first
+		{"projects", "is_inbox", "INTEGER NOT NULL DEFAULT 0"},
+	}
+
+	for _, migration := range migrations {
+		exists, err := c.hasColumn(migration.table, migration.column)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if _, err := c.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s",
+			migration.table, migration.column, migration.definition)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasColumn reports whether a table already has the given column
+func (c *CacheDB) hasColumn(table, column string) (bool, error) {
+	rows, err := c.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var cid, notNull, primaryKey int
+		var name, columnType string
+		var defaultValue sql.NullString
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+
+	return false, rows.Err()
+}
+
+// taskRow returns the column values stored for a task in the tasks table
+func taskRow(task TodoistTask) []any {
+	var dueDate, dueString string
+	if task.Due != nil {
+		dueDate = task.Due.Date
+		dueString = task.Due.String
+	}
+
+	labelsJSON, _ := json.Marshal(task.Labels)
+	taskJSON, _ := json.Marshal(task)
+
+	return []any{
+		task.ID,
+		task.Content,
+		task.ProjectID,
+		task.Priority,
+		dueDate,
+		dueString,
+		task.IsCompleted,
+		string(labelsJSON),
+		task.Description,
+		task.URL,
+		task.CreatedAt.Format(time.RFC3339),
+		string(taskJSON),
+	}
+}
+
+// SaveTask adds or replaces a single task in the cache, leaving the others untouched
+func (c *CacheDB) SaveTask(task TodoistTask) error {
+	_, err := c.db.Exec(`
+		INSERT OR REPLACE INTO tasks (id, content, project_id, priority, due_date, due_string,
+			is_completed, labels, description, url, created_at, task_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, taskRow(task)...)
+	return err
+}
+
 // SaveTasks saves tasks to the cache
 func (c *CacheDB) SaveTasks(tasks []TodoistTask) error {
 	tx, err := c.db.Begin()
@@ -126,8 +351,8 @@ func (c *CacheDB) SaveTasks(tasks []TodoistTask) error {
 	// Insert new tasks
 	stmt, err := tx.Prepare(`
 		INSERT INTO tasks (id, content, project_id, priority, due_date, due_string,
-			is_completed, labels, description, url, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			is_completed, labels, description, url, created_at, task_json)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -135,28 +360,7 @@ func (c *CacheDB) SaveTasks(tasks []TodoistTask) error {
 	defer func() { _ = stmt.Close() }()
 
 	for _, task := range tasks {
-		var dueDate, dueString string
-		if task.Due != nil {
-			dueDate = task.Due.Date
-			dueString = task.Due.String
-		}
-
-		labelsJSON, _ := json.Marshal(task.Labels)
-
-		_, err := stmt.Exec(
-			task.ID,
-			task.Content,
-			task.ProjectID,
-			task.Priority,
-			dueDate,
-			dueString,
-			task.IsCompleted,
-			string(labelsJSON),
-			task.Description,
-			task.URL,
-			task.CreatedAt.Format(time.RFC3339),
-		)
-		if err != nil {
+		if _, err := stmt.Exec(taskRow(task)...); err != nil {
 			return err
 		}
 	}
@@ -186,14 +390,14 @@ func (c *CacheDB) SaveProjects(projects []TodoistProject) error {
 	}
 
 	// Insert new projects
-	stmt, err := tx.Prepare("INSERT INTO projects (id, name, color) VALUES (?, ?, ?)")
+	stmt, err := tx.Prepare("INSERT INTO projects (id, name, color, is_inbox) VALUES (?, ?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer func() { _ = stmt.Close() }()
 
 	for _, project := range projects {
-		_, err := stmt.Exec(project.ID, project.Name, project.Color)
+		_, err := stmt.Exec(project.ID, project.Name, project.Color, project.IsInboxProject)
 		if err != nil {
 			return err
 		}
@@ -214,7 +418,7 @@ func (c *CacheDB) SaveProjects(projects []TodoistProject) error {
 func (c *CacheDB) LoadTasks() ([]TodoistTask, error) {
 	rows, err := c.db.Query(`
 		SELECT id, content, project_id, priority, due_date, due_string,
-			is_completed, labels, description, url, created_at
+			is_completed, labels, description, url, created_at, task_json
 		FROM tasks
 		ORDER BY priority DESC, created_at DESC
 	`)
@@ -227,6 +431,7 @@ func (c *CacheDB) LoadTasks() ([]TodoistTask, error) {
 	for rows.Next() {
 		var task TodoistTask
 		var dueDate, dueString, labelsJSON, createdAtStr string
+		var taskJSON sql.NullString
 
 		err := rows.Scan(
 			&task.ID,
@@ -240,11 +445,21 @@ func (c *CacheDB) LoadTasks() ([]TodoistTask, error) {
 			&task.Description,
 			&task.URL,
 			&createdAtStr,
+			&taskJSON,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		// Prefer the full task JSON when available
+		if taskJSON.Valid && taskJSON.String != "" {
+			var fullTask TodoistTask
+			if err := json.Unmarshal([]byte(taskJSON.String), &fullTask); err == nil {
+				tasks = append(tasks, fullTask)
+				continue
+			}
+		}
+
 		// Parse due date if present
 		if dueDate != "" {
 			task.Due = &Due{
@@ -277,82 +492,14 @@ func (c *CacheDB) LoadTodaysTasks() ([]TodoistTask, error) {
 	if err != nil {
 		return nil, err
 	}
-	
-	// Get today's date in YYYY-MM-DD format for comparison
-	today := time.Now().Format("2006-01-02")
-	var todaysTasks []TodoistTask
-	
-	// Filter tasks to include only those due today or overdue
-	for _, task := range allTasks {
-		if task.Due != nil {
-			taskDate := task.Due.Date
-			// Include task if it's due today or overdue
-			if taskDate == today || isCacheTaskOverdue(taskDate, today) {
-				todaysTasks = append(todaysTasks, task)
-			}
-		}
-	}
-	
-	// Sort tasks with smart ordering logic (same as API)
-	sort.Slice(todaysTasks, func(i, j int) bool {
-		taskI := todaysTasks[i]
-		taskJ := todaysTasks[j]
-		
-		// Determine if each task is overdue
-		isOverdueI := isCacheTaskOverdue(taskI.Due.Date, today)
-		isOverdueJ := isCacheTaskOverdue(taskJ.Due.Date, today)
-		
-		// Prioritize overdue tasks over today's tasks
-		if isOverdueI && !isOverdueJ {
-			return true
-		}
-		if !isOverdueI && isOverdueJ {
-			return false
-		}
-		
-		// Parse dates for comparison
-		dateI, errI := time.Parse("2006-01-02", taskI.Due.Date)
-		dateJ, errJ := time.Parse("2006-01-02", taskJ.Due.Date)
-		
-		// Fallback to priority sorting if date parsing fails
-		if errI != nil || errJ != nil {
-			return taskI.Priority > taskJ.Priority
-		}
-		
-		// For overdue tasks: sort by date (oldest first)
-		if isOverdueI && isOverdueJ {
-			return dateI.Before(dateJ)
-		}
-		
-		// For today's tasks: sort by priority (higher priority first)
-		return taskI.Priority > taskJ.Priority
-	})
-	
-	return todaysTasks, nil
-}
 
-// isCacheTaskOverdue checks if a task date is before today's date (same logic as API)
-// Returns false if either date cannot be parsed
-func isCacheTaskOverdue(taskDate, today string) bool {
-	// Parse the task due date
-	taskTime, err := time.Parse("2006-01-02", taskDate)
-	if err != nil {
-		return false
-	}
-	
-	// Parse today's date
-	todayTime, err := time.Parse("2006-01-02", today)
-	if err != nil {
-		return false
-	}
-	
-	// Return true if task date is before today
-	return taskTime.Before(todayTime)
+	// Filter and sort them like tasks fetched from the API
+	return filterTodaysTasks(allTasks), nil
 }
 
 // LoadProjects loads projects from the cache
 func (c *CacheDB) LoadProjects() ([]TodoistProject, error) {
-	rows, err := c.db.Query("SELECT id, name, color FROM projects ORDER BY name")
+	rows, err := c.db.Query("SELECT id, name, color, is_inbox FROM projects ORDER BY name")
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +508,7 @@ func (c *CacheDB) LoadProjects() ([]TodoistProject, error) {
 	var projects []TodoistProject
 	for rows.Next() {
 		var project TodoistProject
-		err := rows.Scan(&project.ID, &project.Name, &project.Color)
+		err := rows.Scan(&project.ID, &project.Name, &project.Color, &project.IsInboxProject)
 		if err != nil {
 			return nil, err
 		}
@@ -393,32 +540,209 @@ func (c *CacheDB) IsStale(cacheType string, maxAge time.Duration) bool {
 	return time.Since(updatedTime) > maxAge
 }
 
-// refreshCacheInBackground refreshes both tasks and projects cache in the background
-func refreshCacheInBackground(client *TodoistClient, cache *CacheDB) tea.Cmd {
-	return tea.Cmd(func() tea.Msg {
-		// Fetch fresh data from API
-		tasks, err := client.GetTodaysTasks()
-		if err != nil {
-			return errorMsg(fmt.Errorf("failed to refresh tasks cache: %w", err))
+// LastUpdated returns when the given cache type was last refreshed
+// Returns the zero time if the cache has never been populated
+func (c *CacheDB) LastUpdated(cacheType string) time.Time {
+	var lastUpdated string
+	key := cacheType + "_last_updated"
+
+	err := c.db.QueryRow(
+		"SELECT value FROM cache_metadata WHERE key = ?",
+		key,
+	).Scan(&lastUpdated)
+	if err != nil {
+		return time.Time{}
+	}
+
+	updatedTime, err := time.Parse(time.RFC3339, lastUpdated)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return updatedTime
+}
+
+// clearSynced removes the cached tasks and projects along with what a sync relies on: the sync token,
+// the ETags and Last-Modified dates, and when tasks and projects were last updated
+// Other metadata, like when the stale sweep last reminded or the account's timezone, is kept
+func clearSynced(tx *sql.Tx) error {
+	for _, table := range []string{"tasks", "projects"} {
+		if _, err := tx.Exec("DELETE FROM " + table); err != nil {
+			return err
 		}
+	}
+	_, err := tx.Exec(
+		"DELETE FROM cache_metadata WHERE key IN (?, 'tasks_last_updated', 'projects_last_updated') OR key LIKE ? OR key LIKE ?",
+		syncTokenKey, etagKeyPrefix+"%", lastModifiedKeyPrefix+"%",
+	)
+	return err
+}
 
-		projects, err := client.GetProjects()
-		if err != nil {
-			return errorMsg(fmt.Errorf("failed to refresh projects cache: %w", err))
+// Clear removes all cached tasks and projects, and the metadata of their syncs
+func (c *CacheDB) Clear() error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := clearSynced(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReplaceAll swaps everything cached for a full sync of the tasks and the projects, in a single transaction
+// ETags are dropped as Clear does, so the next refresh fetches labels and sections unconditionally
+func (c *CacheDB) ReplaceAll(delta taskDelta, projects []TodoistProject) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := clearSynced(tx); err != nil {
+		return err
+	}
+
+	for _, task := range delta.changed {
+		if _, err := tx.Exec(`
+			INSERT INTO tasks (id, content, project_id, priority, due_date, due_string,
+				is_completed, labels, description, url, created_at, task_json)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, taskRow(task)...); err != nil {
+			return err
+		}
+	}
+	for _, project := range projects {
+		if _, err := tx.Exec("INSERT INTO projects (id, name, color, is_inbox) VALUES (?, ?, ?, ?)",
+			project.ID, project.Name, project.Color, project.IsInboxProject); err != nil {
+			return err
 		}
+	}
 
-		// Save to cache
-		if err := cache.SaveTasks(tasks); err != nil {
-			return errorMsg(fmt.Errorf("failed to save tasks to cache: %w", err))
+	// Remember the sync token and when tasks and projects were last updated
+	now := time.Now().Format(time.RFC3339)
+	for key, value := range map[string]string{
+		syncTokenKey:            delta.token,
+		"tasks_last_updated":    now,
+		"projects_last_updated": now,
+	} {
+		if _, err := tx.Exec("INSERT INTO cache_metadata (key, value) VALUES (?, ?)", key, value); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// refreshCacheInBackground refreshes the tasks, projects, labels and sections in the background
+// Each resource is fetched on its own, so whatever arrives is used even if the others fail
+func refreshCacheInBackground(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		msg := cacheRefreshedMsg{failed: make(map[string]error), unchanged: make(map[string]bool)}
+		// Projects, labels and sections are only sent again when they changed since the last refresh
+		// Their validators are saved once each was stored, so a failure to store one fetches it whole again
+		projectsCtx, projectsValidators := withConditional(ctx)
+		labelsCtx, labelsValidators := withConditional(ctx)
+		sectionsCtx, sectionsValidators := withConditional(ctx)
+
+		// Fetch the resources side by side, so the refresh takes as long as the slowest rather than all of them
+		var tasksErr, projectsErr, saveErr, labelsErr, sectionsErr error
+		var projectRenames, labelRenames map[string]string
+		var wg sync.WaitGroup
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			// Sync the changes to every active task so all views work offline
+			allTasks, err := syncTasksToCache(ctx, client, cache)
+			if err != nil {
+				tasksErr = err
+				return
+			}
+			msg.tasks = filterTodaysTasks(allTasks)
+			_ = cache.RecordOverdueCount(dayNow(), allTasks)
+			_ = cache.RecordPlan(dayNow(), msg.tasks)
+		}()
+		go func() {
+			defer wg.Done()
+			msg.projects, projectsErr = client.GetProjects(projectsCtx)
+			switch {
+			case projectsErr == nil:
+				if saveErr = cache.SaveProjects(msg.projects); saveErr == nil {
+					projectsValidators.save(cache)
+				}
+				names := make(map[string]string, len(msg.projects))
+				for _, project := range msg.projects {
+					names[project.ID] = project.Name
+				}
+				projectRenames, _ = cache.detectRenames(referenceProject, names)
+			case errors.Is(projectsErr, errNotModified):
+				// The cached projects are still current
+				_ = cache.setMetadata("projects_last_updated", time.Now().Format(time.RFC3339))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			msg.labels, labelsErr = client.GetLabels(labelsCtx)
+			if labelsErr == nil {
+				// Labels are kept in memory, so they're stored once decoded
+				labelsValidators.save(cache)
+				names := make(map[string]string, len(msg.labels))
+				for _, label := range msg.labels {
+					names[label.ID] = label.Name
+				}
+				labelRenames, _ = cache.detectRenames(referenceLabel, names)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			msg.sections, sectionsErr = client.GetSections(sectionsCtx)
+			if sectionsErr == nil {
+				sectionsValidators.save(cache)
+			}
+		}()
+		wg.Wait()
+
+		if saveErr != nil {
+			return errorMsg(fmt.Errorf("failed to save projects to cache: %w", saveErr))
+		}
+		msg.renamed = map[referenceKind]map[string]string{referenceProject: projectRenames, referenceLabel: labelRenames}
+		// Cached tasks may still carry the old names of renamed labels until Todoist sends them again
+		if len(labelRenames) > 0 {
+			_ = cache.RenameTaskLabels(labelRenames)
+			for i, task := range msg.tasks {
+				msg.tasks[i], _ = renameTaskLabels(task, labelRenames)
+			}
+		}
+		for resource, err := range map[string]*error{resourceProjects: &projectsErr, resourceLabels: &labelsErr, resourceSections: &sectionsErr} {
+			if errors.Is(*err, errNotModified) {
+				msg.unchanged[resource] = true
+				*err = nil
+			}
+		}
+		if tasksErr != nil {
+			msg.failed[resourceTasks] = fmt.Errorf("failed to refresh tasks cache: %w", tasksErr)
+		}
+		if projectsErr != nil {
+			msg.failed[resourceProjects] = fmt.Errorf("failed to refresh projects cache: %w", projectsErr)
+		}
+		if labelsErr != nil {
+			msg.failed[resourceLabels] = fmt.Errorf("failed to refresh labels: %w", labelsErr)
+		}
+		if sectionsErr != nil {
+			msg.failed[resourceSections] = fmt.Errorf("failed to refresh sections: %w", sectionsErr)
 		}
 
-		if err := cache.SaveProjects(projects); err != nil {
-			return errorMsg(fmt.Errorf("failed to save projects to cache: %w", err))
+		// A newer refresh replaced this one, so its results are out of date
+		if ctx.Err() != nil {
+			return errorMsg(ctx.Err())
 		}
 
-		return cacheRefreshedMsg{
-			tasks:    tasks,
-			projects: projects,
+		// Nothing arrived at all, which usually means Todoist can't be reached
+		if len(msg.failed) == len(refreshResources) {
+			return readErrorMsg(msg.failed[resourceTasks])
 		}
+		return msg
 	})
 }
@@ -0,0 +1,379 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Subcommands for adding tasks from anywhere without switching to the interface
+const (
+	// captureDaemonCommand keeps a client ready behind a socket, so captures are added at once
+	captureDaemonCommand = "capture-daemon"
+	// captureCommand adds a task, through the daemon when it runs, prompting for it when not given
+	captureCommand = "capture"
+)
+
+// captureProjectsMaxAge is how long the capture daemon uses its projects before fetching them again
+const captureProjectsMaxAge = 10 * time.Minute
+
+// captureTimeout bounds reading a capture from the socket and adding it, so a stuck client can't block the daemon
+const captureTimeout = 30 * time.Second
+
+// capturer adds tasks written in quick-add syntax, like "Buy milk #Groceries @errand p2 tomorrow"
+type capturer struct {
+	client *TodoistClient
+	cache  *CacheDB
+	// projects are looked up for #Project, refreshed once older than captureProjectsMaxAge
+	projects  []TodoistProject
+	fetchedAt time.Time
+}
+
+// newCapturer sets up the client and cache of the profile to capture into, like the interface does
+// A read-only config is refused, since capturing is all about adding tasks
+func newCapturer(profile string) (*capturer, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if config.isReadOnly() {
+		return nil, fmt.Errorf("can't capture tasks with read_only set in the config")
+	}
+	if err := validateProfiles(config.Profiles); err != nil {
+		return nil, fmt.Errorf("invalid profiles in config: %w", err)
+	}
+	if err := validateAPIURLs(config); err != nil {
+		return nil, fmt.Errorf("invalid API URL: %w", err)
+	}
+	if err := validateRequestHeaders(config.RequestHeaders); err != nil {
+		return nil, fmt.Errorf("invalid request_headers in config: %w", err)
+	}
+	if err := config.selectProfile(profile); err != nil {
+		return nil, err
+	}
+
+	token, err := todoistToken(config)
+	if err != nil {
+		return nil, err
+	}
+	client := NewTodoistClient(token)
+	if err := client.configure(config); err != nil {
+		return nil, err
+	}
+	// Captures made offline are queued in the cache, for the interface to send once online
+	cache, err := newCacheFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize cache: %w", err)
+	}
+
+	c := &capturer{client: client, cache: cache}
+	// Start from the cached projects, so #Project works offline
+	c.projects, _ = cache.LoadProjects()
+	return c, nil
+}
+
+// close releases the capturer's cache
+func (c *capturer) close() error {
+	return c.cache.Close()
+}
+
+// refreshProjects fetches the projects once the ones known are too old, keeping them if Todoist can't be reached
+func (c *capturer) refreshProjects(ctx context.Context) {
+	if time.Since(c.fetchedAt) < captureProjectsMaxAge {
+		return
+	}
+	projects, err := c.client.GetProjects(ctx)
+	if err != nil {
+		return
+	}
+	c.projects, c.fetchedAt = projects, time.Now()
+	_ = c.cache.SaveProjects(projects)
+}
+
+// add creates the task written in quick-add syntax, describing what was added
+// Without a project the task goes to the Inbox; while offline it's queued instead
+func (c *capturer) add(ctx context.Context, text string) (string, error) {
+	c.refreshProjects(ctx)
+	q := parseQuickAdd(text, c.projects, "", nil, time.Now())
+	if q.content == "" {
+		return "", fmt.Errorf("nothing to add")
+	}
+	request := NewTaskRequest{Content: q.content, Labels: q.labels, Priority: q.priority, DueString: q.due}
+	if q.project != nil {
+		request.ProjectID = q.project.ID
+	}
+
+	description := q.content
+	if details := q.describe(); details != "" {
+		description += " (" + details + ")"
+	}
+
+	requestID := newUUID()
+	_, err := c.client.CreateTask(withRequestID(ctx, requestID), request)
+	if isNetworkError(err) {
+		op := newOperation(opCreate, "", q.content, operationPayload{Task: &request, RequestID: requestID})
+		if err := c.cache.QueueOperation(op); err != nil {
+			return "", fmt.Errorf("failed to queue task while offline: %w", err)
+		}
+		return "Queued while offline: " + description, nil
+	}
+	if err != nil {
+		return "", errors.New(friendlyError(err))
+	}
+	return "Added " + description, nil
+}
+
+// captureSocketPath returns the socket the capture daemon of a profile listens on, next to its cache
+func captureSocketPath(profile string) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	name := strings.TrimSuffix(cacheFileName(profile), ".db") + ".capture.sock"
+	// The sockets get a directory of their own, which only the user can enter
+	return filepath.Join(cacheDir, "todoist-tui", "capture", name), nil
+}
+
+// runCaptureDaemon adds the tasks sent to its socket until interrupted, e.g. started with the desktop session
+// Each connection sends one line in quick-add syntax and gets back a line starting with "ok" or "error"
+func runCaptureDaemon(args []string) error {
+	flags := flag.NewFlagSet(captureDaemonCommand, flag.ExitOnError)
+	profileFlag := flags.String("profile", "", "Name of the configured profile (Todoist account) to add tasks to")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	c, err := newCapturer(*profileFlag)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = c.close() }()
+
+	path, err := captureSocketPath(*profileFlag)
+	if err != nil {
+		return err
+	}
+	// Only the user may add tasks to their account, so nobody else may reach the socket, not even while
+	// it's being created; the directory is tightened too in case it was created more open before
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	if err := os.Chmod(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to restrict access to %s: %w", filepath.Dir(path), err)
+	}
+	// A socket left behind by a daemon that didn't exit cleanly is removed, one still answering is not
+	if conn, err := net.Dial("unix", path); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("a capture daemon is already running on %s", path)
+	}
+	_ = os.Remove(path)
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	defer func() { _ = listener.Close() }()
+	if err := os.Chmod(path, 0600); err != nil {
+		return fmt.Errorf("failed to restrict access to %s: %w", path, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	c.refreshProjects(ctx)
+	fmt.Fprintf(os.Stderr, "Adding tasks sent to %s; run \"todoist-tui %s\" to send one, press Ctrl+C to stop\n", path, captureCommand)
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept capture: %w", err)
+		}
+		// Captures are handled one at a time, which is plenty for someone typing them
+		c.serve(ctx, conn)
+	}
+}
+
+// serve reads one capture from a connection and answers with the outcome
+func (c *capturer) serve(ctx context.Context, conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(captureTimeout))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, captureTimeout)
+	defer cancel()
+	result, err := c.add(ctx, strings.TrimSpace(line))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to add %q: %v\n", strings.TrimSpace(line), err)
+		_, _ = fmt.Fprintf(conn, "error %s\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, result)
+	_, _ = fmt.Fprintf(conn, "ok %s\n", result)
+}
+
+// sendCapture hands a capture to the running daemon, reporting net errors when none is listening
+func sendCapture(path, text string) (string, error) {
+	conn, err := net.DialTimeout("unix", path, time.Second)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(captureTimeout))
+
+	if _, err := fmt.Fprintln(conn, strings.ReplaceAll(text, "\n", " ")); err != nil {
+		return "", err
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("no answer from the capture daemon: %w", err)
+	}
+	status, result, _ := strings.Cut(strings.TrimSpace(reply), " ")
+	if status != "ok" {
+		return "", errors.New(result)
+	}
+	return result, nil
+}
+
+// runCapture adds a task given as arguments, read from stdin for "-", or typed into a one-line prompt
+// It goes through the capture daemon when one runs, and adds the task itself otherwise
+func runCapture(args []string) error {
+	flags := flag.NewFlagSet(captureCommand, flag.ExitOnError)
+	profileFlag := flags.String("profile", "", "Name of the configured profile (Todoist account) to add the task to")
+	notifyFlag := flags.Bool("notify", false, "Show the outcome as a desktop notification, e.g. when run from a hotkey without a terminal")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	text := strings.Join(flags.Args(), " ")
+	if text == "-" {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read the task from stdin: %w", err)
+		}
+		text = line
+	}
+	if strings.TrimSpace(text) == "" {
+		typed, ok, err := promptCapture()
+		if err != nil || !ok {
+			return err
+		}
+		text = typed
+	}
+
+	result, err := captureTask(*profileFlag, strings.TrimSpace(text))
+	if *notifyFlag {
+		if err != nil {
+			_ = sendDesktopNotification("Couldn't add to Todoist", err.Error())
+		} else {
+			_ = sendDesktopNotification("Todoist", result)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(result)
+	return nil
+}
+
+// captureTask adds a task through the capture daemon, or directly when no daemon is listening
+func captureTask(profile, text string) (string, error) {
+	path, err := captureSocketPath(profile)
+	if err != nil {
+		return "", err
+	}
+	result, err := sendCapture(path, text)
+	var netErr *net.OpError
+	if err == nil || !errors.As(err, &netErr) || netErr.Op != "dial" {
+		return result, err
+	}
+
+	c, err := newCapturer(profile)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = c.close() }()
+	ctx, cancel := context.WithTimeout(context.Background(), captureTimeout)
+	defer cancel()
+	return c.add(ctx, text)
+}
+
+// capturePromptModel is the one-line prompt of the capture command
+type capturePromptModel struct {
+	input     textinput.Model
+	submitted bool
+}
+
+// promptCapture asks for a task in a one-line prompt, reporting false when it was cancelled
+func promptCapture() (string, bool, error) {
+	input := textinput.New()
+	input.Placeholder = "Buy milk #Groceries @errand p2 tomorrow"
+	input.Width = 60
+	input.Focus()
+
+	final, err := tea.NewProgram(capturePromptModel{input: input}).Run()
+	if err != nil {
+		return "", false, err
+	}
+	prompt, ok := final.(capturePromptModel)
+	if !ok || !prompt.submitted || strings.TrimSpace(prompt.input.Value()) == "" {
+		return "", false, nil
+	}
+	return prompt.input.Value(), true, nil
+}
+
+// Init starts the cursor blinking
+func (m capturePromptModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update submits the task on Enter and cancels on ESC or Ctrl+C
+func (m capturePromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if key, ok := msg.(tea.KeyMsg); ok {
+		switch key.String() {
+		case "enter":
+			m.submitted = true
+			return m, tea.Quit
+		case "esc", "ctrl+c":
+			return m, tea.Quit
+		}
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// View renders the prompt
+func (m capturePromptModel) View() string {
+	if m.submitted {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(popupTitleStyle.Render("📥 Add to Todoist"))
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render("#Project @label p1-p4 and a due date at the end • Enter: add • ESC: cancel"))
+	return popupStyle.Render(b.String()) + "\n"
+}
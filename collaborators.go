@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"unicode"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// assigneeBadgeWidth is the width the assignee badge takes up before the task text, including the space after it
+const assigneeBadgeWidth = 5
+
+// assigneeBadgeColors are the Todoist colors assignee badges are drawn in, dark enough for white initials
+var assigneeBadgeColors = []string{"berry_red", "red", "orange", "olive_green", "green", "teal", "sky_blue", "blue", "grape", "violet", "magenta", "charcoal"}
+
+// TodoistCollaborator represents a person sharing a project with the user
+type TodoistCollaborator struct {
+	// ID is the user ID, as found in a task's assignee
+	ID string `json:"id"`
+	// FullName is the person's name
+	FullName string `json:"full_name"`
+	// Email is the person's email address
+	Email string `json:"email"`
+}
+
+// collaboratorActive is the state of a person currently sharing a project, as opposed to invited or removed
+const collaboratorActive = "active"
+
+// collaboratorState tells whether a person shares a project
+type collaboratorState struct {
+	// ProjectID is the shared project
+	ProjectID string `json:"project_id"`
+	// UserID is the person's user ID
+	UserID string `json:"user_id"`
+	// State is collaboratorActive while they share the project
+	State string `json:"state"`
+}
+
+// collaboratorsLoadedMsg is sent when the collaborators of the shared projects have been fetched
+// Failing to load them only hides the assignee badges and +Name assignment, so the error is kept separate
+type collaboratorsLoadedMsg struct {
+	collaborators []TodoistCollaborator
+	states        []collaboratorState
+	err           error
+}
+
+// GetCollaborators fetches the people sharing any of the user's projects through the Sync API,
+// with the states telling who shares which project
+func (c *TodoistClient) GetCollaborators(ctx context.Context) ([]TodoistCollaborator, []collaboratorState, error) {
+	// Ask only for the collaborator resources, which cover every shared project at once
+	body, err := json.Marshal(map[string]any{"sync_token": fullSyncToken, "resource_types": []string{"collaborators", "collaborator_states"}})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal sync request: %w", err)
+	}
+
+	// Create HTTP POST request for sync endpoint
+	req, err := http.NewRequestWithContext(withSyncRead(ctx), "POST", c.syncAPIBase+"/sync", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(resp)
+	}
+
+	// Parse the collaborators and who shares which project
+	var result struct {
+		Collaborators      []TodoistCollaborator `json:"collaborators"`
+		CollaboratorStates []collaboratorState   `json:"collaborator_states"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Collaborators, result.CollaboratorStates, nil
+}
+
+// loadCollaborators creates a command that fetches the collaborators in the background
+func loadCollaborators(ctx context.Context, client *TodoistClient) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		collaborators, states, err := client.GetCollaborators(ctx)
+		if err != nil {
+			return collaboratorsLoadedMsg{err: fmt.Errorf("failed to load collaborators: %w", err)}
+		}
+		return collaboratorsLoadedMsg{collaborators: collaborators, states: states}
+	})
+}
+
+// setCollaborators indexes the loaded collaborators by user ID for the assignee badges,
+// and the people actively sharing each project for +Name assignment
+func (m *model) setCollaborators(collaborators []TodoistCollaborator, states []collaboratorState) {
+	m.collaborators = make(map[string]TodoistCollaborator, len(collaborators))
+	for _, collaborator := range collaborators {
+		m.collaborators[collaborator.ID] = collaborator
+	}
+	m.projectCollaborators = make(map[string][]string)
+	for _, state := range states {
+		if state.State == collaboratorActive {
+			m.projectCollaborators[state.ProjectID] = append(m.projectCollaborators[state.ProjectID], state.UserID)
+		}
+	}
+}
+
+// collaboratorsOf returns the people actively sharing a project, none for projects that aren't shared
+func (m model) collaboratorsOf(projectID string) []TodoistCollaborator {
+	var people []TodoistCollaborator
+	for _, userID := range m.projectCollaborators[projectID] {
+		if collaborator, ok := m.collaborators[userID]; ok {
+			people = append(people, collaborator)
+		}
+	}
+	return people
+}
+
+// initials returns up to two letters naming a person: the first letters of their first and last name,
+// or of their email address when they have no name
+func initials(name, email string) string {
+	words := strings.Fields(name)
+	if len(words) == 0 {
+		words = strings.Fields(strings.Split(email, "@")[0])
+	}
+	if len(words) > 1 {
+		words = []string{words[0], words[len(words)-1]}
+	}
+
+	var letters []rune
+	for _, word := range words {
+		letters = append(letters, unicode.ToUpper([]rune(word)[0]))
+	}
+	return string(letters)
+}
+
+// assigneeInitials returns the initials of the person a task is assigned to, for its badge
+// Only tasks in shared projects have assignees; people missing from the collaborators get "?"
+// Returns false for unassigned tasks and until the collaborators have been loaded
+func (m model) assigneeInitials(task TodoistTask) (string, bool) {
+	if task.Assignee == "" || m.collaborators == nil {
+		return "", false
+	}
+	collaborator, ok := m.collaborators[task.Assignee]
+	if !ok {
+		return "?", true
+	}
+	if letters := initials(collaborator.FullName, collaborator.Email); letters != "" {
+		return letters, true
+	}
+	return "?", true
+}
+
+// assigneeColor picks the badge color of a person from their user ID
+// The same person always gets the same color, in every view and across restarts
+func assigneeColor(userID string) lipgloss.Color {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(userID))
+	name := assigneeBadgeColors[hash.Sum32()%uint32(len(assigneeBadgeColors))]
+	return lipgloss.Color(todoistColors[name])
+}
+
+// renderAssigneeBadge renders a person's initials in their color, followed by the space before the task text
+// The space takes the task text's style so selected and changed rows stay highlighted up to the badge
+func renderAssigneeBadge(userID, initials string, textStyle lipgloss.Style) string {
+	badge := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("#FFFFFF")).
+		Background(assigneeColor(userID)).
+		Width(assigneeBadgeWidth - 1).
+		Align(lipgloss.Center).
+		Render(initials)
+	return badge + textStyle.Render(" ")
+}
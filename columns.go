@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// availableColumns lists every supported table column in its default display order
+var availableColumns = []string{"priority", "age", "task", "project", "labels", "due"}
+
+// toggleableColumns lists the columns that can be shown or hidden at runtime
+// The task column is always visible
+var toggleableColumns = []string{"priority", "age", "project", "labels", "due"}
+
+// Ways a column handles content wider than the column
+const (
+	overflowWrap     = "wrap"
+	overflowTruncate = "truncate"
+)
+
+// defaultColumnOverflow is how each configurable column handles long content unless configured otherwise
+var defaultColumnOverflow = map[string]string{
+	"task":    overflowWrap,
+	"project": overflowTruncate,
+}
+
+// validateColumnOverflow checks configured overflow behaviors name a configurable column and a known behavior
+func validateColumnOverflow(overflow map[string]string) error {
+	for column, behavior := range overflow {
+		if _, ok := defaultColumnOverflow[strings.ToLower(column)]; !ok {
+			return fmt.Errorf("column %q can't be configured to wrap or truncate, only task and project can", column)
+		}
+		if behavior != overflowWrap && behavior != overflowTruncate {
+			return fmt.Errorf("invalid overflow %q for column %q, use %q or %q", behavior, column, overflowWrap, overflowTruncate)
+		}
+	}
+	return nil
+}
+
+// columnOverflow returns whether a column wraps or truncates long content
+// Compact mode keeps every row on a single line, so it truncates regardless of the config
+func (m model) columnOverflow(column string) string {
+	if m.compact {
+		return overflowTruncate
+	}
+	if m.config != nil {
+		for configured, behavior := range m.config.ColumnOverflow {
+			if strings.EqualFold(configured, column) {
+				return behavior
+			}
+		}
+	}
+	return defaultColumnOverflow[strings.ToLower(column)]
+}
+
+// isValidColumn reports whether the given column name is supported
+func isValidColumn(column string) bool {
+	for _, available := range availableColumns {
+		if strings.EqualFold(column, available) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasColumn reports whether the given column is currently displayed
+func (m model) hasColumn(column string) bool {
+	for _, col := range m.columns {
+		if strings.EqualFold(col, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// columnRank returns the position of a column in the default display order
+func columnRank(column string) int {
+	for i, available := range availableColumns {
+		if strings.EqualFold(column, available) {
+			return i
+		}
+	}
+	return len(availableColumns)
+}
+
+// toggleColumn shows or hides a column, keeping the remaining columns in their current order
+func (m *model) toggleColumn(column string) {
+	// Hide the column if it is currently shown
+	if m.hasColumn(column) {
+		var columns []string
+		for _, col := range m.columns {
+			if !strings.EqualFold(col, column) {
+				columns = append(columns, col)
+			}
+		}
+		m.columns = columns
+		return
+	}
+
+	// Otherwise insert it before the first column that comes after it by default
+	rank := columnRank(column)
+	columns := make([]string, 0, len(m.columns)+1)
+	inserted := false
+	for _, col := range m.columns {
+		if !inserted && columnRank(col) > rank {
+			columns = append(columns, column)
+			inserted = true
+		}
+		columns = append(columns, col)
+	}
+	if !inserted {
+		columns = append(columns, column)
+	}
+	m.columns = columns
+}
+
+// renderColumnMenu creates the popup for toggling columns at runtime
+func (m model) renderColumnMenu() string {
+	var content strings.Builder
+
+	// Menu title
+	content.WriteString(popupTitleStyle.Render("🧱 Columns"))
+	content.WriteString("\n\n")
+
+	// One checkbox per toggleable column
+	for i, column := range toggleableColumns {
+		checkbox := "[ ]"
+		if m.hasColumn(column) {
+			checkbox = "[x]"
+		}
+		line := checkbox + " " + column
+		if i == m.columnMenuIdx {
+			line = lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor).Render("→ " + line)
+		} else {
+			line = "  " + line
+		}
+		content.WriteString(line)
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("↑/↓: select • Space/Enter: toggle • ESC: close")
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleColumnMenuInput handles keyboard input when the column menu is visible
+func (m model) handleColumnMenuInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape", "C":
+		// Close the column menu
+		m.showingColumnMenu = false
+	case "up", "k":
+		// Move selection up with wrap around
+		if m.columnMenuIdx <= 0 {
+			m.columnMenuIdx = len(toggleableColumns) - 1
+		} else {
+			m.columnMenuIdx--
+		}
+	case "down", "j":
+		// Move selection down with wrap around
+		if m.columnMenuIdx >= len(toggleableColumns)-1 {
+			m.columnMenuIdx = 0
+		} else {
+			m.columnMenuIdx++
+		}
+	case "enter", " ":
+		// Toggle the selected column and remember the choice
+		m.toggleColumn(toggleableColumns[m.columnMenuIdx])
+		if m.config != nil {
+			m.config.Columns = m.columns
+			return m, saveConfig(*m.config)
+		}
+	}
+	return m, nil
+}
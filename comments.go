@@ -0,0 +1,315 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TodoistComment represents a comment on a Todoist task
+type TodoistComment struct {
+	// ID is the unique identifier for the comment
+	ID string `json:"id"`
+	// TaskID is the ID of the task the comment belongs to
+	TaskID string `json:"task_id"`
+	// Content is the comment text
+	Content string `json:"content"`
+	// PostedAt is when the comment was posted, in RFC3339 format
+	PostedAt string `json:"posted_at"`
+}
+
+// newCommentRequest represents the data sent to Todoist to post a comment
+type newCommentRequest struct {
+	TaskID  string `json:"task_id"`
+	Content string `json:"content"`
+}
+
+// commentsLoadedMsg is sent when a task's comments have been fetched
+type commentsLoadedMsg struct {
+	taskID   string
+	comments []TodoistComment
+	err      error
+}
+
+// commentPostedMsg is sent when a new comment has been posted, or failed to post
+type commentPostedMsg struct {
+	taskID  string
+	comment *TodoistComment
+	err     error
+}
+
+// GetComments fetches all comments on a task from Todoist, oldest first
+func (c *TodoistClient) GetComments(ctx context.Context, taskID string) ([]TodoistComment, error) {
+	// Create HTTP GET request for the task's comments
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiBase+"/comments?"+url.Values{"task_id": {taskID}}.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check for successful response
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse JSON response into comments slice
+	var comments []TodoistComment
+	if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return comments, nil
+}
+
+// CreateComment posts a new comment on a task in Todoist
+func (c *TodoistClient) CreateComment(ctx context.Context, taskID, content string) (*TodoistComment, error) {
+	// Convert the comment request to JSON
+	commentJSON, err := json.Marshal(newCommentRequest{TaskID: taskID, Content: content})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	// Create HTTP POST request for comments endpoint
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/comments", bytes.NewBuffer(commentJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse the JSON response into the created comment
+	var comment TodoistComment
+	if err := json.NewDecoder(resp.Body).Decode(&comment); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &comment, nil
+}
+
+// loadComments creates a command that fetches a task's comments
+func loadComments(ctx context.Context, client *TodoistClient, taskID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		comments, err := client.GetComments(ctx, taskID)
+		if err != nil {
+			return commentsLoadedMsg{taskID: taskID, err: fmt.Errorf("failed to load comments: %w", err)}
+		}
+		return commentsLoadedMsg{taskID: taskID, comments: comments}
+	})
+}
+
+// postComment creates a command that posts a comment on a task
+func postComment(ctx context.Context, client *TodoistClient, taskID, content string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		comment, err := client.CreateComment(ctx, taskID, content)
+		if err != nil {
+			return commentPostedMsg{taskID: taskID, err: fmt.Errorf("failed to post comment: %w", err)}
+		}
+		return commentPostedMsg{taskID: taskID, comment: comment}
+	})
+}
+
+// openComments shows the comments popup for a task and starts fetching its comments
+func (m *model) openComments(task TodoistTask) tea.Cmd {
+	m.showingComments = true
+	m.commentsTaskID = task.ID
+	m.comments = nil
+	m.commentDraft = ""
+	m.commentsError = ""
+	m.commentsScroll = 0
+	m.commentPosting = false
+	if m.offline {
+		m.commentsLoading = false
+		m.commentsError = "Comments aren't available while offline"
+		return nil
+	}
+	m.commentsLoading = true
+	return loadComments(m.requests.base(), m.client, task.ID)
+}
+
+// commentsPopupWidth is the width of the comments popup for the current terminal size
+func (m model) commentsPopupWidth() int {
+	if m.width < 80 {
+		return m.width - 10
+	}
+	return 70
+}
+
+// commentsVisibleLines is the number of comment lines that fit in the popup at once
+func (m model) commentsVisibleLines() int {
+	// Leave room for the title, task, draft, instructions and popup border
+	return max(m.height-16, 3)
+}
+
+// commentLines renders the loaded comments as wrapped lines, oldest first
+func (m model) commentLines() []string {
+	// Popup padding takes two columns on each side
+	wrap := lipgloss.NewStyle().Width(max(m.commentsPopupWidth()-6, 10))
+
+	var lines []string
+	for i, comment := range m.comments {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		posted := comment.PostedAt
+		if t, err := time.Parse(time.RFC3339, comment.PostedAt); err == nil {
+			posted = t.Local().Format("Mon Jan 2 15:04")
+		}
+		lines = append(lines, popupFieldStyle.Render(posted))
+		lines = append(lines, strings.Split(wrap.Render(comment.Content), "\n")...)
+	}
+	return lines
+}
+
+// maxCommentsScroll is the furthest the comments can be scrolled, showing the newest at the bottom
+func (m model) maxCommentsScroll() int {
+	return max(len(m.commentLines())-m.commentsVisibleLines(), 0)
+}
+
+// renderComments creates the popup listing a task's comments with a field for posting a new one
+func (m model) renderComments() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("💬 Comments"))
+	content.WriteString("\n\n")
+	content.WriteString(m.taskSummary(m.commentsTaskID))
+	content.WriteString("\n\n")
+
+	// Comments, scrolled to the current window
+	lines := m.commentLines()
+	switch {
+	case m.commentsLoading:
+		content.WriteString("Loading comments...")
+		content.WriteString("\n")
+	case len(lines) == 0 && m.commentsError == "":
+		content.WriteString("No comments yet")
+		content.WriteString("\n")
+	case len(lines) > 0:
+		start := min(m.commentsScroll, m.maxCommentsScroll())
+		end := min(start+m.commentsVisibleLines(), len(lines))
+		if start > 0 {
+			content.WriteString(loadingStyle.MarginLeft(0).Render(fmt.Sprintf("↑ %d more line(s)", start)))
+			content.WriteString("\n")
+		}
+		content.WriteString(strings.Join(lines[start:end], "\n"))
+		content.WriteString("\n")
+		if end < len(lines) {
+			content.WriteString(loadingStyle.MarginLeft(0).Render(fmt.Sprintf("↓ %d more line(s)", len(lines)-end)))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	if m.commentsError != "" {
+		content.WriteString(errorStyle.MarginLeft(0).Render(m.commentsError))
+		content.WriteString("\n\n")
+	}
+
+	// Read-only mode shows the comments without a field to write one
+	if m.config.isReadOnly() {
+		content.WriteString("↑/↓: scroll • ESC: close (read-only: comments can't be posted)")
+	} else {
+		// New comment field
+		content.WriteString(popupFieldStyle.Render("New comment: "))
+		if m.commentPosting {
+			content.WriteString(m.commentDraft + " (posting...)")
+		} else {
+			content.WriteString(m.commentDraft + "│")
+		}
+		content.WriteString("\n\n")
+
+		// Instructions
+		content.WriteString("↑/↓: scroll • type to write • Enter: post • ESC: close")
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(m.commentsPopupWidth()).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleCommentsInput handles keyboard input when the comments popup is visible
+func (m model) handleCommentsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Only scrolling and closing work in read-only mode, so no draft is written that can't be posted
+	if m.config.isReadOnly() {
+		switch msg.String() {
+		case "esc", "escape", "up", "down", "pgup", "pgdown":
+		default:
+			return m, nil
+		}
+	}
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the popup, dropping any unposted draft
+		m.showingComments = false
+	case "up":
+		// Scroll towards older comments
+		m.commentsScroll = max(min(m.commentsScroll, m.maxCommentsScroll())-1, 0)
+	case "down":
+		// Scroll towards newer comments
+		m.commentsScroll = min(m.commentsScroll+1, m.maxCommentsScroll())
+	case "pgup":
+		// Scroll a page towards older comments
+		m.commentsScroll = max(min(m.commentsScroll, m.maxCommentsScroll())-m.commentsVisibleLines(), 0)
+	case "pgdown":
+		// Scroll a page towards newer comments
+		m.commentsScroll = min(m.commentsScroll+m.commentsVisibleLines(), m.maxCommentsScroll())
+	case "backspace":
+		// Remove the last character of the draft
+		if len(m.commentDraft) > 0 && !m.commentPosting {
+			runes := []rune(m.commentDraft)
+			m.commentDraft = string(runes[:len(runes)-1])
+		}
+	case "enter":
+		// Post the draft as a new comment
+		draft := strings.TrimSpace(m.commentDraft)
+		if draft == "" || m.commentPosting {
+			return m, nil
+		}
+		if m.offline {
+			m.commentsError = "Comments can't be posted while offline"
+			return m, nil
+		}
+		m.commentPosting = true
+		m.commentsError = ""
+		return m, postComment(m.requests.base(), m.client, m.commentsTaskID, draft)
+	default:
+		// Typing goes into the draft
+		if len(msg.String()) == 1 && msg.String() != "\x1b" && !m.commentPosting {
+			m.commentDraft += msg.String()
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,59 @@
+package main
+
+// horizontalScrollStep is how many characters h/l scroll the selected task in compact mode
+const horizontalScrollStep = 8
+
+// compactTaskLine renders task content on a single line for compact mode
+// The visible window starts at offset; ellipses mark content hidden on either side
+func compactTaskLine(content string, width, offset int) string {
+	runes := []rune(content)
+
+	// Everything fits, nothing to scroll
+	if len(runes) <= width || width < 3 {
+		return content
+	}
+
+	// Clamp the offset to the scrollable range
+	maxOffset := len(runes) - width
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	visible := runes[offset : offset+width]
+
+	// Mark hidden content on the left
+	if offset > 0 {
+		visible[0] = '…'
+	}
+	// Mark hidden content on the right
+	if offset < maxOffset {
+		visible[len(visible)-1] = '…'
+	}
+
+	return string(visible)
+}
+
+// scrollSelectedTask moves the horizontal scroll window of the selected task by delta characters
+func (m *model) scrollSelectedTask(delta int) {
+	if m.columnOverflow("task") != overflowTruncate || m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return
+	}
+
+	// Work out how far the selected task can scroll
+	taskWidth := m.columnWidth("task")
+	maxOffset := len([]rune(m.allTasks[m.selectedIndex].Content)) - taskWidth
+	if maxOffset < 0 {
+		maxOffset = 0
+	}
+
+	m.hScroll += delta
+	if m.hScroll > maxOffset {
+		m.hScroll = maxOffset
+	}
+	if m.hScroll < 0 {
+		m.hScroll = 0
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleTaskCompleted drops a completed task from the list, or keeps it struck through when enabled
+// Returns a command recording the completion for the history screen
+// Completing the last open subtask of a task may offer to complete the task too
+func (m *model) handleTaskCompleted(taskID string) tea.Cmd {
+	var record tea.Cmd
+	found := false
+	for _, task := range m.allTasks {
+		if task.ID == taskID {
+			found = true
+			record = recordCompletion(m.cache, task)
+			if m.countCompletedSubtask(task) {
+				record = tea.Batch(record, m.offerParentCompletion(task))
+			}
+		}
+	}
+	// A parent completed from its dialog may not be in the list
+	if !found && m.completeParentTask.ID == taskID {
+		record = recordCompletion(m.cache, m.completeParentTask)
+	}
+
+	if !m.keepCompleted {
+		m.removeTask(taskID)
+		return record
+	}
+	if m.completedTasks == nil {
+		m.completedTasks = make(map[string]bool)
+	}
+	m.completedTasks[taskID] = true
+	return record
+}
+
+// toggleKeepCompleted switches between keeping and removing tasks completed this session
+// Turning it off drops the completed tasks still shown
+func (m *model) toggleKeepCompleted() tea.Cmd {
+	m.keepCompleted = !m.keepCompleted
+	if !m.keepCompleted {
+		for taskID := range m.completedTasks {
+			m.removeTask(taskID)
+		}
+		m.completedTasks = nil
+	}
+
+	// Remember the choice for the next start
+	if m.config != nil {
+		m.config.KeepCompleted = m.keepCompleted
+		return saveConfig(*m.config)
+	}
+	return nil
+}
+
+// renderCompletedProgress counts the tasks completed this session while they are kept visible
+func (m model) renderCompletedProgress() string {
+	if !m.keepCompleted || len(m.completedTasks) == 0 {
+		return ""
+	}
+	return loadingStyle.Render(fmt.Sprintf("✔ %d task(s) completed this session", len(m.completedTasks)))
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Config holds user preferences that persist between runs
+type Config struct {
+	// Columns is the list of table columns to display
+	Columns []string `json:"columns,omitempty"`
+	// ColumnWidths sizes columns by name: a fixed width like "20", "flex" or "flex2" for a weighted share
+	// of the leftover width, or a range like "10-30" bounding a share
+	ColumnWidths map[string]string `json:"column_widths,omitempty"`
+	// Compact shows each task on a single line instead of wrapping
+	Compact bool `json:"compact,omitempty"`
+	// NotificationRules configures reminders sent before tasks with specific labels are due
+	NotificationRules []NotificationRule `json:"notification_rules,omitempty"`
+	// Notify sends desktop notifications when tasks come due or become overdue
+	Notify *NotifyConfig `json:"notify,omitempty"`
+	// MaxUrgentTasks warns when more open tasks than this are P1; 0 disables the check
+	MaxUrgentTasks int `json:"max_urgent_tasks,omitempty"`
+	// ProjectAbbreviations maps project names or IDs to short labels used when the project column is narrow
+	ProjectAbbreviations map[string]string `json:"project_abbreviations,omitempty"`
+	// KeepCompleted keeps tasks completed during a session visible, struck through, until the next refresh
+	KeepCompleted bool `json:"keep_completed,omitempty"`
+	// CompleteParents offers to complete a task when its last open subtask is completed in the app
+	CompleteParents bool `json:"complete_parents,omitempty"`
+	// ColumnOverflow sets whether the task and project columns wrap or truncate long content
+	ColumnOverflow map[string]string `json:"column_overflow,omitempty"`
+	// Announce passes the selected task to screen readers or Braille displays when the selection changes
+	Announce *AnnounceConfig `json:"announce,omitempty"`
+	// RefreshInterval is how often tasks are re-fetched in the background, e.g. "5m"; "off" disables it
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+	// PomodoroLength is how long a pomodoro started with 'P' lasts, e.g. "25m"
+	PomodoroLength string `json:"pomodoro_length,omitempty"`
+	// StaleSweep sets which tasks the stale task sweep offers to clean up and how often it reminds of them
+	StaleSweep *StaleSweepConfig `json:"stale_sweep,omitempty"`
+	// Someday sets the label and backlog project of the someday/maybe backlog
+	Someday *SomedayConfig `json:"someday,omitempty"`
+	// MaxRetries is how many times a rate limited or failed request is retried; 0 disables retries
+	MaxRetries *int `json:"max_retries,omitempty"`
+	// FormatRules style the rows of tasks matching conditions, e.g. bold red text for tasks overdue by a week
+	FormatRules []FormatRule `json:"format_rules,omitempty"`
+	// Theme is the name of the built-in color theme (light, dark or solarized)
+	Theme string `json:"theme,omitempty"`
+	// Colors overrides individual colors of the theme
+	Colors *Theme `json:"colors,omitempty"`
+	// Timezone forces the timezone of "today": an IANA name like "Europe/Berlin", "todoist" for the account's, or "local"
+	Timezone string `json:"timezone,omitempty"`
+	// DayStartsAt is the time of day "today" begins, e.g. "4am" to keep tasks due today from turning overdue at midnight
+	DayStartsAt string `json:"day_starts_at,omitempty"`
+	// TokenCommand prints the API token, e.g. ["pass", "show", "todoist"]; TODOIST_TOKEN is the fallback
+	TokenCommand []string `json:"token_command,omitempty"`
+	// Keychain reads the API token from the OS keychain; TODOIST_TOKEN is the fallback
+	Keychain bool `json:"keychain,omitempty"`
+	// APIURL sends requests to another server instead of https://api.todoist.com, e.g. an API gateway
+	APIURL string `json:"api_url,omitempty"`
+	// RequestHeaders are added to every API request, e.g. for a corporate gateway; ${NAME} reads an environment variable
+	// and {uuid} becomes a new ID for each request
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	// GitContexts map git repositories and branches to the project or label opened when starting inside them
+	GitContexts []GitContext `json:"git_contexts,omitempty"`
+	// IssueSync imports Jira or GitLab issues as tasks in a project
+	IssueSync *IssueSyncConfig `json:"issue_sync,omitempty"`
+	// ReadLater is the read-later service tasks that are just a link are sent to
+	ReadLater *ReadLaterConfig `json:"read_later,omitempty"`
+	// SlackStatus sets a "focusing until HH:MM" Slack status while a pomodoro runs
+	SlackStatus *SlackStatusConfig `json:"slack_status,omitempty"`
+	// RequestLog is a file a line is appended to for every API request, with its status and duration
+	RequestLog string `json:"request_log,omitempty"`
+	// SessionSummary is where the summary of a session goes on exit: "" prints it, "off" drops it,
+	// and anything else is a file it's appended to
+	SessionSummary string `json:"session_summary,omitempty"`
+	// Sort is the order each view is sorted in, e.g. {"today": "priority", "project": "manual"}
+	Sort map[string]string `json:"sort,omitempty"`
+	// Keys replaces the keys of actions, e.g. {"complete": ["d"], "stats": ["g"]}; an empty list unbinds one
+	Keys map[string][]string `json:"keys,omitempty"`
+	// KeyTranslations maps keys as a terminal sends them to the keys they stand for, e.g. {"ctrl+h": "backspace"}
+	KeyTranslations map[string]string `json:"key_translations,omitempty"`
+	// Profiles are the Todoist accounts to switch between, each with its own cache
+	Profiles []Profile `json:"profiles,omitempty"`
+	// ReadOnly disables every action that changes tasks or projects, and refuses to send anything but reads
+	ReadOnly bool `json:"read_only,omitempty"`
+	// References are the IDs of the projects and labels the settings name, recorded by the app; profiles keep their own
+	References *NameReferences `json:"references,omitempty"`
+
+	// safeMode is set by --safe-mode: the defaults are used instead of the config file, which is never saved
+	safeMode bool
+	// readOnly is set by --read-only, kept apart from ReadOnly so saving the config doesn't make it stick
+	readOnly bool
+	// timezone is set by --timezone, kept apart from Timezone for the same reason
+	timezone string
+	// profile is the name of the profile the app runs as, or "" for TODOIST_TOKEN
+	profile string
+	// tokens remembers the token of each profile once read, so token commands only prompt once
+	tokens map[string]string
+}
+
+// configPath returns the location of the config file in the user's config directory
+func configPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		// Fallback to home directory
+		homeDir, homeErr := os.UserHomeDir()
+		if homeErr != nil {
+			return "", fmt.Errorf("failed to get config directory: %w", err)
+		}
+		configDir = filepath.Join(homeDir, ".config")
+	}
+
+	return filepath.Join(configDir, "todoist-tui", "config.json"), nil
+}
+
+// LoadConfig reads the config file, returning an empty config if none exists yet
+func LoadConfig() (*Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// Save writes the config file, creating its directory if needed
+func (c Config) Save() error {
+	// Leave the user's config alone while troubleshooting in safe mode
+	if c.safeMode {
+		return nil
+	}
+
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
+
+// saveConfig creates a command that persists the config in the background
+func saveConfig(config Config) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := config.Save(); err != nil {
+			return errorMsg(err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxDayCutoff is the latest the day may start; later than noon would move the day the other way
+const maxDayCutoff = 12 * time.Hour
+
+// dayCutoff is how long after midnight the day changes, set with day_starts_at
+// Until then tasks due yesterday still count as due today, for working past midnight
+var dayCutoff time.Duration
+
+// parseDayCutoff reads a day_starts_at setting, a time of day like "4am", "4:30am" or "04:00"
+// An empty setting starts the day at midnight
+func parseDayCutoff(setting string) (time.Duration, error) {
+	setting = strings.ToLower(strings.TrimSpace(setting))
+	if setting == "" {
+		return 0, nil
+	}
+	match := dueTimePattern.FindStringSubmatch(setting)
+	if match == nil || strings.TrimSpace(match[0]) != setting {
+		return 0, fmt.Errorf("invalid day_starts_at %q, use a time of day like 4am or 04:00", setting)
+	}
+	hour, minute, ok := parseClockTime(match)
+	cutoff := time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute
+	if !ok || cutoff > maxDayCutoff {
+		return 0, fmt.Errorf("invalid day_starts_at %q, the day can start at noon at the latest", setting)
+	}
+	return cutoff, nil
+}
+
+// applyDayCutoff makes "today" change at the given time after midnight instead of at midnight
+// Must be called before anything else runs
+func applyDayCutoff(cutoff time.Duration) {
+	dayCutoff = cutoff
+}
+
+// dayNow returns the current time in the timezone of "today", moved back by the day cutoff, so its date is
+// the day that's still going on
+// Use it wherever "today" decides what is due, overdue or upcoming; due strings typed by the user are still
+// resolved from the clock, like Todoist does
+func dayNow() time.Time {
+	return dayOf(time.Now())
+}
+
+// dayOf moves a moment into the timezone of "today" and back by the day cutoff, so its date is the day it
+// counts for, e.g. a task completed at 1am before a 4am cutoff counts for the day before
+func dayOf(t time.Time) time.Time {
+	return t.In(todayLocation()).Add(-dayCutoff)
+}
+
+// dayDate returns the date the given number of days after the app's today, as YYYY-MM-DD
+// Sent to Todoist instead of "today" or "tomorrow", which Todoist resolves by its clock and the account's timezone
+func dayDate(days int) string {
+	return dayNow().AddDate(0, 0, days).Format("2006-01-02")
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// duplicatePair is two open tasks in the same project with near-identical content
+type duplicatePair struct {
+	// first is the older of the two tasks
+	first  TodoistTask
+	second TodoistTask
+	// similarity scores how alike the contents are, from duplicateThreshold to 1
+	similarity float64
+}
+
+// duplicatesFoundMsg is sent with the near-duplicate pairs among the cached tasks, most alike first
+type duplicatesFoundMsg []duplicatePair
+
+// duplicateMergedMsg is sent when a duplicate has been merged into the task kept, or merging failed
+type duplicateMergedMsg struct {
+	kept    TodoistTask
+	removed TodoistTask
+	err     error
+}
+
+// findDuplicatePairs pairs up open tasks of the same project whose contents are similar enough to be duplicates
+func findDuplicatePairs(tasks []TodoistTask) []duplicatePair {
+	byProject := make(map[string][]TodoistTask)
+	for _, task := range tasks {
+		byProject[task.ProjectID] = append(byProject[task.ProjectID], task)
+	}
+
+	var pairs []duplicatePair
+	for _, projectTasks := range byProject {
+		for i := range projectTasks {
+			for j := i + 1; j < len(projectTasks); j++ {
+				score := contentSimilarity(projectTasks[i].Content, projectTasks[j].Content)
+				if score < duplicateThreshold {
+					continue
+				}
+				first, second := projectTasks[i], projectTasks[j]
+				if second.CreatedAt.Before(first.CreatedAt) {
+					first, second = second, first
+				}
+				pairs = append(pairs, duplicatePair{first: first, second: second, similarity: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].similarity != pairs[j].similarity {
+			return pairs[i].similarity > pairs[j].similarity
+		}
+		return pairs[i].first.Content < pairs[j].first.Content
+	})
+	return pairs
+}
+
+// findDuplicates creates a command that scans the cached open tasks for near-duplicates
+func findDuplicates(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to scan for duplicates: %w", err))
+		}
+		return duplicatesFoundMsg(findDuplicatePairs(tasks))
+	})
+}
+
+// mergeComment is the comment keeping a removed duplicate's description on the task kept
+func mergeComment(removed TodoistTask) string {
+	return fmt.Sprintf("Merged from duplicate \"%s\":\n\n%s", removed.Content, removed.Description)
+}
+
+// mergeDuplicate creates a command that merges a duplicate into the task kept
+// The duplicate's description is posted as a comment first, so nothing is lost if deleting fails
+func mergeDuplicate(ctx context.Context, client *TodoistClient, cache *CacheDB, kept, removed TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if strings.TrimSpace(removed.Description) != "" {
+			if _, err := client.CreateComment(ctx, kept.ID, mergeComment(removed)); err != nil {
+				return duplicateMergedMsg{kept: kept, removed: removed, err: fmt.Errorf("failed to merge duplicate: %w", err)}
+			}
+		}
+		if err := client.DeleteTask(ctx, removed.ID); err != nil {
+			return duplicateMergedMsg{kept: kept, removed: removed, err: fmt.Errorf("failed to delete duplicate: %w", err)}
+		}
+		// Drop it from the cache right away, so another scan doesn't find it again
+		_ = cache.RemoveTasks([]string{removed.ID})
+		return duplicateMergedMsg{kept: kept, removed: removed}
+	})
+}
+
+// openDuplicates shows the merge assistant and scans the cache for duplicates
+func (m *model) openDuplicates() tea.Cmd {
+	m.showingDuplicates = true
+	m.duplicatePairs = nil
+	m.duplicateIndex = 0
+	m.duplicatesScanned = false
+	m.duplicateMerging = false
+	return findDuplicates(m.cache)
+}
+
+// handleDuplicatesFound shows the pairs found by the scan
+func (m *model) handleDuplicatesFound(msg duplicatesFoundMsg) {
+	m.duplicatePairs = msg
+	m.duplicateIndex = 0
+	m.duplicatesScanned = true
+}
+
+// handleDuplicateMerged drops the merged duplicate from the list and from every pair it was in
+// A failed merge leaves the pair shown, to try again or skip
+func (m *model) handleDuplicateMerged(msg duplicateMergedMsg) tea.Cmd {
+	m.duplicateMerging = false
+	if msg.err != nil {
+		return m.notify(toastError, msg.err.Error())
+	}
+	m.removeTask(msg.removed.ID)
+
+	var pairs []duplicatePair
+	for i, pair := range m.duplicatePairs {
+		if pair.first.ID == msg.removed.ID || pair.second.ID == msg.removed.ID {
+			// Keep the position among the pairs left
+			if i < m.duplicateIndex {
+				m.duplicateIndex--
+			}
+			continue
+		}
+		pairs = append(pairs, pair)
+	}
+	m.duplicatePairs = pairs
+	return m.notify(toastSuccess, fmt.Sprintf("Merged \"%s\" into \"%s\"", msg.removed.Content, msg.kept.Content))
+}
+
+// renderDuplicateTask writes one task of a pair with what sets it apart from the other
+func (m model) renderDuplicateTask(number int, task TodoistTask) string {
+	var b strings.Builder
+	priority := lipgloss.NewStyle().Foreground(priorityColors[task.Priority]).Render(getPriorityText(task.Priority))
+	b.WriteString(fmt.Sprintf("%s %s %s\n", popupFieldStyle.Render(fmt.Sprintf("%d:", number)), priority, task.Content))
+
+	var details []string
+	if task.Due != nil {
+		details = append(details, "due "+task.Due.Date)
+	}
+	if len(task.Labels) > 0 {
+		details = append(details, "@"+strings.Join(task.Labels, " @"))
+	}
+	if !task.CreatedAt.IsZero() {
+		details = append(details, "created "+task.CreatedAt.Local().Format("Jan 2 2006"))
+	}
+	if task.CommentCount > 0 {
+		details = append(details, fmt.Sprintf("%d comment(s)", task.CommentCount))
+	}
+	if len(details) > 0 {
+		b.WriteString("   " + projectStyle.MarginLeft(0).Render(strings.Join(details, " • ")) + "\n")
+	}
+	if description := strings.TrimSpace(task.Description); description != "" {
+		firstLine, _, _ := strings.Cut(description, "\n")
+		b.WriteString("   " + compactTaskLine(firstLine, 60, 0) + "\n")
+	}
+	return b.String()
+}
+
+// renderDuplicates creates the merge assistant screen, walking through the pairs one at a time
+func (m model) renderDuplicates() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🧹 Merge Duplicates"))
+	content.WriteString("\n\n")
+
+	switch {
+	case !m.duplicatesScanned:
+		content.WriteString(loadingStyle.MarginLeft(0).Render("Scanning open tasks..."))
+		content.WriteString("\n\n")
+		content.WriteString("ESC: close")
+	case m.duplicateIndex >= len(m.duplicatePairs):
+		if len(m.duplicatePairs) == 0 {
+			content.WriteString("No near-duplicate open tasks found")
+		} else {
+			content.WriteString("No more pairs to review")
+		}
+		content.WriteString("\n\n")
+		content.WriteString("ESC: close")
+	default:
+		pair := m.duplicatePairs[m.duplicateIndex]
+		content.WriteString(fmt.Sprintf("Pair %d of %d • %.0f%% alike • in %s", m.duplicateIndex+1, len(m.duplicatePairs),
+			pair.similarity*100, m.client.GetProjectName(pair.first.ProjectID)))
+		content.WriteString("\n\n")
+		content.WriteString(m.renderDuplicateTask(1, pair.first))
+		content.WriteString("\n")
+		content.WriteString(m.renderDuplicateTask(2, pair.second))
+		content.WriteString("\n")
+		if m.duplicateMerging {
+			content.WriteString(loadingStyle.MarginLeft(0).Render("Merging..."))
+		} else {
+			content.WriteString("Keeping one adds the other's description to it as a comment and deletes the other")
+		}
+		content.WriteString("\n\n")
+		content.WriteString("1: keep 1 • 2: keep 2 • n: skip • p: previous • ESC: close")
+	}
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleDuplicatesInput handles keyboard input when the merge assistant is visible
+func (m model) handleDuplicatesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" || key == "escape" || m.keys.action(keyContextMain, key) == actionDuplicates {
+		// Close the merge assistant
+		m.showingDuplicates = false
+		return m, nil
+	}
+	// Wait for a merge to finish before moving on
+	if m.duplicateMerging || m.duplicateIndex >= len(m.duplicatePairs) {
+		if key == "p" && !m.duplicateMerging && m.duplicateIndex > 0 {
+			m.duplicateIndex--
+		}
+		return m, nil
+	}
+
+	pair := m.duplicatePairs[m.duplicateIndex]
+	switch key {
+	case "1":
+		m.duplicateMerging = true
+		return m, mergeDuplicate(m.requests.base(), m.client, m.cache, pair.first, pair.second)
+	case "2":
+		m.duplicateMerging = true
+		return m, mergeDuplicate(m.requests.base(), m.client, m.cache, pair.second, pair.first)
+	case "n", "s", "right", "l":
+		// Leave both tasks as they are
+		m.duplicateIndex++
+	case "p", "left", "h":
+		if m.duplicateIndex > 0 {
+			m.duplicateIndex--
+		}
+	}
+	return m, nil
+}
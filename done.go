@@ -0,0 +1,280 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doneDays is how many days back the done screen lists completed tasks
+const doneDays = 7
+
+// doneLimit is the most completed tasks fetched for the done screen, the Sync API's maximum
+const doneLimit = 200
+
+// doneVisibleRows is the number of completed tasks listed at once on the done screen
+const doneVisibleRows = 15
+
+// TodoistCompletedTask represents a completion from the Sync API's completed tasks
+type TodoistCompletedTask struct {
+	// TaskID is the ID of the completed task, used to reopen it
+	TaskID string `json:"task_id"`
+	// Content is the task title
+	Content string `json:"content"`
+	// ProjectID is the ID of the project the task was in
+	ProjectID string `json:"project_id"`
+	// SectionID is the ID of the section the task was in, if any
+	SectionID string `json:"section_id"`
+	// CompletedAt is when the task was completed
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+// completedTasksLoadedMsg is sent when the recently completed tasks have been fetched for the done screen
+type completedTasksLoadedMsg struct {
+	tasks []TodoistCompletedTask
+	err   error
+}
+
+// taskReopenedMsg is sent when a completed task has been reopened
+type taskReopenedMsg struct {
+	taskID  string
+	content string
+}
+
+// GetCompletedTasks fetches the tasks completed since the given time, newest first
+func (c *TodoistClient) GetCompletedTasks(ctx context.Context, since time.Time) ([]TodoistCompletedTask, error) {
+	// The Sync API takes times in UTC without a zone
+	query := url.Values{}
+	query.Set("since", since.UTC().Format("2006-01-02T15:04:05"))
+	query.Set("limit", strconv.Itoa(doneLimit))
+
+	// Create HTTP GET request for the completed tasks endpoint
+	req, err := http.NewRequestWithContext(ctx, "GET", c.syncAPIBase+"/completed/get_all?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse the completed items
+	var result struct {
+		Items []TodoistCompletedTask `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Items, nil
+}
+
+// ReopenTask marks a completed task as not completed, bringing it back to the active tasks
+func (c *TodoistClient) ReopenTask(ctx context.Context, taskID string) error {
+	// Create HTTP POST request for task reopen endpoint
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/tasks/"+taskID+"/reopen", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return newAPIError(resp)
+	}
+
+	return nil
+}
+
+// loadCompletedTasks creates a command that fetches the tasks completed in the last doneDays days
+func loadCompletedTasks(ctx context.Context, client *TodoistClient) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := client.GetCompletedTasks(ctx, time.Now().AddDate(0, 0, -doneDays))
+		if err != nil {
+			return completedTasksLoadedMsg{err: fmt.Errorf("failed to load completed tasks: %w", err)}
+		}
+		return completedTasksLoadedMsg{tasks: tasks}
+	})
+}
+
+// reopenTask creates a command that reopens a completed task
+func reopenTask(ctx context.Context, client *TodoistClient, task TodoistCompletedTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := client.ReopenTask(ctx, task.TaskID); err != nil {
+			return errorMsg(fmt.Errorf("failed to reopen task: %w", err))
+		}
+		return taskReopenedMsg{taskID: task.TaskID, content: task.Content}
+	})
+}
+
+// openDone shows the done screen and fetches the recently completed tasks
+func (m *model) openDone() tea.Cmd {
+	m.showingDone = true
+	m.doneTasks = nil
+	m.doneIdx = 0
+	m.doneError = ""
+	if m.offline {
+		m.doneLoading = false
+		m.doneError = "Completed tasks aren't available while offline"
+		return nil
+	}
+	m.doneLoading = true
+	return loadCompletedTasks(m.requests.base(), m.client)
+}
+
+// handleTaskReopened drops a reopened task from the done screen and reloads the list it returns to
+func (m *model) handleTaskReopened(msg taskReopenedMsg) tea.Cmd {
+	var remaining []TodoistCompletedTask
+	for _, task := range m.doneTasks {
+		if task.TaskID != msg.taskID {
+			remaining = append(remaining, task)
+		}
+	}
+	m.doneTasks = remaining
+	if m.doneIdx >= len(m.doneTasks) && m.doneIdx > 0 {
+		m.doneIdx = len(m.doneTasks) - 1
+	}
+
+	// A task completed this session is no longer struck through
+	delete(m.completedTasks, msg.taskID)
+	return tea.Batch(m.notify(toastSuccess, "Task reopened: "+msg.content), m.reloadCurrentView())
+}
+
+// completionDay names the day a task was completed on, as heading its group on the done screen
+// now is the app's today, from dayNow
+func completionDay(completedAt, now time.Time) string {
+	day := dayOf(completedAt).Format("2006-01-02")
+	switch day {
+	case now.Format("2006-01-02"):
+		return "Today"
+	case now.AddDate(0, 0, -1).Format("2006-01-02"):
+		return "Yesterday"
+	default:
+		return dayOf(completedAt).Format("Monday, Jan 2")
+	}
+}
+
+// renderDone creates the done screen listing the recently completed tasks, grouped by the day they were completed
+func (m model) renderDone() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render(fmt.Sprintf("✅ Done: Last %d Days", doneDays)))
+	content.WriteString("\n\n")
+
+	switch {
+	case m.doneLoading:
+		content.WriteString("Loading completed tasks...")
+		content.WriteString("\n")
+	case m.doneError != "":
+		content.WriteString(errorStyle.MarginLeft(0).Render(m.doneError))
+		content.WriteString("\n")
+	case len(m.doneTasks) == 0:
+		content.WriteString("Nothing completed")
+		content.WriteString("\n")
+	default:
+		// Show a window of tasks around the selection, heading each day's group
+		start := 0
+		if m.doneIdx >= doneVisibleRows {
+			start = m.doneIdx - doneVisibleRows + 1
+		}
+		end := min(start+doneVisibleRows, len(m.doneTasks))
+		now := dayNow()
+		selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+		lastDay := ""
+		for i := start; i < end; i++ {
+			task := m.doneTasks[i]
+			if day := completionDay(task.CompletedAt, now); day != lastDay {
+				content.WriteString(popupFieldStyle.Render(day + ":"))
+				content.WriteString("\n")
+				lastDay = day
+			}
+			line := fmt.Sprintf("%s ✔ %s", task.CompletedAt.In(todayLocation()).Format("15:04"), task.Content)
+			if i == m.doneIdx {
+				content.WriteString(selected.Render("→ " + line))
+			} else {
+				content.WriteString("  " + line + " ")
+				content.WriteString(projectStyle.Render(m.client.GetProjectName(task.ProjectID)))
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString(fmt.Sprintf("\n(%d/%d)\n", m.doneIdx+1, len(m.doneTasks)))
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	if m.config.isReadOnly() {
+		content.WriteString("↑/↓: select • ESC: close")
+	} else {
+		content.WriteString("↑/↓: select • u: reopen • ESC: close")
+	}
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleDoneInput handles keyboard input when the done screen is visible
+func (m model) handleDoneInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.keys.action(keyContextMain, msg.String()) == actionDone {
+		// The key that opened the screen closes it
+		m.showingDone = false
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the done screen
+		m.showingDone = false
+	case "up", "k":
+		// Move selection up
+		if m.doneIdx > 0 {
+			m.doneIdx--
+		}
+	case "down", "j":
+		// Move selection down
+		if m.doneIdx < len(m.doneTasks)-1 {
+			m.doneIdx++
+		}
+	case "u":
+		// Reopen the selected task, which returns it to the lists it belongs in
+		if m.config.isReadOnly() {
+			return m, m.notify(toastInfo, readOnlyNotice)
+		}
+		if m.doneIdx >= 0 && m.doneIdx < len(m.doneTasks) {
+			return m, reopenTask(m.requests.base(), m.client, m.doneTasks[m.doneIdx])
+		}
+	}
+	return m, nil
+}
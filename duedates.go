@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dueTimePattern matches a time of day at the end of a due string, e.g. "5pm", "at 5:30pm" or "17:00"
+// A bare number isn't a time, so "jan 5" keeps its day
+var dueTimePattern = regexp.MustCompile(`(?:^|\s)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)$|(?:^|\s)(?:at\s+)?(\d{1,2}):(\d{2})$`)
+
+// dueOffsetPattern matches relative dates like "in 3 days", "2 weeks" or "in a month"
+var dueOffsetPattern = regexp.MustCompile(`^(?:in\s+)?(\d+|a|an)\s+(day|week|month|year)s?$`)
+
+// dueOrdinalSuffix matches the suffix of ordinal day numbers like "1st" or "23rd"
+var dueOrdinalSuffix = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)$`)
+
+// dueWeekdays maps weekday names and their common abbreviations to weekdays
+var dueWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// dueMonths maps month names and their abbreviations to months
+var dueMonths = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// duePreview is the date a due string resolves to, as shown under the deadline field
+type duePreview struct {
+	date      time.Time // Resolved day, including the time of day when hasTime is set
+	hasTime   bool      // Whether the string named a time of day
+	recurring bool      // Whether the string repeats, in which case date is the first occurrence
+	none      bool      // Whether the string clears the due date
+}
+
+// parseDueString resolves the common forms of Todoist's due date language locally
+// It understands today/tomorrow, weekdays, "next week", "in 3 days", dates like "jan 5" or 2026-01-05,
+// a trailing time like "5pm" and "every ..." recurrences. Todoist understands more than this,
+// so a string that doesn't parse here may still be accepted when the task is saved
+func parseDueString(input string, now time.Time) (duePreview, bool) {
+	s := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch s {
+	case "no date", "no due date":
+		return duePreview{none: true}, true
+	}
+
+	// Split off a trailing time of day
+	var preview duePreview
+	var hour, minute int
+	if match := dueTimePattern.FindStringSubmatch(s); match != nil {
+		var ok bool
+		if hour, minute, ok = parseClockTime(match); !ok {
+			return duePreview{}, false
+		}
+		preview.hasTime = true
+		s = strings.TrimSpace(s[:len(s)-len(match[0])])
+	}
+
+	// Recurring strings are previewed as their first occurrence
+	var date time.Time
+	var ok bool
+	if rest, found := strings.CutPrefix(s, "every "); found {
+		preview.recurring = true
+		date, ok = parseDueRecurrence(rest, today)
+	} else if s == "daily" || s == "weekly" || s == "monthly" || s == "yearly" {
+		preview.recurring = true
+		date, ok = today, true
+	} else {
+		date, ok = parseDueDate(s, today)
+	}
+	if !ok {
+		return duePreview{}, false
+	}
+
+	preview.date = date.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	return preview, true
+}
+
+// parseClockTime converts a dueTimePattern match into hours and minutes
+func parseClockTime(match []string) (hour, minute int, ok bool) {
+	hourText, minuteText, meridiem := match[1], match[2], match[3]
+	if hourText == "" {
+		hourText, minuteText = match[4], match[5]
+	}
+	hour, _ = strconv.Atoi(hourText)
+	if minuteText != "" {
+		minute, _ = strconv.Atoi(minuteText)
+	}
+	switch meridiem {
+	case "am", "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return hour, minute, hour < 24 && minute < 60
+}
+
+// parseDueDate resolves a non-recurring date phrase relative to today
+// An empty phrase is today, so a time on its own like "5pm" is today at that time
+func parseDueDate(s string, today time.Time) (time.Time, bool) {
+	switch s {
+	case "", "today", "tod", "tonight":
+		return today, true
+	case "tomorrow", "tom":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "next week":
+		return nextWeekday(today, time.Monday, false), true
+	case "weekend", "this weekend":
+		return nextWeekday(today, time.Saturday, true), true
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), true
+	}
+
+	// Weekdays mean their next occurrence, "next friday" the one in the following week
+	if weekday, ok := dueWeekdays[strings.TrimPrefix(s, "this ")]; ok {
+		return nextWeekday(today, weekday, false), true
+	}
+	if name, found := strings.CutPrefix(s, "next "); found {
+		if weekday, ok := dueWeekdays[name]; ok {
+			monday := nextWeekday(today, time.Monday, false)
+			return monday.AddDate(0, 0, (int(weekday)+6)%7), true
+		}
+	}
+
+	// Relative offsets like "in 3 days"
+	if match := dueOffsetPattern.FindStringSubmatch(s); match != nil {
+		n := 1
+		if match[1] != "a" && match[1] != "an" {
+			n, _ = strconv.Atoi(match[1])
+		}
+		switch match[2] {
+		case "day":
+			return today.AddDate(0, 0, n), true
+		case "week":
+			return today.AddDate(0, 0, 7*n), true
+		case "month":
+			return today.AddDate(0, n, 0), true
+		default:
+			return today.AddDate(n, 0, 0), true
+		}
+	}
+
+	// Full dates
+	if date, err := time.ParseInLocation("2006-01-02", s, today.Location()); err == nil {
+		return date, true
+	}
+	return parseDueMonthDay(s, today)
+}
+
+// parseDueMonthDay resolves dates written with a month name, e.g. "jan 5", "5th january" or "march 3 2027"
+// Without a year, a date already past this year means next year
+func parseDueMonthDay(s string, today time.Time) (time.Time, bool) {
+	words := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(words) < 2 || len(words) > 3 {
+		return time.Time{}, false
+	}
+
+	// Accept both "jan 5" and "5 jan"
+	month, ok := dueMonths[words[0]]
+	dayText := words[1]
+	if !ok {
+		month, ok = dueMonths[words[1]]
+		dayText = words[0]
+	}
+	if !ok {
+		return time.Time{}, false
+	}
+	if match := dueOrdinalSuffix.FindStringSubmatch(dayText); match != nil {
+		dayText = match[1]
+	}
+	day, err := strconv.Atoi(dayText)
+	if err != nil || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	year := today.Year()
+	if len(words) == 3 {
+		if year, err = strconv.Atoi(words[2]); err != nil || year < 1000 {
+			return time.Time{}, false
+		}
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, today.Location())
+	if date.Month() != month {
+		// Days past the end of the month, like "feb 30"
+		return time.Time{}, false
+	}
+	if len(words) == 2 && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, true
+}
+
+// parseDueRecurrence resolves the first occurrence of an "every ..." phrase
+// Recurrences start today when today matches, like in Todoist
+func parseDueRecurrence(s string, today time.Time) (time.Time, bool) {
+	switch s {
+	case "day", "week", "month", "year", "other day", "other week", "morning", "evening":
+		return today, true
+	case "weekday", "workday":
+		for today.Weekday() == time.Saturday || today.Weekday() == time.Sunday {
+			today = today.AddDate(0, 0, 1)
+		}
+		return today, true
+	}
+	if weekday, ok := dueWeekdays[s]; ok {
+		return nextWeekday(today, weekday, true), true
+	}
+	if match := dueOffsetPattern.FindStringSubmatch(s); match != nil {
+		return today, true
+	}
+	// "every jan 5" or "every 2026-01-05"
+	return parseDueDate(s, today)
+}
+
+// nextWeekday returns the next day falling on the given weekday, counting today when includeToday is set
+func nextWeekday(today time.Time, weekday time.Weekday, includeToday bool) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 && !includeToday {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+// describe formats the resolved date for the preview, e.g. "Fri, Oct 23 17:00 (in 6 days) • repeats"
+func (p duePreview) describe(now time.Time) string {
+	if p.none {
+		return "no due date"
+	}
+
+	text := p.date.Format("Mon, Jan 2")
+	if p.date.Year() != now.Year() {
+		text += p.date.Format(" 2006")
+	}
+	if p.hasTime {
+		text += p.date.Format(" 15:04")
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	day := time.Date(p.date.Year(), p.date.Month(), p.date.Day(), 0, 0, 0, 0, now.Location())
+	switch days := int(math.Round(day.Sub(today).Hours() / 24)); {
+	case days == 0:
+		text += " (today)"
+	case days == 1:
+		text += " (tomorrow)"
+	case days == -1:
+		text += " (yesterday)"
+	case days < 0:
+		text += fmt.Sprintf(" (%d days ago)", -days)
+	default:
+		text += fmt.Sprintf(" (in %d days)", days)
+	}
+
+	if p.recurring {
+		text += " • repeats"
+	}
+	return text
+}
+
+// renderDuePreview shows what a deadline resolves to, or warns that it wasn't recognized
+func renderDuePreview(dueString string, now time.Time) string {
+	preview, ok := parseDueString(dueString, now)
+	if !ok {
+		return staleStyle.UnsetMargins().Render("⚠ Not recognized, Todoist may not understand it")
+	}
+	return projectStyle.Render("→ " + preview.describe(now))
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/pkg/browser"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// duplicateThreshold is the similarity above which a new task is reported as a likely duplicate
+const duplicateThreshold = 0.8
+
+// normalizeContent lowercases task content and collapses whitespace for comparison
+func normalizeContent(content string) string {
+	return strings.Join(strings.Fields(strings.ToLower(content)), " ")
+}
+
+// levenshtein returns the edit distance between two strings, counted in runes
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// contentSimilarity scores how alike two task contents are, from 0 (different) to 1 (identical)
+func contentSimilarity(a, b string) float64 {
+	a, b = normalizeContent(a), normalizeContent(b)
+	longest := max(len([]rune(a)), len([]rune(b)))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// findSimilarTask returns the open task most similar to the given content, if any is similar enough
+func findSimilarTask(tasks []TodoistTask, content string) (TodoistTask, bool) {
+	var best TodoistTask
+	bestScore := 0.0
+	for _, task := range tasks {
+		if score := contentSimilarity(task.Content, content); score > bestScore {
+			best, bestScore = task, score
+		}
+	}
+	return best, bestScore >= duplicateThreshold
+}
+
+// checkDuplicate looks for an open task similar to the one being created
+// The cache holds every open task, so this works offline and across projects
+func (m model) checkDuplicate(content string) (TodoistTask, bool) {
+	if m.cache == nil {
+		return TodoistTask{}, false
+	}
+	tasks, err := m.cache.LoadTasks()
+	if err != nil {
+		return TodoistTask{}, false
+	}
+	return findSimilarTask(tasks, content)
+}
+
+// renderDuplicateWarning describes the similar task found when creating a task
+func (m model) renderDuplicateWarning() string {
+	task := m.createTaskForm.duplicate
+	var b strings.Builder
+	b.WriteString(staleStyle.MarginLeft(0).Render("⚠️ Similar task exists: " + task.Content))
+	b.WriteString("\n")
+	b.WriteString("   in " + m.client.GetProjectName(task.ProjectID))
+	b.WriteString("\n\n")
+	b.WriteString("o: open it in Todoist • j: jump to it • c/Enter: create anyway • ESC: keep editing")
+	return b.String()
+}
+
+// handleDuplicateInput handles keyboard input while the similar task warning is shown
+func (m model) handleDuplicateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	similar := *m.createTaskForm.duplicate
+	switch msg.String() {
+	case "esc", "escape":
+		// Back to the form to change the task
+		m.createTaskForm.duplicate = nil
+	case "c", "enter":
+		// Create the task as entered
+		m.createTaskForm.duplicate = nil
+		m.createTaskForm.duplicateConfirmed = true
+		return m.handleCreateTaskInput(tea.KeyMsg{Type: tea.KeyEnter})
+	case "o":
+		// Open the existing task instead of creating a new one
+		_ = browser.OpenURL(similar.URL)
+		m.createTaskForm.duplicate = nil
+		return m.handleCreateTaskInput(tea.KeyMsg{Type: tea.KeyEsc})
+	case "j":
+		// Close the form and select the existing task
+		m.createTaskForm.duplicate = nil
+		updated, _ := m.handleCreateTaskInput(tea.KeyMsg{Type: tea.KeyEsc})
+		m = updated.(model)
+		return m, m.jumpToTask(similar.ID, similar.ProjectID)
+	}
+	return m, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"strings"
+)
+
+// startEditTask opens the task form pre-populated with the given task's details
+func (m *model) startEditTask(task TodoistTask) {
+	// Find the task's project in the project list
+	selectedProjectIdx := -1
+	for i, project := range m.projects {
+		if project.ID == task.ProjectID {
+			selectedProjectIdx = i
+			break
+		}
+	}
+
+	// Use the human readable due string so recurrence is preserved
+	deadline := ""
+	if task.Due != nil {
+		deadline = task.Due.String
+		if deadline == "" {
+			deadline = task.Due.Date
+		}
+	}
+
+	m.showingCreateTask = true
+	m.createTaskForm = createTaskFormState{
+		content:            newFormInput(task.Content),
+		priority:           task.Priority,
+		projectID:          task.ProjectID,
+		projectName:        m.client.GetProjectName(task.ProjectID),
+		sectionID:          task.SectionID,
+		selectedProjectIdx: selectedProjectIdx,
+		projectSearch:      newFormInput(""),
+		filteredProjects:   m.projects,
+		labels:             strings.Join(task.Labels, ", "),
+		deadline:           newFormInput(deadline),
+		activeField:        fieldContent,
+		editingTaskID:      task.ID,
+		originalDeadline:   deadline,
+	}
+}
+
+// taskUpdate builds the update request for the task being edited
+func (f createTaskFormState) taskUpdate() UpdateTaskRequest {
+	labels := parseLabels(f.labels)
+	if labels == nil {
+		labels = []string{} // Send an empty list so removed labels are cleared
+	}
+
+	update := UpdateTaskRequest{
+		Content:  f.content.Value(),
+		Priority: f.priority,
+		Labels:   &labels,
+	}
+
+	// Only send the due string when it changed, so recurring dates are not reset
+	deadline := strings.TrimSpace(f.deadline.Value())
+	if deadline != f.originalDeadline {
+		if deadline == "" {
+			update.DueString = "no date"
+		} else {
+			update.DueString = deadline
+		}
+	}
+
+	return update
+}
+
+// parseLabels splits a comma-separated list of label names
+// Leading @ characters are accepted and stripped, matching Todoist's quick add syntax
+func parseLabels(input string) []string {
+	var labels []string
+	for _, label := range strings.Split(input, ",") {
+		label = strings.TrimPrefix(strings.TrimSpace(label), "@")
+		if label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// errNotModified is returned by a conditional fetch when the data hasn't changed since it was last fetched
+// The caller keeps what it has, without decoding anything or redrawing the list
+var errNotModified = errors.New("not modified since the last fetch")
+
+// Prefixes of the cache_metadata keys holding the validators of each endpoint, followed by the request path
+const (
+	etagKeyPrefix         = "etag "
+	lastModifiedKeyPrefix = "last_modified "
+)
+
+// conditionalKey marks a request context as one whose data the caller still holds, see withConditional
+type conditionalKey struct{}
+
+// responseValidators holds the ETag and Last-Modified of a conditional fetch until its data was stored
+type responseValidators struct {
+	mu sync.Mutex
+	// key is the request path the validators belong to, "" until a response arrived
+	key          string
+	etag         string
+	lastModified string
+}
+
+// withConditional lets the GET request made with the returned context be answered with 304 Not Modified
+// when the data didn't change, sending the ETag or Last-Modified of the previous response
+// Only use it where the data of the previous response is still at hand, since a 304 carries none, and for
+// a single resource: its validators are kept until the caller saves them, once the data is safely stored
+func withConditional(ctx context.Context) (context.Context, *responseValidators) {
+	validators := &responseValidators{}
+	return context.WithValue(ctx, conditionalKey{}, validators), validators
+}
+
+// save remembers the validators of the response for the next conditional request
+// Call it only once the response's data was stored, or a failure to store it would be answered with 304s
+// from then on; nothing is saved when no response arrived
+func (v *responseValidators) save(cache *CacheDB) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if cache == nil || v.key == "" {
+		return
+	}
+	_ = cache.setMetadata(etagKeyPrefix+v.key, v.etag)
+	_ = cache.setMetadata(lastModifiedKeyPrefix+v.key, v.lastModified)
+}
+
+// useValidators keeps the ETag and Last-Modified of responses in the cache, so conditional requests also
+// work after a restart; without a cache no validators are kept
+func (c *TodoistClient) useValidators(cache *CacheDB) {
+	c.validators.Store(cache)
+}
+
+// conditionalRequests is middleware sending the validators of the previous response with conditional
+// requests as If-None-Match or If-Modified-Since, and handing those of a new response to the caller
+func (c *TodoistClient) conditionalRequests(next requestHandler) requestHandler {
+	return func(req *http.Request) (*http.Response, error) {
+		cache := c.validators.Load()
+		validators, _ := req.Context().Value(conditionalKey{}).(*responseValidators)
+		if cache == nil || validators == nil || req.Method != http.MethodGet {
+			return next(req)
+		}
+
+		key := req.URL.RequestURI()
+		if etag := cache.metadata(etagKeyPrefix + key); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		} else if lastModified := cache.metadata(lastModifiedKeyPrefix + key); lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+
+		resp, err := next(req)
+		if err == nil && resp.StatusCode == http.StatusOK {
+			validators.mu.Lock()
+			validators.key = key
+			validators.etag = resp.Header.Get("ETag")
+			validators.lastModified = resp.Header.Get("Last-Modified")
+			validators.mu.Unlock()
+		}
+		return resp, err
+	}
+}
+
+// metadata returns a value from cache_metadata, or "" when it isn't set
+func (c *CacheDB) metadata(key string) string {
+	var value string
+	_ = c.db.QueryRow("SELECT value FROM cache_metadata WHERE key = ?", key).Scan(&value)
+	return value
+}
+
+// setMetadata stores a value in cache_metadata, removing the key for an empty value
+func (c *CacheDB) setMetadata(key, value string) error {
+	if value == "" {
+		_, err := c.db.Exec("DELETE FROM cache_metadata WHERE key = ?", key)
+		return err
+	}
+	_, err := c.db.Exec("INSERT OR REPLACE INTO cache_metadata (key, value) VALUES (?, ?)", key, value)
+	return err
+}
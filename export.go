@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// exportFormats lists the supported export formats
+var exportFormats = []string{"json", "csv", "md"}
+
+// exportedTask is a task as written by an export, with the project name resolved
+type exportedTask struct {
+	ID          string   `json:"id"`
+	Content     string   `json:"content"`
+	Description string   `json:"description,omitempty"`
+	Project     string   `json:"project"`
+	Priority    string   `json:"priority"`
+	Due         string   `json:"due,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+	URL         string   `json:"url"`
+}
+
+// exportDoneMsg is sent when the tasks of the current view have been written to a file
+type exportDoneMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// isValidExportFormat checks whether a format name is one of the supported export formats
+func isValidExportFormat(format string) bool {
+	for _, f := range exportFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// newExportedTask converts a task for export, resolving its project name
+// Tasks without a URL, like ones created optimistically, are linked by ID as copied snippets are
+func newExportedTask(task TodoistTask, client *TodoistClient) exportedTask {
+	due := ""
+	if task.Due != nil {
+		due = task.Due.Date
+	}
+	return exportedTask{
+		ID:          task.ID,
+		Content:     task.Content,
+		Description: task.Description,
+		Project:     client.GetProjectName(task.ProjectID),
+		Priority:    getPriorityText(task.Priority),
+		Due:         due,
+		Labels:      task.Labels,
+		URL:         taskWebURL(task),
+	}
+}
+
+// exportTasks writes tasks in the given format, keeping their order
+func exportTasks(w io.Writer, format string, tasks []TodoistTask, client *TodoistClient) error {
+	exported := make([]exportedTask, 0, len(tasks))
+	for _, task := range tasks {
+		exported = append(exported, newExportedTask(task, client))
+	}
+
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(exported)
+	case "csv":
+		writer := csv.NewWriter(w)
+		_ = writer.Write([]string{"id", "content", "description", "project", "priority", "due", "labels", "url"})
+		for _, t := range exported {
+			_ = writer.Write([]string{t.ID, t.Content, t.Description, t.Project, t.Priority, t.Due, strings.Join(t.Labels, ","), t.URL})
+		}
+		writer.Flush()
+		return writer.Error()
+	case "md":
+		var b strings.Builder
+		b.WriteString("| Priority | Task | Project | Due | Labels |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, t := range exported {
+			labels := make([]string, 0, len(t.Labels))
+			for _, label := range t.Labels {
+				labels = append(labels, "@"+label)
+			}
+			b.WriteString(fmt.Sprintf("| %s | [%s](%s) | %s | %s | %s |\n", t.Priority, escapeMarkdownCell(t.Content), t.URL,
+				escapeMarkdownCell(t.Project), t.Due, strings.Join(labels, " ")))
+		}
+		_, err := io.WriteString(w, b.String())
+		return err
+	default:
+		return fmt.Errorf("unknown export format %q (use %s)", format, strings.Join(exportFormats, ", "))
+	}
+}
+
+// escapeMarkdownCell keeps text from breaking out of a Markdown table cell or link
+func escapeMarkdownCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "[", "\\[", "]", "\\]", "\n", " ").Replace(text)
+}
+
+// writeExport writes tasks to a file, or to stdout when the path is empty or "-"
+func writeExport(path, format string, tasks []TodoistTask, client *TodoistClient) error {
+	if path == "" || path == "-" {
+		return exportTasks(os.Stdout, format, tasks, client)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	if err := exportTasks(file, format, tasks, client); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return file.Close()
+}
+
+// runExport exports today's tasks without starting the interface, for --export
+// Cached tasks are used when available, falling back to the API like a normal start
+func runExport(config *Config, format, output string) error {
+	token, err := todoistToken(config)
+	if err != nil {
+		return err
+	}
+	client := NewTodoistClient(token)
+	if err := client.configure(config); err != nil {
+		return err
+	}
+
+	cache, err := newCacheFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	defer func() { _ = cache.Close() }()
+
+	var tasks []TodoistTask
+	var projects []TodoistProject
+	if !cache.LastUpdated("tasks").IsZero() {
+		if tasks, err = cache.LoadTodaysTasks(); err != nil {
+			return fmt.Errorf("failed to load cached tasks: %w", err)
+		}
+		if projects, err = cache.LoadProjects(); err != nil {
+			return fmt.Errorf("failed to load cached projects: %w", err)
+		}
+	} else {
+		// The export runs once from the command line, so nothing cancels its requests
+		// Only today's tasks are fetched, so they aren't saved as the cached task list
+		ctx := context.Background()
+		if tasks, err = client.GetFilteredTasks(ctx, todayFilter); err != nil {
+			return err
+		}
+		if projects, err = client.GetProjects(ctx); err != nil {
+			return err
+		}
+		_ = cache.SaveProjects(projects)
+		tasks = filterTodaysTasks(tasks)
+	}
+	client.LoadProjectsFromCache(projects)
+
+	// Leave out tasks parked locally, as the interface does
+	parked, err := cache.LoadParkedTasks()
+	if err != nil {
+		return fmt.Errorf("failed to load parked tasks: %w", err)
+	}
+	tasks = model{parkedTasks: parked}.hideParked(tasks)
+
+	return writeExport(output, format, tasks, client)
+}
+
+// exportTasksToFile creates a command that writes the given tasks to a file
+func exportTasksToFile(client *TodoistClient, path, format string, tasks []TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := writeExport(path, format, tasks, client); err != nil {
+			return exportDoneMsg{path: path, err: err}
+		}
+		return exportDoneMsg{path: path, count: len(tasks)}
+	})
+}
+
+// defaultExportPath suggests a file name for exporting the current view in a format
+func (m model) defaultExportPath(format string) string {
+	name := "today"
+	switch m.view {
+	case viewProject:
+		name = strings.ToLower(strings.ReplaceAll(m.client.GetProjectName(m.viewProjectID), " ", "-"))
+	case viewUpcoming:
+		name = "upcoming"
+	case viewLabel:
+		name = "label-" + strings.ToLower(strings.ReplaceAll(m.viewLabelName, " ", "-"))
+	case viewSomeday:
+		name = "someday"
+	}
+	return fmt.Sprintf("todoist-%s-%s.%s", name, time.Now().Format("2006-01-02"), format)
+}
+
+// openExport shows the export popup for the current view
+func (m *model) openExport() {
+	m.showingExport = true
+	m.exportFormatIdx = 0
+	m.exportPath = m.defaultExportPath(exportFormats[0])
+	m.exportPathEdited = false
+	m.exportResult = ""
+	m.exportError = ""
+}
+
+// renderExport creates the popup for exporting the current view
+func (m model) renderExport() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("📤 Export Tasks"))
+	content.WriteString("\n\n")
+	content.WriteString(fmt.Sprintf("%d task(s) in the current view", len(m.allTasks)))
+	if m.filterQuery != "" {
+		content.WriteString(fmt.Sprintf(" matching /%s", m.filterQuery))
+	}
+	content.WriteString("\n\n")
+
+	// Format choice
+	content.WriteString(popupFieldStyle.Render("Format: "))
+	selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+	for i, format := range exportFormats {
+		if i == m.exportFormatIdx {
+			content.WriteString(selected.Render(" " + format + " "))
+		} else {
+			content.WriteString(" " + format + " ")
+		}
+	}
+	content.WriteString("\n\n")
+
+	// Output file
+	content.WriteString(popupFieldStyle.Render("File: "))
+	content.WriteString(m.exportPath + "│")
+	content.WriteString("\n\n")
+
+	if m.exportError != "" {
+		content.WriteString(errorStyle.MarginLeft(0).Render(m.exportError))
+		content.WriteString("\n\n")
+	} else if m.exportResult != "" {
+		content.WriteString(m.exportResult)
+		content.WriteString("\n\n")
+	}
+
+	// Instructions
+	content.WriteString("←/→: format • type to edit file • Enter: export • ESC: close")
+
+	// Calculate popup size and position
+	maxWidth := 60
+	if m.width < 70 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleExportInput handles keyboard input when the export popup is visible
+func (m model) handleExportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the popup
+		m.showingExport = false
+	case "left", "right":
+		// Change the format, keeping the suggested file name in step unless it was edited
+		delta := 1
+		if msg.String() == "left" {
+			delta = len(exportFormats) - 1
+		}
+		m.exportFormatIdx = (m.exportFormatIdx + delta) % len(exportFormats)
+		if !m.exportPathEdited {
+			m.exportPath = m.defaultExportPath(exportFormats[m.exportFormatIdx])
+		}
+	case "backspace":
+		// Remove the last character of the file name
+		if len(m.exportPath) > 0 {
+			m.exportPath = m.exportPath[:len(m.exportPath)-1]
+			m.exportPathEdited = true
+		}
+	case "enter":
+		// Write the tasks in the order they are listed
+		path := strings.TrimSpace(m.exportPath)
+		if path == "" || path == "-" {
+			m.exportError = "Enter a file name to export to"
+			return m, nil
+		}
+		m.exportError = ""
+		m.exportResult = "Exporting..."
+		tasks := append([]TodoistTask(nil), m.allTasks...)
+		return m, exportTasksToFile(m.client, path, exportFormats[m.exportFormatIdx], tasks)
+	default:
+		// Typing edits the file name
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.exportPath += msg.String()
+			m.exportPathEdited = true
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterMatchStyle marks the characters of a task matched by the filter
+var filterMatchStyle = lipgloss.NewStyle().Bold(true).Underline(true)
+
+// matchesFilter reports whether a task matches the filter by content, project name or label
+func (m model) matchesFilter(task TodoistTask) bool {
+	if m.filterQuery == "" {
+		return true
+	}
+	if _, ok := fuzzyMatch(task.Content, m.filterQuery); ok {
+		return true
+	}
+	if _, ok := fuzzyMatch(m.client.GetProjectName(task.ProjectID), m.filterQuery); ok {
+		return true
+	}
+	for _, label := range task.Labels {
+		if _, ok := fuzzyMatch(label, m.filterQuery); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setTasks stores the tasks of the current view in its sort order, keeping tasks that don't match the filter out of navigation
+func (m *model) setTasks(tasks []TodoistTask) {
+	m.tasks = m.sortForView(m.withPendingCreates(tasks))
+	m.applyFilter()
+}
+
+// applyFilter rebuilds the navigable task list from the loaded tasks after the filter changed
+func (m *model) applyFilter() {
+	// The upcoming view also leaves out collapsed days
+	if m.view == viewUpcoming {
+		m.setUpcomingTasks(m.tasks)
+		return
+	}
+
+	m.allTasks = nil
+	for _, task := range m.tasks {
+		if m.matchesFilter(task) {
+			m.allTasks = append(m.allTasks, task)
+		}
+	}
+
+	// Keep the selection within the matching tasks
+	if len(m.allTasks) == 0 {
+		m.selectedIndex = -1
+	} else if m.selectedIndex == -1 || m.selectedIndex >= len(m.allTasks) {
+		m.selectedIndex = 0
+	}
+}
+
+// highlightFilterMatches styles the characters of a rendered task line matched by the filter
+// The line is aligned with the full content, since wrapping and truncation only drop or add characters
+func (m model) highlightFilterMatches(line, content string, base lipgloss.Style) string {
+	positions, ok := fuzzyMatch(content, m.filterQuery)
+	if m.filterQuery == "" || !ok {
+		return line
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	// Walk the content alongside the line, rendering runs of matched and unmatched characters
+	contentRunes := []rune(content)
+	cursor := 0
+	var b, run strings.Builder
+	runMatched := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		style := base
+		if runMatched {
+			style = base.Inherit(filterMatchStyle)
+		}
+		b.WriteString(style.Render(run.String()))
+		run.Reset()
+	}
+	for _, char := range line {
+		isMatch := false
+		for i := cursor; i < len(contentRunes); i++ {
+			if contentRunes[i] == char {
+				isMatch = matched[i]
+				cursor = i + 1
+				break
+			}
+		}
+		if isMatch != runMatched {
+			flush()
+			runMatched = isMatch
+		}
+		run.WriteRune(char)
+	}
+	flush()
+	return b.String()
+}
+
+// renderFilterBar shows the filter prompt while typing, or the active filter afterwards
+func (m model) renderFilterBar() string {
+	if !m.filtering && m.filterQuery == "" {
+		return ""
+	}
+	prompt := "/" + m.filterQuery
+	if m.filtering {
+		prompt += "│"
+	}
+	status := fmt.Sprintf("  %d of %d tasks", len(m.allTasks), len(m.tasks))
+	if m.filtering {
+		status += " • Enter: done • ESC: clear"
+	} else {
+		status += " • /: edit • ESC: clear"
+	}
+	return titleStyle.Render(prompt) + loadingStyle.Render(status)
+}
+
+// clearFilter removes the filter and shows all loaded tasks again
+func (m *model) clearFilter() {
+	m.filtering = false
+	m.filterQuery = ""
+	m.applyFilter()
+}
+
+// handleFilterInput handles keyboard input while the filter prompt is open
+func (m model) handleFilterInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Drop the filter entirely
+		m.clearFilter()
+	case "enter":
+		// Keep the filter and go back to navigating the matching tasks
+		m.filtering = false
+	case "up":
+		// Move selection up without leaving the prompt
+		if m.selectedIndex > 0 {
+			m.selectedIndex--
+			m.hScroll = 0
+		}
+	case "down":
+		// Move selection down without leaving the prompt
+		if m.selectedIndex < len(m.allTasks)-1 {
+			m.selectedIndex++
+			m.hScroll = 0
+		}
+	case "backspace":
+		// Remove the last character and widen the results
+		if len(m.filterQuery) > 0 {
+			runes := []rune(m.filterQuery)
+			m.filterQuery = string(runes[:len(runes)-1])
+			m.applyFilter()
+		}
+	default:
+		// Narrow the results as the query is typed
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.filterQuery += msg.String()
+			m.selectedIndex = 0
+			m.applyFilter()
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FormatRule styles the rows of tasks matching a condition, like conditional formatting in a spreadsheet
+type FormatRule struct {
+	// If is the condition, e.g. "label=@home" or "overdue>7d and priority=p1"
+	If string `json:"if"`
+	// Color is the text color of matching rows, as a name, ANSI number or hex value
+	Color string `json:"color,omitempty"`
+	// Background is the background color of matching rows
+	Background string `json:"background,omitempty"`
+	// Bold, Italic and Underline emphasize the task text of matching rows
+	Bold      bool `json:"bold,omitempty"`
+	Italic    bool `json:"italic,omitempty"`
+	Underline bool `json:"underline,omitempty"`
+}
+
+// formatFields are the task fields a condition can test
+var formatFields = []string{"label", "project", "priority", "overdue", "content"}
+
+// formatOperators are the comparisons a condition can use, longest first so they are matched correctly
+var formatOperators = []string{"!=", ">=", "<=", "=", ">", "<", "~"}
+
+// namedColors maps color names usable in rules to ANSI colors, so they follow the terminal palette
+var namedColors = map[string]string{
+	"black":   "0",
+	"red":     "9",
+	"green":   "10",
+	"yellow":  "11",
+	"blue":    "12",
+	"magenta": "13",
+	"cyan":    "14",
+	"white":   "15",
+	"gray":    "8",
+	"grey":    "8",
+}
+
+// formatCondition is a single parsed test, such as overdue>7d
+type formatCondition struct {
+	field string
+	op    string
+	// value is the text to compare with, lowercased and without a leading @ for labels
+	value string
+	// number is the value of priority and overdue conditions, as a P level or number of days
+	number int
+}
+
+// formatRule is a rule with its condition parsed, ready to be evaluated while rendering
+type formatRule struct {
+	conditions []formatCondition
+	style      formatStyle
+}
+
+// formatStyle is the emphasis a row gets from the rules it matches
+type formatStyle struct {
+	color      lipgloss.Color
+	background lipgloss.Color
+	bold       bool
+	italic     bool
+	underline  bool
+}
+
+// compileFormatRules parses the configured rules, reporting the first invalid one
+func compileFormatRules(rules []FormatRule) ([]formatRule, error) {
+	var compiled []formatRule
+	for i, rule := range rules {
+		conditions, err := parseFormatCondition(rule.If)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format rule %d (%q): %w", i+1, rule.If, err)
+		}
+		compiled = append(compiled, formatRule{
+			conditions: conditions,
+			style: formatStyle{
+				color:      ruleColor(rule.Color),
+				background: ruleColor(rule.Background),
+				bold:       rule.Bold,
+				italic:     rule.Italic,
+				underline:  rule.Underline,
+			},
+		})
+	}
+	return compiled, nil
+}
+
+// parseFormatCondition parses conditions joined by "and", such as "label=@home and priority=p1"
+func parseFormatCondition(condition string) ([]formatCondition, error) {
+	var conditions []formatCondition
+	for _, part := range strings.Split(condition, " and ") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, fmt.Errorf("empty condition")
+		}
+
+		// Split the test into field, operator and value at the first operator
+		var c formatCondition
+		for _, op := range formatOperators {
+			if i := strings.Index(part, op); i > 0 && (c.op == "" || i < strings.Index(part, c.op)) {
+				c.field = strings.ToLower(strings.TrimSpace(part[:i]))
+				c.op = op
+				c.value = strings.ToLower(strings.TrimSpace(part[i+len(op):]))
+			}
+		}
+		if c.op == "" {
+			return nil, fmt.Errorf("missing operator in %q, use one of %s", part, strings.Join(formatOperators, " "))
+		}
+
+		switch c.field {
+		case "label":
+			c.value = strings.TrimPrefix(c.value, "@")
+		case "priority":
+			level, err := strconv.Atoi(strings.TrimPrefix(c.value, "p"))
+			if err != nil || level < 1 || level > 4 {
+				return nil, fmt.Errorf("invalid priority %q, use p1 to p4", c.value)
+			}
+			c.number = level
+		case "overdue":
+			days, err := strconv.Atoi(strings.TrimSuffix(c.value, "d"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid number of days %q, e.g. 7d", c.value)
+			}
+			c.number = days
+		case "project", "content":
+		default:
+			return nil, fmt.Errorf("unknown field %q, use one of %s", c.field, strings.Join(formatFields, ", "))
+		}
+
+		// Text fields can only be compared for (in)equality or containment, numbers not for containment
+		numeric := c.field == "priority" || c.field == "overdue"
+		if (numeric && c.op == "~") || (!numeric && c.op != "=" && c.op != "!=" && c.op != "~") {
+			return nil, fmt.Errorf("operator %s can't be used with %s", c.op, c.field)
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// ruleColor resolves a color from a rule, accepting names as well as anything lipgloss understands
+func ruleColor(color string) lipgloss.Color {
+	if ansi, ok := namedColors[strings.ToLower(color)]; ok {
+		return lipgloss.Color(ansi)
+	}
+	return lipgloss.Color(color)
+}
+
+// compareNumbers applies a comparison operator to two numbers
+func compareNumbers(a int, op string, b int) bool {
+	switch op {
+	case "=":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+// compareText applies an equality or containment operator to lowercased text
+func compareText(text, op, value string) bool {
+	switch op {
+	case "=":
+		return text == value
+	case "!=":
+		return text != value
+	case "~":
+		return strings.Contains(text, value)
+	}
+	return false
+}
+
+// daysOverdue returns how many days ago a task was due, negative when due in the future
+func daysOverdue(task TodoistTask, now time.Time) (int, bool) {
+	if task.Due == nil {
+		return 0, false
+	}
+	due, err := time.ParseInLocation("2006-01-02", task.Due.Date, time.Local)
+	if err != nil {
+		return 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return int(today.Sub(due).Hours() / 24), true
+}
+
+// matchesCondition reports whether a task passes a single condition
+func (m model) matchesCondition(task TodoistTask, c formatCondition, now time.Time) bool {
+	switch c.field {
+	case "label":
+		// label!=x matches tasks without the label, the others tasks with a matching label
+		op := c.op
+		if op == "!=" {
+			op = "="
+		}
+		found := false
+		for _, label := range task.Labels {
+			if compareText(strings.ToLower(label), op, c.value) {
+				found = true
+			}
+		}
+		return found == (c.op != "!=")
+	case "project":
+		return compareText(strings.ToLower(m.client.GetProjectName(task.ProjectID)), c.op, c.value)
+	case "content":
+		return compareText(strings.ToLower(task.Content), c.op, c.value)
+	case "priority":
+		// P1 is the most urgent, which the API calls priority 4
+		return compareNumbers(5-task.Priority, c.op, c.number)
+	case "overdue":
+		days, ok := daysOverdue(task, now)
+		return ok && compareNumbers(days, c.op, c.number)
+	}
+	return false
+}
+
+// formatStyleFor combines the styles of all rules a task matches, later rules overriding earlier ones
+func (m model) formatStyleFor(task TodoistTask) formatStyle {
+	var style formatStyle
+	now := dayNow()
+	for _, rule := range m.formatRules {
+		matched := true
+		for _, c := range rule.conditions {
+			if !m.matchesCondition(task, c, now) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if rule.style.color != "" {
+			style.color = rule.style.color
+		}
+		if rule.style.background != "" {
+			style.background = rule.style.background
+		}
+		style.bold = style.bold || rule.style.bold
+		style.italic = style.italic || rule.style.italic
+		style.underline = style.underline || rule.style.underline
+	}
+	return style
+}
+
+// apply adds the emphasis and, unless the row is highlighted otherwise, the background to a style
+func (f formatStyle) apply(style lipgloss.Style, highlighted bool) lipgloss.Style {
+	if f.background != "" && !highlighted {
+		style = style.Background(f.background)
+	}
+	if f.bold {
+		style = style.Bold(true)
+	}
+	if f.italic {
+		style = style.Italic(true)
+	}
+	if f.underline {
+		style = style.Underline(true)
+	}
+	return style
+}
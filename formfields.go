@@ -0,0 +1,57 @@
+package main
+
+import (
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newFormInput creates a text input for the task form holding the given value
+// Inputs stay focused and only the active field is sent keys, so focus doesn't need to follow the active field
+func newFormInput(value string) textinput.Model {
+	input := textinput.New()
+	input.Prompt = ""
+	// A steady cursor avoids a stream of blink messages while the form is open
+	input.Cursor.SetMode(cursor.CursorStatic)
+	input.Focus()
+	input.SetValue(value)
+	return input
+}
+
+// renderFormInput renders a form input, showing its cursor only while its field is active
+func renderFormInput(input textinput.Model, active bool) string {
+	if active {
+		return input.View()
+	}
+	return input.Value()
+}
+
+// activeInput returns the text input of the active form field, or nil for fields without one
+func (f *createTaskFormState) activeInput() *textinput.Model {
+	switch f.activeField {
+	case fieldContent:
+		return &f.content
+	case fieldProject:
+		return &f.projectSearch
+	case fieldDeadline:
+		return &f.deadline
+	}
+	return nil
+}
+
+// updateFormInput passes a message to the active field's text input, refiltering projects when the search changed
+// Besides keys, this delivers text pasted from the clipboard with Ctrl+V
+func (m *model) updateFormInput(msg tea.Msg) tea.Cmd {
+	input := m.createTaskForm.activeInput()
+	if input == nil {
+		return nil
+	}
+
+	search := m.createTaskForm.projectSearch.Value()
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+	if m.createTaskForm.projectSearch.Value() != search {
+		m.updateProjectFilter()
+	}
+	return cmd
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// GitContext maps a git repository, and optionally its branches, to the project or label of its tasks
+// Starting the app inside the repository opens that project or label instead of today's tasks
+type GitContext struct {
+	// Repo is the repository's directory name, e.g. "todoist-tui", or its full path
+	Repo string `json:"repo"`
+	// Branch is a glob the current branch must match, e.g. "feature/*"; empty matches any branch
+	Branch string `json:"branch,omitempty"`
+	// Project is the name or ID of the project shown on startup, which new tasks go to
+	Project string `json:"project,omitempty"`
+	// Label is shown on startup when no project is given, and added to new tasks
+	Label string `json:"label,omitempty"`
+}
+
+// gitLocation is the repository and branch a directory is in
+type gitLocation struct {
+	// root is the top-level directory of the repository
+	root string
+	// branch is the checked out branch, or "HEAD" when detached
+	branch string
+}
+
+// validateGitContexts checks that every git context names a repository, a project or label, and a valid branch glob
+func validateGitContexts(contexts []GitContext) error {
+	for i, context := range contexts {
+		if context.Repo == "" {
+			return fmt.Errorf("context %d: repo is required", i+1)
+		}
+		if context.Project == "" && context.Label == "" {
+			return fmt.Errorf("context %d (%s): project or label is required", i+1, context.Repo)
+		}
+		if _, err := path.Match(context.Branch, ""); err != nil {
+			return fmt.Errorf("context %d (%s): invalid branch pattern %q: %w", i+1, context.Repo, context.Branch, err)
+		}
+	}
+	return nil
+}
+
+// currentGitLocation asks git for the repository and branch of a directory
+// Returns false outside a repository, or when git isn't installed
+func currentGitLocation(dir string) (gitLocation, bool) {
+	root, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return gitLocation{}, false
+	}
+	// Unlike resolving HEAD, this also names the branch of a repository without commits
+	branch, err := exec.Command("git", "-C", dir, "branch", "--show-current").Output()
+	if err != nil {
+		return gitLocation{}, false
+	}
+	location := gitLocation{root: strings.TrimSpace(string(root)), branch: strings.TrimSpace(string(branch))}
+	if location.branch == "" {
+		location.branch = "HEAD"
+	}
+	return location, true
+}
+
+// matchGitContext returns the first context matching the repository and branch, or nil
+func matchGitContext(contexts []GitContext, location gitLocation) *GitContext {
+	for i, context := range contexts {
+		if context.Repo != filepath.Base(location.root) && filepath.Clean(context.Repo) != location.root {
+			continue
+		}
+		if context.Branch != "" {
+			if matched, _ := path.Match(context.Branch, location.branch); !matched {
+				continue
+			}
+		}
+		return &contexts[i]
+	}
+	return nil
+}
+
+// detectGitContext returns the context of the repository the app was started in, or nil
+func detectGitContext(contexts []GitContext) (*GitContext, gitLocation) {
+	if len(contexts) == 0 {
+		return nil, gitLocation{}
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, gitLocation{}
+	}
+	location, ok := currentGitLocation(dir)
+	if !ok {
+		return nil, gitLocation{}
+	}
+	return matchGitContext(contexts, location), location
+}
+
+// applyGitContext opens the git context's project or label once the projects are known on startup
+// Waits for the projects if none are cached yet, so a first run still finds the project
+func (m *model) applyGitContext() tea.Cmd {
+	context := m.gitContext
+	if context == nil || m.gitContextApplied {
+		return nil
+	}
+	where := fmt.Sprintf("%s (%s)", filepath.Base(m.gitLocation.root), m.gitLocation.branch)
+
+	if context.Project != "" {
+		if len(m.projects) == 0 {
+			return nil
+		}
+		m.gitContextApplied = true
+		projectID, ok := m.gitContextProjectID()
+		if !ok {
+			return m.notify(toastError, fmt.Sprintf("Project %q of the git context for %s not found", context.Project, where))
+		}
+		m.view = viewProject
+		m.viewProjectID = projectID
+		m.selectedIndex = -1
+		m.hScroll = 0
+		m.loading = true
+		return tea.Batch(m.notify(toastInfo, "Showing the tasks for "+where),
+			loadProjectTasks(m.requests.replace(requestGroupView), m.client, projectID))
+	}
+
+	m.gitContextApplied = true
+	label := m.currentName(referenceLabel, context.Label)
+	return tea.Batch(m.notify(toastInfo, "Showing the tasks for "+where), m.switchToLabel(strings.TrimPrefix(label, "@")))
+}
+
+// gitContextProjectID finds the git context's project by ID or name
+func (m model) gitContextProjectID() (string, bool) {
+	for _, project := range m.projects {
+		if project.ID == m.gitContext.Project {
+			return project.ID, true
+		}
+	}
+	if project := findProjectByName(m.projects, m.currentName(referenceProject, m.gitContext.Project)); project != nil {
+		return project.ID, true
+	}
+	return "", false
+}
+
+// prefillGitContext puts the git context's project and label into a new task form
+func (m *model) prefillGitContext() {
+	if m.gitContext == nil {
+		return
+	}
+	if projectID, ok := m.gitContextProjectID(); ok && m.gitContext.Project != "" {
+		for i, project := range m.createTaskForm.filteredProjects {
+			if project.ID == projectID {
+				m.createTaskForm.selectedProjectIdx = i
+				m.createTaskForm.projectID = project.ID
+				m.createTaskForm.projectName = project.Name
+			}
+		}
+	}
+	if m.gitContext.Label != "" {
+		m.createTaskForm.labels = strings.TrimPrefix(m.currentName(referenceLabel, m.gitContext.Label), "@")
+	}
+}
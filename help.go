@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpKeyWidth is the width of the key column on the help screen
+const helpKeyWidth = 16
+
+// keyLabels are the names shown for keys whose Bubble Tea name is hard to read
+var keyLabels = map[string]string{
+	" ":     "space",
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// footerHint suggests task list actions on the footer, shown with their current keys
+type footerHint struct {
+	actions []keyAction
+	label   string
+}
+
+// taskListHints are the footer's hints below a list of tasks, most useful first
+// The ones that don't fit the terminal are left to the help screen
+var taskListHints = []footerHint{
+	{[]keyAction{actionUp, actionDown}, "navigate"},
+	{[]keyAction{actionDetails}, "details"},
+	{[]keyAction{actionComplete}, "complete"},
+	{[]keyAction{actionNewTask}, "new task"},
+	{[]keyAction{actionReschedule}, "reschedule"},
+	{[]keyAction{actionEdit}, "edit"},
+	{[]keyAction{actionOpen}, "open"},
+	{[]keyAction{actionProjects}, "projects"},
+	{[]keyAction{actionUpcoming}, "upcoming"},
+	{[]keyAction{actionFilter}, "filter"},
+	{[]keyAction{actionSearch}, "search"},
+	{[]keyAction{actionRefresh}, "refresh"},
+	{[]keyAction{actionCut}, "cut"},
+	{[]keyAction{actionPaste}, "paste"},
+	{[]keyAction{actionSelect}, "select"},
+	{[]keyAction{actionComments}, "comments"},
+	{[]keyAction{actionPark}, "park"},
+	{[]keyAction{actionLabels}, "labels"},
+	{[]keyAction{actionNewProject}, "new project"},
+}
+
+// emptyListHints are the footer's hints when there are no tasks to show
+var emptyListHints = []footerHint{
+	{[]keyAction{actionRefresh}, "refresh"},
+	{[]keyAction{actionNewTask}, "new task"},
+	{[]keyAction{actionNewProject}, "new project"},
+	{[]keyAction{actionProjects}, "projects"},
+	{[]keyAction{actionUpcoming}, "upcoming"},
+}
+
+// footerHints returns the footer's line of hints: as many as fit the terminal, then how to quit and get help
+// Unbound actions are skipped, and rebound ones show their new keys
+func (m model) footerHints(hints []footerHint) string {
+	const separator = " • "
+	tail := m.escapeHint()
+	if help := m.keys.keyFor(keyContextMain, actionHelp); help != "" {
+		tail += separator + help + ": help"
+	}
+
+	// Leave room for the footer's margin and the tail; the width is unknown until the first resize
+	room := m.width - loadingStyle.GetMarginLeft() - lipgloss.Width(tail)
+
+	var line strings.Builder
+	for _, hint := range hints {
+		keys := make([]string, 0, len(hint.actions))
+		for _, action := range hint.actions {
+			if key := m.keys.keyFor(keyContextMain, action); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+		text := strings.Join(keys, "/") + ": " + hint.label + separator
+		if m.width > 0 && lipgloss.Width(line.String()+text) > room {
+			break
+		}
+		line.WriteString(text)
+	}
+	return line.String() + tail
+}
+
+// formatKeys joins the keys of a binding for display, shortening runs of digits like 1-7
+func formatKeys(keys []string) string {
+	if len(keys) > 2 && isDigitRun(keys) {
+		return keys[0] + "-" + keys[len(keys)-1]
+	}
+	labels := make([]string, len(keys))
+	for i, key := range keys {
+		labels[i] = key
+		if label, ok := keyLabels[key]; ok {
+			labels[i] = label
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// isDigitRun reports whether the keys are consecutive digits in order
+func isDigitRun(keys []string) bool {
+	for i, key := range keys {
+		if len(key) != 1 || key[0] < '0' || key[0] > '9' || (i > 0 && key[0] != keys[i-1][0]+1) {
+			return false
+		}
+	}
+	return true
+}
+
+// helpLines returns the lines of the help screen, one section per key context
+func (m model) helpLines() []string {
+	keys := m.keys
+	if keys == nil {
+		keys = builtinKeymap
+	}
+
+	var lines []string
+	for _, context := range keys.contexts {
+		lines = append(lines, popupFieldStyle.Render(context.title+":"))
+		for _, binding := range context.bindings {
+			// Actions unbound in the config have no keys
+			if len(binding.keys) == 0 {
+				continue
+			}
+			label := lipgloss.NewStyle().Width(helpKeyWidth).Render(formatKeys(binding.keys))
+			lines = append(lines, label+" "+binding.help)
+		}
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// helpVisibleLines returns how many lines of the help screen fit on the terminal
+func (m model) helpVisibleLines() int {
+	return max(5, m.height-8)
+}
+
+// scrollHelp moves the help screen by the given number of lines, keeping it within its content
+func (m *model) scrollHelp(lines int) {
+	maxScroll := max(0, len(m.helpLines())-m.helpVisibleLines())
+	m.helpScroll = min(max(0, m.helpScroll+lines), maxScroll)
+}
+
+// renderHelp creates the help screen listing every key binding
+func (m model) renderHelp() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("❓ Keys"))
+	content.WriteString("\n\n")
+
+	// The visible part of the bindings
+	lines := m.helpLines()
+	visible := m.helpVisibleLines()
+	end := min(len(lines), m.helpScroll+visible)
+	content.WriteString(strings.Join(lines[m.helpScroll:end], "\n"))
+	content.WriteString("\n")
+	if len(lines) > visible {
+		content.WriteString(projectStyle.Render(fmt.Sprintf("Lines %d-%d of %d", m.helpScroll+1, end, len(lines))))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("↑/↓: scroll • PgUp/PgDn: page • ESC: close")
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleHelpInput handles keyboard input when the help screen is visible
+func (m model) handleHelpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The help key closes the screen too, whichever key it's bound to
+	if m.keys.action(keyContextMain, msg.String()) == actionHelp {
+		m.showingHelp = false
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "escape", "q":
+		// Close the help screen
+		m.showingHelp = false
+	case "up", "k":
+		m.scrollHelp(-1)
+	case "down", "j":
+		m.scrollHelp(1)
+	case "pgup":
+		m.scrollHelp(-m.helpVisibleLines())
+	case "pgdown":
+		m.scrollHelp(m.helpVisibleLines())
+	}
+	return m, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// changeHighlightDuration is how long new or changed tasks stay highlighted after a refresh
+const changeHighlightDuration = 8 * time.Second
+
+// clearHighlightsMsg is sent when change highlights should be removed
+// It carries the generation it was scheduled for so newer highlights are not cleared early
+type clearHighlightsMsg int
+
+// taskChanged reports whether a refreshed task differs from its previous version in a visible way
+func taskChanged(before, after TodoistTask) bool {
+	if before.Content != after.Content || before.Priority != after.Priority || before.ProjectID != after.ProjectID {
+		return true
+	}
+
+	// Compare due dates, treating a missing due date as empty
+	var beforeDue, afterDue Due
+	if before.Due != nil {
+		beforeDue = *before.Due
+	}
+	if after.Due != nil {
+		afterDue = *after.Due
+	}
+	return beforeDue.Date != afterDue.Date || beforeDue.Datetime != afterDue.Datetime
+}
+
+// diffTasks returns the IDs of tasks that are new or changed compared to the previous list
+func diffTasks(previous, current []TodoistTask) map[string]bool {
+	// Index the previous tasks by ID
+	previousByID := make(map[string]TodoistTask, len(previous))
+	for _, task := range previous {
+		previousByID[task.ID] = task
+	}
+
+	changed := make(map[string]bool)
+	for _, task := range current {
+		before, existed := previousByID[task.ID]
+		if !existed || taskChanged(before, task) {
+			changed[task.ID] = true
+		}
+	}
+	return changed
+}
+
+// markChanges highlights tasks that differ from the currently displayed list
+// Returns a command that clears the highlights after a short delay, or nil if nothing changed
+func (m *model) markChanges(tasks []TodoistTask) tea.Cmd {
+	// Nothing to compare against on the very first load
+	if len(m.allTasks) == 0 {
+		return nil
+	}
+
+	changed := diffTasks(m.allTasks, tasks)
+	if len(changed) == 0 {
+		return nil
+	}
+
+	m.changedTasks = changed
+	m.highlightGen++
+	generation := m.highlightGen
+	return tea.Tick(changeHighlightDuration, func(time.Time) tea.Msg {
+		return clearHighlightsMsg(generation)
+	})
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// historyVisibleRows is the number of completed and planned tasks each listed on the history screen
+const historyVisibleRows = 15
+
+// completionEntry records a task completed on a day
+type completionEntry struct {
+	// TaskID is the ID of the completed task
+	TaskID string
+	// Content is the task title when it was completed
+	Content string
+	// ProjectID is the project the task was in
+	ProjectID string
+	// ParentID is the task it was a subtask of, so its parent can count it as done
+	ParentID string
+	// CompletedAt is when the task was completed
+	CompletedAt time.Time
+}
+
+// plannedTask records a task that was on the today list on a day
+type plannedTask struct {
+	// TaskID is the ID of the planned task
+	TaskID string
+	// Content is the task title when it was planned
+	Content string
+	// ProjectID is the project the task was in
+	ProjectID string
+	// Priority is the task's API priority (4=urgent)
+	Priority int
+}
+
+// historyLoadedMsg is sent with the completions and plan recorded for a day
+type historyLoadedMsg struct {
+	// day is the date in YYYY-MM-DD format
+	day string
+	// completed holds the tasks completed that day, in order
+	completed []completionEntry
+	// plan holds the tasks that were on the today list that day, most urgent first
+	plan []plannedTask
+}
+
+// RecordCompletions stores completed tasks under the day they were completed, which starts at day_starts_at
+// A task completed in the app and seen again in a later sync is only kept once per day
+func (c *CacheDB) RecordCompletions(entries []completionEntry) error {
+	for _, entry := range entries {
+		if _, err := c.db.Exec(`
+			INSERT OR IGNORE INTO completion_history (task_id, day, content, project_id, parent_id, completed_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`, entry.TaskID, dayOf(entry.CompletedAt).Format("2006-01-02"), entry.Content, entry.ProjectID, entry.ParentID,
+			entry.CompletedAt.Format(time.RFC3339)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RecordPlan adds today's tasks to the day's plan snapshot
+// Tasks are only added, so the snapshot holds every task that was on the today list at some point that day
+func (c *CacheDB) RecordPlan(now time.Time, tasks []TodoistTask) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	day := now.Format("2006-01-02")
+	for _, task := range tasks {
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO plan_history (day, task_id, content, project_id, priority)
+			VALUES (?, ?, ?, ?, ?)
+		`, day, task.ID, task.Content, task.ProjectID, task.Priority); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LoadHistoryDay loads the completions and plan snapshot recorded for a day
+func (c *CacheDB) LoadHistoryDay(day string) (historyLoadedMsg, error) {
+	history := historyLoadedMsg{day: day}
+
+	rows, err := c.db.Query(`
+		SELECT task_id, content, project_id, completed_at FROM completion_history
+		WHERE day = ? ORDER BY completed_at
+	`, day)
+	if err != nil {
+		return history, err
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var entry completionEntry
+		var completedAt string
+		if err := rows.Scan(&entry.TaskID, &entry.Content, &entry.ProjectID, &completedAt); err != nil {
+			return history, err
+		}
+		entry.CompletedAt, _ = time.Parse(time.RFC3339, completedAt)
+		history.completed = append(history.completed, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return history, err
+	}
+
+	planRows, err := c.db.Query(`
+		SELECT task_id, content, project_id, priority FROM plan_history
+		WHERE day = ? ORDER BY priority DESC, content
+	`, day)
+	if err != nil {
+		return history, err
+	}
+	defer func() { _ = planRows.Close() }()
+	for planRows.Next() {
+		var task plannedTask
+		if err := planRows.Scan(&task.TaskID, &task.Content, &task.ProjectID, &task.Priority); err != nil {
+			return history, err
+		}
+		history.plan = append(history.plan, task)
+	}
+
+	return history, planRows.Err()
+}
+
+// loadHistoryDay creates a command that reads a day's history from the cache
+func loadHistoryDay(cache *CacheDB, day time.Time) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		history, err := cache.LoadHistoryDay(day.Format("2006-01-02"))
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load history: %w", err))
+		}
+		return history
+	})
+}
+
+// recordCompletion creates a command that stores a task completed in the app in the history
+// The task leaves the cache too, so its parent's subtask progress counts it as done before the next refresh
+func recordCompletion(cache *CacheDB, task TodoistTask) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		entry := completionEntry{TaskID: task.ID, Content: task.Content, ProjectID: task.ProjectID, ParentID: task.ParentID, CompletedAt: time.Now()}
+		if err := cache.RecordCompletions([]completionEntry{entry}); err != nil {
+			return errorMsg(fmt.Errorf("failed to record completion: %w", err))
+		}
+		// Recurring tasks stay open on their next date
+		if !isRecurring(task) {
+			if err := cache.RemoveTasks([]string{task.ID}); err != nil {
+				return errorMsg(fmt.Errorf("failed to remove completed task from cache: %w", err))
+			}
+		}
+		return nil
+	})
+}
+
+// openHistory shows the history screen for today
+func (m *model) openHistory() tea.Cmd {
+	m.showingHistory = true
+	m.historyDay = dayNow()
+	m.history = historyLoadedMsg{}
+	return loadHistoryDay(m.cache, m.historyDay)
+}
+
+// moveHistoryDay shows the history of the day the given number of days away, never past today
+func (m *model) moveHistoryDay(days int) tea.Cmd {
+	day := m.historyDay.AddDate(0, 0, days)
+	if day.Format("2006-01-02") > dayNow().Format("2006-01-02") {
+		return nil
+	}
+	m.historyDay = day
+	return loadHistoryDay(m.cache, day)
+}
+
+// renderHistory creates the history screen for the browsed day
+func (m model) renderHistory() string {
+	var content strings.Builder
+	history := m.history
+
+	// Screen title with the browsed day
+	title := "📜 History: " + m.historyDay.Format("Monday, Jan 2 2006")
+	if m.historyDay.Format("2006-01-02") == dayNow().Format("2006-01-02") {
+		title += " (today)"
+	}
+	content.WriteString(popupTitleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	// Tasks completed that day, in the order they were completed
+	completed := make(map[string]bool)
+	content.WriteString(popupFieldStyle.Render(fmt.Sprintf("Completed (%d):", len(history.completed))))
+	content.WriteString("\n")
+	if len(history.completed) == 0 {
+		content.WriteString(projectStyle.Render("Nothing recorded"))
+		content.WriteString("\n")
+	}
+	for i, entry := range history.completed {
+		completed[entry.TaskID] = true
+		if i == historyVisibleRows {
+			content.WriteString(projectStyle.Render(fmt.Sprintf("… and %d more", len(history.completed)-i)))
+			content.WriteString("\n")
+			break
+		}
+		content.WriteString(fmt.Sprintf("%s ✔ %s ", entry.CompletedAt.In(todayLocation()).Format("15:04"), entry.Content))
+		content.WriteString(projectStyle.Render(m.client.GetProjectName(entry.ProjectID)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// The day's plan, marking what got done
+	done := 0
+	for _, task := range history.plan {
+		if completed[task.TaskID] {
+			done++
+		}
+	}
+	content.WriteString(popupFieldStyle.Render(fmt.Sprintf("Plan (%d of %d done):", done, len(history.plan))))
+	content.WriteString("\n")
+	if len(history.plan) == 0 {
+		content.WriteString(projectStyle.Render("No snapshot, the today list wasn't loaded that day"))
+		content.WriteString("\n")
+	}
+	for i, task := range history.plan {
+		if i == historyVisibleRows {
+			content.WriteString(projectStyle.Render(fmt.Sprintf("… and %d more", len(history.plan)-i)))
+			content.WriteString("\n")
+			break
+		}
+		mark := "·"
+		if completed[task.TaskID] {
+			mark = "✔"
+		}
+		priority := lipgloss.NewStyle().Foreground(priorityColors[task.Priority]).Render(fmt.Sprintf("P%d", 5-task.Priority))
+		content.WriteString(fmt.Sprintf("%s %s %s ", mark, priority, task.Content))
+		content.WriteString(projectStyle.Render(m.client.GetProjectName(task.ProjectID)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("[: previous day • ]: next day • ESC: close")
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleHistoryInput handles keyboard input when the history screen is visible
+func (m model) handleHistoryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape", "H":
+		// Close the history screen
+		m.showingHistory = false
+	case "[":
+		return m, m.moveHistoryDay(-1)
+	case "]":
+		return m, m.moveHistoryDay(1)
+	}
+	return m, nil
+}
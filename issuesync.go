@@ -0,0 +1,437 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Issue trackers issues can be imported from
+const (
+	issueSourceJira   = "jira"
+	issueSourceGitLab = "gitlab"
+)
+
+// defaultGitLabURL is the GitLab server used when a source doesn't name one
+const defaultGitLabURL = "https://gitlab.com"
+
+// defaultIssueSyncInterval is how often issues are imported when no interval is configured
+const defaultIssueSyncInterval = 15 * time.Minute
+
+// issueSyncLimit is the most issues fetched from a source at once
+const issueSyncLimit = 100
+
+// issueKeyPrefix starts the description line holding an imported task's issue key
+const issueKeyPrefix = "Issue: "
+
+// issueKeyPattern finds the issue key line in a task description
+var issueKeyPattern = regexp.MustCompile(`(?m)^` + issueKeyPrefix + `(\S+)\s*$`)
+
+// IssueSyncConfig configures importing issues from Jira or GitLab as Todoist tasks
+type IssueSyncConfig struct {
+	// Interval is how often the sources are polled, e.g. "15m" (the default); "off" only imports at startup
+	Interval string `json:"interval,omitempty"`
+	// Sources are the issue lists to import
+	Sources []IssueSource `json:"sources"`
+}
+
+// IssueSource is a list of issues imported into a Todoist project
+// Values of URL, Email and Token may read environment variables written as ${NAME}
+type IssueSource struct {
+	// Type is jira or gitlab
+	Type string `json:"type"`
+	// URL is the server, e.g. https://example.atlassian.net; GitLab defaults to https://gitlab.com
+	URL string `json:"url,omitempty"`
+	// Query is the JQL query for Jira, or extra query parameters for GitLab's assigned issues, e.g. "labels=backend"
+	Query string `json:"query,omitempty"`
+	// Email is the Jira Cloud account the token belongs to; without it the token is sent as a bearer token
+	Email string `json:"email,omitempty"`
+	// Token is the Jira API token or personal access token, or the GitLab personal access token
+	Token string `json:"token"`
+	// Project is the name or ID of the Todoist project the issues become tasks in
+	Project string `json:"project"`
+}
+
+// name describes the source in messages
+func (s IssueSource) name() string {
+	if s.Type == issueSourceJira {
+		return "Jira"
+	}
+	return "GitLab"
+}
+
+// externalIssue is an issue fetched from a source
+type externalIssue struct {
+	// Key identifies the issue across sources, e.g. PROJ-123 or group/project#42
+	Key string
+	// Title is the issue summary
+	Title string
+	// URL opens the issue in the browser
+	URL string
+	// Due is the issue's due date as YYYY-MM-DD, if any
+	Due string
+}
+
+// issueSyncTickMsg is sent by the issue sync ticker with the time of the tick
+type issueSyncTickMsg time.Time
+
+// issuesSyncedMsg is sent when the issue sources have been polled
+// Each source is imported on its own, so the errors of failed sources don't stop the others
+type issuesSyncedMsg struct {
+	created []TodoistTask
+	errs    []error
+}
+
+// parseIssueSyncInterval reads the issue sync interval like a refresh interval, with its own default
+func parseIssueSyncInterval(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultIssueSyncInterval, nil
+	}
+	return parseRefreshInterval(value)
+}
+
+// interval returns how often the sources are polled, checked by validateIssueSync at startup
+func (c *IssueSyncConfig) interval() time.Duration {
+	interval, _ := parseIssueSyncInterval(c.Interval)
+	return interval
+}
+
+// validateIssueSync checks that every issue source is complete
+func validateIssueSync(c *IssueSyncConfig) error {
+	if c == nil {
+		return nil
+	}
+	if _, err := parseIssueSyncInterval(c.Interval); err != nil {
+		return err
+	}
+	for i, source := range c.Sources {
+		switch source.Type {
+		case issueSourceJira:
+			if source.URL == "" || source.Query == "" {
+				return fmt.Errorf("source %d: Jira sources need url and query", i+1)
+			}
+		case issueSourceGitLab:
+			if _, err := url.ParseQuery(source.Query); err != nil {
+				return fmt.Errorf("source %d: invalid GitLab query %q: %w", i+1, source.Query, err)
+			}
+		default:
+			return fmt.Errorf("source %d: unknown type %q, use %s or %s", i+1, source.Type, issueSourceJira, issueSourceGitLab)
+		}
+		if source.Token == "" || source.Project == "" {
+			return fmt.Errorf("source %d: token and project are required", i+1)
+		}
+	}
+	return nil
+}
+
+// fetchIssues fetches the issues of a source
+func fetchIssues(ctx context.Context, source IssueSource) ([]externalIssue, error) {
+	if source.Type == issueSourceJira {
+		return fetchJiraIssues(ctx, source)
+	}
+	return fetchGitLabIssues(ctx, source)
+}
+
+// fetchJiraIssues fetches the issues matching a Jira source's JQL query
+func fetchJiraIssues(ctx context.Context, source IssueSource) ([]externalIssue, error) {
+	server := strings.TrimSuffix(os.ExpandEnv(source.URL), "/")
+	query := url.Values{}
+	query.Set("jql", source.Query)
+	query.Set("fields", "summary,duedate")
+	query.Set("maxResults", fmt.Sprint(issueSyncLimit))
+
+	// Create HTTP GET request for the search endpoint
+	req, err := http.NewRequestWithContext(ctx, "GET", server+"/rest/api/2/search?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Jira Cloud takes the account email with an API token, Jira Server a personal access token
+	token := os.ExpandEnv(source.Token)
+	if source.Email != "" {
+		req.SetBasicAuth(os.ExpandEnv(source.Email), token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var result struct {
+		Issues []struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+				DueDate string `json:"duedate"`
+			} `json:"fields"`
+		} `json:"issues"`
+	}
+	if err := getIssueJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]externalIssue, 0, len(result.Issues))
+	for _, issue := range result.Issues {
+		issues = append(issues, externalIssue{
+			Key:   issue.Key,
+			Title: issue.Fields.Summary,
+			URL:   server + "/browse/" + issue.Key,
+			Due:   issue.Fields.DueDate,
+		})
+	}
+	return issues, nil
+}
+
+// fetchGitLabIssues fetches the open issues assigned to the owner of a GitLab source's token
+func fetchGitLabIssues(ctx context.Context, source IssueSource) ([]externalIssue, error) {
+	server := defaultGitLabURL
+	if source.URL != "" {
+		server = strings.TrimSuffix(os.ExpandEnv(source.URL), "/")
+	}
+	query, err := url.ParseQuery(source.Query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %w", err)
+	}
+	query.Set("scope", "assigned_to_me")
+	query.Set("state", "opened")
+	query.Set("per_page", fmt.Sprint(issueSyncLimit))
+
+	// Create HTTP GET request for the issues endpoint
+	req, err := http.NewRequestWithContext(ctx, "GET", server+"/api/v4/issues?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", os.ExpandEnv(source.Token))
+
+	var result []struct {
+		Title      string `json:"title"`
+		WebURL     string `json:"web_url"`
+		DueDate    string `json:"due_date"`
+		References struct {
+			Full string `json:"full"`
+		} `json:"references"`
+	}
+	if err := getIssueJSON(req, &result); err != nil {
+		return nil, err
+	}
+
+	issues := make([]externalIssue, 0, len(result))
+	for _, issue := range result {
+		issues = append(issues, externalIssue{
+			Key:   issue.References.Full,
+			Title: issue.Title,
+			URL:   issue.WebURL,
+			Due:   issue.DueDate,
+		})
+	}
+	return issues, nil
+}
+
+// getIssueJSON sends a request to an issue tracker and decodes its JSON response
+// Requests go outside the Todoist client, so its headers and request log stay with Todoist
+func getIssueJSON(req *http.Request, result any) error {
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// issueTaskRequest turns an issue into the task mirroring it, with the issue key in the description
+func issueTaskRequest(issue externalIssue, projectID string) NewTaskRequest {
+	return NewTaskRequest{
+		Content:     issue.Key + ": " + issue.Title,
+		Description: issue.URL + "\n\n" + issueKeyPrefix + issue.Key,
+		ProjectID:   projectID,
+		DueString:   issue.Due,
+	}
+}
+
+// taskIssueKey returns the issue key in an imported task's description
+func taskIssueKey(task TodoistTask) (string, bool) {
+	match := issueKeyPattern.FindStringSubmatch(task.Description)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
+
+// resolveIssueProject finds the Todoist project a source imports into by name or ID,
+// fetching the projects when they aren't known yet
+func resolveIssueProject(ctx context.Context, client *TodoistClient, projects []TodoistProject, name string) (string, error) {
+	if len(projects) == 0 {
+		fetched, err := client.GetProjects(ctx)
+		if err != nil {
+			return "", err
+		}
+		projects = fetched
+	}
+	for _, project := range projects {
+		if project.ID == name {
+			return project.ID, nil
+		}
+	}
+	if project := findProjectByName(projects, name); project != nil {
+		return project.ID, nil
+	}
+	return "", fmt.Errorf("project %q not found", name)
+}
+
+// importIssues creates a task for every issue of a source that hasn't been imported before
+// An issue counts as imported when an open task in the project carries its key, or the cache remembers
+// importing it, so completing or deleting the task doesn't bring it back while the issue stays open
+func importIssues(ctx context.Context, client *TodoistClient, cache *CacheDB, projects []TodoistProject, source IssueSource) ([]TodoistTask, error) {
+	projectID, err := resolveIssueProject(ctx, client, projects, source.Project)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := fetchIssues(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the keys of the issues already imported
+	imported, err := cache.LoadImportedIssues()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load imported issues: %w", err)
+	}
+	tasks, err := client.GetProjectTasks(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		if key, ok := taskIssueKey(task); ok {
+			imported[key] = true
+		}
+	}
+
+	var created []TodoistTask
+	for _, issue := range issues {
+		if issue.Key == "" || imported[issue.Key] {
+			continue
+		}
+		task, err := client.CreateTask(ctx, issueTaskRequest(issue, projectID))
+		if err != nil {
+			return created, fmt.Errorf("failed to import %s: %w", issue.Key, err)
+		}
+		if err := cache.SaveImportedIssue(issue.Key, task.ID); err != nil {
+			return created, fmt.Errorf("failed to remember imported %s: %w", issue.Key, err)
+		}
+		imported[issue.Key] = true
+		created = append(created, *task)
+	}
+	return created, nil
+}
+
+// syncIssues creates a command that imports the new issues of every source
+func syncIssues(ctx context.Context, client *TodoistClient, cache *CacheDB, projects []TodoistProject, sources []IssueSource) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		var msg issuesSyncedMsg
+		for _, source := range sources {
+			created, err := importIssues(ctx, client, cache, projects, source)
+			msg.created = append(msg.created, created...)
+			if err != nil {
+				msg.errs = append(msg.errs, fmt.Errorf("failed to import %s issues into %s: %w", source.name(), source.Project, err))
+			}
+		}
+		return msg
+	})
+}
+
+// scheduleIssueSync returns a command that fires the next issue sync tick, or nil when polling is off
+func scheduleIssueSync(interval time.Duration) tea.Cmd {
+	if interval <= 0 {
+		return nil
+	}
+	return tea.Tick(interval, func(t time.Time) tea.Msg {
+		return issueSyncTickMsg(t)
+	})
+}
+
+// startIssueSyncing imports the new issues once the first frame is up and starts polling the sources
+func (m *model) startIssueSyncing() tea.Cmd {
+	// Importing issues creates tasks, which read-only mode doesn't allow
+	if m.config == nil || m.config.IssueSync == nil || m.config.isReadOnly() {
+		return nil
+	}
+	return tea.Batch(m.startIssueSync(), scheduleIssueSync(m.config.IssueSync.interval()))
+}
+
+// startIssueSync imports the new issues unless an import is running, there's nothing to import or Todoist can't be reached
+func (m *model) startIssueSync() tea.Cmd {
+	if m.config == nil || m.config.IssueSync == nil || len(m.config.IssueSync.Sources) == 0 {
+		return nil
+	}
+	if m.syncingIssues || m.offline || m.cache == nil {
+		return nil
+	}
+	m.syncingIssues = true
+	return syncIssues(m.requests.base(), m.client, m.cache, m.projects, m.currentSettings().IssueSync.Sources)
+}
+
+// handleIssueSyncTick imports the new issues and schedules the next tick
+func (m *model) handleIssueSyncTick() tea.Cmd {
+	return tea.Batch(m.startIssueSync(), scheduleIssueSync(m.config.IssueSync.interval()))
+}
+
+// handleIssuesSynced reports the imported issues and shows their tasks where they belong
+func (m *model) handleIssuesSynced(msg issuesSyncedMsg) tea.Cmd {
+	m.syncingIssues = false
+	var cmds []tea.Cmd
+	for _, err := range msg.errs {
+		cmds = append(cmds, m.notify(toastError, err.Error()))
+	}
+	switch len(msg.created) {
+	case 0:
+	case 1:
+		cmds = append(cmds, m.notify(toastSuccess, "Imported issue: "+msg.created[0].Content), m.reloadCurrentView())
+	default:
+		cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Imported %d issues", len(msg.created))), m.reloadCurrentView())
+	}
+	return tea.Batch(cmds...)
+}
+
+// LoadImportedIssues loads the keys of the issues imported as tasks
+func (c *CacheDB) LoadImportedIssues() (map[string]bool, error) {
+	rows, err := c.db.Query("SELECT issue_key FROM imported_issues")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	imported := make(map[string]bool)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		imported[key] = true
+	}
+	return imported, rows.Err()
+}
+
+// SaveImportedIssue remembers that an issue was imported as a task
+func (c *CacheDB) SaveImportedIssue(key, taskID string) error {
+	_, err := c.db.Exec(`
+		INSERT INTO imported_issues (issue_key, task_id)
+		VALUES (?, ?)
+		ON CONFLICT(issue_key) DO UPDATE SET
+			task_id = excluded.task_id
+	`, key, taskID)
+	return err
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// syncTokenKey is the cache_metadata key holding the Sync API token of the last task sync
+const syncTokenKey = "items_sync_token"
+
+// fullSyncToken asks the Sync API for all tasks instead of the changes since an earlier sync
+const fullSyncToken = "*"
+
+// errSyncTokenInvalid is returned when the Sync API no longer accepts a stored sync token
+var errSyncTokenInvalid = errors.New("sync token is no longer valid")
+
+// syncItem is a task as returned by the Sync API, which names some fields differently from the REST API
+type syncItem struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	SectionID   string    `json:"section_id"`
+	ParentID    string    `json:"parent_id"`
+	Content     string    `json:"content"`
+	Description string    `json:"description"`
+	Checked     bool      `json:"checked"`
+	IsDeleted   bool      `json:"is_deleted"`
+	Labels      []string  `json:"labels"`
+	Priority    int       `json:"priority"`
+	Responsible string    `json:"responsible_uid"`
+	AssignedBy  string    `json:"assigned_by_uid"`
+	AddedBy     string    `json:"added_by_uid"`
+	AddedAt     time.Time `json:"added_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	Due         *Due      `json:"due"`
+	Duration    *Duration `json:"duration"`
+	CompletedAt time.Time `json:"completed_at"`
+	ChildOrder  int       `json:"child_order"`
+}
+
+// task converts a Sync API item to the REST API task the rest of the app uses
+// Items carry no comment count; watched tasks fetch theirs from the REST API
+func (item syncItem) task() TodoistTask {
+	task := TodoistTask{
+		ID:          item.ID,
+		ProjectID:   item.ProjectID,
+		SectionID:   item.SectionID,
+		ParentID:    item.ParentID,
+		Content:     item.Content,
+		Description: item.Description,
+		IsCompleted: item.Checked,
+		Labels:      item.Labels,
+		Priority:    item.Priority,
+		Assignee:    item.Responsible,
+		AssignerID:  item.AssignedBy,
+		CreatedAt:   item.AddedAt,
+		UpdatedAt:   item.UpdatedAt,
+		CreatorID:   item.AddedBy,
+		Due:         item.Due,
+		Duration:    item.Duration,
+		URL:         "https://todoist.com/showTask?id=" + item.ID,
+		Order:       item.ChildOrder,
+	}
+	if task.Labels == nil {
+		task.Labels = []string{}
+	}
+
+	// The Sync API puts the time into the date, the REST API keeps them apart
+	if task.Due != nil && len(task.Due.Date) > len("2006-01-02") {
+		due := *task.Due
+		due.Datetime = due.Date
+		due.Date = due.Date[:len("2006-01-02")]
+		task.Due = &due
+	}
+	return task
+}
+
+// completion converts a checked item to a history entry, dated now when Todoist gave no completion time
+func (item syncItem) completion() completionEntry {
+	completedAt := item.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+	return completionEntry{TaskID: item.ID, Content: item.Content, ProjectID: item.ProjectID, ParentID: item.ParentID, CompletedAt: completedAt}
+}
+
+// taskDelta holds the task changes since an earlier sync, or all tasks for a full sync
+type taskDelta struct {
+	// full is true when changed holds every active task and the cached tasks should be replaced
+	full bool
+	// changed holds the tasks that were added or changed
+	changed []TodoistTask
+	// removed holds the IDs of tasks that were completed or deleted
+	removed []string
+	// completed records the removed tasks that were completed, for the history screen
+	completed []completionEntry
+	// token is the sync token to send next time
+	token string
+}
+
+// itemsSyncResponse represents the Sync API response to a read request for items
+// The client streams it field by field; the mock server sends it whole
+type itemsSyncResponse struct {
+	SyncToken string     `json:"sync_token"`
+	FullSync  bool       `json:"full_sync"`
+	Items     []syncItem `json:"items"`
+}
+
+// SyncTasks fetches the task changes since the sync with the given token, or all tasks for fullSyncToken
+// Falls back to a full sync when Todoist no longer accepts the token
+func (c *TodoistClient) SyncTasks(ctx context.Context, token string) (taskDelta, error) {
+	if token == "" {
+		token = fullSyncToken
+	}
+	delta, err := c.syncItems(ctx, token)
+	if errors.Is(err, errSyncTokenInvalid) && token != fullSyncToken {
+		return c.syncItems(ctx, fullSyncToken)
+	}
+	return delta, err
+}
+
+// syncItems sends a single Sync API read request for items
+func (c *TodoistClient) syncItems(ctx context.Context, token string) (taskDelta, error) {
+	// Convert the read request to JSON
+	body, err := json.Marshal(map[string]any{"sync_token": token, "resource_types": []string{"items"}})
+	if err != nil {
+		return taskDelta{}, fmt.Errorf("failed to marshal sync request: %w", err)
+	}
+
+	// Create HTTP POST request for sync endpoint
+	req, err := http.NewRequestWithContext(withSyncRead(ctx), "POST", c.syncAPIBase+"/sync", bytes.NewBuffer(body))
+	if err != nil {
+		return taskDelta{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return taskDelta{}, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Todoist rejects tokens it no longer knows, e.g. after a long time offline
+	if resp.StatusCode == http.StatusBadRequest && token != fullSyncToken {
+		return taskDelta{}, errSyncTokenInvalid
+	}
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return taskDelta{}, newAPIError(resp)
+	}
+
+	// Parse the changed items one at a time, since a full sync can hold thousands
+	// Completed and deleted items are dropped from the active tasks
+	delta := taskDelta{full: token == fullSyncToken}
+	err = decodeJSONObject(resp.Body, map[string]func(*json.Decoder) error{
+		"sync_token": func(decoder *json.Decoder) error { return decoder.Decode(&delta.token) },
+		"full_sync": func(decoder *json.Decoder) error {
+			var full bool
+			err := decoder.Decode(&full)
+			delta.full = delta.full || full
+			return err
+		},
+		"items": func(decoder *json.Decoder) error {
+			return decodeArrayElements(decoder, func(item syncItem) {
+				if item.Checked || item.IsDeleted {
+					delta.removed = append(delta.removed, item.ID)
+					if item.Checked && !item.IsDeleted {
+						delta.completed = append(delta.completed, item.completion())
+					}
+					return
+				}
+				delta.changed = append(delta.changed, item.task())
+			})
+		},
+	})
+	if err != nil {
+		return taskDelta{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return delta, nil
+}
+
+// SyncToken returns the Sync API token of the last task sync, or "" when tasks were never synced
+func (c *CacheDB) SyncToken() string {
+	var token string
+	if err := c.db.QueryRow("SELECT value FROM cache_metadata WHERE key = ?", syncTokenKey).Scan(&token); err != nil {
+		return ""
+	}
+	return token
+}
+
+// ApplyTaskDelta saves the tasks from a sync along with its token
+// A full sync replaces all cached tasks; otherwise only the changed and removed tasks are touched
+func (c *CacheDB) ApplyTaskDelta(delta taskDelta) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if delta.full {
+		if _, err := tx.Exec("DELETE FROM tasks"); err != nil {
+			return err
+		}
+	}
+
+	for _, task := range delta.changed {
+		if _, err := tx.Exec(`
+			INSERT OR REPLACE INTO tasks (id, content, project_id, priority, due_date, due_string,
+				is_completed, labels, description, url, created_at, task_json)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, taskRow(task)...); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range delta.removed {
+		if _, err := tx.Exec("DELETE FROM tasks WHERE id = ?", id); err != nil {
+			return err
+		}
+	}
+
+	// Remember the token and when tasks were last updated
+	if _, err := tx.Exec("INSERT OR REPLACE INTO cache_metadata (key, value) VALUES (?, ?)", syncTokenKey, delta.token); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(
+		"INSERT OR REPLACE INTO cache_metadata (key, value) VALUES ('tasks_last_updated', ?)",
+		time.Now().Format(time.RFC3339),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// syncTasksToCache brings the cached tasks up to date through the Sync API and returns all of them
+// Only the changes since the last sync are downloaded, unless tasks were never synced or the token expired
+func syncTasksToCache(ctx context.Context, client *TodoistClient, cache *CacheDB) ([]TodoistTask, error) {
+	delta, err := client.SyncTasks(ctx, cache.SyncToken())
+	if err != nil {
+		return nil, err
+	}
+	if err := cache.ApplyTaskDelta(delta); err != nil {
+		return nil, fmt.Errorf("failed to save tasks to cache: %w", err)
+	}
+	// Tasks completed elsewhere, e.g. on the phone, show up on the history screen too
+	_ = cache.RecordCompletions(delta.completed)
+	return cache.LoadTasks()
+}
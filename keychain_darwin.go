@@ -0,0 +1,11 @@
+//go:build darwin
+
+package main
+
+import "os/exec"
+
+// readKeychain reads a generic password from the macOS Keychain through the security tool
+func readKeychain(service, account string) (string, error) {
+	output, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	return string(output), err
+}
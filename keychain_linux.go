@@ -0,0 +1,11 @@
+//go:build linux
+
+package main
+
+import "os/exec"
+
+// readKeychain reads a secret from the Secret Service (GNOME Keyring, KWallet) through secret-tool (libsecret)
+func readKeychain(service, account string) (string, error) {
+	output, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	return string(output), err
+}
@@ -0,0 +1,10 @@
+//go:build !darwin && !windows && !linux
+
+package main
+
+import "fmt"
+
+// readKeychain reports that no keychain is known on this platform
+func readKeychain(service, account string) (string, error) {
+	return "", fmt.Errorf("no supported keychain on this platform, use token_command instead")
+}
@@ -0,0 +1,23 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// readKeychain reads a web credential from the Windows Credential Manager through PowerShell
+func readKeychain(service, account string) (string, error) {
+	// Escape single quotes for PowerShell string literals
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+
+	script := fmt.Sprintf(`[void][Windows.Security.Credentials.PasswordVault,Windows.Security.Credentials,ContentType=WindowsRuntime]
+$c = (New-Object Windows.Security.Credentials.PasswordVault).Retrieve(%s, %s)
+$c.RetrievePassword()
+$c.Password`, quote(service), quote(account))
+
+	output, err := exec.Command("powershell", "-NoProfile", "-Command", script).Output()
+	return string(output), err
+}
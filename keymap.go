@@ -0,0 +1,367 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyAction names something a key does, e.g. "complete"; the names are used in the "keys" config setting
+type keyAction string
+
+// Actions of the main view, the visual-select mode and the task details popup
+const (
+	actionBack          keyAction = "back"
+	actionUp            keyAction = "up"
+	actionDown          keyAction = "down"
+	actionPageUp        keyAction = "page_up"
+	actionPageDown      keyAction = "page_down"
+	actionFirst         keyAction = "first"
+	actionLast          keyAction = "last"
+	actionScrollLeft    keyAction = "scroll_left"
+	actionScrollRight   keyAction = "scroll_right"
+	actionDetails       keyAction = "details"
+	actionComplete      keyAction = "complete"
+	actionReschedule    keyAction = "reschedule"
+	actionEdit          keyAction = "edit"
+	actionNewTask       keyAction = "new_task"
+	actionOpen          keyAction = "open"
+	actionComments      keyAction = "comments"
+	actionLink          keyAction = "link"
+	actionSkip          keyAction = "skip"
+	actionWatch         keyAction = "watch"
+	actionCut           keyAction = "cut"
+	actionPaste         keyAction = "paste"
+	actionPark          keyAction = "park"
+	actionParked        keyAction = "parked"
+	actionProjects      keyAction = "projects"
+	actionUpcoming      keyAction = "upcoming"
+	actionToggleDay     keyAction = "toggle_day"
+	actionFilter        keyAction = "filter"
+	actionSearch        keyAction = "search"
+	actionReplace       keyAction = "replace"
+	actionSelect        keyAction = "select"
+	actionTriage        keyAction = "triage"
+	actionKeepCompleted keyAction = "keep_completed"
+	actionRefresh       keyAction = "refresh"
+	actionResync        keyAction = "resync"
+	actionColumns       keyAction = "columns"
+	actionExport        keyAction = "export"
+	actionSyncStatus    keyAction = "sync_status"
+	actionStats         keyAction = "stats"
+	actionHistory       keyAction = "history"
+	actionProfiles      keyAction = "profiles"
+	actionHelp          keyAction = "help"
+	actionMessages      keyAction = "messages"
+	actionJumpRelated   keyAction = "jump_related"
+	actionMark          keyAction = "mark"
+	actionMove          keyAction = "move"
+	actionLabel         keyAction = "label"
+	actionSort          keyAction = "sort"
+	actionLabels        keyAction = "labels"
+	actionDone          keyAction = "done"
+	actionReadLater     keyAction = "read_later"
+	actionAgenda        keyAction = "agenda"
+	actionPomodoro      keyAction = "pomodoro"
+	actionTrack         keyAction = "track"
+	actionTimeReport    keyAction = "time_report"
+	actionDuplicates    keyAction = "duplicates"
+	actionStaleSweep    keyAction = "stale_sweep"
+	actionSomeday       keyAction = "someday"
+	actionPromote       keyAction = "promote"
+	actionDemote        keyAction = "demote"
+	actionNewProject    keyAction = "new_project"
+	actionCopy          keyAction = "copy"
+)
+
+// Contexts dispatched through the keymap
+const (
+	keyContextMain   = "main"
+	keyContextVisual = "visual"
+	keyContextPopup  = "popup"
+)
+
+// keyBinding ties an action to the keys that trigger it and a short description for the help screen
+type keyBinding struct {
+	action keyAction
+	keys   []string
+	help   string
+}
+
+// keyContext groups the bindings that apply in one part of the app
+type keyContext struct {
+	// name identifies the context for dispatching, empty for contexts only listed on the help screen
+	name string
+	// title heads the context's section on the help screen
+	title string
+	// bindings are the context's keys, in the order the help screen lists them
+	bindings []keyBinding
+}
+
+// defaultKeyContexts returns the built-in key bindings of every context
+// Contexts without a name are handled elsewhere with fixed keys and only listed on the help screen
+func defaultKeyContexts() []keyContext {
+	return []keyContext{
+		{
+			title: "Everywhere",
+			bindings: []keyBinding{
+				{keys: []string{"ctrl+c"}, help: "quit"},
+				{keys: []string{strings.ToLower(strings.SplitN(getDeleteShortcutText(), ":", 2)[0])}, help: "delete the selected task(s)"},
+			},
+		},
+		{
+			name:  keyContextMain,
+			title: "Task list",
+			bindings: []keyBinding{
+				{actionUp, []string{"up", "k"}, "select the previous task"},
+				{actionDown, []string{"down", "j"}, "select the next task"},
+				{actionPageUp, []string{"pgup"}, "previous page"},
+				{actionPageDown, []string{"pgdown"}, "next page"},
+				{actionFirst, []string{"home"}, "select the first task"},
+				{actionLast, []string{"end"}, "select the last task"},
+				{actionScrollLeft, []string{"left", "h"}, "scroll the selected task left (compact mode)"},
+				{actionScrollRight, []string{"right", "l"}, "scroll the selected task right (compact mode)"},
+				{actionDetails, []string{"enter", " "}, "show task details"},
+				{actionComplete, []string{"e", "E"}, "complete the selected task"},
+				{actionReschedule, []string{"t"}, "reschedule"},
+				{actionEdit, []string{"i", "I"}, "edit"},
+				{actionNewTask, []string{"q", "Q"}, "new task"},
+				{actionNewProject, []string{"n"}, "new project"},
+				{actionOpen, []string{"o", "O"}, "open in Todoist"},
+				{actionCopy, []string{"y"}, "copy as a Markdown checklist item"},
+				{actionComments, []string{"c"}, "comments"},
+				{actionLink, []string{"m"}, "link to another task"},
+				{actionSkip, []string{"N"}, "skip this occurrence of a recurring task"},
+				{actionReadLater, []string{"b"}, "send a link task to read later and complete it"},
+				{actionPomodoro, []string{"P"}, "start or stop a pomodoro for the task"},
+				{actionTrack, []string{"T"}, "start or stop tracking time on the task"},
+				{actionWatch, []string{"w", "W"}, "watch for changes"},
+				{actionCut, []string{"x"}, "cut, to paste into another view"},
+				{actionPaste, []string{"V"}, "paste the cut task into this view"},
+				{actionPark, []string{"z"}, "park until a date"},
+				{actionParked, []string{"Z"}, "show parked tasks"},
+				{actionProjects, []string{"p", "ctrl+p"}, "browse projects"},
+				{actionLabels, []string{"L"}, "browse labels"},
+				{actionSomeday, []string{"B"}, "someday/maybe backlog"},
+				{actionPromote, []string{"+"}, "promote a someday task to today"},
+				{actionDemote, []string{"-"}, "move the task to someday"},
+				{actionUpcoming, []string{"u"}, "switch between today and upcoming"},
+				{actionToggleDay, []string{"1", "2", "3", "4", "5", "6", "7"}, "collapse/expand a day (upcoming view)"},
+				{actionFilter, []string{"/"}, "filter the list"},
+				{actionSearch, []string{"ctrl+f"}, "search all open tasks"},
+				{actionDuplicates, []string{"ctrl+d"}, "find and merge duplicate tasks"},
+				{actionStaleSweep, []string{"ctrl+o"}, "sweep old undated tasks"},
+				{actionReplace, []string{"F"}, "find & replace"},
+				{actionSelect, []string{"v"}, "select several tasks"},
+				{actionTriage, []string{"!"}, "triage P1 tasks (with max_urgent_tasks)"},
+				{actionKeepCompleted, []string{"X"}, "keep completed tasks visible"},
+				{actionRefresh, []string{"r"}, "refresh"},
+				{actionResync, []string{"R"}, "full resync"},
+				{actionColumns, []string{"C"}, "columns"},
+				{actionSort, []string{"s"}, "cycle the sort order, remembered per view"},
+				{actionExport, []string{"ctrl+e"}, "export"},
+				{actionSyncStatus, []string{"S"}, "sync status"},
+				{actionStats, []string{"G"}, "stats"},
+				{actionHistory, []string{"H"}, "history"},
+				{actionDone, []string{"D"}, "recently completed tasks"},
+				{actionAgenda, []string{"a"}, "today's agenda by hour"},
+				{actionTimeReport, []string{"ctrl+t"}, "time tracked by day or week"},
+				{actionProfiles, []string{"A"}, "switch profile"},
+				{actionMessages, []string{"M"}, "message history"},
+				{actionHelp, []string{"?"}, "this help"},
+				{actionBack, []string{"esc"}, "cancel link, clear filter, back to today, or quit"},
+			},
+		},
+		{
+			name:  keyContextVisual,
+			title: "Selecting tasks",
+			bindings: []keyBinding{
+				{actionMark, []string{" "}, "select or unselect, then move down"},
+				{actionComplete, []string{"e", "E"}, "complete the selected tasks"},
+				{actionReschedule, []string{"t"}, "reschedule the selected tasks"},
+				{actionMove, []string{"p"}, "move the selected tasks to a project"},
+				{actionLabel, []string{"@"}, "add or remove a label"},
+				{actionCopy, []string{"y"}, "copy the selected tasks as a Markdown checklist"},
+				{actionSelect, []string{"v"}, "stop selecting"},
+				{actionBack, []string{"esc"}, "stop selecting"},
+			},
+		},
+		{
+			name:  keyContextPopup,
+			title: "Task details",
+			bindings: []keyBinding{
+				{actionComplete, []string{"e", "E"}, "complete"},
+				{actionReschedule, []string{"t"}, "reschedule"},
+				{actionEdit, []string{"i", "I"}, "edit"},
+				{actionOpen, []string{"o", "O"}, "open in Todoist"},
+				{actionCopy, []string{"y"}, "copy as a Markdown checklist item"},
+				{actionComments, []string{"c"}, "comments"},
+				{actionLink, []string{"m"}, "link to another task"},
+				{actionSkip, []string{"N"}, "skip this occurrence"},
+				{actionJumpRelated, []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, "jump to a related task"},
+				{actionUp, []string{"up", "k"}, "scroll up"},
+				{actionDown, []string{"down", "j"}, "scroll down"},
+				{actionPageUp, []string{"pgup"}, "scroll up a page"},
+				{actionPageDown, []string{"pgdown"}, "scroll down a page"},
+				{actionBack, []string{"esc"}, "close"},
+			},
+		},
+		{
+			title: "New and edit task form",
+			bindings: []keyBinding{
+				{keys: []string{"tab", "down"}, help: "next field"},
+				{keys: []string{"shift+tab", "up"}, help: "previous field"},
+				{keys: []string{"left", "right"}, help: "change priority, project, section or label suggestion"},
+				{keys: []string{" "}, help: "add the suggested label"},
+				{keys: []string{"enter"}, help: "save"},
+				{keys: []string{"esc"}, help: "cancel"},
+			},
+		},
+	}
+}
+
+// keymap resolves pressed keys to actions, with the bindings from the config applied
+type keymap struct {
+	// contexts are all bindings, for the help screen
+	contexts []keyContext
+	// actions maps a context name and key to the action it triggers
+	actions map[string]map[string]keyAction
+	// translations maps keys as the terminal sends them to the keys they stand for
+	translations map[string]tea.KeyMsg
+}
+
+// builtinKeymap is used when no keymap was configured, e.g. when the app couldn't start
+var builtinKeymap, _ = newKeymap(nil, nil)
+
+// newKeymap builds the keymap, replacing the keys of the actions named in overrides
+// An action's new keys apply in every context it appears in; an empty list unbinds it
+// Keys the terminal sends are translated with the platform's table and the translations given
+func newKeymap(overrides map[string][]string, translations map[string]string) (*keymap, error) {
+	contexts := defaultKeyContexts()
+	translated, err := newKeyTranslations(translations)
+	if err != nil {
+		return nil, fmt.Errorf("key_translations: %w", err)
+	}
+
+	// Only actions dispatched through the keymap can be rebound
+	known := make(map[keyAction]bool)
+	for _, context := range contexts {
+		for _, binding := range context.bindings {
+			if context.name != "" {
+				known[binding.action] = true
+			}
+		}
+	}
+	for name := range overrides {
+		if !known[keyAction(name)] {
+			return nil, fmt.Errorf("unknown action %q in keys, valid actions are: %s", name, strings.Join(actionNames(known), ", "))
+		}
+	}
+
+	k := &keymap{contexts: contexts, actions: make(map[string]map[string]keyAction), translations: translated}
+	for i, context := range contexts {
+		if context.name == "" {
+			continue
+		}
+		lookup := make(map[string]keyAction)
+		for j, binding := range context.bindings {
+			if keys, ok := overrides[string(binding.action)]; ok {
+				binding.keys = keys
+				contexts[i].bindings[j].keys = keys
+			}
+			for _, key := range binding.keys {
+				if other, taken := lookup[key]; taken && other != binding.action {
+					return nil, fmt.Errorf("key %q is bound to both %q and %q in the %s keys", key, other, binding.action, strings.ToLower(context.title))
+				}
+				lookup[key] = binding.action
+			}
+		}
+		k.actions[context.name] = lookup
+	}
+	return k, nil
+}
+
+// isDeleteKey reports whether a key deletes the selected tasks: Backspace with Alt, or Option on macOS
+// It's handled before the keymap, so it works on every screen that shows tasks
+func isDeleteKey(msg tea.KeyMsg) bool {
+	return msg.Type == tea.KeyBackspace && msg.Alt
+}
+
+// actionNames returns the sorted names of the given actions
+func actionNames(actions map[keyAction]bool) []string {
+	var names []string
+	for action := range actions {
+		names = append(names, string(action))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// action returns the action a key triggers in a context, or "" when the key is unbound there
+func (k *keymap) action(context, key string) keyAction {
+	if k == nil {
+		k = builtinKeymap
+	}
+	// Bubble Tea names the escape key "esc"
+	if key == "escape" {
+		key = "esc"
+	}
+	return k.actions[context][key]
+}
+
+// keyFor returns the first key bound to an action in a context for display, or "" when it's unbound
+func (k *keymap) keyFor(context string, action keyAction) string {
+	if k == nil {
+		k = builtinKeymap
+	}
+	for _, c := range k.contexts {
+		if c.name != context {
+			continue
+		}
+		for _, binding := range c.bindings {
+			if binding.action == action && len(binding.keys) > 0 {
+				return formatKeys(binding.keys[:1])
+			}
+		}
+	}
+	return ""
+}
+
+// keysFor returns all keys bound to an action in a context for display, or "" when it's unbound
+func (k *keymap) keysFor(context string, action keyAction) string {
+	if k == nil {
+		k = builtinKeymap
+	}
+	for _, c := range k.contexts {
+		if c.name != context {
+			continue
+		}
+		for _, binding := range c.bindings {
+			if binding.action == action && len(binding.keys) > 0 {
+				return formatKeys(binding.keys)
+			}
+		}
+	}
+	return ""
+}
+
+// keyIndex returns the position of a key among the keys of an action, e.g. 2 for "3" of the day toggles
+func (k *keymap) keyIndex(context string, action keyAction, key string) int {
+	if k == nil {
+		k = builtinKeymap
+	}
+	for _, c := range k.contexts {
+		if c.name != context {
+			continue
+		}
+		for _, binding := range c.bindings {
+			if binding.action == action {
+				return slices.Index(binding.keys, key)
+			}
+		}
+	}
+	return -1
+}
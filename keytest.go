@@ -0,0 +1,134 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// keyTestCommand is the subcommand that shows the keys the app receives, to fix bindings for a terminal
+const keyTestCommand = "keytest"
+
+// keyTestHistory is how many of the last keys the key test lists
+const keyTestHistory = 15
+
+// keyTestEntry is a key received by the key test
+type keyTestEntry struct {
+	// sent is the name of the key as the terminal sent it, or the bytes of input Bubble Tea didn't recognize
+	sent string
+	// seen is the name of the key after translation, as the rest of the app sees it
+	seen string
+	// action is what the key does in the task list, if anything
+	action string
+}
+
+// keyTestModel is the interface of the key test
+type keyTestModel struct {
+	keys     *keymap
+	received []keyTestEntry
+}
+
+// Init starts the key test without any commands
+func (m keyTestModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update records each key received; Ctrl+C quits
+func (m keyTestModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var entry keyTestEntry
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		seen := m.keys.translate(msg)
+		entry = keyTestEntry{sent: keyTestName(msg), seen: keyTestName(seen), action: keyTestAction(m.keys, seen)}
+	default:
+		// Escape sequences Bubble Tea doesn't know arrive as messages of their own, holding the raw bytes
+		value := reflect.ValueOf(msg)
+		if !strings.Contains(strings.ToLower(fmt.Sprintf("%T", msg)), "unknown") || value.Kind() != reflect.Slice ||
+			value.Type().Elem().Kind() != reflect.Uint8 {
+			return m, nil
+		}
+		entry = keyTestEntry{sent: fmt.Sprintf("%q", value.Bytes()), seen: "(not a key)", action: "-"}
+	}
+	m.received = append(m.received, entry)
+	if len(m.received) > keyTestHistory {
+		m.received = m.received[len(m.received)-keyTestHistory:]
+	}
+	return m, nil
+}
+
+// keyTestName names a key the way the "keys" and "key_translations" settings write it, with the code point of a typed character
+func keyTestName(msg tea.KeyMsg) string {
+	name := msg.String()
+	if len(msg.Runes) == 1 {
+		name = fmt.Sprintf("%s (%U)", name, msg.Runes[0])
+	}
+	if msg.Paste {
+		name += " (pasted)"
+	}
+	return name
+}
+
+// keyTestAction describes what a key does in the task list
+func keyTestAction(keys *keymap, msg tea.KeyMsg) string {
+	if isDeleteKey(msg) {
+		return "delete"
+	}
+	if action := keys.action(keyContextMain, msg.String()); action != "" {
+		return string(action)
+	}
+	return "-"
+}
+
+// View lists the last keys received, newest last
+func (m keyTestModel) View() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("⌨️  Key Test"))
+	b.WriteString("\n\n")
+	b.WriteString(loadingStyle.Render("Press keys to see what the app receives • Ctrl+C: quit"))
+	b.WriteString("\n")
+	b.WriteString(loadingStyle.Render("Map a key that arrives wrong to the one it stands for with key_translations in the config"))
+	b.WriteString("\n\n")
+
+	column := lipgloss.NewStyle().Width(28)
+	b.WriteString(popupFieldStyle.Render(column.Render("Terminal sent") + column.Render("App sees") + "Task list action"))
+	b.WriteString("\n")
+	for _, entry := range m.received {
+		b.WriteString(column.Render(entry.sent) + column.Render(entry.seen) + entry.action)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// runKeyTest runs the keytest subcommand, with the theme, keys and key translations of the config
+func runKeyTest(args []string) error {
+	flags := flag.NewFlagSet(keyTestCommand, flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	theme, err := loadTheme(config.Theme, config.Colors)
+	if err != nil {
+		return err
+	}
+	applyTheme(theme)
+	keys, err := newKeymap(config.Keys, config.KeyTranslations)
+	if err != nil {
+		return fmt.Errorf("invalid keys in config: %w", err)
+	}
+
+	if _, err := tea.NewProgram(keyTestModel{keys: keys}).Run(); err != nil {
+		return fmt.Errorf("failed to run key test: %w", err)
+	}
+	return nil
+}
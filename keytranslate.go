@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// parseKeyName turns a key name as Bubble Tea writes it, e.g. "alt+backspace", "shift+tab" or "x", into the key
+func parseKeyName(name string) (tea.KeyMsg, error) {
+	var key tea.KeyMsg
+	rest := name
+	if name != "alt+" {
+		rest, key.Alt = strings.CutPrefix(name, "alt+")
+	}
+
+	// Single characters are typed text
+	if utf8.RuneCountInString(rest) == 1 {
+		key.Type = tea.KeyRunes
+		key.Runes = []rune(rest)
+		return key, nil
+	}
+
+	// Named keys: the special keys have negative types, the control keys go up to DEL
+	for keyType := tea.KeyF20; keyType <= tea.KeyBackspace; keyType++ {
+		if keyType != tea.KeyRunes && keyType.String() == rest {
+			key.Type = keyType
+			return key, nil
+		}
+	}
+	return tea.KeyMsg{}, fmt.Errorf("unknown key %q, run \"todoist-tui %s\" to see the names of keys", name, keyTestCommand)
+}
+
+// newKeyTranslations combines the translations of the platform with those from the config, which win
+// Both map the key a terminal sends to the key the app should see, e.g. {"ctrl+h": "backspace"}
+func newKeyTranslations(configured map[string]string) (map[string]tea.KeyMsg, error) {
+	names := maps.Clone(platformKeyTranslations)
+	maps.Copy(names, configured)
+
+	translations := make(map[string]tea.KeyMsg, len(names))
+	for from, to := range names {
+		if _, err := parseKeyName(from); err != nil {
+			return nil, err
+		}
+		key, err := parseKeyName(to)
+		if err != nil {
+			return nil, err
+		}
+		translations[from] = key
+	}
+	return translations, nil
+}
+
+// translate replaces a key the terminal sent with the one it stands for, leaving other keys and pastes alone
+func (k *keymap) translate(msg tea.KeyMsg) tea.KeyMsg {
+	if k == nil {
+		k = builtinKeymap
+	}
+	if msg.Paste {
+		return msg
+	}
+	if key, ok := k.translations[msg.String()]; ok {
+		return key
+	}
+	return msg
+}
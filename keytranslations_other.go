@@ -0,0 +1,7 @@
+//go:build !windows
+
+package main
+
+// platformKeyTranslations fix up keys as terminals on this platform send them
+// Terminals here send the keys Bubble Tea expects; odd ones are fixed with key_translations in the config
+var platformKeyTranslations = map[string]string{}
@@ -0,0 +1,10 @@
+//go:build windows
+
+package main
+
+// platformKeyTranslations fix up keys as consoles and ConPTY on Windows send them
+// Some of them send Backspace as Ctrl+H, with or without Alt, where other terminals send DEL
+var platformKeyTranslations = map[string]string{
+	"ctrl+h":     "backspace",
+	"alt+ctrl+h": "alt+backspace",
+}
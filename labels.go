@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelsColumnWidth is the width of the labels column in the task table
+const labelsColumnWidth = 18
+
+// TodoistLabel represents a personal label from the Todoist API
+type TodoistLabel struct {
+	// ID is the unique identifier for the label
+	ID string `json:"id"`
+	// Name is the label name, without the leading @
+	Name string `json:"name"`
+	// Color is the Todoist color name (e.g., "berry_red")
+	Color string `json:"color"`
+	// Order is the label's position in the label list
+	Order int `json:"order"`
+	// IsFavorite indicates whether the label is marked as favorite
+	IsFavorite bool `json:"is_favorite"`
+}
+
+// todoistColors maps Todoist color names to their hex values
+var todoistColors = map[string]string{
+	"berry_red":   "#B8256F",
+	"red":         "#DB4035",
+	"orange":      "#FF9933",
+	"yellow":      "#FAD000",
+	"olive_green": "#AFB83B",
+	"lime_green":  "#7ECC49",
+	"green":       "#299438",
+	"mint_green":  "#6ACCBC",
+	"teal":        "#158FAD",
+	"sky_blue":    "#14AAF5",
+	"light_blue":  "#96C3EB",
+	"blue":        "#4073FF",
+	"grape":       "#884DFF",
+	"violet":      "#AF38EB",
+	"lavender":    "#EB96EB",
+	"magenta":     "#E05194",
+	"salmon":      "#FF8D85",
+	"charcoal":    "#808080",
+	"grey":        "#B8B8B8",
+	"taupe":       "#CCAC93",
+}
+
+// labelsLoadedMsg is sent when the personal labels have been fetched
+// Failing to load labels only disables suggestions and colors, so the error is kept separate
+type labelsLoadedMsg struct {
+	labels []TodoistLabel
+	err    error
+}
+
+// GetLabels fetches all personal labels from Todoist
+func (c *TodoistClient) GetLabels(ctx context.Context) ([]TodoistLabel, error) {
+	// Create HTTP GET request for labels endpoint
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiBase+"/labels", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// A conditional request is answered without data when nothing changed
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+
+	// Check for successful response
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse JSON response into labels slice, one label at a time
+	labels := []TodoistLabel{}
+	if err := decodeJSONArray(resp.Body, func(label TodoistLabel) { labels = append(labels, label) }); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return labels, nil
+}
+
+// loadLabels creates a command that fetches the personal labels in the background
+func loadLabels(ctx context.Context, client *TodoistClient) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		labels, err := client.GetLabels(ctx)
+		if err != nil {
+			return labelsLoadedMsg{err: fmt.Errorf("failed to load labels: %w", err)}
+		}
+		return labelsLoadedMsg{labels: labels}
+	})
+}
+
+// fuzzySearchLabels filters labels using the same in-order character matching as project search
+func fuzzySearchLabels(labels []TodoistLabel, query string) []TodoistLabel {
+	if query == "" {
+		return labels
+	}
+
+	var filtered []TodoistLabel
+	for _, label := range labels {
+		if _, ok := fuzzyMatch(label.Name, query); ok {
+			filtered = append(filtered, label)
+		}
+	}
+	return filtered
+}
+
+// currentLabelQuery returns the label being typed, after the last comma of the labels field
+func currentLabelQuery(input string) string {
+	if i := strings.LastIndex(input, ","); i >= 0 {
+		input = input[i+1:]
+	}
+	return strings.TrimPrefix(strings.TrimSpace(input), "@")
+}
+
+// completeLabel replaces the label being typed with the chosen label and starts the next one
+func completeLabel(input, name string) string {
+	prefix := ""
+	if i := strings.LastIndex(input, ","); i >= 0 {
+		prefix = input[:i+1] + " "
+	}
+	return prefix + name + ", "
+}
+
+// labelSuggestions returns the labels matching what is being typed in the create form
+func (m model) labelSuggestions() []TodoistLabel {
+	return fuzzySearchLabels(m.labels, currentLabelQuery(m.createTaskForm.labels))
+}
+
+// labelColor returns the display color of a label, falling back to charcoal for unknown labels
+func (m model) labelColor(name string) lipgloss.Color {
+	for _, label := range m.labels {
+		if label.Name == name {
+			if hex, ok := todoistColors[label.Color]; ok {
+				return lipgloss.Color(hex)
+			}
+		}
+	}
+	return lipgloss.Color(todoistColors["charcoal"])
+}
+
+// truncateLabels renders a task's labels as plain text fitting within the given width
+func truncateLabels(labels []string, width int) string {
+	var text string
+	for _, name := range labels {
+		chip := "@" + name
+		if text != "" {
+			chip = " " + chip
+		}
+		if len(text)+len(chip) > width-1 {
+			return text + "…"
+		}
+		text += chip
+	}
+	return text
+}
+
+// renderLabelChips renders a task's labels as colored chips fitting within the given width
+func (m model) renderLabelChips(labels []string, width int) string {
+	var chips []string
+	used := 0
+	for _, name := range labels {
+		chip := "@" + name
+		// Leave room for the separating space and an overflow marker
+		if used+len(chip)+1 > width-1 {
+			chips = append(chips, "…")
+			break
+		}
+		chips = append(chips, lipgloss.NewStyle().Foreground(m.labelColor(name)).Render(chip))
+		used += len(chip) + 1
+	}
+	return strings.Join(chips, " ")
+}
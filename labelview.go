@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// labelPickerVisibleRows is the number of labels shown at once in the label list
+const labelPickerVisibleRows = 10
+
+// labelTasksLoadedMsg is sent when the tasks carrying a label have been loaded
+type labelTasksLoadedMsg struct {
+	label     string
+	tasks     []TodoistTask
+	fromCache bool // Whether the tasks came from the cache while offline
+}
+
+// labelCountsLoadedMsg maps label names to the number of open tasks carrying them, counted in the cache
+type labelCountsLoadedMsg map[string]int
+
+// labelEntry is a label listed in the label list, with its number of open tasks
+type labelEntry struct {
+	name  string
+	count int
+}
+
+// loadLabelTasks creates a command that fetches the active tasks carrying a label in the background
+func loadLabelTasks(ctx context.Context, client *TodoistClient, label string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := client.GetTasksByLabel(ctx, label)
+		if err != nil {
+			return readErrorMsg(err)
+		}
+		return labelTasksLoadedMsg{label: label, tasks: tasks}
+	})
+}
+
+// countLabels creates a command that counts the open tasks of each label in the cache
+func countLabels(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(err)
+		}
+		counts := make(map[string]int)
+		for _, task := range tasks {
+			for _, label := range task.Labels {
+				counts[label]++
+			}
+		}
+		return labelCountsLoadedMsg(counts)
+	})
+}
+
+// labelEntries returns the labels matching the label list search: the personal labels in their Todoist order,
+// then labels only found on tasks, such as shared ones, by name
+func (m model) labelEntries() []labelEntry {
+	var entries []labelEntry
+	listed := make(map[string]bool)
+	for _, label := range m.labels {
+		entries = append(entries, labelEntry{name: label.Name, count: m.labelCounts[label.Name]})
+		listed[label.Name] = true
+	}
+
+	var others []labelEntry
+	for name, count := range m.labelCounts {
+		if !listed[name] {
+			others = append(others, labelEntry{name: name, count: count})
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i].name < others[j].name })
+	entries = append(entries, others...)
+
+	if m.labelPickerSearch == "" {
+		return entries
+	}
+	var matches []labelEntry
+	for _, entry := range entries {
+		if _, ok := fuzzyMatch(entry.name, m.labelPickerSearch); ok {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// openLabelPicker shows the label list and counts the tasks of each label
+func (m *model) openLabelPicker() tea.Cmd {
+	m.showingLabelPicker = true
+	m.labelPickerSearch = ""
+	m.labelPickerIdx = 0
+	if m.cache == nil {
+		return nil
+	}
+	return countLabels(m.cache)
+}
+
+// switchToLabel shows the label view for a label and loads its tasks
+func (m *model) switchToLabel(label string) tea.Cmd {
+	m.view = viewLabel
+	m.viewProjectID = ""
+	m.viewLabelName = label
+	m.tasks = nil
+	m.allTasks = nil
+	m.selectedIndex = -1
+	m.hScroll = 0
+	m.loading = true
+	return loadLabelTasks(m.requests.replace(requestGroupView), m.client, label)
+}
+
+// renderLabelPicker creates the popup listing the labels with their task counts
+func (m model) renderLabelPicker() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("🏷️ Labels"))
+	content.WriteString("\n\n")
+
+	// Search query with cursor
+	content.WriteString(popupFieldStyle.Render("Search: "))
+	content.WriteString(m.labelPickerSearch + "│")
+	content.WriteString("\n\n")
+
+	// Show a window of labels around the selection
+	entries := m.labelEntries()
+	if len(entries) == 0 {
+		content.WriteString("No matching labels")
+		content.WriteString("\n")
+	} else {
+		start := 0
+		if m.labelPickerIdx >= labelPickerVisibleRows {
+			start = m.labelPickerIdx - labelPickerVisibleRows + 1
+		}
+		end := min(start+labelPickerVisibleRows, len(entries))
+		for i := start; i < end; i++ {
+			// Counts are shown once the cache has been read
+			count := ""
+			if m.labelCounts != nil {
+				count = fmt.Sprintf(" (%d)", entries[i].count)
+			}
+			if i == m.labelPickerIdx {
+				content.WriteString(lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor).Render("→ @" + entries[i].name + count))
+			} else {
+				content.WriteString("  " + lipgloss.NewStyle().Foreground(m.labelColor(entries[i].name)).Render("@"+entries[i].name) + projectStyle.Render(count))
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString(fmt.Sprintf("\n(%d/%d)\n", m.labelPickerIdx+1, len(entries)))
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("Type: search • ↑/↓: select • Enter: open • ESC: cancel")
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleLabelPickerInput handles keyboard input when the label list is visible
+func (m model) handleLabelPickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	entries := m.labelEntries()
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the list without changing views
+		m.showingLabelPicker = false
+	case "up":
+		// Move selection up with wrap around
+		if len(entries) > 0 {
+			if m.labelPickerIdx <= 0 {
+				m.labelPickerIdx = len(entries) - 1
+			} else {
+				m.labelPickerIdx--
+			}
+		}
+	case "down":
+		// Move selection down with wrap around
+		if len(entries) > 0 {
+			if m.labelPickerIdx >= len(entries)-1 {
+				m.labelPickerIdx = 0
+			} else {
+				m.labelPickerIdx++
+			}
+		}
+	case "backspace":
+		// Remove the last search character
+		if len(m.labelPickerSearch) > 0 {
+			m.labelPickerSearch = m.labelPickerSearch[:len(m.labelPickerSearch)-1]
+			m.labelPickerIdx = 0
+		}
+	case "enter":
+		// Switch to the selected label's view and fetch its tasks
+		if m.labelPickerIdx >= 0 && m.labelPickerIdx < len(entries) {
+			m.showingLabelPicker = false
+			return m, m.switchToLabel(entries[m.labelPickerIdx].name)
+		}
+	default:
+		// Add typed characters to the search
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.labelPickerSearch += msg.String()
+			m.labelPickerIdx = 0
+		}
+	}
+	return m, nil
+}
+
+// addLabelToTask creates a command that adds a label to a task, keeping its other labels
+// Returns the updated task so the list updates like after an edit
+func addLabelToTask(ctx context.Context, client *TodoistClient, task TodoistTask, label string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		labels, _ := withLabel(task.Labels, label, true)
+		updated, err := client.UpdateTask(ctx, task.ID, UpdateTaskRequest{Labels: &labels})
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to paste task: %w", err))
+		}
+		return taskUpdatedMsg(*updated)
+	})
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// columnSpec sizes a table column: a fixed width, or a share of the width left over by the fixed columns
+type columnSpec struct {
+	// width is the width of a fixed column, 0 for flexible ones
+	width int
+	// flex is the weight of a flexible column's share of the leftover width
+	flex int
+	// min and max bound a flexible column's width; max 0 leaves it unbounded
+	min int
+	max int
+}
+
+// defaultColumnSpecs size the columns unless configured otherwise
+// The task column takes what the others leave, and the project column gives up a few cells on narrow terminals
+var defaultColumnSpecs = map[string]columnSpec{
+	"priority": {width: 8},
+	"task":     {flex: 1, min: 20},
+	"project":  {flex: 1, min: 15, max: 20},
+	"labels":   {width: labelsColumnWidth},
+	"due":      {width: 14},
+	"age":      {width: 4},
+}
+
+// columnMargins is the space the style of each column keeps to its left
+var columnMargins = map[string]int{
+	"priority": 4,
+	"task":     4,
+	"project":  4,
+	"labels":   4,
+	"due":      4,
+	"age":      4,
+}
+
+// tableRightMargin is the width kept free to the right of the table
+const tableRightMargin = 6
+
+// parseColumnSpec reads a column width: "20" for a fixed width, "flex" or "flex2" for a weighted share of
+// the leftover width, or "10-30" for a share bounded by a minimum and maximum, weighted with a suffix like "10-30/2"
+func parseColumnSpec(value string) (columnSpec, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+
+	// Fixed width
+	if width, err := strconv.Atoi(value); err == nil {
+		if width < 1 {
+			return columnSpec{}, fmt.Errorf("invalid width %q: must be at least 1", value)
+		}
+		return columnSpec{width: width}, nil
+	}
+
+	// Flexible width with an optional weight
+	if weight, ok := strings.CutPrefix(value, "flex"); ok {
+		spec := columnSpec{flex: 1, min: 1}
+		if weight != "" {
+			flex, err := strconv.Atoi(weight)
+			if err != nil || flex < 1 {
+				return columnSpec{}, fmt.Errorf("invalid flex weight %q: must be a whole number of at least 1", weight)
+			}
+			spec.flex = flex
+		}
+		return spec, nil
+	}
+
+	// Bounded flexible width, e.g. "10-30" or "10-30/2"
+	bounds, weight, weighted := strings.Cut(value, "/")
+	low, high, ranged := strings.Cut(bounds, "-")
+	if !ranged {
+		return columnSpec{}, fmt.Errorf("invalid width %q: use a number like 20, flex, flex2 or a range like 10-30", value)
+	}
+	minWidth, errMin := strconv.Atoi(low)
+	maxWidth, errMax := strconv.Atoi(high)
+	if errMin != nil || errMax != nil || minWidth < 1 || maxWidth < minWidth {
+		return columnSpec{}, fmt.Errorf("invalid width range %q: use two widths like 10-30, the smaller first", bounds)
+	}
+	spec := columnSpec{flex: 1, min: minWidth, max: maxWidth}
+	if weighted {
+		flex, err := strconv.Atoi(weight)
+		if err != nil || flex < 1 {
+			return columnSpec{}, fmt.Errorf("invalid flex weight %q: must be a whole number of at least 1", weight)
+		}
+		spec.flex = flex
+	}
+	return spec, nil
+}
+
+// parseColumnList reads a --columns value like "priority:8,task:flex,project:20"
+// Returns the column names in order and the widths given for some of them
+func parseColumnList(value string) ([]string, map[string]columnSpec, error) {
+	var columns []string
+	specs := make(map[string]columnSpec)
+	for _, part := range strings.Split(value, ",") {
+		name, width, sized := strings.Cut(strings.TrimSpace(part), ":")
+		name = strings.TrimSpace(name)
+		if !isValidColumn(name) {
+			return nil, nil, fmt.Errorf("invalid column: %s. Valid columns are: %s", name, strings.Join(availableColumns, ", "))
+		}
+		columns = append(columns, name)
+		if sized {
+			spec, err := parseColumnSpec(width)
+			if err != nil {
+				return nil, nil, fmt.Errorf("column %s: %w", name, err)
+			}
+			specs[strings.ToLower(name)] = spec
+		}
+	}
+	return columns, specs, nil
+}
+
+// columnSpecs combines the default widths with the configured ones and those given with --columns, which win
+func columnSpecs(configured map[string]string, flagged map[string]columnSpec) (map[string]columnSpec, error) {
+	specs := make(map[string]columnSpec, len(defaultColumnSpecs))
+	for name, spec := range defaultColumnSpecs {
+		specs[name] = spec
+	}
+	for name, value := range configured {
+		if !isValidColumn(name) {
+			return nil, fmt.Errorf("unknown column %q, valid columns are: %s", name, strings.Join(availableColumns, ", "))
+		}
+		spec, err := parseColumnSpec(value)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %w", name, err)
+		}
+		specs[strings.ToLower(name)] = spec
+	}
+	for name, spec := range flagged {
+		specs[name] = spec
+	}
+	return specs, nil
+}
+
+// layoutColumns works out the width of each column within the available width
+// Fixed columns get their width and flexible ones their minimum; the rest is shared out by weight,
+// with the share of columns that reach their maximum going to the others
+func layoutColumns(columns []string, specs map[string]columnSpec, available int) map[string]int {
+	widths := make(map[string]int, len(columns))
+	flexible := make(map[string]columnSpec)
+	left := available
+	for _, column := range columns {
+		name := strings.ToLower(column)
+		spec, ok := specs[name]
+		if !ok {
+			spec = defaultColumnSpecs[name]
+		}
+		left -= columnMargins[name]
+		if spec.flex == 0 {
+			widths[name] = spec.width
+			left -= spec.width
+			continue
+		}
+		widths[name] = max(spec.min, 1)
+		left -= widths[name]
+		flexible[name] = spec
+	}
+
+	// Share out the leftover width until it's used up or every flexible column is at its maximum
+	for left > 0 && len(flexible) > 0 {
+		weights := 0
+		for _, spec := range flexible {
+			weights += spec.flex
+		}
+		given := 0
+		for _, column := range columns {
+			name := strings.ToLower(column)
+			spec, ok := flexible[name]
+			if !ok {
+				continue
+			}
+			share := max(left*spec.flex/weights, 1)
+			if spec.max > 0 {
+				share = min(share, spec.max-widths[name])
+			}
+			share = min(share, left-given)
+			widths[name] += share
+			given += share
+			if spec.max > 0 && widths[name] >= spec.max {
+				delete(flexible, name)
+			}
+		}
+		if given == 0 {
+			break
+		}
+		left -= given
+	}
+	return widths
+}
+
+// columnWidth returns the width of a column in the current terminal, or 0 when it isn't shown
+func (m model) columnWidth(column string) int {
+	return m.layoutWidths()[strings.ToLower(column)]
+}
+
+// layoutWidths lays out the shown columns across the terminal width
+func (m model) layoutWidths() map[string]int {
+	specs := m.columnSpecs
+	if specs == nil {
+		specs = defaultColumnSpecs
+	}
+	return layoutColumns(m.columns, specs, m.width-tableRightMargin)
+}
+
+// dueColumnText writes a task's due date briefly for the due column, e.g. "today 17:00", "Fri" or "3w overdue"
+// Also reports whether the task is overdue, by date or by a time already passed today
+func dueColumnText(task TodoistTask, now time.Time) (string, bool) {
+	days, ok := daysOverdue(task, now)
+	if !ok {
+		if task.Due != nil {
+			return task.Due.Date, false
+		}
+		return "", false
+	}
+	if days > 0 {
+		return overdueAge(days) + " overdue", true
+	}
+
+	var text string
+	day, _ := time.ParseInLocation("2006-01-02", task.Due.Date, time.Local)
+	switch {
+	case days == 0:
+		text = "today"
+	case days == -1:
+		text = "tomorrow"
+	case days > -7:
+		text = day.Format("Mon")
+	case day.Year() == now.Year():
+		text = day.Format("Jan 2")
+	default:
+		text = day.Format("Jan 2 2006")
+	}
+
+	// Add the time for tasks due at one
+	dueTime, timed := parseDueTime(task.Due)
+	if timed {
+		text += " " + dueTime.Local().Format("15:04")
+	}
+	return text, timed && days == 0 && dueTime.Before(now)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxRelatedShown is the number of related tasks listed in the popup, each jumpable with a digit key
+const maxRelatedShown = 9
+
+// taskLink is one direction of a local link between two tasks
+type taskLink struct {
+	// TaskID is the task the link belongs to
+	TaskID string
+	// RelatedID is the linked task
+	RelatedID string
+	// RelatedContent is the linked task's content when the link was made
+	RelatedContent string
+	// RelatedProjectID is the linked task's project, used to jump to it
+	RelatedProjectID string
+}
+
+// linksLoadedMsg is sent with all task links, grouped by task ID
+type linksLoadedMsg map[string][]taskLink
+
+// LoadLinks loads all task links from the cache
+func (c *CacheDB) LoadLinks() ([]taskLink, error) {
+	rows, err := c.db.Query("SELECT task_id, related_id, related_content, related_project_id FROM task_links ORDER BY linked_at")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var links []taskLink
+	for rows.Next() {
+		var link taskLink
+		if err := rows.Scan(&link.TaskID, &link.RelatedID, &link.RelatedContent, &link.RelatedProjectID); err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// ToggleLink links two tasks in both directions, or unlinks them if they were already linked
+func (c *CacheDB) ToggleLink(a, b TodoistTask) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Remove an existing link first; if there was one, that's all
+	result, err := tx.Exec(`
+		DELETE FROM task_links
+		WHERE (task_id = ? AND related_id = ?) OR (task_id = ? AND related_id = ?)
+	`, a.ID, b.ID, b.ID, a.ID)
+	if err != nil {
+		return err
+	}
+	if removed, _ := result.RowsAffected(); removed > 0 {
+		return tx.Commit()
+	}
+
+	insert := "INSERT INTO task_links (task_id, related_id, related_content, related_project_id) VALUES (?, ?, ?, ?)"
+	if _, err := tx.Exec(insert, a.ID, b.ID, b.Content, b.ProjectID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(insert, b.ID, a.ID, a.Content, a.ProjectID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// groupLinks indexes links by the task they belong to
+func groupLinks(links []taskLink) linksLoadedMsg {
+	grouped := make(linksLoadedMsg)
+	for _, link := range links {
+		grouped[link.TaskID] = append(grouped[link.TaskID], link)
+	}
+	return grouped
+}
+
+// loadLinks creates a command that reads the task links from the cache
+func loadLinks(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		links, err := cache.LoadLinks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load task links: %w", err))
+		}
+		return groupLinks(links)
+	})
+}
+
+// toggleLink creates a command that links or unlinks two tasks
+func toggleLink(cache *CacheDB, a, b TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.ToggleLink(a, b); err != nil {
+			return errorMsg(fmt.Errorf("failed to link tasks: %w", err))
+		}
+		return loadLinks(cache)()
+	})
+}
+
+// handleLinkKey starts linking from the selected task, or links the selected task to the one picked earlier
+func (m *model) handleLinkKey() tea.Cmd {
+	if m.cache == nil || m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	task := m.allTasks[m.selectedIndex]
+
+	// First press picks the source task; the list stays usable to find the other one
+	if m.linkSource == nil {
+		m.linkSource = &task
+		return nil
+	}
+
+	// Pressing it on the source again cancels
+	source := *m.linkSource
+	m.linkSource = nil
+	if source.ID == task.ID {
+		return nil
+	}
+	return toggleLink(m.cache, source, task)
+}
+
+// renderLinkStatus describes the pending link while a source task is picked
+func (m model) renderLinkStatus() string {
+	if m.linkSource == nil {
+		return ""
+	}
+	return staleStyle.Render("🔗 Linking \"" + m.linkSource.Content + "\" — select another task and press m • ESC cancels")
+}
+
+// renderRelatedTasks lists a task's linked tasks for the task popup
+func (m model) renderRelatedTasks(taskID string) string {
+	links := m.taskLinks[taskID]
+	if len(links) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(popupFieldStyle.Render("Related: "))
+	b.WriteString("\n")
+	for i, link := range links {
+		if i == maxRelatedShown {
+			b.WriteString(fmt.Sprintf("   …and %d more\n", len(links)-maxRelatedShown))
+			break
+		}
+		b.WriteString(fmt.Sprintf("%d. %s (%s)\n", i+1, link.RelatedContent, m.client.GetProjectName(link.RelatedProjectID)))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// jumpToRelated selects the nth related task of the selected task, opening its project if it isn't listed
+func (m *model) jumpToRelated(n int) tea.Cmd {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	links := m.taskLinks[m.allTasks[m.selectedIndex].ID]
+	if n < 0 || n >= len(links) || n >= maxRelatedShown {
+		return nil
+	}
+	return m.jumpToTask(links[n].RelatedID, links[n].RelatedProjectID)
+}
+
+// jumpToTask selects a task, opening its project if it isn't in the current list
+func (m *model) jumpToTask(taskID, projectID string) tea.Cmd {
+	// Select it directly if it is in the current list
+	for i, task := range m.allTasks {
+		if task.ID == taskID {
+			m.selectedIndex = i
+			m.hScroll = 0
+			return nil
+		}
+	}
+
+	// Otherwise open its project and select it once loaded
+	m.showingPopup = false
+	m.view = viewProject
+	m.viewProjectID = projectID
+	m.pendingSelectID = taskID
+	m.selectedIndex = -1
+	m.hScroll = 0
+	m.loading = true
+	return loadProjectTasks(m.requests.replace(requestGroupView), m.client, projectID)
+}
+
+// selectPendingTask selects the task a jump was waiting for, if it is in the list
+func (m *model) selectPendingTask() {
+	if m.pendingSelectID == "" {
+		return
+	}
+	for i, task := range m.allTasks {
+		if task.ID == m.pendingSelectID {
+			m.selectedIndex = i
+			break
+		}
+	}
+	m.pendingSelectID = ""
+}
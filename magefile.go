@@ -172,6 +172,12 @@ func Dev() error {
 	return sh.Run("go", "run", ".")
 }
 
+// Mock runs the mock Todoist server with the demo fixtures for local development
+func Mock() error {
+	fmt.Println("Starting mock Todoist server...")
+	return sh.Run("go", "run", ".", "mock-server")
+}
+
 // Deps downloads and tidy dependencies
 func Deps() error {
 	fmt.Println("Downloading dependencies...")
@@ -1,82 +1,61 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
-	"runtime"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/pkg/browser"
 )
 
 // Global styling variables for the TUI interface
+// They are built from the active theme by applyTheme
 var (
 	// titleStyle defines the styling for section titles
-	titleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")).
-			MarginLeft(2)
+	titleStyle lipgloss.Style
 
 	// headerStyle defines the styling for table headers
-	headerStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#374151")).
-			MarginLeft(4).
-			MarginBottom(1)
+	headerStyle lipgloss.Style
 
 	// taskStyle defines the base styling for task content
-	taskStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#374151")).
-			MarginLeft(4)
+	taskStyle lipgloss.Style
 
 	// projectStyle defines the styling for project names
-	projectStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
-			Italic(true)
+	projectStyle lipgloss.Style
 
 	// priorityColors maps priority levels to their display colors
-	priorityColors = map[int]lipgloss.Color{
-		1: lipgloss.Color("#9CA3AF"), // Low priority - light gray
-		2: lipgloss.Color("#6366F1"), // Normal priority - soft indigo
-		3: lipgloss.Color("#F59E0B"), // High priority - amber
-		4: lipgloss.Color("#F97316"), // Urgent priority - orange (less harsh than red)
-	}
+	priorityColors map[int]lipgloss.Color
 
 	// errorStyle defines the styling for error messages
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#EF4444")).
-			MarginLeft(2)
+	errorStyle lipgloss.Style
+
+	// staleStyle defines the styling for offline and stale data indicators
+	staleStyle lipgloss.Style
 
 	// loadingStyle defines the styling for loading and status messages
-	loadingStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#6B7280")).
-			MarginLeft(2)
+	loadingStyle lipgloss.Style
 
 	// popupStyle defines the styling for the task details popup
-	popupStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#7C3AED")).
-			Padding(1, 2).
-			Foreground(lipgloss.Color("#374151"))
+	popupStyle lipgloss.Style
 
 	// popupTitleStyle defines the styling for popup titles
-	popupTitleStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#7C3AED")).
-			MarginBottom(1)
+	popupTitleStyle lipgloss.Style
 
 	// popupFieldStyle defines the styling for popup field labels
-	popupFieldStyle = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#4B5563"))
+	popupFieldStyle lipgloss.Style
 
 	// Selection colors for highlighting selected tasks
-	selectionBgColor = lipgloss.Color("#EDE9FE") // Light purple background
-	selectionFgColor = lipgloss.Color("#5B21B6") // Dark purple foreground
+	selectionBgColor lipgloss.Color
+	selectionFgColor lipgloss.Color
+
+	// changedBgColor highlights tasks that are new or changed after a refresh
+	changedBgColor lipgloss.Color
 )
 
 // model represents the application state for the Bubble Tea TUI
@@ -101,6 +80,8 @@ type model struct {
 	selectedIndex int
 	// showingPopup indicates whether the task details popup is visible
 	showingPopup bool
+	// popupScroll is the first line of details shown in the popup
+	popupScroll int
 	// allTasks holds the complete list of tasks for navigation (overdue + today)
 	allTasks []TodoistTask
 	// projects holds the list of available projects
@@ -117,6 +98,360 @@ type model struct {
 	taskToDelete string
 	// refreshingInBackground indicates if cache refresh is happening
 	refreshingInBackground bool
+	// showingSyncStatus indicates whether the sync status screen is visible
+	showingSyncStatus bool
+	// confirmingClearOps indicates the sync status screen asks before dropping the queued changes
+	confirmingClearOps bool
+	// showingProfiles indicates whether the profile switcher is visible
+	showingProfiles bool
+	// profileIdx is the highlighted profile in the switcher
+	profileIdx int
+	// switchProfile names the profile to restart as after quitting, "" to exit
+	switchProfile string
+	// showingHistory indicates whether the history screen is visible
+	showingHistory bool
+	// historyDay is the day browsed on the history screen
+	historyDay time.Time
+	// history holds the completions and plan of the browsed day
+	history historyLoadedMsg
+	// showingDone indicates whether the done screen of recently completed tasks is visible
+	showingDone bool
+	// doneTasks holds the recently completed tasks, newest first
+	doneTasks []TodoistCompletedTask
+	// doneIdx is the highlighted task on the done screen
+	doneIdx int
+	// doneLoading indicates whether the completed tasks are being fetched
+	doneLoading bool
+	// doneError describes why the completed tasks couldn't be fetched
+	doneError string
+	// showingAgenda indicates whether the agenda of today's timed tasks is visible
+	showingAgenda bool
+	// agenda holds today's timed tasks laid out by hour
+	agenda agendaLoadedMsg
+	// showingDuplicates indicates whether the assistant merging duplicate tasks is visible
+	showingDuplicates bool
+	// duplicatePairs holds the near-duplicate tasks found by the assistant
+	duplicatePairs []duplicatePair
+	// duplicateIndex is the pair shown by the assistant
+	duplicateIndex int
+	// duplicatesScanned is set once the scan for duplicates has finished
+	duplicatesScanned bool
+	// duplicateMerging is set while a duplicate is being merged
+	duplicateMerging bool
+	// showingStaleSweep indicates whether the sweep of stale tasks is visible
+	showingStaleSweep bool
+	// staleSweep is which tasks count as stale and how often to remind of them
+	staleSweep staleSweep
+	// staleTasks holds the stale tasks found by the sweep
+	staleTasks []TodoistTask
+	// staleIndex is the task shown by the sweep
+	staleIndex int
+	// staleScanned is set once the scan for stale tasks has finished
+	staleScanned bool
+	// staleBusy is set while a stale task is being deleted, labeled or scheduled
+	staleBusy bool
+	// staleConfirmDelete is set while the sweep asks to confirm deleting a task
+	staleConfirmDelete bool
+	// staleScheduling is set while a due date for a stale task is typed
+	staleScheduling bool
+	// staleDueInput is the due date typed for a stale task
+	staleDueInput string
+	// showingTimeReport indicates whether the report of tracked time is visible
+	showingTimeReport bool
+	// timeReport holds the time entries of the shown day or week
+	timeReport timeReportLoadedMsg
+	// timeReportDay is the day shown on the time report, or a day of the shown week
+	timeReportDay time.Time
+	// timeReportWeekly shows the week of timeReportDay instead of the day
+	timeReportWeekly bool
+	// timeReportResult is the outcome of the last CSV export of the time report
+	timeReportResult string
+	// showingStats indicates whether the stats screen is visible
+	showingStats bool
+	// toast is the message shown in the status bar, nil when none
+	toast *toast
+	// toastSeq numbers the toasts, so an expired one doesn't dismiss a newer one
+	toastSeq int
+	// toastHistory holds the recent toasts, oldest first, for the message history popup
+	toastHistory []toast
+	// showingMessages indicates whether the message history popup is visible
+	showingMessages bool
+	// showingHelp indicates whether the help screen is visible
+	showingHelp bool
+	// helpScroll is the first line shown on the help screen
+	helpScroll int
+	// stats holds the open task breakdown shown on the stats screen
+	stats statsLoadedMsg
+	// syncErrors holds the most recent errors for the sync status screen
+	syncErrors []syncErrorEntry
+	// lastTasksSync is when tasks were last fetched from the API
+	lastTasksSync time.Time
+	// lastProjectsSync is when projects were last fetched from the API
+	lastProjectsSync time.Time
+	// config holds the persisted user preferences
+	config *Config
+	// showingColumnMenu indicates whether the column toggle menu is visible
+	showingColumnMenu bool
+	// columnMenuIdx is the index of the selected entry in the column menu
+	columnMenuIdx int
+	// compact renders each task on a single line instead of wrapping
+	compact bool
+	// hScroll is the horizontal scroll offset of the selected task in compact mode
+	hScroll int
+	// changedTasks holds the IDs of tasks that are new or changed since the last refresh
+	changedTasks map[string]bool
+	// highlightGen identifies the latest set of change highlights
+	highlightGen int
+	// view is the set of tasks the main list is showing
+	view viewMode
+	// viewProjectID is the project being browsed in the project view
+	viewProjectID string
+	// viewLabelName is the label whose tasks the label view shows
+	viewLabelName string
+	// someday is the label and backlog project making up the someday/maybe backlog
+	someday somedaySettings
+	// session counts the tasks created and rescheduled since the app started, for the summary on exit
+	session sessionCounts
+	// tutorial is set when running the tutorial, which prompts for one step after another
+	tutorial bool
+	// tutorialStep is the index of the current step in tutorialSteps
+	tutorialStep int
+	// showingLabelPicker indicates whether the label list is visible
+	showingLabelPicker bool
+	// labelPickerSearch is the search query in the label list
+	labelPickerSearch string
+	// labelPickerIdx is the index of the selected label among the matching labels
+	labelPickerIdx int
+	// labelCounts maps label names to their number of open tasks, nil until counted
+	labelCounts map[string]int
+	// showingProjectPicker indicates whether the project picker is visible
+	showingProjectPicker bool
+	// projectPickerSearch is the search query in the project picker
+	projectPickerSearch string
+	// projectPickerResults holds the projects matching the picker search
+	projectPickerResults []TodoistProject
+	// projectPickerIdx is the index of the selected project in the picker results
+	projectPickerIdx int
+	// watchedTasks maps watched task IDs to whether they have unseen changes
+	watchedTasks map[string]bool
+	// subtaskProgress maps parent task IDs to how many of their subtasks are done
+	subtaskProgress map[string]subtaskCount
+	// offline indicates Todoist could not be reached and cached data is shown
+	offline bool
+	// cachedAt is when the displayed cached data was fetched, zero when showing fresh data
+	cachedAt time.Time
+	// cacheStale indicates the displayed cached data is older than cacheMaxAge
+	cacheStale bool
+	// pendingOps is the queue of task changes made while offline, replayed when back online
+	pendingOps []pendingOperation
+	// replayingOps indicates queued operations are currently being sent to Todoist
+	replayingOps bool
+	// showingReschedule indicates whether the reschedule popup is visible
+	showingReschedule bool
+	// showingNewProject indicates whether the prompt for a new project's name is visible
+	showingNewProject bool
+	// newProjectName is the name typed for the new project
+	newProjectName textinput.Model
+	// creatingProject is set while the new project is being created
+	creatingProject bool
+	// rescheduleTaskID is the ID of the task being rescheduled
+	rescheduleTaskID string
+	// rescheduleIdx is the selected preset, or len(reschedulePresets) for a custom date
+	rescheduleIdx int
+	// rescheduleCustom is the custom due date typed into the reschedule popup
+	rescheduleCustom string
+	// taskLinks maps task IDs to their locally linked tasks
+	taskLinks map[string][]taskLink
+	// linkSource is the task picked as the first end of a new link, nil when not linking
+	linkSource *TodoistTask
+	// pendingSelectID is a task to select once the current view finishes loading
+	pendingSelectID string
+	// labels holds the user's personal labels for suggestions and colors
+	labels []TodoistLabel
+	// collaborators maps user IDs to the people sharing projects, for assignee badges; nil until loaded
+	collaborators map[string]TodoistCollaborator
+	// projectCollaborators maps shared project IDs to the user IDs of the people actively sharing them
+	projectCollaborators map[string][]string
+	// sections holds the sections of all projects for grouping and the section picker
+	sections []TodoistSection
+	// parkedTasks holds the tasks hidden locally until a date, soonest first
+	parkedTasks []parkedTask
+	// showingPark indicates whether the park popup is visible
+	showingPark bool
+	// parkTaskID is the ID of the task being parked
+	parkTaskID string
+	// parkIdx is the selected preset, or len(parkPresets) for a custom date
+	parkIdx int
+	// parkCustom is the custom date typed into the park popup
+	parkCustom string
+	// parkError describes why the entered park date was rejected
+	parkError string
+	// showingParked indicates whether the parked tasks screen is visible
+	showingParked bool
+	// parkedIdx is the index of the selected entry on the parked screen
+	parkedIdx int
+	// showingReplace indicates whether the find and replace screen is visible
+	showingReplace bool
+	// replaceTasks holds the open tasks find and replace searches, loaded from the cache when opened
+	replaceTasks []TodoistTask
+	// replaceFind is the text to find in task contents
+	replaceFind string
+	// replaceWith is the text replacing each match
+	replaceWith string
+	// replaceField is the find and replace field being typed into
+	replaceField replaceField
+	// replaceConfirm indicates whether find and replace is waiting for confirmation
+	replaceConfirm bool
+	// showingSearch indicates whether the search screen is visible
+	showingSearch bool
+	// searchTasks holds the open tasks being searched, loaded from the cache when the screen opens
+	searchTasks []TodoistTask
+	// searchQuery is the text or regex typed into the search screen
+	searchQuery string
+	// searchRegex indicates whether the search query is a regular expression
+	searchRegex bool
+	// searchIdx is the index of the selected search result
+	searchIdx int
+	// filtering indicates whether the filter prompt is open for typing
+	filtering bool
+	// filterQuery narrows the listed tasks by content, project name and labels
+	filterQuery string
+	// urgentTasks holds all open P1 tasks, counted when a P1 limit is configured
+	urgentTasks []TodoistTask
+	// showingTriage indicates whether the P1 triage screen is visible
+	showingTriage bool
+	// triageIdx is the index of the selected task on the triage screen
+	triageIdx int
+	// visualMode indicates whether tasks are being selected for a bulk action
+	visualMode bool
+	// markedTasks holds the IDs of the tasks selected in visual-select mode
+	markedTasks map[string]bool
+	// showingBulkConfirm indicates whether the bulk action confirmation is visible
+	showingBulkConfirm bool
+	// bulkAction is the bulk action awaiting confirmation
+	bulkAction string
+	// bulkArg is the due string or project ID for the bulk action
+	bulkArg string
+	// bulkLabel describes bulkArg in the confirmation
+	bulkLabel string
+	// showingCompleteChoice indicates whether the dialog choosing how to complete a recurring task is visible
+	showingCompleteChoice bool
+	// showingCompleteParent indicates whether the dialog offering to complete a task whose subtasks are all done is visible
+	showingCompleteParent bool
+	// completeParentTask is the task offered for completion once its last subtask was completed
+	completeParentTask TodoistTask
+	// completeChoiceTask is the recurring task being completed
+	completeChoiceTask TodoistTask
+	// showingBulkLabel indicates whether the popup picking a label for the selected tasks is visible
+	showingBulkLabel bool
+	// bulkLabelRemove is whether the bulk label popup removes the label instead of adding it
+	bulkLabelRemove bool
+	// bulkLabelQuery is the label name typed in the bulk label popup
+	bulkLabelQuery string
+	// bulkLabelIdx is the index of the highlighted label in the bulk label popup
+	bulkLabelIdx int
+	// projectPickerMove indicates whether the project picker chooses where to move the selected tasks
+	projectPickerMove bool
+	// keepCompleted keeps tasks completed this session visible, struck through, until the next refresh
+	keepCompleted bool
+	// completedTasks holds the IDs of tasks completed this session that are still shown
+	completedTasks map[string]bool
+	// completionQueue holds the tasks completed on screen and waiting to be sent to Todoist in a batch
+	completionQueue []queuedCompletion
+	// completionsSending holds the tasks of the completion batch on its way to Todoist
+	completionsSending []queuedCompletion
+	// pendingCreates holds the tasks shown before Todoist confirmed creating them, by placeholder ID
+	pendingCreates map[string]pendingCreate
+	// pendingDeletes holds the tasks taken out of the list before Todoist confirmed deleting them
+	pendingDeletes map[string]pendingDelete
+	// pendingSeq numbers the placeholders of tasks being created
+	pendingSeq int
+	// pendingFrame is the frame of the glyph shown in front of tasks being created
+	pendingFrame int
+	// pendingSpinning indicates the glyph of tasks being created is being animated
+	pendingSpinning bool
+	// completionGeneration counts the completions queued, so only the flush scheduled after the last one sends them
+	completionGeneration int
+	// showingComments indicates whether the comments popup is visible
+	showingComments bool
+	// commentsTaskID is the ID of the task whose comments are shown
+	commentsTaskID string
+	// comments holds the comments of the task, oldest first
+	comments []TodoistComment
+	// commentsLoading indicates whether the comments are still being fetched
+	commentsLoading bool
+	// commentsScroll is the first comment line shown in the popup
+	commentsScroll int
+	// commentDraft is the new comment being typed
+	commentDraft string
+	// commentPosting indicates whether the draft is being posted
+	commentPosting bool
+	// commentsError describes why the comments couldn't be loaded or posted
+	commentsError string
+	// resyncOnStart drops the cached data when the cache is opened, for --resync
+	resyncOnStart bool
+	// showingExport indicates whether the export popup is visible
+	showingExport bool
+	// exportFormatIdx is the index of the chosen format in exportFormats
+	exportFormatIdx int
+	// exportPath is the file the current view is exported to
+	exportPath string
+	// exportPathEdited indicates whether the file name was typed rather than suggested
+	exportPathEdited bool
+	// exportResult describes the last finished export
+	exportResult string
+	// exportError describes why the last export failed
+	exportError string
+	// refreshInterval is how often tasks and projects are re-fetched in the background, 0 when disabled
+	refreshInterval time.Duration
+	// pomodoroLength is how long a pomodoro lasts
+	pomodoroLength time.Duration
+	// columnSpecs sizes the table columns, from the defaults, the config and --columns
+	columnSpecs map[string]columnSpec
+	// pomodoro is the running pomodoro, nil when none runs
+	pomodoro *pomodoroTimer
+	// pomodoroSeq numbers the pomodoros started, so the ticks of stopped ones are ignored
+	pomodoroSeq int
+	// slackStatusSending is set while the Slack status is being updated, and slackStatusNext holds the status
+	// to send once it's done
+	slackStatusSending bool
+	slackStatusNext    *slackStatus
+	// pomodoroCounts maps task IDs to their number of completed pomodoros
+	pomodoroCounts map[string]int
+	// tracking is the running time entry, nil when no time is tracked
+	tracking *timeEntry
+	// trackedTotals maps task IDs to the time tracked on them by finished entries
+	trackedTotals map[string]time.Duration
+	// staleResources marks the resources whose last background refresh failed
+	staleResources map[string]bool
+	// retryingProjects indicates whether projects failed to load and are retried in the background
+	retryingProjects bool
+	// projectsRetryDelay is the wait before the next projects retry, doubling after each failure
+	projectsRetryDelay time.Duration
+	// requests hands out the contexts API requests run under, so they can be cancelled
+	requests *requestContexts
+	// formatRules are the conditional formatting rules from the config, checked for every rendered task
+	formatRules []formatRule
+	// keys resolves pressed keys to actions, with the bindings from the config applied
+	keys *keymap
+	// register is the task cut with x, hidden from every view until it's pasted with p
+	register *TodoistTask
+	// lastAnnounced is the selection summary most recently passed to the announce channels
+	lastAnnounced string
+	// collapsedDays holds the dates whose tasks are hidden in the upcoming view
+	collapsedDays map[string]bool
+	// lastReminderCheck is when the reminder ticker last looked for due notifications
+	lastReminderCheck time.Time
+	// syncingIssues indicates issues are being imported from Jira or GitLab
+	syncingIssues bool
+	// gitContext is the project or label of the repository the app was started in, nil outside a mapped repository
+	gitContext *GitContext
+	// gitLocation is the repository and branch the app was started in
+	gitLocation gitLocation
+	// gitContextApplied is set once the git context's project or label was opened on startup
+	gitContextApplied bool
+	// overdueSeen holds the IDs of the tasks overdue at the last check for newly overdue tasks, nil before the first
+	overdueSeen map[string]bool
 }
 
 // tasksLoadedMsg is sent when tasks have been successfully loaded from the API
@@ -130,13 +465,17 @@ type cacheLoadedMsg struct {
 	tasks     []TodoistTask
 	projects  []TodoistProject
 	fromCache bool
+	cachedAt  time.Time // When the cached data was fetched
+	stale     bool      // Whether the cached data is older than cacheMaxAge
+	// projectsPending is set when the projects are fetched separately, arriving as a projectsRetriedMsg
+	projectsPending bool
 }
 
 // errorMsg is sent when an error occurs during API operations
 type errorMsg error
 
-// taskCreatedMsg is sent when a task has been successfully created
-type taskCreatedMsg TodoistTask
+// taskUpdatedMsg is sent when a task has been successfully updated
+type taskUpdatedMsg TodoistTask
 
 // taskCompletedMsg is sent when a task has been successfully completed
 type taskCompletedMsg string
@@ -151,45 +490,53 @@ const (
 	fieldContent createTaskFormField = iota
 	fieldPriority
 	fieldProject
+	fieldSection
+	fieldLabels
 	fieldDeadline
 )
 
 // createTaskFormState holds the state of the create task form
 type createTaskFormState struct {
-	content            string
+	content            textinput.Model
 	priority           int // 1-4 (1=low, 4=urgent)
 	projectID          string
 	projectName        string
+	sectionID          string           // Section within the project, empty for none
 	selectedProjectIdx int              // Index in the filtered projects list
-	projectSearch      string           // Search query for project filtering
+	projectSearch      textinput.Model  // Search query for project filtering
 	filteredProjects   []TodoistProject // Filtered list of projects based on search
-	deadline           string
+	labels             string           // Comma-separated label names
+	labelSuggestionIdx int              // Index of the highlighted label suggestion
+	deadline           textinput.Model
 	activeField        createTaskFormField
+	editingTaskID      string       // ID of the task being edited, empty when creating
+	originalDeadline   string       // Due string of the task being edited, to detect changes
+	duplicate          *TodoistTask // Similar open task found when creating, awaiting a decision
+	duplicateConfirmed bool         // Whether the user chose to create the task despite a similar one
+	assigneeID         string       // Person the new task is assigned to with +Name, empty for nobody
+	failure            string       // Why creating the task failed, shown when the form opens again to retry
+	pasted             []string     // Lines of a multi-line paste, waiting for the choice to create one task per line
 }
 
-// initialModel creates the initial application model with the specified columns
-func initialModel(columns []string) model {
-	// Check for required TODOIST_TOKEN environment variable
-	token := os.Getenv("TODOIST_TOKEN")
-	if token == "" {
-		return model{
-			error: fmt.Errorf("TODOIST_TOKEN environment variable is required"),
-		}
-	}
-
-	// Initialize cache
-	cache, err := NewCacheDB()
+// initialModel creates the initial application model with the specified config, columns and layout
+func initialModel(config *Config, columns []string, compact bool) model {
+	// Read the API token from the profile, token command, keychain or TODOIST_TOKEN
+	token, err := todoistToken(config)
 	if err != nil {
 		return model{
-			error: fmt.Errorf("failed to initialize cache: %w", err),
+			error:  err,
+			config: config,
 		}
 	}
 
-	// Return initialized model with default values
-	return model{
+	// Build initialized model with default values
+	// The cache is opened by Init, so no filesystem work delays the first frame
+	m := model{
 		loading:           true,                    // Start in loading state
 		client:            NewTodoistClient(token), // Initialize API client
-		cache:             cache,                   // Initialize cache
+		config:            config,                  // Store persisted preferences
+		compact:           compact,                 // Store layout mode
+		keepCompleted:     config.KeepCompleted,    // Keep completed tasks visible if preferred
 		columns:           columns,                 // Store column configuration
 		width:             80,                      // Default terminal width
 		height:            24,                      // Default terminal height
@@ -200,20 +547,30 @@ func initialModel(columns []string) model {
 		showingCreateTask: false,                   // Create task form hidden initially
 		creating:          false,                   // Not creating a task initially
 		createTaskForm: createTaskFormState{
-			content:            "",
-			priority:           1,                  // Default to low priority
-			projectID:          "",                 // No project selected initially
-			projectName:        "Inbox",            // Default to Inbox
-			selectedProjectIdx: -1,                 // No project selected initially
-			projectSearch:      "",                 // No search query initially
-			filteredProjects:   []TodoistProject{}, // Empty filtered list initially
-			deadline:           "today",            // Default to today
-			activeField:        fieldContent,       // Start with content field active
+			content:            newFormInput(""),
+			priority:           1,                     // Default to low priority
+			projectID:          "",                    // No project selected initially
+			projectName:        "Inbox",               // Default to Inbox
+			selectedProjectIdx: -1,                    // No project selected initially
+			projectSearch:      newFormInput(""),      // No search query initially
+			filteredProjects:   []TodoistProject{},    // Empty filtered list initially
+			deadline:           newFormInput("today"), // Default to today
+			activeField:        fieldContent,          // Start with content field active
 		},
 		showingDeleteConfirm:   false, // Delete confirmation hidden initially
 		taskToDelete:           "",    // No task pending deletion initially
 		refreshingInBackground: false, // Not refreshing initially
 	}
+
+	// Reach the server with the configured retries and request middleware
+	if err := m.client.configure(config); err != nil {
+		return model{
+			error:  err,
+			config: config,
+		}
+	}
+	m.requests = newRequestContexts()
+	return m
 }
 
 // Init is called when the program starts and returns the initial command to run
@@ -222,18 +579,22 @@ func (m model) Init() tea.Cmd {
 	if m.error != nil {
 		return nil
 	}
-	// Load from cache first for fast startup
-	return loadFromCacheWithCmd(m.client, m.cache)
+	// Open the cache in the background; cached data loads once it's open and network calls after the first frame
+	// A pomodoro kept from the previous profile counts down on
+	if m.pomodoro != nil {
+		return tea.Batch(openCache(m.resyncOnStart, m.config), schedulePomodoroTick(m.pomodoro.id))
+	}
+	return openCache(m.resyncOnStart, m.config)
 }
 
 // loadTasks creates a command that fetches tasks from Todoist API in the background
-func loadTasks(client *TodoistClient) tea.Cmd {
+func loadTasks(ctx context.Context, client *TodoistClient) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
 		// Call the API to get today's tasks
-		tasks, err := client.GetTodaysTasks()
+		tasks, err := client.GetTodaysTasks(ctx)
 		if err != nil {
 			// Return error message if API call fails
-			return errorMsg(err)
+			return readErrorMsg(err)
 		}
 		// Return loaded tasks on success
 		return tasksLoadedMsg(tasks)
@@ -241,56 +602,59 @@ func loadTasks(client *TodoistClient) tea.Cmd {
 }
 
 // loadFromCacheWithCmd loads data from cache with fallback to API
-func loadFromCacheWithCmd(client *TodoistClient, cache *CacheDB) tea.Cmd {
+// Cached data is returned immediately, however old, so startup never waits on the network;
+// the caller refreshes it in the background
+func loadFromCacheWithCmd(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		// Try to load from cache first with proper filtering and sorting
-		cachedTasks, tasksErr := cache.LoadTodaysTasks()
-		cachedProjects, projectsErr := cache.LoadProjects()
-
-		// If cache is available and recent, use it
-		tasksStale := cache.IsStale("tasks", 5*time.Minute)
-		projectsStale := cache.IsStale("projects", 30*time.Minute)
-
-		if tasksErr == nil && projectsErr == nil && !tasksStale && !projectsStale {
-			// Use cached data
-			return cacheLoadedMsg{
-				tasks:     cachedTasks,
-				projects:  cachedProjects,
-				fromCache: true,
+		// Use the cache if it has ever been populated
+		cachedAt := cache.LastUpdated("tasks")
+		if !cachedAt.IsZero() {
+			// Load with the same filtering and sorting as the API
+			cachedTasks, tasksErr := cache.LoadTodaysTasks()
+			cachedProjects, projectsErr := cache.LoadProjects()
+			if tasksErr == nil && projectsErr == nil {
+				return cacheLoadedMsg{
+					tasks:     cachedTasks,
+					projects:  cachedProjects,
+					fromCache: true,
+					cachedAt:  cachedAt,
+					stale:     cache.IsStale("tasks", cacheMaxAge),
+				}
 			}
 		}
 
-		// Cache is stale or unavailable, fetch from API
-		tasks, err := client.GetTodaysTasks()
-		if err != nil {
-			// Fallback to cached data if API fails
-			if tasksErr == nil {
-				tasks = cachedTasks
-			} else {
-				return errorMsg(err)
-			}
-		}
+		// Nothing usable in the cache, so fetch the tasks and projects side by side: the tasks are shown as soon
+		// as they arrive, with project IDs until the project names land
+		return tea.Batch(syncTodaysTasks(ctx, client, cache), fetchProjects(ctx, client, cache))()
+	})
+}
 
-		projects, err := client.GetProjects()
+// syncTodaysTasks creates a command that fetches all tasks from the API into the cache, for offline use,
+// and shows today's
+// The projects are fetched separately, arriving as a projectsRetriedMsg
+func syncTodaysTasks(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		allTasks, err := syncTasksToCache(ctx, client, cache)
 		if err != nil {
-			// Fallback to cached data if API fails
-			if projectsErr == nil {
-				projects = cachedProjects
-			} else {
-				return errorMsg(err)
-			}
+			return errorMsg(err)
 		}
+		_ = cache.RecordOverdueCount(dayNow(), allTasks)
+		_ = cache.RecordPlan(dayNow(), filterTodaysTasks(allTasks))
+		return cacheLoadedMsg{tasks: filterTodaysTasks(allTasks), projectsPending: true}
+	})
+}
 
-		// Save fresh data to cache
-		_ = cache.SaveTasks(tasks)
-		_ = cache.SaveProjects(projects)
-
-		return cacheLoadedMsg{
-			tasks:     tasks,
-			projects:  projects,
-			fromCache: false,
+// fuzzyMatch checks whether all characters of the query appear in order in the text (case-insensitive)
+// Returns the rune positions of the matched characters in the text
+func fuzzyMatch(text, query string) ([]int, bool) {
+	queryRunes := []rune(strings.ToLower(query))
+	var positions []int
+	for i, char := range []rune(strings.ToLower(text)) {
+		if len(positions) < len(queryRunes) && char == queryRunes[len(positions)] {
+			positions = append(positions, i)
 		}
-	})
+	}
+	return positions, len(positions) == len(queryRunes)
 }
 
 // fuzzySearchProjects filters projects based on a search query using simple fuzzy matching
@@ -301,21 +665,9 @@ func fuzzySearchProjects(projects []TodoistProject, query string) []TodoistProje
 	}
 
 	var filtered []TodoistProject
-	queryLower := strings.ToLower(query)
-
 	for _, project := range projects {
-		projectNameLower := strings.ToLower(project.Name)
-
-		// Simple fuzzy matching: check if all characters from query appear in order
-		queryIdx := 0
-		for _, char := range projectNameLower {
-			if queryIdx < len(queryLower) && char == rune(queryLower[queryIdx]) {
-				queryIdx++
-			}
-		}
-
 		// If all query characters were found in order, include this project
-		if queryIdx == len(queryLower) {
+		if _, ok := fuzzyMatch(project.Name, query); ok {
 			filtered = append(filtered, project)
 		}
 	}
@@ -325,7 +677,7 @@ func fuzzySearchProjects(projects []TodoistProject, query string) []TodoistProje
 
 // updateProjectFilter updates the filtered projects list and resets selection
 func (m *model) updateProjectFilter() {
-	m.createTaskForm.filteredProjects = fuzzySearchProjects(m.projects, m.createTaskForm.projectSearch)
+	m.createTaskForm.filteredProjects = fuzzySearchProjects(m.projects, m.createTaskForm.projectSearch.Value())
 
 	// Reset selection to first filtered project if available
 	if len(m.createTaskForm.filteredProjects) > 0 {
@@ -341,8 +693,8 @@ func (m *model) updateProjectFilter() {
 	}
 }
 
-// Update handles incoming messages and updates the model state accordingly
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+// update handles incoming messages and updates the model state accordingly
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		// Update terminal dimensions when window is resized
@@ -352,16 +704,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.KeyMsg:
 		// Always handle Ctrl+C to quit
 		if msg.String() == "ctrl+c" {
+			m.queueUnsentChanges()
+			m.requests.stop()
 			return m, tea.Quit
 		}
 
+		// Ignore other keys until the cache is open, since most actions rely on it
+		if m.cache == nil && m.error == nil {
+			return m, nil
+		}
+
 		// Check for delete combination first (Cmd+Backspace on macOS, Alt+Backspace elsewhere)
-		if (msg.Type == tea.KeyBackspace && msg.Alt) ||
-			(msg.Type == tea.KeyBackspace && runtime.GOOS == "darwin" && msg.Alt) {
+		if isDeleteKey(msg) && !m.config.isReadOnly() {
 			// Handle delete for current view
-			if !m.showingDeleteConfirm && !m.showingCreateTask {
+			if !m.showingDeleteConfirm && !m.showingCreateTask && !m.showingSyncStatus && !m.showingStats && !m.showingHelp && !m.showingHistory && !m.showingDone && !m.showingAgenda && !m.showingTimeReport && !m.showingDuplicates && !m.showingStaleSweep && !m.showingColumnMenu && !m.showingProjectPicker && !m.showingLabelPicker && !m.showingReschedule && !m.showingNewProject && !m.showingPark && !m.showingParked && !m.showingReplace && !m.showingSearch && !m.filtering && !m.showingTriage && !m.showingBulkConfirm && !m.showingBulkLabel && !m.showingCompleteChoice && !m.showingCompleteParent && !m.showingProfiles && !m.showingMessages && !m.showingComments && !m.showingExport {
+				// Delete all selected tasks in visual-select mode
+				if m.hasMarkedTasks() && !m.showingPopup {
+					m.confirmBulk(bulkDelete, "", "")
+					return m, nil
+				}
 				if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
 					selectedTask := m.allTasks[m.selectedIndex]
+					if isPlaceholder(selectedTask.ID) {
+						return m, m.notify(toastInfo, "Still creating this task, try again in a moment")
+					}
 					if m.showingPopup {
 						m.showingPopup = false // Close popup first
 					}
@@ -375,18 +741,79 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle input based on current view state
 		if m.showingDeleteConfirm {
 			return m.handleDeleteConfirmInput(msg)
+		} else if m.showingCompleteChoice {
+			return m.handleCompleteChoiceInput(msg)
+		} else if m.showingCompleteParent {
+			return m.handleCompleteParentInput(msg)
+		} else if m.showingProfiles {
+			return m.handleProfilesInput(msg)
+		} else if m.showingMessages {
+			return m.handleMessagesInput(msg)
+		} else if m.showingBulkConfirm {
+			return m.handleBulkConfirmInput(msg)
+		} else if m.showingBulkLabel {
+			return m.handleBulkLabelInput(msg)
+		} else if m.showingSyncStatus {
+			return m.handleSyncStatusInput(msg)
+		} else if m.showingHelp {
+			return m.handleHelpInput(msg)
+		} else if m.showingStats {
+			return m.handleStatsInput(msg)
+		} else if m.showingHistory {
+			return m.handleHistoryInput(msg)
+		} else if m.showingDone {
+			return m.handleDoneInput(msg)
+		} else if m.showingAgenda {
+			return m.handleAgendaInput(msg)
+		} else if m.showingTimeReport {
+			return m.handleTimeReportInput(msg)
+		} else if m.showingDuplicates {
+			return m.handleDuplicatesInput(msg)
+		} else if m.showingStaleSweep {
+			return m.handleStaleSweepInput(msg)
+		} else if m.showingParked {
+			return m.handleParkedScreenInput(msg)
+		} else if m.showingReplace {
+			return m.handleReplaceInput(msg)
+		} else if m.showingSearch {
+			return m.handleSearchInput(msg)
+		} else if m.showingTriage {
+			return m.handleTriageInput(msg)
+		} else if m.showingPark {
+			return m.handleParkInput(msg)
+		} else if m.showingComments {
+			return m.handleCommentsInput(msg)
+		} else if m.showingExport {
+			return m.handleExportInput(msg)
+		} else if m.showingColumnMenu {
+			return m.handleColumnMenuInput(msg)
+		} else if m.showingProjectPicker {
+			return m.handleProjectPickerInput(msg)
+		} else if m.showingLabelPicker {
+			return m.handleLabelPickerInput(msg)
+		} else if m.showingReschedule {
+			return m.handleRescheduleInput(msg)
+		} else if m.showingNewProject {
+			return m.handleNewProjectInput(msg)
 		} else if m.showingCreateTask {
 			return m.handleCreateTaskInput(msg)
 		} else if m.showingPopup {
 			return m.handlePopupInput(msg)
+		} else if m.filtering {
+			return m.handleFilterInput(msg)
+		} else if m.visualMode {
+			return m.handleVisualInput(msg)
 		} else {
 			return m.handleMainViewInput(msg)
 		}
 
 	case tasksLoadedMsg:
+		// Ignore today's tasks if another view was opened meanwhile
+		if m.view != viewToday {
+			return m, nil
+		}
 		// Handle successful task loading
-		m.tasks = []TodoistTask(msg)
-		m.allTasks = []TodoistTask(msg) // Store all tasks for navigation
+		m.setTasks(m.hideParked(msg)) // Store matching tasks for navigation
 		m.loading = false
 		m.error = nil
 		// Set initial selection to first task if we have tasks
@@ -401,20 +828,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selectedIndex = -1
 			}
 		}
+		// Replay changes queued while offline; project names missing meanwhile are fetched alongside
+		return m, m.goOnline()
+
+	case projectsRetryTickMsg:
+		// Try loading the projects again
+		return m, fetchProjects(m.requests.base(), m.client, m.cache)
+
+	case projectsRetriedMsg:
+		// Handle projects loaded in the background after an earlier failure
+		return m, m.handleProjectsLoaded(msg)
+
+	case accountTimezoneMsg:
+		// Follow the timezone of the Todoist account once fetched
+		return m, m.handleAccountTimezone(msg)
+
+	case cacheOpenedMsg:
+		// Handle the cache database opened after startup
+		return m, m.handleCacheOpened(msg)
+
+	case firstPaintMsg:
+		// Start fetching labels, sections and collaborators, and importing issues, now that the first frame is on screen
+		return m, tea.Batch(loadLabels(m.requests.base(), m.client), loadSections(m.requests.base(), m.client), loadCollaborators(m.requests.base(), m.client),
+			m.startIssueSyncing(), loadPomodoroCounts(m.cache), loadTimeTracking(m.cache), remindOfStaleTasks(m.cache, m.staleSweep),
+			refreshAccountTimezone(m.requests.base(), m.client, m.cache, m.config))
 
 	case cacheLoadedMsg:
 		// Handle data loaded from cache or fresh API call
-		m.tasks = msg.tasks
-		m.allTasks = msg.tasks
-		m.projects = msg.projects
+		startup.mark("tasks shown")
+		var highlightCmd, replayCmd tea.Cmd
+		if m.view == viewToday {
+			tasks := m.hideParked(msg.tasks)
+			highlightCmd = m.markChanges(tasks)
+			m.setTasks(tasks)
+		}
 		m.loading = false
 		m.error = nil
 
-		// Populate client's project cache for project name lookups
-		m.client.LoadProjectsFromCache(m.projects)
-
-		// Initialize filtered projects with all projects
-		m.createTaskForm.filteredProjects = m.projects
+		// Use the projects that came with the tasks for name lookups and the project picker; projects fetched
+		// separately fill them in when they land, which may be before or after the tasks
+		if !msg.projectsPending {
+			m.projects = msg.projects
+			m.client.LoadProjectsFromCache(m.projects)
+			m.createTaskForm.filteredProjects = m.projects
+		}
 
 		// Set initial selection to first task if we have tasks
 		if len(m.allTasks) > 0 && m.selectedIndex == -1 {
@@ -429,31 +886,108 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-		// Set default project to first one (usually Inbox) if available
-		if len(m.projects) > 0 {
-			m.createTaskForm.selectedProjectIdx = 0
-			m.createTaskForm.projectID = m.projects[0].ID
-			m.createTaskForm.projectName = m.projects[0].Name
+		// Default new tasks to the Inbox
+		m.selectInboxProject()
+
+		// Open the project or label of the repository the app was started in
+		contextCmd := m.applyGitContext()
+
+		// Remember when fresh data last arrived from the API, or how old the cached data is
+		if msg.fromCache {
+			m.cachedAt = msg.cachedAt
+			m.cacheStale = msg.stale
+		} else {
+			m.lastTasksSync = time.Now()
+			replayCmd = m.goOnline()
 		}
 
 		// If data was loaded from cache, start background refresh
 		if msg.fromCache && !m.refreshingInBackground {
 			m.refreshingInBackground = true
-			return m, refreshCacheInBackground(m.client, m.cache)
+			return m, tea.Batch(highlightCmd, refreshCacheInBackground(m.requests.replace(requestGroupRefresh), m.client, m.cache), loadUrgentTasks(m.cache, m.config),
+				loadSubtaskProgress(m.cache), contextCmd)
+		}
+		// Fresh data arrived, so check watched tasks for changes
+		if !msg.fromCache {
+			return m, tea.Batch(highlightCmd, replayCmd, checkWatchedTasks(m.requests.base(), m.client, m.cache), loadUrgentTasks(m.cache, m.config),
+				loadSubtaskProgress(m.cache), m.checkNewlyOverdue(), contextCmd)
 		}
+		return m, tea.Batch(highlightCmd, loadUrgentTasks(m.cache, m.config), loadSubtaskProgress(m.cache), contextCmd)
 
 	case cacheRefreshedMsg:
 		// Handle cache refresh completion - update UI with fresh data
+		// Whatever was refreshed is used, and the rest is marked stale
 		m.refreshingInBackground = false
-		m.tasks = msg.tasks
-		m.allTasks = msg.tasks
-		m.projects = msg.projects
+		m.recordRefreshResults(msg.failed)
+		replayCmd := m.goOnline()
+		// Follow labels renamed in Todoist before the view is reloaded under their names
+		renameCmd := m.applyRenames(msg.renamed)
+		var highlightCmd, reloadCmd, projectsCmd, watchCmd tea.Cmd
+		if _, failed := msg.failed[resourceTasks]; !failed {
+			m.lastTasksSync = time.Now()
+			selectedID := m.selectedTaskID()
+			switch m.view {
+			case viewToday:
+				tasks := m.hideParked(msg.tasks)
+				highlightCmd = m.markChanges(tasks)
+				m.setTasks(tasks)
+				// Keep the same task selected, and its popup open, even if it moved in the list
+				m.restoreSelection(selectedID)
+			case viewProject:
+				// Show the refreshed cache in other views, selecting the same task once loaded
+				m.pendingSelectID = selectedID
+				reloadCmd = loadCachedProjectTasks(m.cache, m.viewProjectID)
+			case viewUpcoming:
+				m.pendingSelectID = selectedID
+				reloadCmd = loadCachedUpcomingTasks(m.cache)
+			case viewLabel:
+				m.pendingSelectID = selectedID
+				reloadCmd = loadCachedLabelTasks(m.cache, m.viewLabelName)
+			case viewSomeday:
+				m.pendingSelectID = selectedID
+				reloadCmd = m.reloadSomeday()
+			}
+			// Fresh tasks arrived, so check watched tasks for changes and tasks that became overdue
+			watchCmd = tea.Batch(checkWatchedTasks(m.requests.base(), m.client, m.cache), m.checkNewlyOverdue())
+		}
+		var unchangedCmds []tea.Cmd
+		if _, failed := msg.failed[resourceProjects]; failed {
+			// Keep trying to load projects in the background, showing the last known names meanwhile
+			projectsCmd = m.startProjectsRetry()
+		} else if msg.unchanged[resourceProjects] {
+			// Keep the projects shown; fetch them in full if there are none to keep
+			m.lastProjectsSync = time.Now()
+			if len(m.projects) == 0 {
+				unchangedCmds = append(unchangedCmds, fetchProjects(m.requests.base(), m.client, m.cache))
+			}
+		} else {
+			m.lastProjectsSync = time.Now()
+			m.projects = msg.projects
 
-		// Populate client's project cache for project name lookups
-		m.client.LoadProjectsFromCache(m.projects)
+			// Populate client's project cache for project name lookups
+			m.client.LoadProjectsFromCache(m.projects)
 
-		// Update filtered projects
-		m.createTaskForm.filteredProjects = m.projects
+			// Update filtered projects
+			m.createTaskForm.filteredProjects = m.projects
+		}
+		if msg.unchanged[resourceLabels] {
+			if m.labels == nil {
+				unchangedCmds = append(unchangedCmds, loadLabels(m.requests.base(), m.client))
+			}
+		} else if _, failed := msg.failed[resourceLabels]; !failed {
+			m.labels = msg.labels
+		}
+		if msg.unchanged[resourceSections] {
+			if m.sections == nil {
+				unchangedCmds = append(unchangedCmds, loadSections(m.requests.base(), m.client))
+			}
+		} else if _, failed := msg.failed[resourceSections]; !failed {
+			m.sections = msg.sections
+			// Regroup the project view unless it's being reloaded anyway
+			if m.view == viewProject && reloadCmd == nil {
+				m.setTasks(m.tasks)
+			}
+		}
 
 		// Maintain current selection if possible
 		if m.selectedIndex >= len(m.allTasks) {
@@ -470,6 +1004,232 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.createTaskForm.projectID = project.ID
 			m.createTaskForm.projectName = project.Name
 		}
+		// Settings naming projects and labels follow them by ID
+		referencesCmd := m.followReferences()
+		return m, tea.Batch(highlightCmd, reloadCmd, replayCmd, renameCmd, referencesCmd, projectsCmd, watchCmd, loadUrgentTasks(m.cache, m.config),
+			loadSubtaskProgress(m.cache), tea.Batch(unchangedCmds...))
+
+	case autoRefreshTickMsg:
+		// Re-fetch tasks and projects on the auto-refresh interval
+		return m, m.handleAutoRefreshTick()
+
+	case urgentTasksLoadedMsg:
+		// Handle the P1 tasks counted for the P1 limit
+		m.urgentTasks = msg
+		if m.triageIdx >= len(m.urgentTasks) {
+			m.triageIdx = max(len(m.urgentTasks)-1, 0)
+		}
+
+	case clipboardPastedMsg:
+		// Paste the clipboard into the form, offering one task per line for several lines
+		if m.showingCreateTask {
+			return m, m.pasteIntoForm(string(msg))
+		}
+
+	case tasksCreatedMsg:
+		// Handle tasks created from the lines of a paste
+		return m, m.handleTasksCreated(msg)
+
+	case bulkAppliedMsg:
+		// Handle a bulk action applied to the selected tasks
+		if msg.action == bulkReschedule {
+			m.session.rescheduled += msg.count
+		}
+		if msg.err != nil {
+			// Some tasks were refused, e.g. completed elsewhere meanwhile; the others were changed
+			m.recordSyncError(msg.err)
+			toast := m.notify(toastError, fmt.Sprintf("Bulk %s applied to %d task(s), %d failed: %s",
+				msg.action, msg.count, msg.failed, friendlyError(msg.err)))
+			return m, tea.Batch(toast, m.reloadCurrentView())
+		}
+		toast := m.notify(toastSuccess, fmt.Sprintf("Bulk %s applied to %d task(s)", msg.action, msg.count))
+		return m, tea.Batch(toast, m.reloadCurrentView())
+
+	case reminderTickMsg:
+		// Notify about tasks coming due
+		return m, m.handleReminderTick(time.Time(msg))
+
+	case issueSyncTickMsg:
+		// Poll the issue sources for new issues
+		return m, m.handleIssueSyncTick()
+
+	case issuesSyncedMsg:
+		// Report the issues imported as tasks
+		return m, m.handleIssuesSynced(msg)
+
+	case overdueCheckedMsg:
+		// Remember the overdue tasks, so only the ones overdue after the next refresh are notified about
+		m.overdueSeen = msg
+
+	case watchesLoadedMsg:
+		// Handle watched task state loaded or updated
+		m.watchedTasks = msg
+
+	case clearHighlightsMsg:
+		// Remove change highlights unless newer ones were added since
+		if int(msg) == m.highlightGen {
+			m.changedTasks = nil
+		}
+
+	case projectTasksLoadedMsg:
+		// Ignore tasks for a project that is no longer being viewed
+		if m.view != viewProject || msg.projectID != m.viewProjectID {
+			return m, nil
+		}
+		// Sorting groups the tasks by section, so navigation follows the rendered order
+		m.setTasks(m.hideParked(msg.tasks))
+		m.loading = false
+		m.error = nil
+		// Select the first task, or reset selection if it's out of bounds
+		if len(m.allTasks) > 0 && (m.selectedIndex == -1 || m.selectedIndex >= len(m.allTasks)) {
+			m.selectedIndex = 0
+		} else if len(m.allTasks) == 0 {
+			m.selectedIndex = -1
+		}
+		// Select the task a jump to a related task was waiting for
+		m.selectPendingTask()
+		// Replay changes queued while offline
+		if !msg.fromCache {
+			return m, m.goOnline()
+		}
+
+	case labelTasksLoadedMsg:
+		// Ignore tasks for a label that is no longer being viewed
+		if m.view != viewLabel || msg.label != m.viewLabelName {
+			return m, nil
+		}
+		m.setTasks(m.hideParked(msg.tasks))
+		m.loading = false
+		m.error = nil
+		// Select the first task, or reset selection if it's out of bounds
+		if len(m.allTasks) > 0 && (m.selectedIndex == -1 || m.selectedIndex >= len(m.allTasks)) {
+			m.selectedIndex = 0
+		} else if len(m.allTasks) == 0 {
+			m.selectedIndex = -1
+		}
+		// Select the task a refresh was keeping selected
+		m.selectPendingTask()
+		// Replay changes queued while offline
+		if !msg.fromCache {
+			return m, m.goOnline()
+		}
+
+	case somedayTasksLoadedMsg:
+		// Ignore the backlog if another view was opened meanwhile
+		if m.view != viewSomeday {
+			return m, nil
+		}
+		m.setTasks(m.hideParked(msg))
+		m.loading = false
+		m.error = nil
+		// Select the first task, or reset selection if it's out of bounds
+		if len(m.allTasks) > 0 && (m.selectedIndex == -1 || m.selectedIndex >= len(m.allTasks)) {
+			m.selectedIndex = 0
+		} else if len(m.allTasks) == 0 {
+			m.selectedIndex = -1
+		}
+		// Select the task a refresh was keeping selected
+		m.selectPendingTask()
+
+	case somedayMovedMsg:
+		// Handle a task promoted out of the backlog or moved into it
+		return m, m.handleSomedayMoved(msg)
+
+	case labelCountsLoadedMsg:
+		// Handle the task counts shown in the label list
+		m.labelCounts = msg
+
+	case historyLoadedMsg:
+		// Handle a day's history, unless another day was picked meanwhile
+		if msg.day == m.historyDay.Format("2006-01-02") {
+			m.history = msg
+		}
+
+	case completedTasksLoadedMsg:
+		// Handle the completed tasks for the done screen
+		m.doneLoading = false
+		if msg.err != nil {
+			m.doneError = msg.err.Error()
+		} else {
+			m.doneTasks = msg.tasks
+		}
+
+	case taskReopenedMsg:
+		// Handle a task reopened from the done screen
+		return m, m.handleTaskReopened(msg)
+
+	case slackStatusMsg:
+		// Report a Slack status that couldn't be set or cleared
+		return m, m.handleSlackStatus(msg)
+
+	case readLaterSentMsg:
+		// Complete a link task sent to read later
+		return m, m.handleReadLaterSent(msg)
+
+	case statsLoadedMsg:
+		// Handle the open task breakdown for the stats screen
+		m.stats = msg
+
+	case agendaLoadedMsg:
+		// Handle today's timed tasks for the agenda
+		m.agenda = msg
+
+	case subtaskProgressLoadedMsg:
+		// Handle the subtask counts of parent tasks
+		m.subtaskProgress = msg
+
+	case parentTaskFoundMsg:
+		// Offer to complete the parent of the last completed subtask
+		m.showParentCompletion(TodoistTask(msg))
+
+	case pomodoroTickMsg:
+		// Count the running pomodoro down
+		return m, m.handlePomodoroTick(int(msg))
+
+	case pomodoroCountsLoadedMsg:
+		// Handle the completed pomodoros of the tasks
+		m.pomodoroCounts = msg
+
+	case timeTrackingLoadedMsg:
+		// Handle the running time entry and the time tracked on the tasks
+		return m, m.handleTimeTrackingLoaded(msg)
+
+	case timeTrackingTickMsg:
+		// Update the running time in the footer
+		return m, m.handleTrackingTick(int64(msg))
+
+	case timeReportLoadedMsg:
+		// Handle the time entries of the time report
+		m.timeReport = msg
+
+	case timeReportExportedMsg:
+		// Handle the time report's CSV export
+		m.handleTimeReportExported(msg)
+
+	case duplicatesFoundMsg:
+		// Handle the near-duplicate tasks found in the cache
+		m.handleDuplicatesFound(msg)
+
+	case duplicateMergedMsg:
+		// Handle a merged duplicate
+		return m, m.handleDuplicateMerged(msg)
+
+	case staleTasksFoundMsg:
+		// Handle the stale tasks found in the cache
+		m.handleStaleTasksFound(msg)
+
+	case staleTaskSweptMsg:
+		// Handle a stale task deleted, labeled or scheduled
+		return m, m.handleStaleTaskSwept(msg)
+
+	case staleReminderMsg:
+		// Remind of the stale tasks waiting to be swept
+		return m, m.handleStaleReminder(int(msg))
+
+	case syncStatusLoadedMsg:
+		// Handle sync timestamps read from the cache
+		m.lastTasksSync = msg.tasksUpdated
+		m.lastProjectsSync = msg.projectsUpdated
 
 	case projectsLoadedMsg:
 		// Handle successful project loading (fallback for old API calls)
@@ -480,185 +1240,428 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Initialize filtered projects with all projects
 		m.createTaskForm.filteredProjects = m.projects
-		// Set default project to first one (usually Inbox) if available
-		if len(m.projects) > 0 {
-			m.createTaskForm.selectedProjectIdx = 0
-			m.createTaskForm.projectID = m.projects[0].ID
-			m.createTaskForm.projectName = m.projects[0].Name
-		}
-
-	case taskCreatedMsg:
-		// Handle successful task creation
+		// Default new tasks to the Inbox
+		m.selectInboxProject()
+		// Settings naming projects follow them by ID
+		return m, m.followReferences()
+
+	case taskCreateSentMsg:
+		// Swap the placeholder of a new task for the task, or take it out if creating it failed
+		return m, m.handleTaskCreateSent(msg)
+
+	case taskDeleteSentMsg:
+		// Put a task back if deleting it failed
+		return m, m.handleTaskDeleteSent(msg)
+
+	case pendingSpinnerMsg:
+		// Animate the glyph of tasks being created
+		return m, m.handlePendingSpinner()
+	case taskUpdatedMsg:
+		// Handle successful task update
 		m.creating = false
 		m.showingCreateTask = false
-		// Reset form state with first project if available
-		defaultProjectIdx := -1
-		defaultProjectID := ""
-		defaultProjectName := "Inbox"
-		if len(m.projects) > 0 {
-			defaultProjectIdx = 0
-			defaultProjectID = m.projects[0].ID
-			defaultProjectName = m.projects[0].Name
-		}
 		m.createTaskForm = createTaskFormState{
-			content:            "",
+			content:            newFormInput(""),
 			priority:           1,
-			projectID:          defaultProjectID,
-			projectName:        defaultProjectName,
-			selectedProjectIdx: defaultProjectIdx,
-			projectSearch:      "",
-			filteredProjects:   m.projects, // Reset to all projects
-			deadline:           "today",
+			projectID:          "",
+			projectName:        "Inbox",
+			selectedProjectIdx: -1,
+			projectSearch:      newFormInput(""),
+			filteredProjects:   m.projects,
+			deadline:           newFormInput("today"),
 			activeField:        fieldContent,
 		}
-		m.loading = true
 
-		// Also save updated tasks to cache
-		_ = m.cache.SaveTasks(m.allTasks)
-		return m, loadTasks(m.client)
-	case taskCompletedMsg:
-		// Handle successful task completion
-		taskID := string(msg)
-		// Remove the completed task from our local list
-		var updatedTasks []TodoistTask
-		for _, task := range m.allTasks {
-			if task.ID != taskID {
-				updatedTasks = append(updatedTasks, task)
+		// Replace the task locally until the reload finishes
+		updatedTask := TodoistTask(msg)
+		counted := false
+		for i := range m.tasks {
+			if m.tasks[i].ID == updatedTask.ID {
+				m.session.noteUpdate(m.tasks[i], updatedTask)
+				counted = true
+				m.tasks[i] = updatedTask
 			}
 		}
-		m.allTasks = updatedTasks
-		m.tasks = updatedTasks
-
-		// Adjust selection if needed
-		if m.selectedIndex >= len(m.allTasks) {
-			if len(m.allTasks) > 0 {
-				m.selectedIndex = len(m.allTasks) - 1
-			} else {
-				m.selectedIndex = -1
+		for i := range m.allTasks {
+			if m.allTasks[i].ID == updatedTask.ID {
+				if !counted {
+					m.session.noteUpdate(m.allTasks[i], updatedTask)
+					counted = true
+				}
+				m.allTasks[i] = updatedTask
 			}
 		}
+		m.loading = true
+
+		// Also save the updated task to cache
+		_ = m.cache.SaveTask(updatedTask)
+		toast := m.notify(toastSuccess, "Saved changes to "+updatedTask.Content)
+		return m, tea.Batch(toast, m.reloadCurrentView())
+	case taskCompletedMsg:
+		// Handle successful task completion
+		// Remove the completed task from our local list, or keep it struck through
+		toast := m.notify(toastSuccess, m.taskMessage("Task completed", string(msg)))
+		return m, tea.Batch(toast, m.handleTaskCompleted(string(msg)))
+
+	case completionFlushMsg:
+		// Send the completions queued since the last batch
+		return m, m.handleCompletionFlush(msg)
+
+	case completionsSentMsg:
+		// Bring back the tasks Todoist couldn't complete
+		return m, m.handleCompletionsSent(msg)
 
 	case taskDeletedMsg:
 		// Handle successful task deletion
-		taskID := string(msg)
 		// Remove the deleted task from our local list
-		var updatedTasks []TodoistTask
-		for _, task := range m.allTasks {
-			if task.ID != taskID {
-				updatedTasks = append(updatedTasks, task)
+		toast := m.notify(toastSuccess, m.taskMessage("Task deleted", string(msg)))
+		m.removeTask(string(msg))
+		return m, toast
+
+	case upcomingTasksLoadedMsg:
+		// Ignore upcoming tasks if another view was opened meanwhile
+		if m.view != viewUpcoming {
+			return m, nil
+		}
+		m.setUpcomingTasks(msg.tasks)
+		m.loading = false
+		m.error = nil
+		// Select the task a refresh was keeping selected
+		m.selectPendingTask()
+		// Replay changes queued while offline
+		if !msg.fromCache {
+			return m, m.goOnline()
+		}
+
+	case linksLoadedMsg:
+		// Handle task links loaded or changed
+		m.taskLinks = msg
+
+	case labelsLoadedMsg:
+		// Handle labels fetched for suggestions and chips, without blocking the task list on failure
+		if msg.err != nil {
+			m.recordSyncError(msg.err)
+		} else {
+			m.labels = msg.labels
+			return m, m.followReferences()
+		}
+
+	case collaboratorsLoadedMsg:
+		// Handle collaborators fetched for assignee badges, without blocking the task list on failure
+		if msg.err != nil {
+			m.recordSyncError(msg.err)
+		} else {
+			m.setCollaborators(msg.collaborators, msg.states)
+		}
+
+	case commentsLoadedMsg:
+		// Ignore comments of a task whose popup was closed meanwhile
+		if !m.showingComments || msg.taskID != m.commentsTaskID {
+			return m, nil
+		}
+		m.commentsLoading = false
+		if msg.err != nil {
+			m.commentsError = msg.err.Error()
+		} else {
+			m.comments = msg.comments
+			// Start at the newest comments
+			m.commentsScroll = m.maxCommentsScroll()
+		}
+
+	case commentPostedMsg:
+		// Show the posted comment, keeping the draft if posting failed
+		if msg.taskID != m.commentsTaskID {
+			return m, nil
+		}
+		m.commentPosting = false
+		if msg.err != nil {
+			m.commentsError = msg.err.Error()
+		} else {
+			m.comments = append(m.comments, *msg.comment)
+			m.commentDraft = ""
+			m.commentsScroll = m.maxCommentsScroll()
+		}
+
+	case exportDoneMsg:
+		// Show how the export went in the popup
+		if msg.err != nil {
+			m.exportResult = ""
+			m.exportError = msg.err.Error()
+		} else {
+			m.exportResult = fmt.Sprintf("✅ Exported %d task(s) to %s", msg.count, msg.path)
+		}
+
+	case sectionsLoadedMsg:
+		// Handle sections fetched for grouping, regrouping the project being viewed
+		if msg.err != nil {
+			m.recordSyncError(msg.err)
+		} else {
+			m.sections = msg.sections
+			if m.view == viewProject {
+				m.setTasks(m.tasks)
 			}
 		}
-		m.allTasks = updatedTasks
-		m.tasks = updatedTasks
 
-		// Adjust selection if needed
+	case parkedTasksLoadedMsg:
+		// Handle parked tasks loaded or changed, hiding any that are still listed
+		m.parkedTasks = msg
+		if m.view == viewUpcoming {
+			m.setUpcomingTasks(m.tasks)
+		} else {
+			m.tasks = m.hideParked(m.tasks)
+			m.allTasks = m.hideParked(m.allTasks)
+		}
 		if m.selectedIndex >= len(m.allTasks) {
-			if len(m.allTasks) > 0 {
-				m.selectedIndex = len(m.allTasks) - 1
-			} else {
-				m.selectedIndex = -1
-			}
+			m.selectedIndex = len(m.allTasks) - 1
+		}
+		if m.parkedIdx >= len(m.parkedTasks) {
+			m.parkedIdx = max(len(m.parkedTasks)-1, 0)
+		}
+
+	case pendingOperationsLoadedMsg:
+		// Handle the operation queue loaded or changed
+		m.pendingOps = msg
+
+	case tasksReplacedMsg:
+		// Handle find and replace finishing
+		for _, task := range msg.updated {
+			_ = m.cache.SaveTask(task)
+		}
+		if msg.err != nil && len(msg.updated) == 0 {
+			return m.Update(errorMsg(msg.err))
+		}
+		if msg.err != nil {
+			// Some tasks were renamed; list the rest on the sync status screen
+			m.recordSyncError(fmt.Errorf("%d of %d task(s) not renamed: %w", msg.failed, msg.failed+len(msg.updated), msg.err))
+		}
+		return m, m.reloadCurrentView()
+	case operationsReplayedMsg:
+		// Handle queued operations sent to Todoist
+		m.replayingOps = false
+		m.pendingOps = msg.ops
+		if msg.err != nil {
+			// Connection dropped again partway through
+			return m.Update(offlineMsg{err: msg.err})
 		}
+		// Pick up the server's view of the replayed changes
+		if msg.replayed > 0 {
+			toast := m.notify(toastSuccess, fmt.Sprintf("Synced %d queued change(s)", msg.replayed))
+			return m, tea.Batch(toast, m.reloadCurrentView())
+		}
+
+	case offlineMsg:
+		// Keep showing cached data when Todoist can't be reached
+		m.offline = true
+		m.loading = false
+		m.refreshingInBackground = false
+		m.recordSyncError(msg.err)
+		if m.cachedAt.IsZero() {
+			m.cachedAt = m.cache.LastUpdated("tasks")
+		}
+		// Other views are served from the cache while offline
+		switch m.view {
+		case viewProject:
+			return m, loadCachedProjectTasks(m.cache, m.viewProjectID)
+		case viewUpcoming:
+			return m, loadCachedUpcomingTasks(m.cache)
+		case viewLabel:
+			return m, loadCachedLabelTasks(m.cache, m.viewLabelName)
+		case viewSomeday:
+			return m, m.reloadSomeday()
+		}
+
+	case snippetCopiedMsg:
+		// Report whether the copied tasks reached the clipboard
+		return m, m.handleSnippetCopied(msg)
+
+	case projectCreatedMsg:
+		// Handle a project created from the new project prompt
+		return m, m.handleProjectCreated(TodoistProject(msg))
+
+	case toastExpiredMsg:
+		// Hide the toast once it has been shown long enough
+		m.dismissToast(int(msg))
 
 	case errorMsg:
+		// Requests cancelled on purpose were replaced by newer ones or stopped on quit
+		if isCanceled(error(msg)) {
+			return m, nil
+		}
+		m.loading = false
+		m.refreshingInBackground = false
+		m.recordSyncError(error(msg))
+		// Keep a task list already on screen and report the error in a toast, so the failed action can be retried
+		if m.hasShownTasks() {
+			m.creating = false
+			m.creatingProject = false
+			return m, m.notify(toastError, friendlyError(error(msg)))
+		}
 		// Handle error messages
 		m.error = error(msg)
-		m.loading = false
+
+	default:
+		// Text pasted from the clipboard arrives as a message for the form's text inputs
+		if m.showingCreateTask {
+			return m, m.updateFormInput(msg)
+		}
+		if m.showingNewProject {
+			var cmd tea.Cmd
+			m.newProjectName, cmd = m.newProjectName.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
 }
 
+// removeTask drops a task from the local lists and keeps the selection in bounds
+func (m *model) removeTask(taskID string) {
+	var updatedTasks, updatedAllTasks []TodoistTask
+	for _, task := range m.tasks {
+		if task.ID != taskID {
+			updatedTasks = append(updatedTasks, task)
+		}
+	}
+	for _, task := range m.allTasks {
+		if task.ID != taskID {
+			updatedAllTasks = append(updatedAllTasks, task)
+		}
+	}
+	m.tasks = updatedTasks
+	m.allTasks = updatedAllTasks
+
+	// Adjust selection if needed
+	if m.selectedIndex >= len(m.allTasks) {
+		if len(m.allTasks) > 0 {
+			m.selectedIndex = len(m.allTasks) - 1
+		} else {
+			m.selectedIndex = -1
+		}
+	}
+}
+
 // View renders the current application state as a string for display
 func (m model) View() string {
+	startup.mark("first frame")
+	defer tracer.observe(traceFrame, "view", time.Now())
 	var b strings.Builder
 
 	// Display main application title
-	b.WriteString(titleStyle.Render("📋 Today's Tasks & Overdue"))
-	b.WriteString("\n\n")
+	b.WriteString(m.renderHeading())
 
-	// Handle error state
-	if m.error != nil {
-		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.error)))
-		b.WriteString("\n\nPress Ctrl+C to quit")
+	// Show the sync status screen, even when an error occurred
+	if m.showingSyncStatus {
+		b.WriteString(m.renderSyncStatus())
 		return b.String()
 	}
 
-	// Handle loading state
-	if m.loading {
-		if m.refreshingInBackground {
-			b.WriteString(loadingStyle.Render("Loading tasks... (refreshing in background)"))
-		} else {
-			b.WriteString(loadingStyle.Render("Loading tasks..."))
-		}
-		b.WriteString("\n\nPress Ctrl+C to quit")
+	// Show the history screen
+	if m.showingHistory {
+		b.WriteString(m.renderHistory())
 		return b.String()
 	}
 
-	// Handle empty tasks state
-	if len(m.tasks) == 0 {
-		b.WriteString(taskStyle.Render("🎉 No tasks due today! Great job!"))
-	} else {
-		// Separate tasks into overdue and today's categories
-		var overdueTasks, todayTasks []TodoistTask
-		for _, task := range m.tasks {
-			if isTaskOverdue(task) {
-				overdueTasks = append(overdueTasks, task)
-			} else {
-				todayTasks = append(todayTasks, task)
-			}
-		}
+	// Show the done screen
+	if m.showingDone {
+		b.WriteString(m.renderDone())
+		return b.String()
+	}
 
-		// Keep track of task index for selection
-		taskIndex := 0
+	// Show the agenda
+	if m.showingAgenda {
+		b.WriteString(m.renderAgenda())
+		return b.String()
+	}
 
-		// Render overdue tasks section if any exist
-		if len(overdueTasks) > 0 {
-			b.WriteString(titleStyle.Render("⚠️ Overdue Tasks"))
-			b.WriteString("\n")
-			// Generate dynamic headers based on selected columns
-			header, separator := m.generateHeaders()
-			b.WriteString(headerStyle.Render(header))
-			b.WriteString("\n")
-			b.WriteString(headerStyle.Render(separator))
-			b.WriteString("\n")
+	// Show the time report
+	if m.showingTimeReport {
+		b.WriteString(m.renderTimeReport())
+		return b.String()
+	}
 
-			// Render each overdue task with index
-			for _, task := range overdueTasks {
-				m.renderTask(task, &b, taskIndex)
-				taskIndex++
-			}
-			b.WriteString("\n")
-		}
+	// Show the duplicate merge assistant
+	if m.showingDuplicates {
+		b.WriteString(m.renderDuplicates())
+		return b.String()
+	}
 
-		// Render today's tasks section if any exist
-		if len(todayTasks) > 0 {
-			b.WriteString(titleStyle.Render("📅 Today's Tasks"))
-			b.WriteString("\n")
-			// Generate dynamic headers based on selected columns
-			header, separator := m.generateHeaders()
-			b.WriteString(headerStyle.Render(header))
-			b.WriteString("\n")
-			b.WriteString(headerStyle.Render(separator))
-			b.WriteString("\n")
+	// Show the stale task sweep
+	if m.showingStaleSweep {
+		b.WriteString(m.renderStaleSweep())
+		return b.String()
+	}
 
-			// Render each today's task with index
-			for _, task := range todayTasks {
-				m.renderTask(task, &b, taskIndex)
-				taskIndex++
-			}
+	// Show the help screen
+	if m.showingHelp {
+		b.WriteString(m.renderHelp())
+		return b.String()
+	}
+
+	// Show the stats screen
+	if m.showingStats {
+		b.WriteString(m.renderStats())
+		return b.String()
+	}
+
+	// Show the parked tasks screen
+	if m.showingParked {
+		b.WriteString(m.renderParkedScreen())
+		return b.String()
+	}
+
+	// Show the find and replace screen
+	if m.showingReplace {
+		b.WriteString(m.renderReplaceScreen())
+		return b.String()
+	}
+
+	// Show the search screen
+	if m.showingSearch {
+		b.WriteString(m.renderSearchScreen())
+		return b.String()
+	}
+
+	// Show the P1 triage screen
+	if m.showingTriage {
+		b.WriteString(m.renderTriageScreen())
+		return b.String()
+	}
+
+	// Handle error state, noting safe mode since it helps tell where the error comes from
+	if m.error != nil {
+		if safeMode := m.renderSafeModeBanner(); safeMode != "" {
+			b.WriteString(safeMode)
+			b.WriteString("\n\n")
 		}
+		b.WriteString(errorStyle.Render("Error: " + friendlyError(m.error)))
+		// A resync needs a client and a cache, which aren't there when the error came from setting them up
+		if key := m.keys.keyFor(keyContextMain, actionResync); key != "" && m.client != nil && m.cache != nil {
+			b.WriteString(fmt.Sprintf("\n\nPress %s to force a full resync, Ctrl+C to quit", key))
+		} else {
+			b.WriteString("\n\nPress Ctrl+C to quit")
+		}
+		return b.String()
 	}
 
-	// Add footer with help text
-	b.WriteString("\n")
-	if len(m.allTasks) > 0 {
-		deleteText := getDeleteShortcutText()
-		b.WriteString(loadingStyle.Render("↑/↓ or j/k: navigate • Enter/Space: details • e: complete • " + deleteText + " • o: open • q: new task • r: refresh • ESC/Ctrl+C: quit"))
-	} else {
-		b.WriteString(loadingStyle.Render("Press 'r' to refresh, 'q' for new task, ESC/Ctrl+C to quit"))
+	// Handle loading state
+	if m.loading {
+		if m.refreshingInBackground {
+			b.WriteString(loadingStyle.Render("Loading tasks... (refreshing in background)"))
+		} else {
+			b.WriteString(loadingStyle.Render("Loading tasks..."))
+		}
+		b.WriteString("\n\nPress Ctrl+C to quit")
+		return b.String()
 	}
 
+	// Show status lines above the list
+	b.WriteString(m.renderStatusLines())
+
+	// Show the task list, paginated to fit between the status lines and the footer
+	footer := m.renderFooter()
+	b.WriteString(m.renderTaskWindow(m.listPageHeight(b.String(), footer)))
+	b.WriteString(footer)
+
 	// Get the main view content
 	mainView := b.String()
 
@@ -676,6 +1679,104 @@ func (m model) View() string {
 		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
 	}
 
+	// If showing bulk confirmation, overlay it on top of the main view
+	if m.showingBulkConfirm {
+		popup := m.renderBulkConfirm()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing the recurring completion choice, overlay it on top of the main view
+	if m.showingCompleteChoice {
+		popup := m.renderCompleteChoice()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If offering to complete a task whose subtasks are all done, overlay it on top of the main view
+	if m.showingCompleteParent {
+		popup := m.renderCompleteParent()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing the profile switcher, overlay it on top of the main view
+	if m.showingProfiles {
+		popup := m.renderProfiles()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing the message history, overlay it on top of the main view
+	if m.showingMessages {
+		popup := m.renderMessages()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing bulk label popup, overlay it on top of the main view
+	if m.showingBulkLabel {
+		popup := m.renderBulkLabel()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing project picker, overlay it on top of the main view
+	if m.showingProjectPicker {
+		popup := m.renderProjectPicker()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing the label list, overlay it on top of the main view
+	if m.showingLabelPicker {
+		popup := m.renderLabelPicker()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing comments popup, overlay it on top of the main view
+	if m.showingComments {
+		popup := m.renderComments()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing export popup, overlay it on top of the main view
+	if m.showingExport {
+		popup := m.renderExport()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing park popup, overlay it on top of the main view
+	if m.showingPark {
+		popup := m.renderPark()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing reschedule popup, overlay it on top of the main view
+	if m.showingReschedule {
+		popup := m.renderReschedule()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing the new project prompt, overlay it on top of the main view
+	if m.showingNewProject {
+		popup := m.renderNewProject()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
+	// If showing column menu, overlay it on top of the main view
+	if m.showingColumnMenu {
+		popup := m.renderColumnMenu()
+		// Place popup over main view
+		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, mainView) + "\n" + popup
+	}
+
 	// If showing delete confirmation, overlay it on top of the main view
 	if m.showingDeleteConfirm {
 		popup := m.renderDeleteConfirmDialog()
@@ -701,62 +1802,33 @@ func getPriorityText(priority int) string {
 	}
 }
 
-// calculateColumnWidths determines optimal column widths based on terminal size
-// Returns widths for priority, task, and project columns respectively
-func (m model) calculateColumnWidths() (int, int, int) {
-	// Calculate available width (subtract margins and padding)
-	availableWidth := m.width - 8 // Account for margins and spacing
-
-	// Define base column widths
-	priorityWidth := 8 // Fixed width for priority column
-	projectWidth := 20 // Preferred width for project column
-
-	// Calculate task column width from remaining space
-	taskWidth := availableWidth - priorityWidth - projectWidth - 6 // 6 for spacing between columns
-
-	// Ensure minimum widths for readability
-	if taskWidth < 20 {
-		taskWidth = 20
-		projectWidth = 15 // Reduce project width if terminal is narrow
-	}
-	if projectWidth < 10 {
-		projectWidth = 10 // Minimum project width
-	}
-
-	return priorityWidth, taskWidth, projectWidth
-}
-
 // generateHeaders creates table headers and separators based on selected columns
 // Returns header text and separator line for the table
 func (m model) generateHeaders() (string, string) {
-	// Get dynamic column widths based on terminal size
-	priorityWidth, taskWidth, projectWidth := m.calculateColumnWidths()
-
-	var headerParts []string
-	var separatorParts []string
-
-	// Generate headers and separators for each selected column
-	for _, col := range m.columns {
-		switch strings.ToLower(col) {
-		case "priority":
-			headerParts = append(headerParts, "PRIORITY")
-			separatorParts = append(separatorParts, strings.Repeat("─", priorityWidth))
-		case "task":
-			// Pad task header to full column width
-			taskHeader := "TASK" + strings.Repeat(" ", taskWidth-4)
-			headerParts = append(headerParts, taskHeader)
-			separatorParts = append(separatorParts, strings.Repeat("─", taskWidth))
-		case "project":
-			headerParts = append(headerParts, "PROJECT")
-			separatorParts = append(separatorParts, strings.Repeat("─", projectWidth))
+	// Get the column widths laid out for the terminal size
+	widths := m.layoutWidths()
+
+	var header, separator strings.Builder
+
+	// Generate headers and separators for each selected column, lined up with the columns of the rows
+	for i, col := range m.columns {
+		name := strings.ToLower(col)
+		width := widths[name]
+		if i > 0 {
+			// The header style's margin stands in for the first column's
+			gap := strings.Repeat(" ", columnMargins[name])
+			header.WriteString(gap)
+			separator.WriteString(gap)
+		}
+		title := strings.ToUpper(name)
+		if len(title) > width {
+			title = title[:width]
 		}
+		header.WriteString(title + strings.Repeat(" ", width-len(title)))
+		separator.WriteString(strings.Repeat("─", width))
 	}
 
-	// Join parts with double space separation
-	header := strings.Join(headerParts, "  ")
-	separator := strings.Join(separatorParts, "  ")
-
-	return header, separator
+	return strings.TrimRight(header.String(), " "), separator.String()
 }
 
 // wrapText breaks text into multiple lines to fit within the specified width
@@ -799,29 +1871,293 @@ func wrapText(text string, width int) []string {
 	return lines
 }
 
+// renderHeading renders the title of the active view and the blank line below it
+func (m model) renderHeading() string {
+	var b strings.Builder
+	if m.client != nil {
+		title := m.viewTitle()
+		// Tell accounts apart when more than one is configured
+		if m.config.profile != "" {
+			title += " · 👤 " + m.config.profile
+		}
+		// Show which timezone "today" follows when it isn't the computer's
+		if location := dayLocation.Load(); location != nil {
+			title += " · 🕐 " + location.String()
+		}
+		// Show the sort order when it isn't the view's usual one
+		if mode := m.sortMode(); mode != defaultSortMode(m.view) {
+			title += " · ⇅ " + sortModeLabels[mode]
+		}
+		b.WriteString(titleStyle.Render(title))
+	} else {
+		b.WriteString(titleStyle.Render("📋 Today's Tasks & Overdue"))
+	}
+	b.WriteString("\n\n")
+	// Keep the tutorial's current step in sight on every screen
+	if prompt := m.renderTutorialPrompt(); prompt != "" {
+		b.WriteString(prompt)
+		b.WriteString("\n\n")
+	}
+	return b.String()
+}
+
+// renderStatusLines renders the notices shown above the task list, each followed by a blank line
+func (m model) renderStatusLines() string {
+	var b strings.Builder
+
+	// Remind that the user's config and cache are not in use
+	if safeMode := m.renderSafeModeBanner(); safeMode != "" {
+		b.WriteString(safeMode)
+		b.WriteString("\n\n")
+	}
+
+	// Remind that nothing can be changed
+	if readOnly := m.renderReadOnlyBanner(); readOnly != "" {
+		b.WriteString(readOnly)
+		b.WriteString("\n\n")
+	}
+
+	// Show where the data came from when it isn't live
+	if freshness := m.renderFreshness(); freshness != "" {
+		b.WriteString(freshness)
+		b.WriteString("\n\n")
+	}
+
+	// Mark the resources that failed to refresh while others succeeded
+	if staleResources := m.renderStaleResources(); staleResources != "" {
+		b.WriteString(staleResources)
+		b.WriteString("\n\n")
+	}
+
+	// Explain why project IDs are shown instead of names
+	if projectsWarning := m.renderProjectsWarning(); projectsWarning != "" {
+		b.WriteString(projectsWarning)
+		b.WriteString("\n\n")
+	}
+
+	// Warn when too many tasks are P1
+	if urgentWarning := m.renderUrgentWarning(); urgentWarning != "" {
+		b.WriteString(urgentWarning)
+		b.WriteString("\n\n")
+	}
+
+	// Show the day's progress while completed tasks are kept
+	if progress := m.renderCompletedProgress(); progress != "" {
+		b.WriteString(progress)
+		b.WriteString("\n\n")
+	}
+
+	// Show visual-select mode and its keys
+	if visualStatus := m.renderVisualStatus(); visualStatus != "" {
+		b.WriteString(visualStatus)
+		b.WriteString("\n\n")
+	}
+
+	// Show the task picked for linking
+	if linkStatus := m.renderLinkStatus(); linkStatus != "" {
+		b.WriteString(linkStatus)
+		b.WriteString("\n\n")
+	}
+
+	// Show the cut task waiting to be pasted
+	if registerStatus := m.renderRegisterStatus(); registerStatus != "" {
+		b.WriteString(registerStatus)
+		b.WriteString("\n\n")
+	}
+
+	// Show the filter prompt or active filter
+	if filterBar := m.renderFilterBar(); filterBar != "" {
+		b.WriteString(filterBar)
+		b.WriteString("\n\n")
+	}
+
+	return b.String()
+}
+
+// renderTaskList renders the tasks of the active view, remembering the lines each task occupies
+// Only the tasks in the builder's rendered range are rendered in full, the others are just measured
+func (m model) renderTaskList(b *listBuilder) *listBuilder {
+	// Handle empty tasks state
+	if m.view == viewUpcoming {
+		// Day headers are shown even for days without tasks
+		m.renderUpcoming(b)
+	} else if len(m.allTasks) == 0 && m.filterQuery != "" && len(m.tasks) > 0 {
+		b.WriteString(taskStyle.Render("🔍 No tasks match the filter"))
+	} else if len(m.tasks) == 0 && m.view == viewProject {
+		b.WriteString(taskStyle.Render("📭 No active tasks in this project"))
+	} else if len(m.tasks) == 0 && m.view == viewLabel {
+		b.WriteString(taskStyle.Render("📭 No active tasks with this label"))
+	} else if len(m.tasks) == 0 && m.view == viewSomeday {
+		b.WriteString(taskStyle.Render("📭 Nothing put away for someday"))
+	} else if m.isNewAccount() {
+		// Guide a brand-new account to its first task and project
+		b.WriteString(m.renderWelcome())
+	} else if len(m.tasks) == 0 {
+		b.WriteString(taskStyle.Render("🎉 No tasks due today! Great job!"))
+	} else if m.view == viewProject || m.view == viewLabel || m.view == viewSomeday {
+		// Render the project's, label's or backlog's tasks in a single table, a project's grouped under section headers
+		header, separator := m.generateHeaders()
+		b.WriteString(headerStyle.Render(header))
+		b.WriteString("\n")
+		b.WriteString(headerStyle.Render(separator))
+		b.WriteString("\n")
+		grouped := m.view == viewProject && len(m.projectSections(m.viewProjectID)) > 0
+		for taskIndex, task := range m.allTasks {
+			if grouped && (taskIndex == 0 || task.SectionID != m.allTasks[taskIndex-1].SectionID) {
+				if taskIndex > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString(m.renderSectionHeader(task.SectionID))
+				b.WriteString("\n")
+			}
+			m.renderTask(task, b, taskIndex)
+		}
+	} else {
+		// Separate tasks into overdue and today's categories
+		var overdueTasks, todayTasks []TodoistTask
+		for _, task := range m.allTasks {
+			if isTaskOverdue(task) {
+				overdueTasks = append(overdueTasks, task)
+			} else {
+				todayTasks = append(todayTasks, task)
+			}
+		}
+
+		// Keep track of task index for selection
+		taskIndex := 0
+
+		// Render overdue tasks section if any exist
+		if len(overdueTasks) > 0 {
+			b.WriteString(titleStyle.Render("⚠️ Overdue Tasks"))
+			b.WriteString("\n")
+			// Generate dynamic headers based on selected columns
+			header, separator := m.generateHeaders()
+			b.WriteString(headerStyle.Render(header))
+			b.WriteString("\n")
+			b.WriteString(headerStyle.Render(separator))
+			b.WriteString("\n")
+
+			// Render each overdue task with index
+			for _, task := range overdueTasks {
+				m.renderTask(task, b, taskIndex)
+				taskIndex++
+			}
+			b.WriteString("\n")
+		}
+
+		// Render today's tasks section if any exist
+		if len(todayTasks) > 0 {
+			b.WriteString(titleStyle.Render("📅 Today's Tasks"))
+			b.WriteString("\n")
+			// Generate dynamic headers based on selected columns
+			header, separator := m.generateHeaders()
+			b.WriteString(headerStyle.Render(header))
+			b.WriteString("\n")
+			b.WriteString(headerStyle.Render(separator))
+			b.WriteString("\n")
+
+			// Render each today's task with index
+			for _, task := range todayTasks {
+				m.renderTask(task, b, taskIndex)
+				taskIndex++
+			}
+		}
+	}
+
+	return b
+}
+
+// renderFooter renders the help text below the task list
+func (m model) renderFooter() string {
+	var b strings.Builder
+
+	b.WriteString("\n")
+	if toast := m.renderToast(); toast != "" {
+		b.WriteString(toast)
+		b.WriteString("\n")
+	}
+	if lastRefreshed := m.renderLastRefreshed(); lastRefreshed != "" {
+		b.WriteString(lastRefreshed)
+		b.WriteString("\n")
+	}
+	if pomodoro := m.renderPomodoro(); pomodoro != "" {
+		b.WriteString(pomodoro)
+		b.WriteString("\n")
+	}
+	if tracking := m.renderTracking(); tracking != "" {
+		b.WriteString(tracking)
+		b.WriteString("\n")
+	}
+	if len(m.allTasks) > 0 || m.filterQuery != "" {
+		if left := m.keys.keyFor(keyContextMain, actionScrollLeft); left != "" && m.columnOverflow("task") == overflowTruncate {
+			b.WriteString(loadingStyle.Render(left + "/" + m.keys.keyFor(keyContextMain, actionScrollRight) + ": scroll task"))
+			b.WriteString("\n")
+		}
+		if days := m.keys.keysFor(keyContextMain, actionToggleDay); days != "" && m.view == viewUpcoming {
+			b.WriteString(loadingStyle.Render(days + ": collapse/expand day"))
+			b.WriteString("\n")
+		}
+		b.WriteString(loadingStyle.Render(m.footerHints(taskListHints)))
+	} else {
+		b.WriteString(loadingStyle.Render(m.footerHints(emptyListHints)))
+	}
+
+	return b.String()
+}
+
 // renderTask renders a single task row in the table format
 // Handles text wrapping for long task content and maintains column alignment
-func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
+func (m model) renderTask(task TodoistTask, b *listBuilder, taskIndex int) {
+	// Remember the lines this task occupies, for paging
+	b.startTask()
+	defer b.endTask()
+
 	// Check if this task is currently selected
 	isSelected := taskIndex == m.selectedIndex
 
+	// Tasks off screen only take up their lines, which is all paging needs to know about them
+	content := m.taskRowContent(task)
+	taskLines, projectLines := m.taskRowLines(task, content, isSelected)
+	lineCount := max(len(taskLines), len(projectLines), 1)
+	if !b.renders(taskIndex) {
+		b.skipLines(lineCount)
+		return
+	}
+
+	// Check if this task was added or changed by the last refresh
+	isChanged := m.changedTasks[task.ID]
+
 	// Get color for this task's priority level
 	priorityColor := priorityColors[task.Priority]
 	if priorityColor == "" {
 		priorityColor = priorityColors[1] // Default to low priority color if not found
 	}
 
-	// Calculate dynamic column widths based on terminal size
-	priorityWidth, taskWidth, projectWidth := m.calculateColumnWidths()
+	// Apply the conditional formatting rules the task matches
+	format := m.formatStyleFor(task)
+	if format.color != "" {
+		priorityColor = format.color
+	}
+
+	// Lay out the column widths for the terminal size
+	widths := m.layoutWidths()
+	priorityWidth, taskWidth, projectWidth := widths["priority"], widths["task"], widths["project"]
+	labelsWidth, dueWidth, ageWidth := widths["labels"], widths["due"], widths["age"]
 
-	// Prepare task content with text wrapping
-	taskLines := wrapText(task.Content, taskWidth)
+	// Tasks completed this session stay dim and struck through
+	isCompleted := m.completedTasks[task.ID]
 
-	// Prepare project name with truncation if needed
-	projectName := m.client.GetProjectName(task.ProjectID)
-	if len(projectName) > projectWidth {
-		projectName = projectName[:projectWidth-3] + "..."
+	// Colors of the task text, used to highlight filter matches within it
+	textStyle := lipgloss.NewStyle().Foreground(priorityColor)
+	if isSelected {
+		textStyle = textStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+	} else if isChanged {
+		textStyle = textStyle.Background(changedBgColor)
 	}
+	if isCompleted {
+		textStyle = textStyle.Strikethrough(true).Faint(true)
+	}
+	textStyle = format.apply(textStyle, isSelected || isChanged)
 
 	// Render the first line with all column data
 	var firstLineColumns []string
@@ -833,6 +2169,7 @@ func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
 			if isSelected {
 				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
 			}
+			columnStyle = format.apply(columnStyle, isSelected)
 			firstLineColumns = append(firstLineColumns, columnStyle.Render(priorityText))
 		case "task":
 			// Use first line of wrapped text or empty string
@@ -843,14 +2180,65 @@ func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
 			columnStyle := taskStyle.Foreground(priorityColor).Width(taskWidth)
 			if isSelected {
 				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+			} else if isChanged {
+				columnStyle = columnStyle.Background(changedBgColor)
+			}
+			if isCompleted {
+				columnStyle = columnStyle.Strikethrough(true).Faint(true)
+			}
+			columnStyle = format.apply(columnStyle, isSelected || isChanged)
+			taskText := m.highlightFilterMatches(taskContent, content, textStyle)
+			if initials, assigned := m.assigneeInitials(task); assigned {
+				// The badge ends its own colors, so the text after it needs its style of its own
+				if taskText == taskContent {
+					taskText = textStyle.Render(taskText)
+				}
+				taskText = renderAssigneeBadge(task.Assignee, initials, textStyle) + taskText
 			}
-			firstLineColumns = append(firstLineColumns, columnStyle.Render(taskContent))
+			firstLineColumns = append(firstLineColumns, columnStyle.Render(taskText))
 		case "project":
-			columnStyle := projectStyle.Width(projectWidth)
+			columnStyle := projectStyle.MarginLeft(columnMargins["project"]).Width(projectWidth)
+			if isSelected {
+				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+			}
+			firstLineColumns = append(firstLineColumns, columnStyle.Render(projectLines[0]))
+		case "labels":
+			columnStyle := taskStyle.Width(labelsWidth)
+			if isSelected {
+				// Plain text keeps the selection readable
+				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+				firstLineColumns = append(firstLineColumns, columnStyle.Render(truncateLabels(task.Labels, labelsWidth)))
+			} else {
+				firstLineColumns = append(firstLineColumns, columnStyle.Render(m.renderLabelChips(task.Labels, labelsWidth)))
+			}
+		case "due":
+			// Overdue tasks stand out, more the longer they're overdue
+			dueText, overdue := dueColumnText(task, dayNow())
+			columnStyle := taskStyle.Width(dueWidth)
 			if isSelected {
 				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+			} else if overdue {
+				days, _ := taskOverdueDays(task, dayNow())
+				columnStyle = overdueAgeStyle(columnStyle, days)
 			}
-			firstLineColumns = append(firstLineColumns, columnStyle.Render(projectName))
+			if isCompleted {
+				columnStyle = columnStyle.Strikethrough(true).Faint(true)
+			}
+			firstLineColumns = append(firstLineColumns, columnStyle.Render(compactTaskLine(dueText, dueWidth, 0)))
+		case "age":
+			// How long the task has been overdue, empty for tasks that aren't
+			ageText := ""
+			columnStyle := taskStyle.Width(ageWidth)
+			if days, overdue := taskOverdueDays(task, dayNow()); overdue {
+				ageText = overdueAge(days)
+				if !isSelected {
+					columnStyle = overdueAgeStyle(columnStyle, days)
+				}
+			}
+			if isSelected {
+				columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+			}
+			firstLineColumns = append(firstLineColumns, columnStyle.Render(compactTaskLine(ageText, ageWidth, 0)))
 		}
 	}
 
@@ -859,9 +2247,16 @@ func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
 	b.WriteString(row)
 	b.WriteString("\n")
 
-	// Render additional lines for wrapped task content (if any)
-	if len(taskLines) > 1 {
-		for _, line := range taskLines[1:] {
+	// Render additional lines for wrapped task content or project name (if any)
+	if lineCount > 1 {
+		for i := 1; i < lineCount; i++ {
+			line, projectLine := "", ""
+			if i < len(taskLines) {
+				line = taskLines[i]
+			}
+			if i < len(projectLines) {
+				projectLine = projectLines[i]
+			}
 			var additionalColumns []string
 			// Create columns for continuation lines
 			for _, col := range m.columns {
@@ -872,17 +2267,50 @@ func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
 					if isSelected {
 						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
 					}
+					columnStyle = format.apply(columnStyle, isSelected)
 					additionalColumns = append(additionalColumns, columnStyle.Render(""))
 				case "task":
 					// Show wrapped text line with same styling
 					columnStyle := taskStyle.Foreground(priorityColor).Width(taskWidth)
 					if isSelected {
 						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+					} else if isChanged {
+						columnStyle = columnStyle.Background(changedBgColor)
+					}
+					if isCompleted {
+						columnStyle = columnStyle.Strikethrough(true).Faint(true)
 					}
-					additionalColumns = append(additionalColumns, columnStyle.Render(line))
+					columnStyle = format.apply(columnStyle, isSelected || isChanged)
+					taskText := m.highlightFilterMatches(line, content, textStyle)
+					if _, assigned := m.assigneeInitials(task); assigned {
+						// Keep wrapped lines aligned with the text after the badge
+						taskText = strings.Repeat(" ", assigneeBadgeWidth) + taskText
+					}
+					additionalColumns = append(additionalColumns, columnStyle.Render(taskText))
 				case "project":
-					// Empty space for project column on continuation lines
-					columnStyle := projectStyle.Width(projectWidth)
+					// Wrapped project name, or empty space, on continuation lines
+					columnStyle := projectStyle.MarginLeft(columnMargins["project"]).Width(projectWidth)
+					if isSelected {
+						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+					}
+					additionalColumns = append(additionalColumns, columnStyle.Render(projectLine))
+				case "labels":
+					// Empty space for labels column on continuation lines
+					columnStyle := taskStyle.Width(labelsWidth)
+					if isSelected {
+						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+					}
+					additionalColumns = append(additionalColumns, columnStyle.Render(""))
+				case "due":
+					// Empty space for due column on continuation lines
+					columnStyle := taskStyle.Width(dueWidth)
+					if isSelected {
+						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
+					}
+					additionalColumns = append(additionalColumns, columnStyle.Render(""))
+				case "age":
+					// Empty space for age column on continuation lines
+					columnStyle := taskStyle.Width(ageWidth)
 					if isSelected {
 						columnStyle = columnStyle.Background(selectionBgColor).Foreground(selectionFgColor)
 					}
@@ -897,6 +2325,71 @@ func (m model) renderTask(task TodoistTask, b *strings.Builder, taskIndex int) {
 	}
 }
 
+// taskRowContent returns the text shown in a task's row: its content with the badges that apply to it
+func (m model) taskRowContent(task TodoistTask) string {
+	// Badge watched tasks, with a bell when they changed since last looked at
+	content := task.Content
+	if changed, watched := m.watchedTasks[task.ID]; watched && changed {
+		content = "🔔 " + content
+	} else if watched {
+		content = "👁 " + content
+	}
+
+	// Mark recurring tasks, which move to their next date when completed
+	if isRecurring(task) {
+		content = recurringIcon + content
+	}
+
+	// Show how far along the task's checklist of subtasks is
+	if progress := m.renderSubtaskProgress(task); progress != "" {
+		content += " [" + progress + "]"
+	}
+
+	// Spin in front of tasks Todoist hasn't confirmed creating yet
+	if isPlaceholder(task.ID) {
+		content = m.pendingGlyph() + " " + content
+	}
+
+	// Mark tasks selected in visual-select mode
+	if m.visualMode {
+		if m.markedTasks[task.ID] {
+			content = "☑ " + content
+		} else {
+			content = "☐ " + content
+		}
+	}
+	return content
+}
+
+// taskRowLines splits a task's row text and project name into the lines of their columns
+// The longer of the two decides how many lines the row takes up
+func (m model) taskRowLines(task TodoistTask, content string, isSelected bool) (taskLines, projectLines []string) {
+	taskWidth, projectWidth := m.columnWidth("task"), m.columnWidth("project")
+
+	// The assignee badge takes up the start of the task column
+	if _, assigned := m.assigneeInitials(task); assigned {
+		taskWidth -= assigneeBadgeWidth
+	}
+
+	// Prepare task content with text wrapping, or on a single scrollable line when truncating
+	if m.columnOverflow("task") == overflowTruncate {
+		offset := 0
+		if isSelected {
+			offset = m.hScroll
+		}
+		taskLines = []string{compactTaskLine(content, taskWidth, offset)}
+	} else {
+		taskLines = wrapText(content, taskWidth)
+	}
+
+	// Prepare project name, wrapped when configured or otherwise abbreviated or truncated if needed
+	projectLines = []string{m.projectColumnText(task.ProjectID, projectWidth)}
+	if m.columnOverflow("project") == overflowWrap {
+		projectLines = wrapText(m.client.GetProjectName(task.ProjectID), projectWidth)
+	}
+	return taskLines, projectLines
+}
+
 // isTaskOverdue checks if a task is overdue by comparing its due date with today
 // Returns false if the task has no due date or if date parsing fails
 func isTaskOverdue(task TodoistTask) bool {
@@ -906,7 +2399,7 @@ func isTaskOverdue(task TodoistTask) bool {
 	}
 
 	// Get today's date in YYYY-MM-DD format
-	today := time.Now().Format("2006-01-02")
+	today := dayNow().Format("2006-01-02")
 
 	// Parse task due date
 	taskTime, err := time.Parse("2006-01-02", task.Due.Date)
@@ -932,6 +2425,71 @@ func (m model) renderTaskPopup() string {
 	}
 
 	task := m.allTasks[m.selectedIndex]
+	body := m.popupBodyLines(task)
+	instructions := m.popupInstructions(task)
+	visible := m.popupVisibleLines(instructions)
+	var content strings.Builder
+
+	// The visible part of the details, scrolled when they don't fit on the terminal
+	scroll := min(m.popupScroll, max(0, len(body)-visible))
+	end := min(len(body), scroll+visible)
+	content.WriteString(strings.Join(body[scroll:end], "\n"))
+	content.WriteString("\n\n")
+	if len(body) > visible {
+		content.WriteString(projectStyle.Render(fmt.Sprintf("Lines %d-%d of %d • ↑/↓ or PgUp/PgDn to scroll", scroll+1, end, len(body))))
+		content.WriteString("\n\n")
+	}
+	content.WriteString(instructions)
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(m.popupWidth()).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// popupWidth returns the width of the task details popup, narrower on small terminals
+func (m model) popupWidth() int {
+	if m.width < 70 {
+		return m.width - 10
+	}
+	return 60
+}
+
+// popupTextWidth returns the width of the text inside the task details popup, within its padding
+func (m model) popupTextWidth() int {
+	return max(m.popupWidth()-4, 10)
+}
+
+// popupVisibleLines returns how many lines of details fit in the popup above its instructions
+func (m model) popupVisibleLines(instructions string) int {
+	// The border, padding, the scroll position and the blank lines around it take 8 lines
+	instructionLines := lipgloss.Height(lipgloss.NewStyle().Width(m.popupTextWidth()).Render(instructions))
+	return max(5, m.height-8-instructionLines)
+}
+
+// popupPageLines returns how many lines of the selected task's details make a page
+func (m model) popupPageLines() int {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return 1
+	}
+	return m.popupVisibleLines(m.popupInstructions(m.allTasks[m.selectedIndex]))
+}
+
+// scrollPopup moves the task details by the given number of lines, keeping them within their content
+func (m *model) scrollPopup(lines int) {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return
+	}
+	task := m.allTasks[m.selectedIndex]
+	maxScroll := max(0, len(m.popupBodyLines(task))-m.popupVisibleLines(m.popupInstructions(task)))
+	m.popupScroll = min(max(0, m.popupScroll+lines), maxScroll)
+}
+
+// popupBodyLines returns the lines of a task's details, wrapped to the popup's width
+// The title and description are rendered as markdown, the way Todoist shows them
+func (m model) popupBodyLines(task TodoistTask) []string {
+	width := m.popupTextWidth()
 	var content strings.Builder
 
 	// Task title
@@ -939,8 +2497,8 @@ func (m model) renderTaskPopup() string {
 	content.WriteString("\n\n")
 
 	// Task content/title
-	content.WriteString(popupFieldStyle.Render("Title: "))
-	content.WriteString(task.Content)
+	title := wrapMarkdownLine(task.Content, width, popupFieldStyle.Render("Title: "), "", lipgloss.NewStyle())
+	content.WriteString(strings.Join(title, "\n"))
 	content.WriteString("\n\n")
 
 	// Priority
@@ -976,15 +2534,43 @@ func (m model) renderTaskPopup() string {
 	} else {
 		content.WriteString("No due date")
 	}
-	content.WriteString("\n\n")
+	content.WriteString("\n")
+
+	// Estimated duration (if set)
+	if duration, ok := taskDuration(task); ok {
+		content.WriteString(popupFieldStyle.Render("Duration: "))
+		content.WriteString(formatDuration(duration))
+		content.WriteString("\n")
+	}
+
+	// Subtask progress (if the task has subtasks)
+	if progress := m.renderSubtaskProgress(task); progress != "" {
+		content.WriteString(popupFieldStyle.Render("Subtasks: "))
+		content.WriteString(progress + " done")
+		content.WriteString("\n")
+	}
+
+	// Tracked time (if any was tracked on the task)
+	if tracked := m.renderTrackedTime(task); tracked != "" {
+		content.WriteString(popupFieldStyle.Render("Tracked: "))
+		content.WriteString(tracked)
+		content.WriteString("\n")
+	}
+
+	// Completed pomodoros (if any were worked on the task)
+	if pomodoros := m.renderPomodoroCount(task); pomodoros != "" {
+		content.WriteString(popupFieldStyle.Render("Pomodoros: "))
+		content.WriteString(pomodoros)
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
 
 	// Description (if available)
 	if task.Description != "" {
 		content.WriteString(popupFieldStyle.Render("Description: "))
 		content.WriteString("\n")
-		// Wrap description text to fit popup width
-		descLines := wrapText(task.Description, 50)
-		for _, line := range descLines {
+		// Render the description's markdown, wrapped to fit the popup width
+		for _, line := range renderMarkdown(task.Description, width) {
 			content.WriteString(line)
 			content.WriteString("\n")
 		}
@@ -998,22 +2584,34 @@ func (m model) renderTaskPopup() string {
 		content.WriteString("\n\n")
 	}
 
-	// Instructions
-	deleteText := getDeleteShortcutText()
-	content.WriteString("Press 'e' to complete • " + deleteText + " • 'o' to open in Todoist • ESC to close")
+	// Linked tasks (if any)
+	content.WriteString(m.renderRelatedTasks(task.ID))
 
-	// Calculate popup size and position
-	popupContent := content.String()
-	maxWidth := 60
-	if m.width < 70 {
-		maxWidth = m.width - 10
+	// Watch status (if watched)
+	if _, watched := m.watchedTasks[task.ID]; watched {
+		content.WriteString(popupFieldStyle.Render("Watching: "))
+		content.WriteString("yes, notifying on comment, assignee and due date changes")
+		content.WriteString("\n\n")
 	}
 
-	// Apply popup styling with appropriate width
-	styledPopup := popupStyle.Width(maxWidth).Render(popupContent)
+	// Wrap what's left too long, so every line counts when scrolling
+	wrapped := lipgloss.NewStyle().Width(width).Render(strings.TrimRight(content.String(), "\n"))
+	return strings.Split(wrapped, "\n")
+}
 
-	// Center the popup on screen
-	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+// popupInstructions lists the keys of the task details popup
+func (m model) popupInstructions(task TodoistTask) string {
+	deleteText := getDeleteShortcutText()
+	actions := []string{"'e' to complete", "'t' to reschedule"}
+	if isRecurring(task) {
+		actions = append(actions, "'N' to skip this occurrence")
+	}
+	actions = append(actions, "'i' to edit", "'c' for comments", "'m' to link")
+	if len(m.taskLinks[task.ID]) > 0 {
+		actions = append(actions, "1-9 to jump to a related task")
+	}
+	actions = append(actions, deleteText, "'o' to open in Todoist", "ESC to close")
+	return "Press " + strings.Join(actions, " • ")
 }
 
 // renderCreateTaskForm creates a form view for creating new tasks
@@ -1022,7 +2620,11 @@ func (m model) renderCreateTaskForm() string {
 	form := m.createTaskForm
 
 	// Form title
-	content.WriteString(popupTitleStyle.Render("📝 Create New Task"))
+	if form.editingTaskID != "" {
+		content.WriteString(popupTitleStyle.Render("✏️ Edit Task"))
+	} else {
+		content.WriteString(popupTitleStyle.Render("📝 Create New Task"))
+	}
 	content.WriteString("\n\n")
 
 	// Task content field
@@ -1031,12 +2633,15 @@ func (m model) renderCreateTaskForm() string {
 	} else {
 		content.WriteString(popupFieldStyle.Render("  Task: "))
 	}
-	if m.creating {
-		content.WriteString(form.content + " (Creating...)")
-	} else if form.activeField == fieldContent {
-		content.WriteString(form.content + "│")
+	if m.creating && form.editingTaskID != "" {
+		content.WriteString(form.content.Value() + " (Saving...)")
 	} else {
-		content.WriteString(form.content)
+		content.WriteString(renderFormInput(form.content, form.activeField == fieldContent))
+	}
+	// Show the quick-add tokens that will fill the other fields
+	if quickAdd := m.renderQuickAddPreview(); quickAdd != "" {
+		content.WriteString("\n")
+		content.WriteString(quickAdd)
 	}
 	content.WriteString("\n\n")
 
@@ -1065,11 +2670,7 @@ func (m model) renderCreateTaskForm() string {
 	// Show search input and selection when project field is active
 	if form.activeField == fieldProject {
 		// Show search query with cursor
-		if form.projectSearch != "" {
-			content.WriteString(fmt.Sprintf("Search: %s│", form.projectSearch))
-		} else {
-			content.WriteString("Search: │")
-		}
+		content.WriteString("Search: " + renderFormInput(form.projectSearch, true))
 		content.WriteString("\n")
 
 		// Show selected project from filtered results
@@ -1086,22 +2687,75 @@ func (m model) renderCreateTaskForm() string {
 	}
 	content.WriteString("\n\n")
 
+	// Assignee named with +Name in the task content
+	if person, ok := m.collaborators[form.assigneeID]; ok && form.assigneeID != "" {
+		content.WriteString(popupFieldStyle.Render("  Assignee: "))
+		content.WriteString(person.FullName)
+		if m.formAssigneeID() == "" {
+			content.WriteString(projectStyle.Render(" (doesn't share this project, won't be assigned)"))
+		}
+		content.WriteString("\n\n")
+	}
+
+	// Section field
+	if form.activeField == fieldSection {
+		content.WriteString(popupFieldStyle.Render("→ Section: "))
+	} else {
+		content.WriteString(popupFieldStyle.Render("  Section: "))
+	}
+	content.WriteString(m.renderSectionField(form.activeField == fieldSection))
+	content.WriteString("\n\n")
+
+	// Labels field
+	if form.activeField == fieldLabels {
+		content.WriteString(popupFieldStyle.Render("→ Labels: "))
+		content.WriteString(form.labels + "│")
+		content.WriteString("\n")
+
+		// Suggest existing labels matching what is being typed
+		suggestions := m.labelSuggestions()
+		if len(suggestions) > 0 {
+			idx := form.labelSuggestionIdx % len(suggestions)
+			suggestion := lipgloss.NewStyle().Foreground(m.labelColor(suggestions[idx].Name)).Render("@" + suggestions[idx].Name)
+			content.WriteString(fmt.Sprintf("Suggest: ◀ %s ▶ (%d/%d) • Space: add", suggestion, idx+1, len(suggestions)))
+		} else if len(m.labels) > 0 {
+			content.WriteString("No matching labels")
+		}
+	} else {
+		content.WriteString(popupFieldStyle.Render("  Labels: "))
+		content.WriteString(form.labels)
+	}
+	content.WriteString("\n\n")
+
 	// Deadline field
 	if form.activeField == fieldDeadline {
 		content.WriteString(popupFieldStyle.Render("→ Deadline: "))
 	} else {
 		content.WriteString(popupFieldStyle.Render("  Deadline: "))
 	}
-	if form.activeField == fieldDeadline {
-		content.WriteString(form.deadline + "│")
-	} else {
-		content.WriteString(form.deadline)
+	content.WriteString(renderFormInput(form.deadline, form.activeField == fieldDeadline))
+	// Show what the deadline resolves to while typing it
+	if strings.TrimSpace(form.deadline.Value()) != "" {
+		content.WriteString("\n")
+		content.WriteString(renderDuePreview(form.deadline.Value(), time.Now()))
 	}
 	content.WriteString("\n\n")
 
+	// The toast would be hidden behind the form, so a failed create says why here
+	if form.failure != "" {
+		content.WriteString(errorStyle.Render(form.failure))
+		content.WriteString("\n\n")
+	}
+
 	// Instructions
-	if m.creating {
-		content.WriteString("Creating task...")
+	if form.duplicate != nil {
+		content.WriteString(m.renderDuplicateWarning())
+	} else if form.pasted != nil {
+		content.WriteString(m.renderPastedLines())
+	} else if m.creating && form.editingTaskID != "" {
+		content.WriteString("Saving task...")
+	} else if form.editingTaskID != "" {
+		content.WriteString("Tab/Arrow: navigate • Enter: save • ESC: cancel")
 	} else {
 		content.WriteString("Tab/Arrow: navigate • Enter: create • ESC: cancel")
 		content.WriteString("\n")
@@ -1110,6 +2764,10 @@ func (m model) renderCreateTaskForm() string {
 			content.WriteString("←/→: change priority")
 		case fieldProject:
 			content.WriteString("Type: search • ←/→/↑/↓: select • Backspace: clear")
+		case fieldSection:
+			content.WriteString("←/→: select section")
+		case fieldLabels:
+			content.WriteString("Type comma-separated label names")
 		default:
 			content.WriteString("Type to edit field")
 		}
@@ -1176,68 +2834,326 @@ func (m model) renderDeleteConfirmDialog() string {
 
 // handleMainViewInput handles keyboard input when in the main task list view
 func (m model) handleMainViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "escape":
-		// ESC quits the application when in main view
+	// Keys that would change tasks only explain why they do nothing in read-only mode
+	if cmd := m.refuseInReadOnly(keyContextMain, msg); cmd != nil {
+		return m, cmd
+	}
+	action := m.keys.action(keyContextMain, msg.String())
+	if wait := m.waitForPlaceholder(action); wait != nil {
+		return m, wait
+	}
+	switch action {
+	case actionBack:
+		// ESC cancels a pending link first
+		if m.linkSource != nil {
+			m.linkSource = nil
+			return m, nil
+		}
+		// Then it clears an active filter
+		if m.filterQuery != "" {
+			m.clearFilter()
+			return m, nil
+		}
+		// ESC goes back to the today view, or quits when already there
+		if m.view != viewToday && m.client != nil {
+			return m, m.switchToToday()
+		}
+		m.queueUnsentChanges()
+		m.requests.stop()
 		return m, tea.Quit
-	case "r":
+	case actionRefresh:
 		// Refresh tasks if not currently loading and no error
 		if !m.loading && m.error == nil {
 			m.loading = true
-			return m, loadFromCacheWithCmd(m.client, m.cache)
+			if m.view != viewToday {
+				return m, m.reloadCurrentView()
+			}
+			return m, loadFromCacheWithCmd(m.requests.replace(requestGroupView), m.client, m.cache)
+		}
+	case actionUpcoming:
+		// Switch between the upcoming view and today's tasks
+		if m.client != nil && !m.loading {
+			if m.view == viewUpcoming {
+				return m, m.switchToToday()
+			}
+			return m, m.switchToUpcoming()
+		}
+	case actionToggleDay:
+		// Collapse or expand a day in the upcoming view
+		if m.view == viewUpcoming {
+			m.toggleDay(m.keys.keyIndex(keyContextMain, actionToggleDay, msg.String()))
+		}
+	case actionPaste:
+		// Paste the cut task into this view
+		if m.register != nil {
+			return m, m.pasteTask()
+		}
+	case actionProjects:
+		// Show the project list for browsing a project's tasks
+		if m.client != nil && !m.loading {
+			m.openProjectPicker()
 		}
-	case "up", "k":
+	case actionLabels:
+		// Show the label list for browsing a label's tasks
+		if m.client != nil && !m.loading {
+			return m, m.openLabelPicker()
+		}
+	case actionSomeday:
+		// Show the someday/maybe backlog, or go back to today from it
+		if m.client != nil && m.cache != nil {
+			if m.view == viewSomeday {
+				return m, m.switchToToday()
+			}
+			return m, m.switchToSomeday()
+		}
+	case actionPromote:
+		// Take the selected task out of the backlog, due today
+		return m, m.promoteSelected()
+	case actionDemote:
+		// Put the selected task away for someday
+		return m, m.demoteSelected()
+	case actionCut:
+		// Cut the selected task, to paste it into another view
+		return m, m.cutTask()
+	case actionUp:
 		// Move selection up if we have tasks
 		if len(m.allTasks) > 0 {
+			m.hScroll = 0
 			if m.selectedIndex <= 0 {
 				m.selectedIndex = len(m.allTasks) - 1 // Wrap to bottom
 			} else {
 				m.selectedIndex--
 			}
 		}
-	case "down", "j":
+	case actionDown:
 		// Move selection down if we have tasks
 		if len(m.allTasks) > 0 {
+			m.hScroll = 0
 			if m.selectedIndex >= len(m.allTasks)-1 {
 				m.selectedIndex = 0 // Wrap to top
 			} else {
 				m.selectedIndex++
 			}
 		}
-	case "enter", " ":
+	case actionPageDown:
+		// Select the first task on the next page
+		m.pageSelection(1)
+	case actionPageUp:
+		// Select the first task on the previous page
+		m.pageSelection(-1)
+	case actionFirst:
+		// Select the first task
+		if len(m.allTasks) > 0 {
+			m.hScroll = 0
+			m.selectedIndex = 0
+		}
+	case actionLast:
+		// Select the last task
+		if len(m.allTasks) > 0 {
+			m.hScroll = 0
+			m.selectedIndex = len(m.allTasks) - 1
+		}
+	case actionScrollLeft:
+		// Scroll the selected task left in compact mode
+		m.scrollSelectedTask(-horizontalScrollStep)
+	case actionScrollRight:
+		// Scroll the selected task right in compact mode
+		m.scrollSelectedTask(horizontalScrollStep)
+	case actionDetails:
 		// Show popup for selected task if we have selection
 		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
 			m.showingPopup = true
+			m.popupScroll = 0
+			// Opening a changed watched task counts as having seen the change
+			taskID := m.allTasks[m.selectedIndex].ID
+			if m.watchedTasks[taskID] {
+				m.watchedTasks[taskID] = false
+				return m, markWatchSeen(m.cache, taskID)
+			}
+		}
+	case actionReschedule:
+		// Pick a new due date for the selected task
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.openReschedule(m.allTasks[m.selectedIndex])
+		}
+	case actionLink:
+		// Link the selected task to another one
+		return m, m.handleLinkKey()
+	case actionPark:
+		// Hide the selected task until a date
+		if m.cache != nil && m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.openPark(m.allTasks[m.selectedIndex])
+		}
+	case actionParked:
+		// Show the parked tasks
+		if m.cache != nil {
+			m.showingParked = true
+			m.parkedIdx = 0
+		}
+	case actionTriage:
+		// Triage P1 tasks when a P1 limit is configured
+		if m.config != nil && m.config.MaxUrgentTasks > 0 {
+			m.openTriage()
+		}
+	case actionKeepCompleted:
+		// Keep completed tasks visible or remove them right away
+		return m, m.toggleKeepCompleted()
+	case actionSelect:
+		// Start selecting tasks for a bulk action
+		if len(m.allTasks) > 0 {
+			m.visualMode = true
 		}
-	case "o", "O":
+	case actionFilter:
+		// Open the filter prompt, keeping any current query to edit
+		m.filtering = true
+	case actionReplace:
+		// Find and replace text across all open tasks
+		if m.cache != nil {
+			return m, m.openReplace()
+		}
+	case actionSearch:
+		// Search all open tasks
+		if m.cache != nil {
+			return m, m.openSearch()
+		}
+	case actionExport:
+		// Export the listed tasks to a file
+		m.openExport()
+	case actionReadLater:
+		// Send the selected link task to the read-later service
+		return m, m.readLaterSelected()
+	case actionPomodoro:
+		// Start a pomodoro for the selected task, or stop the running one
+		return m, m.togglePomodoro()
+	case actionTrack:
+		// Start or stop tracking time on the selected task
+		return m, m.toggleTracking()
+	case actionSkip:
+		// Skip the current occurrence of the selected recurring task
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) && isRecurring(m.allTasks[m.selectedIndex]) {
+			return m, skipOccurrence(m.requests.base(), m.client, m.allTasks[m.selectedIndex])
+		}
+	case actionComments:
+		// Show the selected task's comments
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			return m, m.openComments(m.allTasks[m.selectedIndex])
+		}
+	case actionWatch:
+		// Start or stop watching the selected task
+		if m.cache != nil && m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			task := m.allTasks[m.selectedIndex]
+			_, watched := m.watchedTasks[task.ID]
+			return m, toggleWatch(m.cache, task, !watched)
+		}
+	case actionOpen:
 		// Open task in Todoist if we have selection
 		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
 			task := m.allTasks[m.selectedIndex]
 			_ = browser.OpenURL(task.URL)
 		}
-	case "e", "E":
+	case actionCopy:
+		// Copy the selected task as a Markdown checklist item with a link back to Todoist
+		return m, m.copySelectedSnippet()
+	case actionComplete:
 		// Complete the selected task if we have selection
 		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
-			selectedTask := m.allTasks[m.selectedIndex]
-			return m, completeTask(m.client, selectedTask.ID)
+			return m, m.completeSelected(m.allTasks[m.selectedIndex])
 		}
-	case "q", "Q":
+	case actionNewTask:
 		// Show create task form
 		if !m.creating {
 			m.showingCreateTask = true
 			// Reset form state
 			m.createTaskForm = createTaskFormState{
-				content:            "",
+				content:            newFormInput(""),
 				priority:           1,
 				projectID:          "",
 				projectName:        "Inbox",
 				selectedProjectIdx: -1,
-				projectSearch:      "",
+				projectSearch:      newFormInput(""),
 				filteredProjects:   m.projects,
-				deadline:           "today",
+				deadline:           newFormInput("today"),
 				activeField:        fieldContent,
 			}
+			// Start from the project and label of the repository the app was started in
+			m.prefillGitContext()
+		}
+	case actionResync:
+		// Force a full resync, which also recovers from errors
+		if !m.loading && m.client != nil && m.cache != nil {
+			return m, m.startFullResync()
 		}
+	case actionEdit:
+		// Show the edit form for the selected task
+		if !m.creating && m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.startEditTask(m.allTasks[m.selectedIndex])
+		}
+	case actionColumns:
+		// Show the column toggle menu
+		m.showingColumnMenu = true
+		m.columnMenuIdx = 0
+	case actionSort:
+		// Sort the view in the next order
+		if len(m.tasks) > 0 {
+			return m, m.cycleSort()
+		}
+	case actionSyncStatus:
+		// Show the sync status screen
+		if m.client != nil && m.cache != nil {
+			m.showingSyncStatus = true
+			m.confirmingClearOps = false
+			return m, loadSyncStatus(m.cache)
+		}
+	case actionHistory:
+		// Show the history screen
+		if m.client != nil && m.cache != nil {
+			return m, m.openHistory()
+		}
+	case actionDone:
+		// Show the recently completed tasks
+		if m.client != nil {
+			return m, m.openDone()
+		}
+	case actionAgenda:
+		// Show today's timed tasks laid out by hour
+		if m.client != nil && m.cache != nil {
+			return m, m.openAgenda()
+		}
+	case actionDuplicates:
+		// Walk through near-duplicate open tasks to merge them
+		if m.client != nil && m.cache != nil {
+			return m, m.openDuplicates()
+		}
+	case actionNewProject:
+		// Ask for the name of a new project
+		if m.client != nil {
+			m.openNewProject()
+		}
+	case actionStaleSweep:
+		// Walk through old undated tasks to delete, label or schedule them
+		if m.client != nil && m.cache != nil {
+			return m, m.openStaleSweep()
+		}
+	case actionTimeReport:
+		// Show the time tracked today
+		if m.client != nil && m.cache != nil {
+			return m, m.openTimeReport()
+		}
+	case actionProfiles:
+		// Show the profile switcher
+		m.openProfiles()
+	case actionStats:
+		// Show the stats screen
+		if m.client != nil && m.cache != nil {
+			return m, m.openStats()
+		}
+	case actionMessages:
+		// Show the message history
+		m.showingMessages = true
+	case actionHelp:
+		// Show the help screen
+		m.showingHelp = true
+		m.helpScroll = 0
 		// Delete case is now handled globally above
 	}
 	return m, nil
@@ -1245,22 +3161,67 @@ func (m model) handleMainViewInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handlePopupInput handles keyboard input when in the task details popup
 func (m model) handlePopupInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc", "escape":
+	if cmd := m.refuseInReadOnly(keyContextPopup, msg); cmd != nil {
+		return m, cmd
+	}
+	switch m.keys.action(keyContextPopup, msg.String()) {
+	case actionBack:
 		// Close popup
 		m.showingPopup = false
-	case "o", "O":
+	case actionUp:
+		// Scroll long details
+		m.scrollPopup(-1)
+	case actionDown:
+		m.scrollPopup(1)
+	case actionPageUp:
+		m.scrollPopup(-m.popupPageLines())
+	case actionPageDown:
+		m.scrollPopup(m.popupPageLines())
+	case actionOpen:
 		// Open task in Todoist
 		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
 			task := m.allTasks[m.selectedIndex]
 			_ = browser.OpenURL(task.URL)
 		}
-	case "e", "E":
+	case actionCopy:
+		// Copy the task as a Markdown checklist item
+		return m, m.copySelectedSnippet()
+	case actionComplete:
 		// Complete the selected task from popup
 		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
-			selectedTask := m.allTasks[m.selectedIndex]
 			m.showingPopup = false // Close popup first
-			return m, completeTask(m.client, selectedTask.ID)
+			return m, m.completeSelected(m.allTasks[m.selectedIndex])
+		}
+	case actionEdit:
+		// Edit the selected task from popup
+		if !m.creating && m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.showingPopup = false // Close popup first
+			m.startEditTask(m.allTasks[m.selectedIndex])
+		}
+	case actionJumpRelated:
+		// Jump to a related task
+		return m, m.jumpToRelated(m.keys.keyIndex(keyContextPopup, actionJumpRelated, msg.String()))
+	case actionLink:
+		// Start or finish linking from popup
+		m.showingPopup = false // Close popup first
+		return m, m.handleLinkKey()
+	case actionReschedule:
+		// Reschedule the selected task from popup
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.showingPopup = false // Close popup first
+			m.openReschedule(m.allTasks[m.selectedIndex])
+		}
+	case actionComments:
+		// Show the selected task's comments from popup
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+			m.showingPopup = false // Close popup first
+			return m, m.openComments(m.allTasks[m.selectedIndex])
+		}
+	case actionSkip:
+		// Skip the current occurrence of a recurring task from popup
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) && isRecurring(m.allTasks[m.selectedIndex]) {
+			m.showingPopup = false // Close popup first
+			return m, skipOccurrence(m.requests.base(), m.client, m.allTasks[m.selectedIndex])
 		}
 		// Delete case is now handled globally above
 	}
@@ -1274,39 +3235,99 @@ func (m model) handleCreateTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	// Answer the similar task warning first
+	if m.createTaskForm.duplicate != nil {
+		return m.handleDuplicateInput(msg)
+	}
+
+	// Answer the offer to create one task per pasted line, or look for several lines in a paste
+	if m.createTaskForm.pasted != nil {
+		return m.handlePastedLinesInput(msg)
+	}
+	if m.createTaskForm.activeField == fieldContent && msg.Paste {
+		return m, m.pasteIntoForm(string(msg.Runes))
+	}
+	if m.createTaskForm.activeField == fieldContent && msg.String() == "ctrl+v" {
+		// Read the clipboard here rather than in the text input, to see the lines before they are joined
+		return m, readClipboard()
+	}
+
 	switch msg.String() {
 	case "esc", "escape":
 		// Cancel create task form
 		m.showingCreateTask = false
 		m.createTaskForm = createTaskFormState{
-			content:            "",
+			content:            newFormInput(""),
 			priority:           1,
 			projectID:          "",
 			projectName:        "Inbox",
 			selectedProjectIdx: -1,
-			projectSearch:      "",
+			projectSearch:      newFormInput(""),
 			filteredProjects:   m.projects,
-			deadline:           "today",
+			deadline:           newFormInput("today"),
 			activeField:        fieldContent,
 		}
 	case "enter":
+		// Fill the other fields from quick-add tokens before submitting
+		m.applyQuickAdd()
 		// Submit the new task if content is not empty
-		if strings.TrimSpace(m.createTaskForm.content) != "" {
+		if strings.TrimSpace(m.createTaskForm.content.Value()) != "" {
+			// Warn before creating a task that looks like one already open
+			if m.createTaskForm.editingTaskID == "" && !m.createTaskForm.duplicateConfirmed {
+				if similar, found := m.checkDuplicate(m.createTaskForm.content.Value()); found {
+					m.createTaskForm.duplicate = &similar
+					return m, nil
+				}
+			}
+			var op pendingOperation
+			var cmd tea.Cmd
+			// Save changes instead when editing an existing task
+			if m.createTaskForm.editingTaskID != "" {
+				update := m.createTaskForm.taskUpdate()
+				op = newOperation(opUpdate, m.createTaskForm.editingTaskID, m.createTaskForm.content.Value(),
+					operationPayload{Update: &update, ProjectID: m.createTaskForm.projectID, SectionID: m.formSectionID()})
+				cmd = updateTaskWithDetails(m.requests.base(), m.client,
+					m.createTaskForm.editingTaskID,
+					update,
+					m.createTaskForm.projectID,
+					m.formSectionID())
+			} else {
+				task := NewTaskRequest{
+					Content:    m.createTaskForm.content.Value(),
+					ProjectID:  m.createTaskForm.projectID,
+					SectionID:  m.formSectionID(),
+					Priority:   m.createTaskForm.priority,
+					Labels:     parseLabels(m.createTaskForm.labels),
+					DueString:  m.createTaskForm.deadline.Value(),
+					AssigneeID: m.formAssigneeID(),
+				}
+				// Online, the task shows up in the list right away, before Todoist confirms it
+				if !m.offline {
+					return m, m.createOptimistically(task)
+				}
+				op = newOperation(opCreate, "", task.Content, operationPayload{Task: &task})
+			}
+			// Close the form right away when the change is queued for later
+			if m.offline {
+				m.showingCreateTask = false
+				return m, m.writeOrQueue(op, cmd)
+			}
 			m.creating = true
-			return m, createTaskWithDetails(m.client,
-				m.createTaskForm.content,
-				m.createTaskForm.priority,
-				m.createTaskForm.projectID,
-				m.createTaskForm.deadline)
+			return m, cmd
 		}
 	case "tab", "down":
 		// Move to next field
 		switch m.createTaskForm.activeField {
 		case fieldContent:
+			m.applyQuickAdd()
 			m.createTaskForm.activeField = fieldPriority
 		case fieldPriority:
 			m.createTaskForm.activeField = fieldProject
 		case fieldProject:
+			m.createTaskForm.activeField = fieldSection
+		case fieldSection:
+			m.createTaskForm.activeField = fieldLabels
+		case fieldLabels:
 			m.createTaskForm.activeField = fieldDeadline
 		case fieldDeadline:
 			m.createTaskForm.activeField = fieldContent
@@ -1315,39 +3336,36 @@ func (m model) handleCreateTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Move to previous field
 		switch m.createTaskForm.activeField {
 		case fieldContent:
+			m.applyQuickAdd()
 			m.createTaskForm.activeField = fieldDeadline
 		case fieldPriority:
 			m.createTaskForm.activeField = fieldContent
 		case fieldProject:
 			m.createTaskForm.activeField = fieldPriority
-		case fieldDeadline:
+		case fieldSection:
 			m.createTaskForm.activeField = fieldProject
+		case fieldLabels:
+			m.createTaskForm.activeField = fieldSection
+		case fieldDeadline:
+			m.createTaskForm.activeField = fieldLabels
 		}
 	case "backspace":
 		// Handle backspace for current field
 		switch m.createTaskForm.activeField {
-		case fieldContent:
-			if len(m.createTaskForm.content) > 0 {
-				m.createTaskForm.content = m.createTaskForm.content[:len(m.createTaskForm.content)-1]
-			}
-		case fieldProject:
-			if len(m.createTaskForm.projectSearch) > 0 {
-				m.createTaskForm.projectSearch = m.createTaskForm.projectSearch[:len(m.createTaskForm.projectSearch)-1]
-				m.updateProjectFilter()
-			}
-		case fieldDeadline:
-			if len(m.createTaskForm.deadline) > 0 {
-				m.createTaskForm.deadline = m.createTaskForm.deadline[:len(m.createTaskForm.deadline)-1]
+		case fieldContent, fieldProject, fieldDeadline:
+			return m, m.updateFormInput(msg)
+		case fieldLabels:
+			if len(m.createTaskForm.labels) > 0 {
+				m.createTaskForm.labels = m.createTaskForm.labels[:len(m.createTaskForm.labels)-1]
+				m.createTaskForm.labelSuggestionIdx = 0
 			}
 		}
 	default:
 		// Handle field-specific input
 		switch m.createTaskForm.activeField {
 		case fieldContent:
-			// Add typed characters to task content
-			if len(msg.String()) == 1 && msg.String() != "\x1b" {
-				m.createTaskForm.content += msg.String()
-			}
+			// Edit the task content, with cursor movement, word deletion and paste
+			return m, m.updateFormInput(msg)
 		case fieldPriority:
 			// Handle priority changes with arrow keys
 			switch msg.String() {
@@ -1388,17 +3406,44 @@ func (m model) handleCreateTaskInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.createTaskForm.projectName = project.Name
 				}
 			default:
-				// Add typed characters to project search
+				// Edit the project search
+				return m, m.updateFormInput(msg)
+			}
+		case fieldSection:
+			// Cycle through the picked project's sections with arrow keys
+			switch msg.String() {
+			case "right":
+				m.cycleFormSection(1)
+			case "left":
+				m.cycleFormSection(-1)
+			}
+		case fieldLabels:
+			// Cycle through label suggestions with arrow keys, add one with space, or type
+			suggestions := m.labelSuggestions()
+			switch msg.String() {
+			case "right":
+				m.createTaskForm.labelSuggestionIdx++
+			case "left":
+				if len(suggestions) > 0 {
+					m.createTaskForm.labelSuggestionIdx = (m.createTaskForm.labelSuggestionIdx + len(suggestions) - 1) % len(suggestions)
+				}
+			case " ":
+				// Label names can't contain spaces, so space completes the suggestion
+				if len(suggestions) > 0 {
+					name := suggestions[m.createTaskForm.labelSuggestionIdx%len(suggestions)].Name
+					m.createTaskForm.labels = completeLabel(m.createTaskForm.labels, name)
+					m.createTaskForm.labelSuggestionIdx = 0
+				}
+			default:
+				// Add typed characters to labels
 				if len(msg.String()) == 1 && msg.String() != "\x1b" {
-					m.createTaskForm.projectSearch += msg.String()
-					m.updateProjectFilter()
+					m.createTaskForm.labels += msg.String()
+					m.createTaskForm.labelSuggestionIdx = 0
 				}
 			}
 		case fieldDeadline:
-			// Add typed characters to deadline
-			if len(msg.String()) == 1 && msg.String() != "\x1b" {
-				m.createTaskForm.deadline += msg.String()
-			}
+			// Edit the deadline
+			return m, m.updateFormInput(msg)
 		}
 	}
 	return m, nil
@@ -1409,10 +3454,14 @@ func (m model) handleDeleteConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "y", "Y":
 		// Confirm deletion
+		// Online, the task leaves the list right away, before Todoist confirms it
 		taskID := m.taskToDelete
 		m.showingDeleteConfirm = false
 		m.taskToDelete = ""
-		return m, deleteTask(m.client, taskID)
+		if !m.offline {
+			return m, m.deleteOptimistically(taskID)
+		}
+		return m, m.writeOrQueue(newOperation(opDelete, taskID, m.taskSummary(taskID), operationPayload{}), nil)
 	case "n", "N", "esc", "escape":
 		// Cancel deletion
 		m.showingDeleteConfirm = false
@@ -1424,41 +3473,328 @@ func (m model) handleDeleteConfirmInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // main is the entry point of the application
 // Handles command-line arguments and starts the TUI
 func main() {
+	// Run the mock Todoist server instead of the interface when asked to
+	if len(os.Args) > 1 && os.Args[1] == mockServerCommand {
+		if err := runMockServer(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Send notifications in the background instead of starting the interface when asked to
+	if len(os.Args) > 1 && os.Args[1] == notifyCommand {
+		if err := runNotifyCommand(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Add tasks sent from anywhere, or add one, instead of starting the interface when asked to
+	if len(os.Args) > 1 && os.Args[1] == captureDaemonCommand {
+		if err := runCaptureDaemon(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == captureCommand {
+		if err := runCapture(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Walk through the interface with fake data instead of starting it for real when asked to
+	if len(os.Args) > 1 && os.Args[1] == tutorialCommand {
+		if err := runTutorial(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Show the keys the app receives instead of starting the interface when asked to
+	if len(os.Args) > 1 && os.Args[1] == keyTestCommand {
+		if err := runKeyTest(os.Args[2:]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Define command-line flags
-	var columnsFlag = flag.String("columns", "task,project", "Comma-separated list of columns to display (priority,task,project)")
+	var columnsFlag = flag.String("columns", "task,project", "Comma-separated list of columns to display (priority,age,task,project,labels,due), each optionally with a width like task:flex or project:20")
+	var resyncFlag = flag.Bool("resync", false, "Drop the local cache and re-download everything on startup")
+	var compactFlag = flag.Bool("compact", false, "Show each task on a single line; scroll long tasks with h/l")
+	var profileStartupFlag = flag.Bool("profile-startup", false, "Print how long startup milestones took after exiting")
+	var exportFlag = flag.String("export", "", "Export today's tasks as "+strings.Join(exportFormats, ", ")+" and exit")
+	var outputFlag = flag.String("output", "-", "File to write the export to, or - for stdout")
+	var refreshIntervalFlag = flag.String("refresh-interval", "", "How often to re-fetch tasks in the background, e.g. 5m (default 5m, off to disable), overriding the config")
+	var safeModeFlag = flag.Bool("safe-mode", false, "Start with the default config and an empty throwaway cache, for troubleshooting")
+	var traceFlag = flag.String("trace", "", "Write frame render, update and API request timings to this file and print a summary on exit")
+	var themeFlag = flag.String("theme", "", "Color theme ("+strings.Join(themeNames(), ", ")+"), overriding the config")
+	var profileFlag = flag.String("profile", "", "Name of the configured profile (Todoist account) to use")
+	var readOnlyFlag = flag.Bool("read-only", false, "Disable every change to tasks and projects and only send reads to Todoist, e.g. for demos or screen sharing")
+	var timezoneFlag = flag.String("timezone", "", "Timezone for \"today\": an IANA name like Europe/Berlin, todoist for the account's, or local, overriding the config")
 	flag.Parse()
 
-	// Parse and clean column names
-	columns := strings.Split(*columnsFlag, ",")
-	for i, col := range columns {
-		columns[i] = strings.TrimSpace(col) // Remove whitespace
+	// Load persisted preferences, or start from the defaults in safe mode without reading the config file
+	config, err := safeModeConfig(), error(nil)
+	if !*safeModeFlag {
+		config, err = LoadConfig()
+	}
+	if err != nil {
+		fmt.Printf("Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	config.readOnly = *readOnlyFlag
+
+	// Pick the account to run as, each with its own token and cache
+	if err := validateProfiles(config.Profiles); err != nil {
+		fmt.Printf("Invalid profiles in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateAPIURLs(config); err != nil {
+		fmt.Printf("Invalid API URL: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateRequestHeaders(config.RequestHeaders); err != nil {
+		fmt.Printf("Invalid request_headers in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateReadLater(config.ReadLater); err != nil {
+		fmt.Printf("Invalid read_later in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateSlackStatus(config.SlackStatus); err != nil {
+		fmt.Printf("Invalid slack_status in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateIssueSync(config.IssueSync); err != nil {
+		fmt.Printf("Invalid issue_sync in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := validateGitContexts(config.GitContexts); err != nil {
+		fmt.Printf("Invalid git_contexts in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := config.selectProfile(*profileFlag); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Pick the timezone of "today" from the flag or the config, so travelling doesn't shift the lists
+	// The account's timezone is read from the cache once it's open, and refreshed in the background
+	config.timezone = *timezoneFlag
+	location, err := resolveTimezone(config.timezoneSetting())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	applyTimezone(location)
+	cutoff, err := parseDayCutoff(config.DayStartsAt)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	applyDayCutoff(cutoff)
+
+	// Check whether columns were given explicitly on the command line
+	columnsFlagSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "columns" {
+			columnsFlagSet = true
+		}
+	})
+
+	// Parse the column names and any widths given with them, preferring the saved choice over the default
+	var columns []string
+	var flagSpecs map[string]columnSpec
+	if !columnsFlagSet && len(config.Columns) > 0 {
+		columns = append(columns, config.Columns...)
+		// Validate that all saved columns are supported
+		for _, col := range columns {
+			if !isValidColumn(col) {
+				fmt.Printf("Invalid column: %s. Valid columns are: %s\n", col, strings.Join(availableColumns, ", "))
+				os.Exit(1)
+			}
+		}
+	} else {
+		columns, flagSpecs, err = parseColumnList(*columnsFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Size the columns from the defaults, the config and the widths given with --columns
+	specs, err := columnSpecs(config.ColumnWidths, flagSpecs)
+	if err != nil {
+		fmt.Printf("Error in column_widths: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Pick the theme from the flag or the config, applying any color overrides
+	themeName := config.Theme
+	if *themeFlag != "" {
+		themeName = *themeFlag
+	}
+	theme, err := loadTheme(themeName, config.Colors)
+	if err != nil {
+		fmt.Printf("Error loading theme: %v\n", err)
+		os.Exit(1)
+	}
+	applyTheme(theme)
+
+	// Validate the configured wrap or truncate behavior of columns
+	if err := validateColumnOverflow(config.ColumnOverflow); err != nil {
+		fmt.Printf("Invalid column_overflow in config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Pick the auto-refresh interval from the flag or the config
+	refreshIntervalValue := config.RefreshInterval
+	if *refreshIntervalFlag != "" {
+		refreshIntervalValue = *refreshIntervalFlag
+	}
+	refreshInterval, err := parseRefreshInterval(refreshIntervalValue)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check the configured pomodoro length
+	pomodoroLength, err := parsePomodoroLength(config.PomodoroLength)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check the stale task sweep settings, which put tasks away with the someday label
+	someday := newSomedaySettings(config.Someday)
+	sweep, err := parseStaleSweep(config.StaleSweep, someday.label)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Parse the conditional formatting rules up front, so mistakes are reported before the interface starts
+	formatRules, err := compileFormatRules(config.FormatRules)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Check the configured sort orders
+	if err := validateSortModes(config.Sort); err != nil {
+		fmt.Printf("Invalid sort in config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Apply the configured key bindings, reporting unknown actions and clashing keys before the interface starts
+	keys, err := newKeymap(config.Keys, config.KeyTranslations)
+	if err != nil {
+		fmt.Printf("Invalid keys in config: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Export without starting the interface when requested
+	if *exportFlag != "" {
+		if !isValidExportFormat(*exportFlag) {
+			fmt.Printf("Invalid export format: %s. Valid formats are: %s\n", *exportFlag, strings.Join(exportFormats, ", "))
+			os.Exit(1)
+		}
+		if err := runExport(config, *exportFlag, *outputFlag); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting tasks: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	// Validate that all specified columns are supported
-	validColumns := map[string]bool{"priority": true, "task": true, "project": true}
-	for _, col := range columns {
-		if !validColumns[strings.ToLower(col)] {
-			fmt.Printf("Invalid column: %s. Valid columns are: priority, task, project\n", col)
+	// Initialize the model, enabling compact mode from either the flag or the config
+	initial := initialModel(config, columns, *compactFlag || config.Compact)
+	// Drop cached data when the cache is opened if a full resync was requested
+	initial.resyncOnStart = *resyncFlag
+	initial.refreshInterval = refreshInterval
+	initial.pomodoroLength = pomodoroLength
+	initial.columnSpecs = specs
+	initial.staleSweep = sweep
+	initial.someday = someday
+	initial.formatRules = formatRules
+	initial.keys = keys
+	// Start in the project or label of the repository the app was started in
+	gitContext, repoLocation := detectGitContext(config.GitContexts)
+	initial.gitContext, initial.gitLocation = gitContext, repoLocation
+
+	// Start recording timings before the first frame
+	if *traceFlag != "" {
+		if tracer, err = newPerfTrace(*traceFlag); err != nil {
+			fmt.Printf("Error starting trace: %v\n", err)
 			os.Exit(1)
 		}
 	}
 
-	// Initialize the model
-	model := initialModel(columns)
+	summary := sessionSummary{started: time.Now()}
+	for {
+		// Initialize and run the Bubble Tea program
+		runStarted := time.Now()
+		p := tea.NewProgram(initial)
+		finalModel, err := p.Run()
+		if err != nil {
+			fmt.Printf("Error running program: %v", err)
+			os.Exit(1)
+		}
 
-	// Set up cleanup for cache database
-	if model.cache != nil {
-		defer func() {
-			if err := model.cache.Close(); err != nil {
+		// Clean up the cache database, which is opened after startup
+		final, ok := finalModel.(model)
+		if !ok {
+			break
+		}
+		// Stop requests still in flight if the program ended some other way than quitting
+		final.requests.stop()
+		if err := summary.add(final.session, final.cache, runStarted); err != nil {
+			fmt.Printf("Error summing up session: %v\n", err)
+		}
+		if final.cache != nil {
+			if err := final.cache.Close(); err != nil {
 				fmt.Printf("Error closing cache: %v\n", err)
 			}
-		}()
+		}
+
+		// Start over as the profile picked in the switcher, keeping the layout and a running pomodoro
+		if final.switchProfile == "" {
+			// A pomodoro still running ends with the app, and so does its Slack status
+			clearSlackStatusOnExit(final)
+			break
+		}
+		config.profile = final.switchProfile
+		// Forget the previous account's timezone; the new profile's cached one is applied once its cache is open
+		applyTimezone(location)
+		initial = initialModel(config, final.columns, final.compact)
+		initial.refreshInterval = refreshInterval
+		initial.pomodoroLength = pomodoroLength
+		initial.columnSpecs = specs
+		// The settings name the new profile's projects and labels by its own references once they're fetched
+		initial.staleSweep = sweep
+		initial.someday = someday
+		initial.formatRules = formatRules
+		initial.keys = keys
+		initial.gitContext, initial.gitLocation = gitContext, repoLocation
+		initial.pomodoro, initial.pomodoroSeq = final.pomodoro, final.pomodoroSeq
 	}
 
-	// Initialize and run the Bubble Tea program
-	p := tea.NewProgram(model)
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running program: %v", err)
-		os.Exit(1)
+	if err := reportSession(summary, config.SessionSummary, os.Stdout); err != nil {
+		fmt.Printf("Error reporting session: %v\n", err)
+	}
+	if *profileStartupFlag {
+		startup.report(os.Stderr)
+	}
+	if tracer != nil {
+		if err := tracer.close(os.Stderr); err != nil {
+			fmt.Printf("Error saving trace: %v\n", err)
+		}
 	}
 }
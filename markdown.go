@@ -0,0 +1,217 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles of the markdown in task titles and descriptions, set by applyTheme
+var (
+	markdownHeadingStyle lipgloss.Style
+	markdownCodeStyle    lipgloss.Style
+	markdownLinkStyle    lipgloss.Style
+	markdownMutedStyle   lipgloss.Style
+)
+
+// applyMarkdownTheme sets the markdown styles from a theme's colors
+func applyMarkdownTheme(t Theme) {
+	markdownHeadingStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(t.Accent))
+	markdownCodeStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Warning))
+	markdownLinkStyle = lipgloss.NewStyle().Underline(true).Foreground(lipgloss.Color(t.Accent))
+	markdownMutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.Muted))
+}
+
+// markdownOrderedPattern matches the number starting an item of an ordered list, e.g. "2. " or "2) "
+var markdownOrderedPattern = regexp.MustCompile(`^(\d+[.)])\s+`)
+
+// markdownSpan is a run of text in one style
+type markdownSpan struct {
+	text  string
+	style lipgloss.Style
+}
+
+// renderMarkdown renders the markdown Todoist uses in titles and descriptions, wrapped to a width:
+// headings, lists, checkboxes, quotes and code blocks, with bold, italic, code and links within lines
+func renderMarkdown(text string, width int) []string {
+	var lines []string
+	inCode := false
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Code blocks are shown as written
+		if strings.HasPrefix(trimmed, "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			lines = append(lines, markdownCodeStyle.Render("  "+line))
+			continue
+		}
+
+		// Nested list items keep their indentation, up to a point
+		indent := strings.Repeat(" ", min(len(line)-len(strings.TrimLeft(line, " \t")), 8))
+		switch {
+		case trimmed == "":
+			lines = append(lines, "")
+		case strings.HasPrefix(trimmed, "#"):
+			heading := strings.TrimLeft(trimmed, "#")
+			if heading == "" || heading[0] != ' ' {
+				lines = append(lines, wrapMarkdownLine(trimmed, width, "", "", lipgloss.NewStyle())...)
+				continue
+			}
+			lines = append(lines, wrapMarkdownLine(strings.TrimSpace(heading), width, "", "", markdownHeadingStyle)...)
+		case strings.HasPrefix(trimmed, "- [ ] "), strings.HasPrefix(trimmed, "* [ ] "):
+			lines = append(lines, wrapMarkdownLine(trimmed[6:], width, indent+"☐ ", indent+"  ", lipgloss.NewStyle())...)
+		case strings.HasPrefix(strings.ToLower(trimmed), "- [x] "), strings.HasPrefix(strings.ToLower(trimmed), "* [x] "):
+			lines = append(lines, wrapMarkdownLine(trimmed[6:], width, indent+"☑ ", indent+"  ", lipgloss.NewStyle().Faint(true))...)
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			lines = append(lines, wrapMarkdownLine(trimmed[2:], width, indent+"• ", indent+"  ", lipgloss.NewStyle())...)
+		case markdownOrderedPattern.MatchString(trimmed):
+			number := markdownOrderedPattern.FindStringSubmatch(trimmed)[1]
+			item := trimmed[len(markdownOrderedPattern.FindString(trimmed)):]
+			lines = append(lines, wrapMarkdownLine(item, width, indent+number+" ", indent+strings.Repeat(" ", len(number)+1), lipgloss.NewStyle())...)
+		case strings.HasPrefix(trimmed, ">"):
+			bar := markdownMutedStyle.Render("│ ")
+			quote := strings.TrimSpace(strings.TrimPrefix(trimmed, ">"))
+			lines = append(lines, wrapMarkdownLine(quote, width, bar, bar, lipgloss.NewStyle().Italic(true))...)
+		default:
+			lines = append(lines, wrapMarkdownLine(trimmed, width, "", "", lipgloss.NewStyle())...)
+		}
+	}
+	return lines
+}
+
+// wrapMarkdownLine renders the inline markdown of a line and wraps it to a width
+// The first line starts with first and the ones it wraps onto with rest, e.g. a bullet and its indentation
+func wrapMarkdownLine(text string, width int, first, rest string, base lipgloss.Style) []string {
+	// Split the spans into words, each of which may change style partway, e.g. "**bold**,"
+	var words [][]markdownSpan
+	var word []markdownSpan
+	for _, span := range parseInlineMarkdown(text, base) {
+		for i, part := range strings.Split(span.text, " ") {
+			if i > 0 && len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+			if part != "" {
+				word = append(word, markdownSpan{text: part, style: span.style})
+			}
+		}
+	}
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+
+	var lines []string
+	var line strings.Builder
+	line.WriteString(first)
+	lineWidth := lipgloss.Width(first)
+	start := lineWidth
+	for _, word := range words {
+		wordWidth := 0
+		for _, span := range word {
+			wordWidth += lipgloss.Width(span.text)
+		}
+		if lineWidth > start && lineWidth+1+wordWidth > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			line.WriteString(rest)
+			lineWidth = lipgloss.Width(rest)
+			start = lineWidth
+		}
+		if lineWidth > start {
+			line.WriteString(" ")
+			lineWidth++
+		}
+		for _, span := range word {
+			line.WriteString(span.style.Render(span.text))
+		}
+		lineWidth += wordWidth
+	}
+	return append(lines, line.String())
+}
+
+// parseInlineMarkdown splits a line into spans of **bold**, *italic*, `code`, [links](url) and plain text
+// Delimiters without a closing one are kept as text, as are underscores inside words like snake_case
+func parseInlineMarkdown(text string, base lipgloss.Style) []markdownSpan {
+	var spans []markdownSpan
+	var current strings.Builder
+	bold, italic := false, false
+	style := func() lipgloss.Style {
+		return base.Bold(bold || base.GetBold()).Italic(italic || base.GetItalic())
+	}
+	flush := func() {
+		if current.Len() > 0 {
+			spans = append(spans, markdownSpan{text: current.String(), style: style()})
+			current.Reset()
+		}
+	}
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		rest := string(runes[i:])
+		switch {
+		case runes[i] == '`':
+			// Code spans are taken literally
+			end := strings.IndexRune(rest[1:], '`')
+			if end < 0 {
+				current.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			code := rest[1 : 1+end]
+			spans = append(spans, markdownSpan{text: code, style: markdownCodeStyle})
+			i += len([]rune(code)) + 1
+		case runes[i] == '[':
+			// Links show their text, followed by the address when it differs
+			closing := strings.Index(rest, "](")
+			end := strings.IndexRune(rest, ')')
+			if closing < 0 || end < closing {
+				current.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			label, url := rest[1:closing], rest[closing+2:end]
+			spans = append(spans, markdownSpan{text: label, style: markdownLinkStyle})
+			if url != label {
+				spans = append(spans, markdownSpan{text: " (" + url + ")", style: markdownMutedStyle})
+			}
+			i += len([]rune(rest[:end]))
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			delimiter := rest[:2]
+			if !bold && !strings.Contains(rest[2:], delimiter) {
+				current.WriteString(delimiter)
+				i++
+				continue
+			}
+			flush()
+			bold = !bold
+			i++
+		case runes[i] == '*' || runes[i] == '_':
+			delimiter := string(runes[i])
+			wordInside := i > 0 && (unicode.IsLetter(runes[i-1]) || unicode.IsDigit(runes[i-1]))
+			if !italic && (!strings.Contains(rest[1:], delimiter) || (delimiter == "_" && wordInside)) {
+				current.WriteRune(runes[i])
+				continue
+			}
+			flush()
+			italic = !italic
+		case strings.HasPrefix(rest, "http://") || strings.HasPrefix(rest, "https://"):
+			// Bare addresses are links too
+			end := strings.IndexFunc(rest, unicode.IsSpace)
+			if end < 0 {
+				end = len(rest)
+			}
+			flush()
+			spans = append(spans, markdownSpan{text: rest[:end], style: markdownLinkStyle})
+			i += len([]rune(rest[:end])) - 1
+		default:
+			current.WriteRune(runes[i])
+		}
+	}
+	flush()
+	return spans
+}
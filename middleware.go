@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// requestHandler sends an API request and returns its response
+type requestHandler func(req *http.Request) (*http.Response, error)
+
+// requestMiddleware wraps sending API requests, e.g. to add headers or look at responses
+// It gets the next handler in the chain and calls it to send the request on
+type requestMiddleware func(next requestHandler) requestHandler
+
+// requestIDPlaceholder in a configured header value is replaced by a new ID for every request
+const requestIDPlaceholder = "{uuid}"
+
+// headerNamePattern matches valid HTTP header names
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// reservedHeaders are set by the client itself, so they can't be configured
+var reservedHeaders = []string{"Authorization", "Content-Type", "Accept-Encoding", "X-Request-Id"}
+
+// use adds middleware to the client's chain, running inside the middleware added before it
+// Must be called before the client sends requests
+func (c *TodoistClient) use(middleware ...requestMiddleware) {
+	c.middleware = append(c.middleware, middleware...)
+}
+
+// handler returns the client's middleware chain around sending a request over HTTP
+func (c *TodoistClient) handler() requestHandler {
+	handler := c.httpClient.Do
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+	return handler
+}
+
+// countRequests is middleware recording when each request is sent, for the rate limit budget
+func (c *TodoistClient) countRequests(next requestHandler) requestHandler {
+	return func(req *http.Request) (*http.Response, error) {
+		now := time.Now()
+		c.mu.Lock()
+		// Pruning as requests are counted keeps the list to one window, even when the budget is never shown
+		c.pruneRequestTimes(now)
+		c.requestTimes = append(c.requestTimes, now)
+		c.mu.Unlock()
+		return next(req)
+	}
+}
+
+// traceRequests is middleware recording how long each request took when --trace is given
+func traceRequests(next requestHandler) requestHandler {
+	return func(req *http.Request) (*http.Response, error) {
+		defer tracer.observeRequest(req, time.Now())
+		return next(req)
+	}
+}
+
+// withHeaders returns middleware setting extra headers on every request, e.g. for a corporate gateway
+// requestIDPlaceholder in a value becomes a new ID for each request, e.g. for tracing
+func withHeaders(headers map[string]string) requestMiddleware {
+	return func(next requestHandler) requestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			for name, value := range headers {
+				req.Header.Set(name, strings.ReplaceAll(value, requestIDPlaceholder, newUUID()))
+			}
+			return next(req)
+		}
+	}
+}
+
+// logRequests returns middleware writing a line per request to w: when it was sent, its method and URL,
+// and the response status or error with how long it took
+// Headers are left out, so tokens never end up in the log
+func logRequests(w io.Writer) requestMiddleware {
+	var mu sync.Mutex
+	return func(next requestHandler) requestHandler {
+		return func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(req)
+
+			outcome := ""
+			if err != nil {
+				outcome = "error: " + err.Error()
+			} else {
+				outcome = resp.Status
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			_, _ = fmt.Fprintf(w, "%s %s %s %s (%s)\n", start.Format(time.RFC3339), req.Method, req.URL, outcome, time.Since(start).Round(time.Millisecond))
+			return resp, err
+		}
+	}
+}
+
+// validateRequestHeaders checks that the configured headers have valid names and don't replace the client's own
+func validateRequestHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if !headerNamePattern.MatchString(name) {
+			return fmt.Errorf("%q is not a valid header name", name)
+		}
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(name, reserved) {
+				return fmt.Errorf("%s is set by the app and can't be configured", reserved)
+			}
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("the value of %s must be a single line", name)
+		}
+	}
+	return nil
+}
+
+// configure applies the config's API settings to the client: retries, the server and the request middleware
+// Configured headers may read environment variables written as ${NAME}, so secrets can stay out of the config file
+func (c *TodoistClient) configure(config *Config) error {
+	c.maxRetries = config.maxRetries()
+	c.setAPIURL(config.apiURL())
+	if config == nil {
+		return nil
+	}
+
+	if len(config.RequestHeaders) > 0 {
+		headers := make(map[string]string, len(config.RequestHeaders))
+		for name, value := range config.RequestHeaders {
+			headers[name] = os.ExpandEnv(value)
+		}
+		c.use(withHeaders(headers))
+	}
+	if config.RequestLog != "" {
+		file, err := os.OpenFile(config.RequestLog, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+		if err != nil {
+			return fmt.Errorf("failed to open request log: %w", err)
+		}
+		c.use(logRequests(file))
+	}
+	if config.isReadOnly() {
+		c.use(refuseWrites)
+	}
+	return nil
+}
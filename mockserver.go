@@ -0,0 +1,782 @@
+package main
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// mockServerCommand is the subcommand that starts the mock Todoist server
+const mockServerCommand = "mock-server"
+
+// mockFixtures holds the canned projects, sections, labels, tasks and comments served by the mock server
+//
+//go:embed fixtures/mock.json
+var mockFixtures []byte
+
+// mockDatePattern matches date placeholders in the fixtures, e.g. {{today}} or {{today-3}}
+// They are resolved when the server starts, so the demo data always has overdue and upcoming tasks
+var mockDatePattern = regexp.MustCompile(`\{\{today([+-]\d+)?\}\}`)
+
+// mockData is the state of the mock server, loaded from the fixtures and changed by write requests
+type mockData struct {
+	Projects []TodoistProject `json:"projects"`
+	Sections []TodoistSection `json:"sections"`
+	Labels   []TodoistLabel   `json:"labels"`
+	Tasks    []TodoistTask    `json:"tasks"`
+	Comments []TodoistComment `json:"comments"`
+	User     todoistUser      `json:"user"`
+	// Collaborators are the people sharing projects, who tasks can be assigned to
+	Collaborators []TodoistCollaborator `json:"collaborators"`
+	// CollaboratorStates tell who shares which project
+	CollaboratorStates []collaboratorState `json:"collaborator_states"`
+	// Completed lists the completed tasks, oldest first
+	Completed []TodoistCompletedTask `json:"completed"`
+}
+
+// mockSyncTokenPrefix starts the mock server's sync tokens, which end in the version they were issued at
+const mockSyncTokenPrefix = "mock-"
+
+// mockServer serves a small in-memory imitation of the Todoist REST and Sync APIs
+// It covers only the endpoints this application uses
+type mockServer struct {
+	mu     sync.Mutex
+	data   mockData
+	nextID int
+	// version counts the task changes, so sync tokens can ask for the changes since one
+	version int
+	// changed and removed map task IDs to the version they were last changed or removed at
+	changed map[string]int
+	removed map[string]int
+	// closed holds the removed tasks that were completed rather than deleted, as checked items
+	closed map[string]syncItem
+	// created maps the X-Request-Id of each create to its task, so a repeated request gets the same task like in Todoist
+	created map[string]TodoistTask
+}
+
+// newMockServer creates a mock server with the embedded fixtures, resolving dates relative to now
+func newMockServer(now time.Time) (*mockServer, error) {
+	today := now.Format("2006-01-02")
+	fixtures := mockDatePattern.ReplaceAllStringFunc(string(mockFixtures), func(placeholder string) string {
+		days, _ := strconv.Atoi(mockDatePattern.FindStringSubmatch(placeholder)[1])
+		if days == 0 {
+			return today
+		}
+		return now.AddDate(0, 0, days).Format("2006-01-02")
+	})
+
+	s := &mockServer{nextID: 9900000001, changed: make(map[string]int), removed: make(map[string]int), closed: make(map[string]syncItem),
+		created: make(map[string]TodoistTask)}
+	if err := json.Unmarshal([]byte(fixtures), &s.data); err != nil {
+		return nil, fmt.Errorf("failed to parse mock fixtures: %w", err)
+	}
+
+	// Tasks are in their manual order as listed, unless the fixtures say otherwise
+	for i := range s.data.Tasks {
+		if s.data.Tasks[i].Order == 0 {
+			s.data.Tasks[i].Order = i + 1
+		}
+	}
+	return s, nil
+}
+
+// handler returns the HTTP handler with all mock routes, requiring a bearer token like the real API
+func (s *mockServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/v2/tasks", s.listTasks)
+	mux.HandleFunc("POST /rest/v2/tasks", s.createTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}", s.updateTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}/close", s.closeTask)
+	mux.HandleFunc("POST /rest/v2/tasks/{id}/reopen", s.reopenTask)
+	mux.HandleFunc("DELETE /rest/v2/tasks/{id}", s.deleteTask)
+	mux.HandleFunc("GET /rest/v2/projects", s.list(func(d *mockData) any { return d.Projects }))
+	mux.HandleFunc("POST /rest/v2/projects", s.createProject)
+	mux.HandleFunc("GET /rest/v2/sections", s.list(func(d *mockData) any { return d.Sections }))
+	mux.HandleFunc("GET /rest/v2/labels", s.list(func(d *mockData) any { return d.Labels }))
+	mux.HandleFunc("GET /rest/v2/comments", s.listComments)
+	mux.HandleFunc("POST /rest/v2/comments", s.createComment)
+	mux.HandleFunc("POST /sync/v9/sync", s.sync)
+	mux.HandleFunc("GET /sync/v9/completed/get_all", s.listCompleted)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Printf("%s %s", r.Method, r.URL)
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		// Compress responses like the real API when the client accepts it
+		if strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			w.Header().Set("Content-Encoding", "gzip")
+			compressed := gzip.NewWriter(w)
+			defer func() { _ = compressed.Close() }()
+			w = gzipResponseWriter{ResponseWriter: w, writer: compressed}
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// gzipResponseWriter compresses everything written to the response body
+type gzipResponseWriter struct {
+	http.ResponseWriter
+	// writer compresses into the wrapped response writer
+	writer io.Writer
+}
+
+// Write compresses the data into the response body
+func (w gzipResponseWriter) Write(data []byte) (int, error) {
+	return w.writer.Write(data)
+}
+
+// writeJSON sends a value as the JSON response body
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+// newID returns a fresh identifier for a created task or comment
+// Must be called with the lock held
+func (s *mockServer) newID() string {
+	id := strconv.Itoa(s.nextID)
+	s.nextID++
+	return id
+}
+
+// touch records that a task was added or changed
+// Must be called with the lock held
+func (s *mockServer) touch(id string) {
+	s.version++
+	s.changed[id] = s.version
+	delete(s.removed, id)
+}
+
+// removeTask drops the task at the given index and records that it was removed
+// Must be called with the lock held
+func (s *mockServer) removeTask(i int) {
+	s.version++
+	s.removed[s.data.Tasks[i].ID] = s.version
+	delete(s.changed, s.data.Tasks[i].ID)
+	s.data.Tasks = append(s.data.Tasks[:i], s.data.Tasks[i+1:]...)
+}
+
+// closeTaskAt completes the task at the given index, moving a recurring task to its next occurrence
+// Must be called with the lock held
+func (s *mockServer) closeTaskAt(i int) {
+	if s.advanceRecurring(&s.data.Tasks[i]) {
+		s.touch(s.data.Tasks[i].ID)
+		return
+	}
+	item := mockSyncItem(s.data.Tasks[i])
+	item.Checked = true
+	item.CompletedAt = time.Now().UTC()
+	s.removeTask(i)
+	s.closed[item.ID] = item
+	s.data.Completed = append(s.data.Completed, TodoistCompletedTask{
+		TaskID:      item.ID,
+		Content:     item.Content,
+		ProjectID:   item.ProjectID,
+		SectionID:   item.SectionID,
+		CompletedAt: item.CompletedAt,
+	})
+}
+
+// findTask returns the index of the task with the given ID, or -1
+// Must be called with the lock held
+func (s *mockServer) findTask(id string) int {
+	for i, task := range s.data.Tasks {
+		if task.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// list returns a handler that serves one of the read-only collections
+// Each list carries an ETag, and a request sending it back as If-None-Match gets 304 Not Modified while it's unchanged
+func (s *mockServer) list(collection func(*mockData) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		body, err := json.Marshal(collection(&s.data))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		etag := fmt.Sprintf(`"%x"`, sha256.Sum256(body))
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			// Nothing is written, so nothing is compressed either
+			w.Header().Del("Content-Encoding")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	}
+}
+
+// mockNextDaysPattern matches the "next N days" filter term
+var mockNextDaysPattern = regexp.MustCompile(`^next (\d+) days$`)
+
+// mockFilterMatches evaluates the subset of Todoist filter queries the app sends:
+// terms "yesterday", "today", "tomorrow", "overdue" and "next N days" joined with "|"
+func mockFilterMatches(task TodoistTask, filter string, now time.Time) (bool, error) {
+	today := now.Format("2006-01-02")
+	matched := false
+	for _, term := range strings.Split(filter, "|") {
+		term = strings.ToLower(strings.TrimSpace(term))
+		switch {
+		case term == "yesterday":
+			matched = matched || (task.Due != nil && task.Due.Date == now.AddDate(0, 0, -1).Format("2006-01-02"))
+		case term == "today":
+			matched = matched || (task.Due != nil && task.Due.Date == today)
+		case term == "tomorrow":
+			matched = matched || (task.Due != nil && task.Due.Date == now.AddDate(0, 0, 1).Format("2006-01-02"))
+		case term == "overdue":
+			matched = matched || (task.Due != nil && task.Due.Date < today)
+		case mockNextDaysPattern.MatchString(term):
+			days, _ := strconv.Atoi(mockNextDaysPattern.FindStringSubmatch(term)[1])
+			last := now.AddDate(0, 0, days-1).Format("2006-01-02")
+			matched = matched || (task.Due != nil && task.Due.Date >= today && task.Due.Date <= last)
+		default:
+			return false, fmt.Errorf("unsupported filter term %q", term)
+		}
+	}
+	return matched, nil
+}
+
+// listTasks serves the active tasks, filtered by project_id, label, ids or a filter query like the real endpoint
+func (s *mockServer) listTasks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filter := r.URL.Query().Get("filter")
+	projectID := r.URL.Query().Get("project_id")
+	label := r.URL.Query().Get("label")
+	var ids map[string]bool
+	if value := r.URL.Query().Get("ids"); value != "" {
+		ids = make(map[string]bool)
+		for _, id := range strings.Split(value, ",") {
+			ids[id] = true
+		}
+	}
+
+	tasks := []TodoistTask{}
+	for _, task := range s.data.Tasks {
+		if filter != "" {
+			matched, err := mockFilterMatches(task, filter, time.Now())
+			if err != nil {
+				// Todoist rejects filters it can't parse
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if !matched {
+				continue
+			}
+		}
+		if (projectID == "" || task.ProjectID == projectID) && (label == "" || slices.Contains(task.Labels, label)) && (ids == nil || ids[task.ID]) {
+			tasks = append(tasks, task)
+		}
+	}
+	if filter != "" {
+		log.Printf("filter %q: %d task(s)", filter, len(tasks))
+	}
+	writeJSON(w, tasks)
+}
+
+// createTask adds a task from a NewTaskRequest body
+func (s *mockServer) createTask(w http.ResponseWriter, r *http.Request) {
+	var request NewTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Content == "" {
+		http.Error(w, "invalid task", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requestID := r.Header.Get("X-Request-Id")
+	if task, repeated := s.created[requestID]; repeated && requestID != "" {
+		writeJSON(w, task)
+		return
+	}
+
+	task := s.addTask(request)
+	if requestID != "" {
+		s.created[requestID] = task
+	}
+	writeJSON(w, task)
+}
+
+// addTask adds a task to the end of its project, the Inbox when none is given
+// Must be called with the lock held
+func (s *mockServer) addTask(request NewTaskRequest) TodoistTask {
+	task := TodoistTask{
+		ID:          s.newID(),
+		ProjectID:   request.ProjectID,
+		SectionID:   request.SectionID,
+		Content:     request.Content,
+		Description: request.Description,
+		Labels:      request.Labels,
+		Priority:    max(request.Priority, 1),
+		Assignee:    request.AssigneeID,
+		CreatedAt:   time.Now().UTC(),
+		Due:         mockDue(request.DueString),
+	}
+	if task.ProjectID == "" && len(s.data.Projects) > 0 {
+		task.ProjectID = s.data.Projects[0].ID
+	}
+	// New tasks go to the end of their project, like in Todoist
+	for _, other := range s.data.Tasks {
+		if other.ProjectID == task.ProjectID {
+			task.Order = max(task.Order, other.Order+1)
+		}
+	}
+	s.data.Tasks = append(s.data.Tasks, task)
+	s.touch(task.ID)
+	return task
+}
+
+// createProject adds a project with the name in the request body
+func (s *mockServer) createProject(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || strings.TrimSpace(request.Name) == "" {
+		http.Error(w, "invalid project", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project := TodoistProject{ID: s.newID(), Name: request.Name, Color: "charcoal"}
+	s.data.Projects = append(s.data.Projects, project)
+	writeJSON(w, project)
+}
+
+// empty turns the mock into a brand-new account: just the Inbox, with no tasks, comments or history
+func (s *mockServer) empty() {
+	var inbox []TodoistProject
+	for _, project := range s.data.Projects {
+		if project.IsInboxProject {
+			inbox = append(inbox, project)
+		}
+	}
+	s.data = mockData{Projects: inbox, User: s.data.User}
+}
+
+// updateTask applies an UpdateTaskRequest body to an existing task
+func (s *mockServer) updateTask(w http.ResponseWriter, r *http.Request) {
+	var request UpdateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "invalid update", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findTask(r.PathValue("id"))
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	task := &s.data.Tasks[i]
+	if request.Content != "" {
+		task.Content = request.Content
+	}
+	if request.Description != "" {
+		task.Description = request.Description
+	}
+	if request.Priority != 0 {
+		task.Priority = request.Priority
+	}
+	if request.Labels != nil {
+		task.Labels = *request.Labels
+	}
+	if request.DueString != "" {
+		task.Due = mockDue(request.DueString)
+	}
+	task.UpdatedAt = time.Now().UTC()
+	s.touch(task.ID)
+	writeJSON(w, task)
+}
+
+// closeTask completes a task, which removes it from the active tasks
+// Recurring tasks move to their next occurrence instead, like in Todoist
+func (s *mockServer) closeTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findTask(r.PathValue("id"))
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	s.closeTaskAt(i)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// reopenTask brings a completed task back to the active tasks
+// Tasks completed in the fixtures come back with just their content, project and section
+func (s *mockServer) reopenTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	i := slices.IndexFunc(s.data.Completed, func(completed TodoistCompletedTask) bool { return completed.TaskID == id })
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	completed := s.data.Completed[i]
+	s.data.Completed = slices.Delete(s.data.Completed, i, i+1)
+
+	task := TodoistTask{ID: id, ProjectID: completed.ProjectID, SectionID: completed.SectionID, Content: completed.Content, Priority: 1}
+	if item, ok := s.closed[id]; ok {
+		task = item.task()
+		task.IsCompleted = false
+		delete(s.closed, id)
+	}
+	task.Order = len(s.data.Tasks) + 1
+	s.data.Tasks = append(s.data.Tasks, task)
+	s.touch(id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listCompleted serves the completed tasks, newest first, limited by the since and limit parameters
+func (s *mockServer) listCompleted(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	since, _ := time.Parse("2006-01-02T15:04:05", r.URL.Query().Get("since"))
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = 30
+	}
+
+	items := []TodoistCompletedTask{}
+	for i := len(s.data.Completed) - 1; i >= 0 && len(items) < limit; i-- {
+		if s.data.Completed[i].CompletedAt.After(since) {
+			items = append(items, s.data.Completed[i])
+		}
+	}
+	writeJSON(w, map[string]any{"items": items})
+}
+
+// deleteTask removes a task
+func (s *mockServer) deleteTask(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findTask(r.PathValue("id"))
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	s.removeTask(i)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// listComments serves the comments of the task given by task_id
+func (s *mockServer) listComments(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	taskID := r.URL.Query().Get("task_id")
+	comments := []TodoistComment{}
+	for _, comment := range s.data.Comments {
+		if comment.TaskID == taskID {
+			comments = append(comments, comment)
+		}
+	}
+	writeJSON(w, comments)
+}
+
+// createComment adds a comment to a task and bumps the task's comment count
+func (s *mockServer) createComment(w http.ResponseWriter, r *http.Request) {
+	var request newCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Content == "" {
+		http.Error(w, "invalid comment", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findTask(request.TaskID)
+	if i < 0 {
+		http.NotFound(w, r)
+		return
+	}
+	s.data.Tasks[i].CommentCount++
+	comment := TodoistComment{
+		ID:       s.newID(),
+		TaskID:   request.TaskID,
+		Content:  request.Content,
+		PostedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	s.data.Comments = append(s.data.Comments, comment)
+	writeJSON(w, comment)
+}
+
+// sync runs the Sync API commands this application sends: moving tasks, skipping occurrences
+// and the completions, deletions, due date and label changes of bulk actions
+// Unknown commands and missing tasks are reported per command, like the real API.
+// A request with a sync token reads the tasks changed since that token, or the user or collaborators, instead
+func (s *mockServer) sync(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Commands      []syncCommand `json:"commands"`
+		SyncToken     string        `json:"sync_token"`
+		ResourceTypes []string      `json:"resource_types"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "invalid commands", http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if request.SyncToken != "" && slices.Contains(request.ResourceTypes, "user") {
+		writeJSON(w, map[string]any{"sync_token": mockSyncTokenPrefix + strconv.Itoa(s.version), "user": s.data.User})
+		return
+	}
+	if request.SyncToken != "" && slices.Contains(request.ResourceTypes, "collaborators") {
+		writeJSON(w, map[string]any{
+			"sync_token":          mockSyncTokenPrefix + strconv.Itoa(s.version),
+			"collaborators":       s.data.Collaborators,
+			"collaborator_states": s.data.CollaboratorStates,
+		})
+		return
+	}
+	if request.SyncToken != "" {
+		s.syncItems(w, request.SyncToken)
+		return
+	}
+
+	status := make(map[string]any)
+	for _, command := range request.Commands {
+		if command.Type == "item_add" {
+			task, err := parseItemAddArgs(command.Args)
+			if err != nil || task.Content == "" {
+				status[command.UUID] = syncCommandError{ErrorCode: 19, Error: "Invalid argument value"}
+				continue
+			}
+			s.addTask(task)
+			status[command.UUID] = "ok"
+			continue
+		}
+		id, _ := command.Args["id"].(string)
+		i := s.findTask(id)
+		switch {
+		case i < 0:
+			status[command.UUID] = syncCommandError{ErrorCode: 22, Error: "Item not found", ErrorTag: errorTagItemNotFound, HTTPCode: http.StatusNotFound}
+		case command.Type == "item_move":
+			task := &s.data.Tasks[i]
+			if sectionID, ok := command.Args["section_id"].(string); ok {
+				task.SectionID = sectionID
+				for _, section := range s.data.Sections {
+					if section.ID == sectionID {
+						task.ProjectID = section.ProjectID
+					}
+				}
+			} else if projectID, ok := command.Args["project_id"].(string); ok {
+				task.ProjectID = projectID
+				task.SectionID = ""
+			}
+			s.touch(id)
+			status[command.UUID] = "ok"
+		case command.Type == "item_update_date_complete":
+			s.advanceRecurring(&s.data.Tasks[i])
+			s.touch(id)
+			status[command.UUID] = "ok"
+		case command.Type == "item_close":
+			s.closeTaskAt(i)
+			status[command.UUID] = "ok"
+		case command.Type == "item_delete":
+			s.removeTask(i)
+			status[command.UUID] = "ok"
+		case command.Type == "item_update":
+			if due, ok := command.Args["due"].(map[string]any); ok {
+				dueString, _ := due["string"].(string)
+				s.data.Tasks[i].Due = mockDue(dueString)
+			}
+			if labels, ok := command.Args["labels"].([]any); ok {
+				s.data.Tasks[i].Labels = []string{}
+				for _, label := range labels {
+					if name, ok := label.(string); ok {
+						s.data.Tasks[i].Labels = append(s.data.Tasks[i].Labels, name)
+					}
+				}
+			}
+			s.touch(id)
+			status[command.UUID] = "ok"
+		default:
+			status[command.UUID] = syncCommandError{ErrorCode: 1, Error: "Unsupported command " + command.Type}
+		}
+	}
+	writeJSON(w, map[string]any{"sync_status": status})
+}
+
+// parseItemAddArgs reads the task an item_add command creates, the reverse of itemAddArgs
+func parseItemAddArgs(args map[string]any) (NewTaskRequest, error) {
+	var parsed struct {
+		NewTaskRequest
+		Due struct {
+			String string `json:"string"`
+		} `json:"due"`
+		ResponsibleUID string `json:"responsible_uid"`
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return NewTaskRequest{}, err
+	}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return NewTaskRequest{}, err
+	}
+	request := parsed.NewTaskRequest
+	request.DueString = parsed.Due.String
+	request.AssigneeID = parsed.ResponsibleUID
+	return request, nil
+}
+
+// syncItems serves the tasks changed since the given sync token, or all tasks for "*"
+// Tokens this server didn't issue are rejected, like expired tokens on the real API
+// Must be called with the lock held
+func (s *mockServer) syncItems(w http.ResponseWriter, token string) {
+	full := token == fullSyncToken
+	since := 0
+	if !full {
+		version, err := strconv.Atoi(strings.TrimPrefix(token, mockSyncTokenPrefix))
+		if err != nil || !strings.HasPrefix(token, mockSyncTokenPrefix) || version > s.version {
+			http.Error(w, `{"error_tag":"SYNC_TOKEN_INVALID"}`, http.StatusBadRequest)
+			return
+		}
+		since = version
+	}
+
+	items := []syncItem{}
+	for _, task := range s.data.Tasks {
+		if full || s.changed[task.ID] > since {
+			items = append(items, mockSyncItem(task))
+		}
+	}
+	if !full {
+		for id, version := range s.removed {
+			if version <= since {
+				continue
+			}
+			if item, ok := s.closed[id]; ok {
+				items = append(items, item)
+			} else {
+				items = append(items, syncItem{ID: id, IsDeleted: true})
+			}
+		}
+	}
+
+	log.Printf("sync from token %s: %d item(s)", token, len(items))
+	writeJSON(w, itemsSyncResponse{
+		SyncToken: mockSyncTokenPrefix + strconv.Itoa(s.version),
+		FullSync:  full,
+		Items:     items,
+	})
+}
+
+// mockSyncItem converts a task to the Sync API's item format
+func mockSyncItem(task TodoistTask) syncItem {
+	item := syncItem{
+		ID:          task.ID,
+		ProjectID:   task.ProjectID,
+		SectionID:   task.SectionID,
+		ParentID:    task.ParentID,
+		Content:     task.Content,
+		Description: task.Description,
+		Labels:      task.Labels,
+		Priority:    task.Priority,
+		Responsible: task.Assignee,
+		AddedAt:     task.CreatedAt,
+		UpdatedAt:   task.UpdatedAt,
+		Due:         task.Due,
+		Duration:    task.Duration,
+		ChildOrder:  task.Order,
+	}
+	if task.Due != nil && task.Due.Datetime != "" {
+		due := *task.Due
+		due.Date = due.Datetime
+		item.Due = &due
+	}
+	return item
+}
+
+// advanceRecurring moves a recurring task's due date to its next occurrence
+// Only daily recurrence is modelled; reports false for tasks that don't repeat
+func (s *mockServer) advanceRecurring(task *TodoistTask) bool {
+	if task.Due == nil || !task.Due.IsRecurring {
+		return false
+	}
+	date, err := time.Parse("2006-01-02", task.Due.Date)
+	if err != nil {
+		return false
+	}
+	task.Due.Date = date.AddDate(0, 0, 1).Format("2006-01-02")
+	return true
+}
+
+// mockDue turns the due strings this application sends into a due date
+// Understands "today", "tomorrow" and YYYY-MM-DD; "no date" and anything else clear the date
+func mockDue(dueString string) *Due {
+	now := time.Now()
+	switch strings.ToLower(strings.TrimSpace(dueString)) {
+	case "today":
+		return &Due{Date: now.Format("2006-01-02"), String: "today"}
+	case "tomorrow":
+		return &Due{Date: now.AddDate(0, 0, 1).Format("2006-01-02"), String: "tomorrow"}
+	}
+	if _, err := time.Parse("2006-01-02", dueString); err == nil {
+		return &Due{Date: dueString, String: dueString}
+	}
+	return nil
+}
+
+// runMockServer runs the mock-server subcommand until interrupted
+func runMockServer(args []string) error {
+	flags := flag.NewFlagSet(mockServerCommand, flag.ExitOnError)
+	addr := flags.String("addr", "127.0.0.1:8787", "Address to listen on")
+	empty := flags.Bool("empty", false, "Serve a brand-new account with just an Inbox instead of the demo data")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server, err := newMockServer(time.Now())
+	if err != nil {
+		return err
+	}
+	if *empty {
+		server.empty()
+	}
+
+	log.SetOutput(os.Stderr)
+	log.Printf("Mock Todoist API listening on http://%s", *addr)
+	log.Printf("Run the app against it with: %s=http://%s todoist-tui --safe-mode", todoistAPIURLEnv, *addr)
+	if err := http.ListenAndServe(*addr, server.handler()); err != nil {
+		return fmt.Errorf("failed to serve mock API: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// notifyCommand is the subcommand that sends desktop notifications without the interface
+const notifyCommand = "notify"
+
+// overdueNotificationTasks is the most task names listed in a notification about several newly overdue tasks
+const overdueNotificationTasks = 3
+
+// NotifyConfig turns on desktop notifications about due and overdue tasks while the interface runs
+type NotifyConfig struct {
+	// Due notifies when a task's due time arrives
+	Due bool `json:"due,omitempty"`
+	// Overdue notifies when tasks become overdue, as found by a refresh
+	Overdue bool `json:"overdue,omitempty"`
+}
+
+// notifiesDue reports whether tasks coming due are notified about
+func (n *NotifyConfig) notifiesDue() bool {
+	return n != nil && n.Due
+}
+
+// notifiesOverdue reports whether newly overdue tasks are notified about
+func (n *NotifyConfig) notifiesOverdue() bool {
+	return n != nil && n.Overdue
+}
+
+// overdueCheckedMsg holds the IDs of the tasks that were overdue at the last check
+type overdueCheckedMsg map[string]bool
+
+// dueNowReminders returns a reminder for every task whose due time falls in (since, now]
+// Tasks due on a day without a time have no moment to notify at, so they're only covered once overdue
+func dueNowReminders(tasks []TodoistTask, since, now time.Time) []reminder {
+	var reminders []reminder
+	for _, task := range tasks {
+		dueTime, ok := parseDueTime(task.Due)
+		if !ok || !dueTime.After(since) || dueTime.After(now) {
+			continue
+		}
+		reminders = append(reminders, reminder{
+			title: "⏰ " + task.Content,
+			body:  fmt.Sprintf("Due now (%s)", dueTime.Local().Format("15:04")),
+		})
+	}
+	return reminders
+}
+
+// overdueTaskIDs returns the IDs of the overdue tasks
+func overdueTaskIDs(tasks []TodoistTask) map[string]bool {
+	overdue := make(map[string]bool)
+	for _, task := range tasks {
+		if isTaskOverdue(task) {
+			overdue[task.ID] = true
+		}
+	}
+	return overdue
+}
+
+// newlyOverdueReminder returns one reminder about the overdue tasks that weren't overdue at the last check
+// A nil seen means there was no earlier check, so the tasks already overdue then aren't news
+func newlyOverdueReminder(tasks []TodoistTask, seen map[string]bool) (reminder, bool) {
+	if seen == nil {
+		return reminder{}, false
+	}
+	var names []string
+	for _, task := range tasks {
+		if isTaskOverdue(task) && !seen[task.ID] {
+			names = append(names, task.Content)
+		}
+	}
+
+	switch {
+	case len(names) == 0:
+		return reminder{}, false
+	case len(names) == 1:
+		return reminder{title: "⚠️ Overdue: " + names[0], body: "This task is now overdue"}, true
+	case len(names) > overdueNotificationTasks:
+		listed := strings.Join(names[:overdueNotificationTasks], ", ")
+		return reminder{
+			title: fmt.Sprintf("⚠️ %d tasks are now overdue", len(names)),
+			body:  fmt.Sprintf("%s and %d more", listed, len(names)-overdueNotificationTasks),
+		}, true
+	default:
+		return reminder{title: fmt.Sprintf("⚠️ %d tasks are now overdue", len(names)), body: strings.Join(names, ", ")}, true
+	}
+}
+
+// unparkedTasks leaves out the tasks parked in the cache, which shouldn't ask for attention
+func unparkedTasks(cache *CacheDB, tasks []TodoistTask) []TodoistTask {
+	parked, err := cache.LoadParkedTasks()
+	if err != nil || len(parked) == 0 {
+		return tasks
+	}
+	hidden := make(map[string]bool, len(parked))
+	for _, p := range parked {
+		hidden[p.TaskID] = true
+	}
+	var visible []TodoistTask
+	for _, task := range tasks {
+		if !hidden[task.ID] {
+			visible = append(visible, task)
+		}
+	}
+	return visible
+}
+
+// checkOverdue creates a command that notifies about cached tasks that became overdue since the last check
+// Returns the tasks overdue now, to compare the next check against
+func checkOverdue(cache *CacheDB, seen map[string]bool) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load tasks for notifications: %w", err))
+		}
+		tasks = unparkedTasks(cache, tasks)
+		if r, ok := newlyOverdueReminder(tasks, seen); ok {
+			_ = sendDesktopNotification(r.title, r.body)
+		}
+		return overdueCheckedMsg(overdueTaskIDs(tasks))
+	})
+}
+
+// checkNewlyOverdue returns a command looking for newly overdue tasks after fresh tasks arrived, when enabled
+func (m model) checkNewlyOverdue() tea.Cmd {
+	if m.config == nil || !m.config.Notify.notifiesOverdue() || m.cache == nil {
+		return nil
+	}
+	return checkOverdue(m.cache, m.overdueSeen)
+}
+
+// runNotifyCommand sends desktop notifications about the tasks coming due and newly overdue tasks until interrupted,
+// for running in the background without the interface, e.g. started with the desktop session
+// Label reminders from notification_rules are sent too
+func runNotifyCommand(args []string) error {
+	flags := flag.NewFlagSet(notifyCommand, flag.ExitOnError)
+	profileFlag := flags.String("profile", "", "Name of the configured profile (Todoist account) to use")
+	refreshIntervalFlag := flags.String("refresh-interval", "", "How often to fetch tasks, e.g. 5m (default 5m), overriding the config")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		return err
+	}
+	if err := validateProfiles(config.Profiles); err != nil {
+		return fmt.Errorf("invalid profiles in config: %w", err)
+	}
+	if err := validateAPIURLs(config); err != nil {
+		return fmt.Errorf("invalid API URL: %w", err)
+	}
+	if err := validateRequestHeaders(config.RequestHeaders); err != nil {
+		return fmt.Errorf("invalid request_headers in config: %w", err)
+	}
+	if err := config.selectProfile(*profileFlag); err != nil {
+		return err
+	}
+
+	// Notifications are about "today" as the interface sees it
+	location, err := resolveTimezone(config.timezoneSetting())
+	if err != nil {
+		return err
+	}
+	applyTimezone(location)
+	cutoff, err := parseDayCutoff(config.DayStartsAt)
+	if err != nil {
+		return err
+	}
+	applyDayCutoff(cutoff)
+
+	refreshIntervalValue := config.RefreshInterval
+	if *refreshIntervalFlag != "" {
+		refreshIntervalValue = *refreshIntervalFlag
+	}
+	refreshInterval, err := parseRefreshInterval(refreshIntervalValue)
+	if err != nil {
+		return err
+	}
+	if refreshInterval == 0 {
+		return fmt.Errorf("the %s command needs a refresh interval to fetch tasks", notifyCommand)
+	}
+
+	token, err := todoistToken(config)
+	if err != nil {
+		return err
+	}
+	client := NewTodoistClient(token)
+	if err := client.configure(config); err != nil {
+		return err
+	}
+	// Parked tasks are left out, as in the interface
+	cache, err := newCacheFor(config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize cache: %w", err)
+	}
+	defer func() { _ = cache.Close() }()
+	if config.timezoneSetting() == timezoneTodoist {
+		// Start from the account's timezone as last fetched, and bring it up to date with the first fetch
+		applyCachedAccountTimezone(cache)
+		if location, err := fetchAccountTimezone(context.Background(), client, cache); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			applyTimezone(location)
+		}
+	}
+
+	// Rules name labels as they're called now, as last seen by the app
+	labels, _ := cache.knownNames(referenceLabel)
+	rules := config.withCurrentNames(map[referenceKind]map[string]string{referenceLabel: labels}).NotificationRules
+
+	fmt.Fprintf(os.Stderr, "Sending notifications about due and overdue tasks, fetching them every %s; press Ctrl+C to stop\n", refreshInterval)
+	var tasks []TodoistTask
+	var overdueSeen map[string]bool
+	var lastFetch time.Time
+	lastCheck := time.Now()
+	for {
+		// Fetch on the refresh interval, and check for due times on every tick in between
+		now := time.Now()
+		if now.Sub(lastFetch) >= refreshInterval {
+			// Tasks due today include the ones with a due time coming up
+			fetched, err := client.GetFilteredTasks(context.Background(), todayFilter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch tasks: %v\n", err)
+			} else {
+				// Trimmed, since the filter also matches tomorrow's tasks
+				tasks = unparkedTasks(cache, filterTodaysTasks(fetched))
+				if r, ok := newlyOverdueReminder(tasks, overdueSeen); ok {
+					_ = sendDesktopNotification(r.title, r.body)
+				}
+				overdueSeen = overdueTaskIDs(tasks)
+			}
+			lastFetch = now
+		}
+
+		reminders := append(dueNowReminders(tasks, lastCheck, now), dueReminders(tasks, rules, lastCheck, now)...)
+		for _, r := range reminders {
+			_ = sendDesktopNotification(r.title, r.body)
+		}
+		lastCheck = now
+		time.Sleep(reminderTickInterval)
+	}
+}
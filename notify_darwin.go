@@ -0,0 +1,14 @@
+//go:build darwin
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// sendDesktopNotification shows a native notification through Notification Center
+func sendDesktopNotification(title, body string) error {
+	script := fmt.Sprintf("display notification %q with title %q", body, title)
+	return exec.Command("osascript", "-e", script).Run()
+}
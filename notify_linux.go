@@ -0,0 +1,10 @@
+//go:build linux
+
+package main
+
+import "os/exec"
+
+// sendDesktopNotification shows a native notification through notify-send (libnotify)
+func sendDesktopNotification(title, body string) error {
+	return exec.Command("notify-send", "--app-name=todoist-tui", title, body).Run()
+}
@@ -0,0 +1,14 @@
+//go:build !darwin && !windows && !linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// sendDesktopNotification rings the terminal bell on platforms without a known notifier
+func sendDesktopNotification(title, body string) error {
+	_, err := fmt.Fprint(os.Stderr, "\a")
+	return err
+}
@@ -0,0 +1,25 @@
+//go:build windows
+
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// sendDesktopNotification shows a native balloon notification through PowerShell
+func sendDesktopNotification(title, body string) error {
+	// Escape single quotes for PowerShell string literals
+	quote := func(s string) string { return "'" + strings.ReplaceAll(s, "'", "''") + "'" }
+
+	script := fmt.Sprintf(`Add-Type -AssemblyName System.Windows.Forms
+$n = New-Object System.Windows.Forms.NotifyIcon
+$n.Icon = [System.Drawing.SystemIcons]::Information
+$n.Visible = $true
+$n.ShowBalloonTip(5000, %s, %s, [System.Windows.Forms.ToolTipIcon]::Info)
+Start-Sleep -Seconds 5
+$n.Dispose()`, quote(title), quote(body))
+
+	return exec.Command("powershell", "-NoProfile", "-Command", script).Start()
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cacheMaxAge is how old cached tasks can be before they are shown as stale
+const cacheMaxAge = 5 * time.Minute
+
+// offlineMsg is sent when a read from Todoist failed because the network is unreachable
+type offlineMsg struct {
+	err error
+}
+
+// isNetworkError reports whether an error means Todoist could not be reached at all,
+// as opposed to Todoist answering with an error
+// Only failing to resolve or connect counts: a request refused locally, like a write in read-only mode,
+// or a response cut off halfway is still an error to show
+func isNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && (opErr.Op == "dial" || opErr.Op == "proxyconnect")
+}
+
+// readErrorMsg converts an error from a read command into a message
+// Network failures become offlineMsg so cached data stays on screen
+func readErrorMsg(err error) tea.Msg {
+	// A cancelled request is not a sign of being offline
+	if isCanceled(err) {
+		return errorMsg(err)
+	}
+	if isNetworkError(err) {
+		return offlineMsg{err: err}
+	}
+	return errorMsg(err)
+}
+
+// LoadProjectTasks loads the cached tasks of a single project
+func (c *CacheDB) LoadProjectTasks(projectID string) ([]TodoistTask, error) {
+	allTasks, err := c.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []TodoistTask
+	for _, task := range allTasks {
+		if task.ProjectID == projectID {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// LoadLabelTasks loads the cached tasks carrying a label
+func (c *CacheDB) LoadLabelTasks(label string) ([]TodoistTask, error) {
+	allTasks, err := c.LoadTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []TodoistTask
+	for _, task := range allTasks {
+		if slices.Contains(task.Labels, label) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// loadCachedLabelTasks creates a command that loads the tasks carrying a label from the cache
+func loadCachedLabelTasks(cache *CacheDB, label string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadLabelTasks(label)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return labelTasksLoadedMsg{label: label, tasks: tasks, fromCache: true}
+	})
+}
+
+// loadCachedProjectTasks creates a command that loads a project's tasks from the cache
+func loadCachedProjectTasks(cache *CacheDB, projectID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadProjectTasks(projectID)
+		if err != nil {
+			return errorMsg(err)
+		}
+		return projectTasksLoadedMsg{projectID: projectID, tasks: tasks, fromCache: true}
+	})
+}
+
+// renderFreshness describes where the displayed data came from when it is not live
+// Returns an empty string when showing fresh data
+func (m model) renderFreshness() string {
+	switch {
+	case m.offline && len(m.pendingOps) > 0:
+		return staleStyle.Render(fmt.Sprintf("📴 Offline — showing cached data from %s, %d change(s) queued", formatSyncTime(m.cachedAt), len(m.pendingOps)))
+	case m.offline:
+		return staleStyle.Render("📴 Offline — showing cached data from " + formatSyncTime(m.cachedAt))
+	case !m.cachedAt.IsZero() && m.cacheStale && m.refreshingInBackground:
+		return staleStyle.Render("⚠️ Stale — cached data from " + formatSyncTime(m.cachedAt) + ", refreshing…")
+	case !m.cachedAt.IsZero() && m.cacheStale:
+		return staleStyle.Render("⚠️ Stale — cached data from " + formatSyncTime(m.cachedAt))
+	default:
+		return ""
+	}
+}
+
+// goOnline clears the offline state after fresh data arrived
+// Returns a command replaying the operations queued while offline, or nil if there are none
+func (m *model) goOnline() tea.Cmd {
+	m.offline = false
+	m.cachedAt = time.Time{}
+	m.cacheStale = false
+
+	// Changes queued before the app was started read-only wait for a normal run
+	if !m.hasPendingOperations() || m.replayingOps || m.config.isReadOnly() {
+		return nil
+	}
+	m.replayingOps = true
+	return replayPendingOperations(m.requests.base(), m.client, m.cache)
+}
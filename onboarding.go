@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectCreatedMsg is sent when a new project was created
+type projectCreatedMsg TodoistProject
+
+// CreateProject creates a project with the given name
+func (c *TodoistClient) CreateProject(ctx context.Context, name string) (*TodoistProject, error) {
+	body, err := json.Marshal(map[string]string{"name": name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal project: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/projects", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return nil, newAPIError(resp)
+	}
+
+	var project TodoistProject
+	if err := json.NewDecoder(resp.Body).Decode(&project); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &project, nil
+}
+
+// createProject creates a command that creates a project
+func createProject(ctx context.Context, client *TodoistClient, name string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		project, err := client.CreateProject(ctx, name)
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to create project: %w", err))
+		}
+		return projectCreatedMsg(*project)
+	})
+}
+
+// inboxProjectIndex returns the index of the Inbox among the projects, where new tasks go by default
+// Falls back to a project named Inbox for caches from before the flag was kept, then to the first project;
+// -1 when there are no projects
+func inboxProjectIndex(projects []TodoistProject) int {
+	for i, project := range projects {
+		if project.IsInboxProject {
+			return i
+		}
+	}
+	for i, project := range projects {
+		if strings.EqualFold(project.Name, "Inbox") {
+			return i
+		}
+	}
+	if len(projects) > 0 {
+		return 0
+	}
+	return -1
+}
+
+// selectInboxProject points the new task form at the Inbox, or at no project when none is known yet,
+// which Todoist takes as the Inbox too
+func (m *model) selectInboxProject() {
+	index := inboxProjectIndex(m.projects)
+	m.createTaskForm.selectedProjectIdx = index
+	if index < 0 {
+		m.createTaskForm.projectID = ""
+		m.createTaskForm.projectName = "Inbox"
+		return
+	}
+	m.createTaskForm.projectID = m.projects[index].ID
+	m.createTaskForm.projectName = m.projects[index].Name
+}
+
+// openNewProject shows the prompt for a new project's name
+func (m *model) openNewProject() {
+	m.showingNewProject = true
+	m.newProjectName = newFormInput("")
+}
+
+// renderNewProject creates the popup asking for a new project's name
+func (m model) renderNewProject() string {
+	var content strings.Builder
+
+	content.WriteString(popupTitleStyle.Render("📁 New Project"))
+	content.WriteString("\n\n")
+	content.WriteString(popupFieldStyle.Render("Name: "))
+	content.WriteString(m.newProjectName.View())
+	content.WriteString("\n\n")
+	if m.creatingProject {
+		content.WriteString(loadingStyle.Render("Creating..."))
+		content.WriteString("\n\n")
+	}
+	content.WriteString("Enter: create • ESC: cancel")
+
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleNewProjectInput handles keyboard input when the new project prompt is visible
+func (m model) handleNewProjectInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.creatingProject {
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the prompt without creating anything
+		m.showingNewProject = false
+	case "enter":
+		// Create the project once it has a name
+		name := strings.TrimSpace(m.newProjectName.Value())
+		if name == "" || m.client == nil {
+			return m, nil
+		}
+		m.creatingProject = true
+		return m, createProject(m.requests.base(), m.client, name)
+	default:
+		var cmd tea.Cmd
+		m.newProjectName, cmd = m.newProjectName.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// handleProjectCreated adds a new project to the pickers and the cache
+func (m *model) handleProjectCreated(project TodoistProject) tea.Cmd {
+	m.showingNewProject = false
+	m.creatingProject = false
+	m.projects = append(m.projects, project)
+	m.client.LoadProjectsFromCache(m.projects)
+	m.createTaskForm.filteredProjects = m.projects
+	_ = m.cache.SaveProjects(m.projects)
+
+	return m.notify(toastSuccess, fmt.Sprintf("Created project #%s • %s: browse it", project.Name,
+		m.keys.keyFor(keyContextMain, actionProjects)))
+}
+
+// isNewAccount reports whether the account looks brand new: nothing due today and no projects besides the Inbox
+func (m model) isNewAccount() bool {
+	if m.view != viewToday || len(m.tasks) > 0 || m.retryingProjects {
+		return false
+	}
+	return len(m.projects) == 0 || (len(m.projects) == 1 && inboxProjectIndex(m.projects) == 0)
+}
+
+// renderWelcome renders the empty state of a brand-new account, offering the first steps
+func (m model) renderWelcome() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render("👋 Welcome! There's nothing here yet"))
+	b.WriteString("\n\n")
+	steps := []struct {
+		action keyAction
+		help   string
+	}{
+		{actionNewTask, "add your first task to the Inbox, e.g. \"Try Todoist TUI tomorrow\""},
+		{actionNewProject, "create a project to group tasks, like Work or Home"},
+		{actionHelp, "see every key"},
+	}
+	for _, step := range steps {
+		key := lipgloss.NewStyle().Width(helpKeyWidth).Render(m.keys.keyFor(keyContextMain, step.action))
+		b.WriteString(taskStyle.Render(key + " " + step.help))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(loadingStyle.Render("Tasks due today or overdue show up here; new tasks are due today unless you pick another date"))
+	return b.String()
+}
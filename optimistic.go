@@ -0,0 +1,294 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// placeholderIDPrefix starts the IDs of the rows shown for tasks still being created
+const placeholderIDPrefix = "pending-"
+
+// pendingSpinnerFrames animate the glyph in front of tasks still being created
+var pendingSpinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// pendingSpinnerInterval is how often the pending glyph moves on
+const pendingSpinnerInterval = 100 * time.Millisecond
+
+// pendingCreate is a task shown in the list before Todoist confirmed creating it
+type pendingCreate struct {
+	// placeholder is the row shown meanwhile, with an ID of its own
+	placeholder TodoistTask
+	request     NewTaskRequest
+	// requestID is the X-Request-Id the task is created with, kept to send it again after a restart
+	requestID string
+	// form is the filled form, opened again if creating the task fails
+	form createTaskFormState
+}
+
+// pendingDelete is a task taken out of the list before Todoist confirmed deleting it
+type pendingDelete struct {
+	task TodoistTask
+	// index is where the task was in the list, to put it back there if deleting it fails
+	index int
+	// view identifies the view the task was deleted in
+	view string
+}
+
+// taskCreateSentMsg is sent when Todoist answered the request to create a task
+type taskCreateSentMsg struct {
+	placeholderID string
+	task          TodoistTask
+	err           error
+}
+
+// taskDeleteSentMsg is sent when Todoist answered the request to delete a task
+type taskDeleteSentMsg struct {
+	taskID string
+	err    error
+}
+
+// pendingSpinnerMsg moves the pending glyph on
+type pendingSpinnerMsg struct{}
+
+// placeholderDue works out the due date a new task will get from its due string, for its placeholder row
+// Strings Todoist understands but the app doesn't leave the placeholder without a date
+func placeholderDue(dueString string, now time.Time) *Due {
+	preview, ok := parseDueString(dueString, now)
+	if !ok || preview.none {
+		return nil
+	}
+	due := &Due{Date: preview.date.Format("2006-01-02"), String: dueString, IsRecurring: preview.recurring}
+	if preview.hasTime {
+		due.Datetime = preview.date.UTC().Format(time.RFC3339)
+	}
+	return due
+}
+
+// createOptimistically shows a new task in the list right away, with a spinner until Todoist confirms it
+func (m *model) createOptimistically(request NewTaskRequest) tea.Cmd {
+	m.pendingSeq++
+	placeholder := TodoistTask{
+		ID:        fmt.Sprintf("%s%d", placeholderIDPrefix, m.pendingSeq),
+		Content:   request.Content,
+		ProjectID: request.ProjectID,
+		SectionID: request.SectionID,
+		Priority:  request.Priority,
+		Labels:    request.Labels,
+		Assignee:  request.AssigneeID,
+		Due:       placeholderDue(request.DueString, time.Now()),
+		CreatedAt: time.Now(),
+	}
+	// Todoist puts tasks without a project in the Inbox
+	if index := inboxProjectIndex(m.projects); placeholder.ProjectID == "" && index >= 0 {
+		placeholder.ProjectID = m.projects[index].ID
+	}
+	if m.pendingCreates == nil {
+		m.pendingCreates = make(map[string]pendingCreate)
+	}
+	pending := pendingCreate{placeholder: placeholder, request: request, requestID: newUUID(), form: m.createTaskForm}
+	m.pendingCreates[placeholder.ID] = pending
+
+	// Close the form and show the task, selected, if it belongs in this view
+	m.showingCreateTask = false
+	m.resetCreateTaskForm()
+	m.setTasks(m.tasks)
+	m.restoreSelection(placeholder.ID)
+
+	return tea.Batch(
+		createTaskWithDetails(withRequestID(m.requests.base(), pending.requestID), m.client, placeholder.ID, request),
+		m.startPendingSpinner())
+}
+
+// handleTaskCreateSent swaps the placeholder of a created task for the task, or takes it out and opens the form
+// again to retry when creating it failed
+func (m *model) handleTaskCreateSent(msg taskCreateSentMsg) tea.Cmd {
+	pending, ok := m.pendingCreates[msg.placeholderID]
+	if !ok {
+		return nil
+	}
+	delete(m.pendingCreates, msg.placeholderID)
+
+	if msg.err != nil {
+		m.removeTask(msg.placeholderID)
+		if isCanceled(msg.err) {
+			return nil
+		}
+		m.recordSyncError(msg.err)
+		if !m.showingCreateTask {
+			m.createTaskForm = pending.form
+			m.createTaskForm.failure = "Couldn't create the task: " + msg.err.Error()
+			m.showingCreateTask = true
+		}
+		return m.notify(toastError, fmt.Sprintf("Couldn't create %s: %s", pending.request.Content, friendlyError(msg.err)))
+	}
+
+	// Keep the row, and its selection, until the reload brings the task
+	for i := range m.tasks {
+		if m.tasks[i].ID == msg.placeholderID {
+			m.tasks[i] = msg.task
+		}
+	}
+	for i := range m.allTasks {
+		if m.allTasks[i].ID == msg.placeholderID {
+			m.allTasks[i] = msg.task
+		}
+	}
+	m.loading = true
+	_ = m.cache.SaveTask(msg.task)
+	m.session.created++
+	toast := m.notify(toastSuccess, fmt.Sprintf("Created in #%s", m.client.GetProjectName(msg.task.ProjectID)))
+	return tea.Batch(toast, m.reloadCurrentView())
+}
+
+// deleteOptimistically takes a task out of the list right away and puts it back if Todoist doesn't delete it
+func (m *model) deleteOptimistically(taskID string) tea.Cmd {
+	index := slices.IndexFunc(m.tasks, func(task TodoistTask) bool { return task.ID == taskID })
+	if index < 0 {
+		return nil
+	}
+	task := m.tasks[index]
+	if m.pendingDeletes == nil {
+		m.pendingDeletes = make(map[string]pendingDelete)
+	}
+	m.pendingDeletes[taskID] = pendingDelete{task: task, index: index, view: m.viewKey()}
+
+	toast := m.notify(toastSuccess, "Task deleted: "+task.Content)
+	m.removeTask(taskID)
+	return tea.Batch(toast, deleteTask(m.requests.base(), m.client, taskID))
+}
+
+// handleTaskDeleteSent puts a task back where it was when Todoist couldn't delete it
+func (m *model) handleTaskDeleteSent(msg taskDeleteSentMsg) tea.Cmd {
+	pending, ok := m.pendingDeletes[msg.taskID]
+	if !ok {
+		return nil
+	}
+	delete(m.pendingDeletes, msg.taskID)
+	if msg.err == nil || isCanceled(msg.err) {
+		return nil
+	}
+
+	m.recordSyncError(msg.err)
+	listed := slices.ContainsFunc(m.tasks, func(task TodoistTask) bool { return task.ID == msg.taskID })
+	if pending.view == m.viewKey() && !listed {
+		selectedID := m.selectedTaskID()
+		m.setTasks(slices.Insert(slices.Clone(m.tasks), min(pending.index, len(m.tasks)), pending.task))
+		m.restoreSelection(selectedID)
+	}
+	return m.notify(toastError, fmt.Sprintf("Couldn't delete %s, restored: %s", pending.task.Content, friendlyError(msg.err)))
+}
+
+// isDeletePending reports whether a task was taken out of the list and Todoist hasn't confirmed deleting it yet
+// Such tasks stay hidden when a refresh brings them back meanwhile
+func (m model) isDeletePending(taskID string) bool {
+	_, pending := m.pendingDeletes[taskID]
+	return pending
+}
+
+// withPendingCreates adds the placeholders of tasks still being created that belong in the current view
+func (m model) withPendingCreates(tasks []TodoistTask) []TodoistTask {
+	for _, pending := range m.pendingCreates {
+		listed := slices.ContainsFunc(tasks, func(task TodoistTask) bool { return task.ID == pending.placeholder.ID })
+		if !listed && m.placeholderFits(pending.placeholder) {
+			tasks = append(slices.Clone(tasks), pending.placeholder)
+		}
+	}
+	return tasks
+}
+
+// placeholderFits reports whether a task being created belongs in the current view
+func (m model) placeholderFits(task TodoistTask) bool {
+	today := dayNow().Format("2006-01-02")
+	switch m.view {
+	case viewToday:
+		return task.Due != nil && task.Due.Date <= today
+	case viewUpcoming:
+		return task.Due != nil && task.Due.Date >= today && task.Due.Date < dayNow().AddDate(0, 0, upcomingDays).Format("2006-01-02")
+	case viewProject:
+		return task.ProjectID == m.viewProjectID
+	case viewLabel:
+		return slices.ContainsFunc(task.Labels, func(label string) bool { return strings.EqualFold(label, m.viewLabelName) })
+	default:
+		return false
+	}
+}
+
+// isPlaceholder reports whether a row stands for a task still being created
+func isPlaceholder(taskID string) bool {
+	return strings.HasPrefix(taskID, placeholderIDPrefix)
+}
+
+// waitForPlaceholder stops actions on the selected task while it's still being created, since Todoist doesn't know it yet
+func (m *model) waitForPlaceholder(action keyAction) tea.Cmd {
+	switch action {
+	case actionDetails, actionComplete, actionReschedule, actionEdit, actionOpen, actionComments, actionLink,
+		actionSkip, actionWatch, actionCut, actionPaste, actionPark, actionReadLater, actionPomodoro, actionTrack,
+		actionPromote, actionDemote, actionJumpRelated, actionSelect:
+	default:
+		return nil
+	}
+	if !isPlaceholder(m.selectedTaskID()) {
+		return nil
+	}
+	return m.notify(toastInfo, "Still creating this task, try again in a moment")
+}
+
+// startPendingSpinner animates the glyph of tasks being created, unless it's already running
+func (m *model) startPendingSpinner() tea.Cmd {
+	if m.pendingSpinning {
+		return nil
+	}
+	m.pendingSpinning = true
+	return tea.Tick(pendingSpinnerInterval, func(time.Time) tea.Msg { return pendingSpinnerMsg{} })
+}
+
+// handlePendingSpinner moves the pending glyph on while any task is still being created
+func (m *model) handlePendingSpinner() tea.Cmd {
+	if len(m.pendingCreates) == 0 {
+		m.pendingSpinning = false
+		return nil
+	}
+	m.pendingFrame++
+	return tea.Tick(pendingSpinnerInterval, func(time.Time) tea.Msg { return pendingSpinnerMsg{} })
+}
+
+// pendingGlyph returns the current frame of the glyph in front of tasks being created
+func (m model) pendingGlyph() string {
+	return pendingSpinnerFrames[m.pendingFrame%len(pendingSpinnerFrames)]
+}
+
+// resetCreateTaskForm empties the new task form, with the Inbox as its project
+func (m *model) resetCreateTaskForm() {
+	m.createTaskForm = createTaskFormState{
+		content:          newFormInput(""),
+		priority:         1,
+		projectSearch:    newFormInput(""),
+		filteredProjects: m.projects,
+		deadline:         newFormInput("today"),
+		activeField:      fieldContent,
+	}
+	m.selectInboxProject()
+}
+
+// queueUnsentChanges keeps the changes Todoist hasn't confirmed when the app quits, as pending operations
+// sent at the next start; quitting cancels the requests on their way
+// A create is sent again with its first X-Request-Id, so Todoist drops it if the first got through
+func (m *model) queueUnsentChanges() {
+	m.queueUnsentCompletions()
+	if m.cache == nil {
+		return
+	}
+	for _, pending := range m.pendingCreates {
+		_ = m.cache.QueueOperation(newOperation(opCreate, "", pending.request.Content,
+			operationPayload{Task: &pending.request, RequestID: pending.requestID}))
+	}
+	for taskID, pending := range m.pendingDeletes {
+		_ = m.cache.QueueOperation(newOperation(opDelete, taskID, pending.task.Content, operationPayload{}))
+	}
+	m.pendingCreates = nil
+	m.pendingDeletes = nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// overdueAgeColors are the colors of overdue ages from the mildest to the most intense, set by applyTheme
+// They blend from the theme's warning color into its error color
+var overdueAgeColors []lipgloss.Color
+
+// overdueAgeSteps is how many days overdue each color of overdueAgeColors starts at
+var overdueAgeSteps = []int{0, 2, 7, 30}
+
+// blendColors mixes two hex colors like "#F59E0B", weight 0 giving the first and 1 the second
+// Colors that aren't hex are returned unmixed
+func blendColors(from, to string, weight float64) lipgloss.Color {
+	parse := func(hex string) ([3]int64, bool) {
+		var rgb [3]int64
+		hex = strings.TrimPrefix(hex, "#")
+		if len(hex) != 6 {
+			return rgb, false
+		}
+		for i := range rgb {
+			value, err := strconv.ParseInt(hex[i*2:i*2+2], 16, 64)
+			if err != nil {
+				return rgb, false
+			}
+			rgb[i] = value
+		}
+		return rgb, true
+	}
+	a, okFrom := parse(from)
+	b, okTo := parse(to)
+	if !okFrom || !okTo {
+		if weight < 0.5 {
+			return lipgloss.Color(from)
+		}
+		return lipgloss.Color(to)
+	}
+	var mixed [3]int64
+	for i := range mixed {
+		mixed[i] = a[i] + int64(float64(b[i]-a[i])*weight+0.5)
+	}
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", mixed[0], mixed[1], mixed[2]))
+}
+
+// newOverdueAgeColors blends the colors of overdue ages from a theme's warning and error colors
+func newOverdueAgeColors(t Theme) []lipgloss.Color {
+	colors := make([]lipgloss.Color, len(overdueAgeSteps))
+	for i := range colors {
+		colors[i] = blendColors(t.Warning, t.Error, float64(i)/float64(len(colors)-1))
+	}
+	return colors
+}
+
+// overdueAge writes how long a task has been overdue briefly, e.g. "2d", "3w", "4mo" or "1y"
+func overdueAge(days int) string {
+	switch {
+	case days < 7:
+		return fmt.Sprintf("%dd", days)
+	case days < 60:
+		return fmt.Sprintf("%dw", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo", days/30)
+	default:
+		return fmt.Sprintf("%dy", days/365)
+	}
+}
+
+// overdueAgeStyle colors an overdue age, more intensely the longer the task has been overdue
+// Tasks overdue for a month or more are bold too
+func overdueAgeStyle(style lipgloss.Style, days int) lipgloss.Style {
+	if len(overdueAgeColors) == 0 {
+		return style
+	}
+	step := 0
+	for i, start := range overdueAgeSteps {
+		if days >= start {
+			step = i
+		}
+	}
+	style = style.Foreground(overdueAgeColors[min(step, len(overdueAgeColors)-1)])
+	if step == len(overdueAgeSteps)-1 {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+// taskOverdueDays returns how many days ago a task was due, when it's overdue by its date
+func taskOverdueDays(task TodoistTask, now time.Time) (int, bool) {
+	days, ok := daysOverdue(task, now)
+	if !ok || days <= 0 {
+		return 0, false
+	}
+	return days, true
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// overdueTrendWeeks is the number of weeks of overdue counts shown on the stats screen
+const overdueTrendWeeks = 8
+
+// sparkBlocks are the bar heights of the daily overdue sparkline, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// overdueDay is the number of overdue tasks recorded on a day
+type overdueDay struct {
+	// Day is the date in YYYY-MM-DD format
+	Day string
+	// Count is the number of open tasks that were overdue that day
+	Count int
+}
+
+// RecordOverdueCount stores how many of the open tasks are overdue today
+// Each refresh overwrites the day's count, so the last refresh of a day is kept
+func (c *CacheDB) RecordOverdueCount(now time.Time, tasks []TodoistTask) error {
+	count := 0
+	for _, task := range tasks {
+		if !task.IsCompleted && isTaskOverdue(task) {
+			count++
+		}
+	}
+	_, err := c.db.Exec(`
+		INSERT INTO overdue_history (day, count)
+		VALUES (?, ?)
+		ON CONFLICT(day) DO UPDATE SET count = excluded.count
+	`, now.Format("2006-01-02"), count)
+	return err
+}
+
+// LoadOverdueHistory loads the overdue counts recorded since the given day, oldest first
+func (c *CacheDB) LoadOverdueHistory(since time.Time) ([]overdueDay, error) {
+	rows, err := c.db.Query("SELECT day, count FROM overdue_history WHERE day >= ? ORDER BY day", since.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var history []overdueDay
+	for rows.Next() {
+		var day overdueDay
+		if err := rows.Scan(&day.Day, &day.Count); err != nil {
+			return nil, err
+		}
+		history = append(history, day)
+	}
+
+	return history, rows.Err()
+}
+
+// overdueTrendStart returns the Monday starting the oldest week of the trend
+func overdueTrendStart(now time.Time) time.Time {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	return monday.AddDate(0, 0, -7*(overdueTrendWeeks-1))
+}
+
+// overdueWeeks averages the recorded counts per week, skipping weeks without any record
+func overdueWeeks(history []overdueDay, start time.Time) []statsCount {
+	var weeks []statsCount
+	for week := range overdueTrendWeeks {
+		from := start.AddDate(0, 0, 7*week)
+		to := from.AddDate(0, 0, 7)
+		total, days := 0, 0
+		for _, day := range history {
+			if day.Day >= from.Format("2006-01-02") && day.Day < to.Format("2006-01-02") {
+				total += day.Count
+				days++
+			}
+		}
+		if days == 0 {
+			continue
+		}
+		color, _ := errorStyle.GetForeground().(lipgloss.Color)
+		weeks = append(weeks, statsCount{
+			label: "Week of " + from.Format("Jan 2"),
+			count: (total + days/2) / days,
+			color: color,
+		})
+	}
+	return weeks
+}
+
+// renderOverdueSparkline draws one block per day of the trend, blank for days without a record
+func renderOverdueSparkline(history []overdueDay, start, now time.Time) string {
+	counts := make(map[string]int)
+	maxCount := 0
+	for _, day := range history {
+		counts[day.Day] = day.Count
+		maxCount = max(maxCount, day.Count)
+	}
+
+	var b strings.Builder
+	for day := start; !day.After(now); day = day.AddDate(0, 0, 1) {
+		count, ok := counts[day.Format("2006-01-02")]
+		switch {
+		case !ok:
+			b.WriteRune(' ')
+		case maxCount == 0:
+			b.WriteRune(sparkBlocks[0])
+		default:
+			b.WriteRune(sparkBlocks[count*(len(sparkBlocks)-1)/maxCount])
+		}
+	}
+	return b.String()
+}
+
+// describeOverdueTrend compares the latest weekly average with the oldest one shown
+func describeOverdueTrend(weeks []statsCount) string {
+	if len(weeks) < 2 {
+		return "Not enough history yet, check back next week"
+	}
+	first, last := weeks[0].count, weeks[len(weeks)-1].count
+	since := strings.TrimPrefix(weeks[0].label, "Week of ")
+	switch {
+	case last > first:
+		return fmt.Sprintf("📈 Growing: %d → %d overdue since the week of %s", first, last, since)
+	case last < first:
+		return fmt.Sprintf("📉 Shrinking: %d → %d overdue since the week of %s", first, last, since)
+	default:
+		return fmt.Sprintf("Steady at %d overdue since the week of %s", last, since)
+	}
+}
+
+// renderOverdueTrend creates the overdue trend section of the stats screen
+func (m model) renderOverdueTrend() string {
+	var b strings.Builder
+	now := dayNow()
+	start := overdueTrendStart(now)
+
+	b.WriteString(popupFieldStyle.Render(fmt.Sprintf("Overdue trend (last %d weeks):", overdueTrendWeeks)))
+	b.WriteString("\n")
+	if len(m.stats.overdueHistory) == 0 {
+		b.WriteString("No overdue counts recorded yet")
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	weeks := overdueWeeks(m.stats.overdueHistory, start)
+	b.WriteString(renderStatsChart(weeks, 0))
+	b.WriteString(projectStyle.Render("Daily: "))
+	b.WriteString(renderOverdueSparkline(m.stats.overdueHistory, start, now))
+	b.WriteString("\n")
+	b.WriteString(describeOverdueTrend(weeks))
+	b.WriteString("\n")
+	return b.String()
+}
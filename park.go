@@ -0,0 +1,362 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// parkedTask is a task hidden from all views until a date, regardless of its Todoist due date
+type parkedTask struct {
+	// TaskID is the ID of the parked task
+	TaskID string
+	// Content is the task content when it was parked, shown on the parked screen
+	Content string
+	// HiddenUntil is the date the task reappears, in YYYY-MM-DD format
+	HiddenUntil string
+}
+
+// parkPreset is a quick choice in the park popup
+type parkPreset struct {
+	// label is shown in the popup
+	label string
+	// until returns the date the task reappears
+	until func(now time.Time) time.Time
+}
+
+// parkPresets are the choices offered by the park popup, followed by a custom entry
+var parkPresets = []parkPreset{
+	{label: "Tomorrow", until: func(now time.Time) time.Time { return now.AddDate(0, 0, 1) }},
+	{label: "After the weekend", until: nextMonday},
+	{label: "In a week", until: func(now time.Time) time.Time { return now.AddDate(0, 0, 7) }},
+	{label: "In a month", until: func(now time.Time) time.Time { return now.AddDate(0, 1, 0) }},
+}
+
+// parkedTasksLoadedMsg is sent with the currently parked tasks
+type parkedTasksLoadedMsg []parkedTask
+
+// nextMonday returns the first Monday after now
+func nextMonday(now time.Time) time.Time {
+	days := (8 - int(now.Weekday())) % 7
+	if days == 0 {
+		days = 7
+	}
+	return now.AddDate(0, 0, days)
+}
+
+// parseParkDate parses a custom park date, either YYYY-MM-DD or a number of days from now
+func parseParkDate(input string, now time.Time) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if days, err := strconv.Atoi(strings.TrimSuffix(input, "d")); err == nil && days > 0 {
+		return now.AddDate(0, 0, days), nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", input, todayLocation())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, use YYYY-MM-DD or a number of days", input)
+	}
+	return date, nil
+}
+
+// LoadParkedTasks loads the parked tasks, dropping the ones whose date has passed
+func (c *CacheDB) LoadParkedTasks() ([]parkedTask, error) {
+	today := dayNow().Format("2006-01-02")
+	if _, err := c.db.Exec("DELETE FROM parked_tasks WHERE hidden_until <= ?", today); err != nil {
+		return nil, err
+	}
+
+	rows, err := c.db.Query("SELECT task_id, content, hidden_until FROM parked_tasks ORDER BY hidden_until")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var parked []parkedTask
+	for rows.Next() {
+		var p parkedTask
+		if err := rows.Scan(&p.TaskID, &p.Content, &p.HiddenUntil); err != nil {
+			return nil, err
+		}
+		parked = append(parked, p)
+	}
+
+	return parked, rows.Err()
+}
+
+// SaveParkedTask parks a task, replacing any earlier date
+func (c *CacheDB) SaveParkedTask(p parkedTask) error {
+	_, err := c.db.Exec(`
+		INSERT INTO parked_tasks (task_id, content, hidden_until)
+		VALUES (?, ?, ?)
+		ON CONFLICT(task_id) DO UPDATE SET
+			content = excluded.content,
+			hidden_until = excluded.hidden_until
+	`, p.TaskID, p.Content, p.HiddenUntil)
+	return err
+}
+
+// RemoveParkedTask makes a parked task visible again
+func (c *CacheDB) RemoveParkedTask(taskID string) error {
+	_, err := c.db.Exec("DELETE FROM parked_tasks WHERE task_id = ?", taskID)
+	return err
+}
+
+// loadParkedTasks creates a command that reads the parked tasks from the cache
+func loadParkedTasks(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		parked, err := cache.LoadParkedTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load parked tasks: %w", err))
+		}
+		return parkedTasksLoadedMsg(parked)
+	})
+}
+
+// parkTask creates a command that hides a task until a date
+func parkTask(cache *CacheDB, p parkedTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.SaveParkedTask(p); err != nil {
+			return errorMsg(fmt.Errorf("failed to park task: %w", err))
+		}
+		return loadParkedTasks(cache)()
+	})
+}
+
+// unparkTask creates a command that makes a parked task visible again
+func unparkTask(cache *CacheDB, taskID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.RemoveParkedTask(taskID); err != nil {
+			return errorMsg(fmt.Errorf("failed to unpark task: %w", err))
+		}
+		return loadParkedTasks(cache)()
+	})
+}
+
+// isParked reports whether a task is currently hidden
+func (m model) isParked(taskID string) bool {
+	for _, p := range m.parkedTasks {
+		if p.TaskID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+// hideParked returns the tasks that are not parked, also leaving out a task cut and waiting to be pasted
+// and, unless completed tasks are kept, tasks completed but not yet sent to Todoist, as well as tasks being deleted
+func (m model) hideParked(tasks []TodoistTask) []TodoistTask {
+	if len(m.parkedTasks) == 0 && m.register == nil && len(m.completionQueue) == 0 && len(m.completionsSending) == 0 &&
+		len(m.pendingDeletes) == 0 {
+		return tasks
+	}
+	var visible []TodoistTask
+	for _, task := range tasks {
+		if !m.isParked(task.ID) && !m.isCut(task.ID) && (m.keepCompleted || !m.isCompletionPending(task.ID)) &&
+			!m.isDeletePending(task.ID) {
+			visible = append(visible, task)
+		}
+	}
+	return visible
+}
+
+// openPark shows the park popup for a task
+func (m *model) openPark(task TodoistTask) {
+	m.showingPark = true
+	m.parkTaskID = task.ID
+	m.parkIdx = 0
+	m.parkCustom = ""
+	m.parkError = ""
+}
+
+// renderPark creates the popup for choosing how long to park a task
+func (m model) renderPark() string {
+	var content strings.Builder
+	now := dayNow()
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("🅿️ Park Task"))
+	content.WriteString("\n\n")
+	content.WriteString(m.taskSummary(m.parkTaskID))
+	content.WriteString("\n\n")
+	content.WriteString("Hide until:\n")
+
+	// Preset choices followed by the custom entry
+	selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+	for i, preset := range parkPresets {
+		line := fmt.Sprintf("%s (%s)", preset.label, preset.until(now).Format("Mon Jan 2"))
+		if i == m.parkIdx {
+			content.WriteString(selected.Render("→ " + line))
+		} else {
+			content.WriteString("  " + line)
+		}
+		content.WriteString("\n")
+	}
+	custom := "Custom: " + m.parkCustom
+	if m.parkIdx == len(parkPresets) {
+		content.WriteString(selected.Render("→ " + custom + "│"))
+	} else {
+		content.WriteString("  " + custom)
+	}
+	content.WriteString("\n\n")
+
+	if m.parkError != "" {
+		content.WriteString(errorStyle.MarginLeft(0).Render(m.parkError))
+		content.WriteString("\n\n")
+	}
+
+	// Instructions
+	content.WriteString("↑/↓: select • type YYYY-MM-DD or days for custom • Enter: park • ESC: cancel")
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleParkInput handles keyboard input when the park popup is visible
+func (m model) handleParkInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the popup without parking
+		m.showingPark = false
+	case "up":
+		// Move selection up with wrap around
+		if m.parkIdx <= 0 {
+			m.parkIdx = len(parkPresets)
+		} else {
+			m.parkIdx--
+		}
+	case "down", "tab":
+		// Move selection down with wrap around
+		if m.parkIdx >= len(parkPresets) {
+			m.parkIdx = 0
+		} else {
+			m.parkIdx++
+		}
+	case "backspace":
+		// Remove the last character of the custom date
+		if m.parkIdx == len(parkPresets) && len(m.parkCustom) > 0 {
+			m.parkCustom = m.parkCustom[:len(m.parkCustom)-1]
+		}
+	case "enter":
+		// Work out the date the task reappears
+		now := dayNow()
+		var until time.Time
+		if m.parkIdx < len(parkPresets) {
+			until = parkPresets[m.parkIdx].until(now)
+		} else {
+			date, err := parseParkDate(m.parkCustom, now)
+			if err != nil {
+				m.parkError = err.Error()
+				return m, nil
+			}
+			until = date
+		}
+		if until.Format("2006-01-02") <= now.Format("2006-01-02") {
+			m.parkError = "The date must be in the future"
+			return m, nil
+		}
+
+		// Hide the task right away and remember it in the cache
+		p := parkedTask{TaskID: m.parkTaskID, Content: m.taskSummary(m.parkTaskID), HiddenUntil: until.Format("2006-01-02")}
+		m.showingPark = false
+		m.parkedTasks = append(m.parkedTasks, p)
+		sort.SliceStable(m.parkedTasks, func(i, j int) bool { return m.parkedTasks[i].HiddenUntil < m.parkedTasks[j].HiddenUntil })
+		m.removeTask(p.TaskID)
+		return m, parkTask(m.cache, p)
+	default:
+		// Typing goes into the custom date, selecting it
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.parkIdx = len(parkPresets)
+			m.parkCustom += msg.String()
+			m.parkError = ""
+		}
+	}
+	return m, nil
+}
+
+// renderParkedScreen creates the screen listing parked tasks
+func (m model) renderParkedScreen() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🅿️ Parked Tasks"))
+	content.WriteString("\n\n")
+
+	if len(m.parkedTasks) == 0 {
+		content.WriteString("No parked tasks")
+		content.WriteString("\n")
+	} else {
+		// Parked tasks are kept soonest to reappear first
+		selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+		for i, p := range m.parkedTasks {
+			line := fmt.Sprintf("%s  %s", p.HiddenUntil, p.Content)
+			if i == m.parkedIdx {
+				content.WriteString(selected.Render("→ " + line))
+			} else {
+				content.WriteString("  " + line)
+			}
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("↑/↓: select • u: unpark • ESC: close")
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleParkedScreenInput handles keyboard input when the parked screen is visible
+func (m model) handleParkedScreenInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape", "Z":
+		// Close the parked screen
+		m.showingParked = false
+	case "up", "k":
+		// Move selection up
+		if m.parkedIdx > 0 {
+			m.parkedIdx--
+		}
+	case "down", "j":
+		// Move selection down
+		if m.parkedIdx < len(m.parkedTasks)-1 {
+			m.parkedIdx++
+		}
+	case "u":
+		// Show the selected task again and reload the list to bring it back
+		if m.parkedIdx >= 0 && m.parkedIdx < len(m.parkedTasks) {
+			taskID := m.parkedTasks[m.parkedIdx].TaskID
+
+			var remaining []parkedTask
+			for _, p := range m.parkedTasks {
+				if p.TaskID != taskID {
+					remaining = append(remaining, p)
+				}
+			}
+			m.parkedTasks = remaining
+			if m.parkedIdx >= len(m.parkedTasks) && m.parkedIdx > 0 {
+				m.parkedIdx--
+			}
+			return m, tea.Batch(unparkTask(m.cache, taskID), m.reloadCurrentView())
+		}
+	}
+	return m, nil
+}
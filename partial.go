@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+)
+
+// Resources fetched by a background refresh, each of which can fail on its own
+const (
+	resourceTasks    = "tasks"
+	resourceProjects = "projects"
+	resourceLabels   = "labels"
+	resourceSections = "sections"
+)
+
+// refreshResources lists the refreshed resources in the order their staleness is shown
+var refreshResources = []string{resourceTasks, resourceProjects, resourceLabels, resourceSections}
+
+// recordRefreshResults marks the resources that failed to refresh as stale, and the rest as fresh
+func (m *model) recordRefreshResults(failed map[string]error) {
+	for _, resource := range refreshResources {
+		if err, ok := failed[resource]; ok {
+			if m.staleResources == nil {
+				m.staleResources = make(map[string]bool)
+			}
+			m.staleResources[resource] = true
+			m.recordSyncError(err)
+		} else {
+			delete(m.staleResources, resource)
+		}
+	}
+}
+
+// renderStaleResources lists the resources whose last refresh failed while others succeeded
+func (m model) renderStaleResources() string {
+	if m.offline || len(m.staleResources) == 0 {
+		return ""
+	}
+	var markers []string
+	for _, resource := range refreshResources {
+		if m.staleResources[resource] {
+			markers = append(markers, resource+": stale")
+		}
+	}
+	return staleStyle.Render("⚠️ Partly refreshed — " + strings.Join(markers, " • ") + " (see S for errors)")
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxPastedLinesPreview is the number of pasted lines listed when offering to create one task per line
+const maxPastedLinesPreview = 8
+
+// listMarkerPattern matches the bullet, number or checkbox in front of a line copied from a list
+var listMarkerPattern = regexp.MustCompile(`^(?:[-*+•]\s+|\d+[.)]\s+)?(?:\[[ xX]?\]\s+)?`)
+
+// clipboardPastedMsg carries the clipboard text read for Ctrl+V in the content field
+type clipboardPastedMsg string
+
+// tasksCreatedMsg is sent when a batch of tasks was sent to Todoist
+type tasksCreatedMsg struct {
+	created int
+	// failed holds the positions in the batch of the tasks that weren't created, with the reason of the last one in err
+	failed []int
+	err    error
+	// lines holds the pasted lines the tasks were created from
+	lines []string
+}
+
+// pastedLines splits pasted text into the task contents it holds, one per non-empty line, without list markers
+// Returns nil for text on a single line, which is pasted as it is
+func pastedLines(text string) []string {
+	var lines []string
+	for _, line := range strings.FieldsFunc(text, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		line = strings.TrimSpace(listMarkerPattern.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) < 2 {
+		return nil
+	}
+	return lines
+}
+
+// readClipboard creates a command that reads the clipboard for pasting into the content field
+func readClipboard() tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		text, err := clipboard.ReadAll()
+		if err != nil {
+			return nil
+		}
+		return clipboardPastedMsg(text)
+	})
+}
+
+// pasteIntoForm pastes text into the active form field, or offers to create one task per line when the text
+// pasted into the content of a new task spans several lines
+func (m *model) pasteIntoForm(text string) tea.Cmd {
+	form := &m.createTaskForm
+	if form.activeField == fieldContent && form.editingTaskID == "" {
+		if lines := pastedLines(text); lines != nil {
+			form.pasted = lines
+			return nil
+		}
+	}
+	return m.updateFormInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+}
+
+// handlePastedLinesInput handles keyboard input while offering to create one task per pasted line
+func (m model) handlePastedLinesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	lines := m.createTaskForm.pasted
+	switch msg.String() {
+	case "enter", "y", "Y":
+		// Create a task for each line, with the other fields of the form
+		m.createTaskForm.pasted = nil
+		return m, m.createPastedTasks(lines)
+	case "n", "N":
+		// Paste the lines into the content as one task
+		m.createTaskForm.pasted = nil
+		return m, m.updateFormInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strings.Join(lines, " ")), Paste: true})
+	case "esc", "escape":
+		// Drop the paste and keep editing
+		m.createTaskForm.pasted = nil
+	}
+	return m, nil
+}
+
+// renderPastedLines describes the lines of a multi-line paste and the choices for them
+func (m model) renderPastedLines() string {
+	lines := m.createTaskForm.pasted
+	var b strings.Builder
+	b.WriteString(staleStyle.MarginLeft(0).Render(fmt.Sprintf("📋 Pasted %d lines", len(lines))))
+	b.WriteString("\n")
+	for i, line := range lines {
+		if i == maxPastedLinesPreview {
+			b.WriteString(fmt.Sprintf("   … and %d more\n", len(lines)-i))
+			break
+		}
+		b.WriteString("   • " + line + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("y/Enter: create %d tasks • n: paste as one task • ESC: discard", len(lines)))
+	return b.String()
+}
+
+// pastedTaskRequests turns pasted lines into new tasks: each takes the project, section, priority, labels,
+// deadline and assignee of the form, and quick-add tokens on a line override them for that task
+func (m model) pastedTaskRequests(lines []string) []NewTaskRequest {
+	form := m.createTaskForm
+	requests := make([]NewTaskRequest, 0, len(lines))
+	for _, line := range lines {
+		q := parseQuickAdd(line, m.projects, form.projectID, m.collaboratorsOf, time.Now())
+		request := NewTaskRequest{
+			Content:    q.content,
+			ProjectID:  form.projectID,
+			SectionID:  m.formSectionID(),
+			Priority:   form.priority,
+			Labels:     parseLabels(form.labels),
+			DueString:  form.deadline.Value(),
+			AssigneeID: m.formAssigneeID(),
+		}
+		if q.project != nil && q.project.ID != request.ProjectID {
+			request.ProjectID = q.project.ID
+			request.SectionID = ""
+			request.AssigneeID = ""
+		}
+		for _, label := range q.labels {
+			if !containsFold(request.Labels, label) {
+				request.Labels = append(request.Labels, label)
+			}
+		}
+		if q.priority != 0 {
+			request.Priority = q.priority
+		}
+		if q.due != "" {
+			request.DueString = q.due
+		}
+		if q.assignee != nil {
+			request.AssigneeID = q.assignee.ID
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
+
+// createPastedTasks closes the form and creates a task for each pasted line, queued one by one while offline
+func (m *model) createPastedTasks(lines []string) tea.Cmd {
+	requests := m.pastedTaskRequests(lines)
+	m.showingCreateTask = false
+	m.resetCreateTaskForm()
+
+	if m.offline {
+		var cmds []tea.Cmd
+		for i := range requests {
+			op := newOperation(opCreate, "", requests[i].Content, operationPayload{Task: &requests[i]})
+			cmds = append(cmds, queueOperation(m.cache, op))
+		}
+		toast := m.notify(toastInfo, fmt.Sprintf("Queued %d tasks to create when back online", len(requests)))
+		return tea.Batch(toast, tea.Sequence(cmds...))
+	}
+	m.loading = true
+	batch := createTasksBatch(m.requests.base(), m.client, requests)
+	return tea.Cmd(func() tea.Msg {
+		msg := batch().(tasksCreatedMsg)
+		msg.lines = lines
+		return msg
+	})
+}
+
+// itemAddArgs builds the arguments of the Sync API command that creates a task
+func itemAddArgs(request NewTaskRequest) map[string]any {
+	args := map[string]any{"content": request.Content, "temp_id": newUUID()}
+	if request.Description != "" {
+		args["description"] = request.Description
+	}
+	if request.ProjectID != "" {
+		args["project_id"] = request.ProjectID
+	}
+	if request.SectionID != "" {
+		args["section_id"] = request.SectionID
+	}
+	if request.Priority != 0 {
+		args["priority"] = request.Priority
+	}
+	if len(request.Labels) > 0 {
+		args["labels"] = request.Labels
+	}
+	if request.DueString != "" {
+		args["due"] = map[string]any{"string": request.DueString}
+	}
+	if request.AssigneeID != "" {
+		args["responsible_uid"] = request.AssigneeID
+	}
+	return args
+}
+
+// createTasksBatch creates a command that creates tasks in a single Sync API request
+func createTasksBatch(ctx context.Context, client *TodoistClient, requests []NewTaskRequest) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		commands := make([]syncCommand, len(requests))
+		for i, request := range requests {
+			commands[i] = newSyncCommand("item_add", itemAddArgs(request))
+		}
+		var msg tasksCreatedMsg
+		failedCommands, err := client.runSyncCommandBatch(ctx, commands)
+		if err != nil {
+			for i := range commands {
+				msg.failed = append(msg.failed, i)
+			}
+			msg.err = fmt.Errorf("failed to create %d tasks: %w", len(requests), err)
+			return msg
+		}
+		for i, command := range commands {
+			if err := failedCommands[command.UUID]; err != nil {
+				msg.failed = append(msg.failed, i)
+				msg.err = err
+				continue
+			}
+			msg.created++
+		}
+		return msg
+	})
+}
+
+// handleTasksCreated shows the tasks created from pasted lines, and opens the form again with the lines
+// that failed, to retry them
+func (m *model) handleTasksCreated(msg tasksCreatedMsg) tea.Cmd {
+	m.loading = false
+	m.session.created += msg.created
+	if msg.err != nil && isCanceled(msg.err) {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	if msg.created > 0 {
+		m.loading = true
+		cmds = append(cmds, m.notify(toastSuccess, fmt.Sprintf("Created %d tasks", msg.created)), m.reloadCurrentView())
+	}
+	if len(msg.failed) > 0 {
+		m.recordSyncError(msg.err)
+		if !m.showingCreateTask {
+			m.resetCreateTaskForm()
+			for _, i := range msg.failed {
+				if i < len(msg.lines) {
+					m.createTaskForm.pasted = append(m.createTaskForm.pasted, msg.lines[i])
+				}
+			}
+			m.createTaskForm.failure = fmt.Sprintf("Couldn't create %d of the tasks: %s", len(msg.failed), friendlyError(msg.err))
+			m.showingCreateTask = true
+		}
+		cmds = append(cmds, m.notify(toastError, fmt.Sprintf("Couldn't create %d of the tasks: %s", len(msg.failed), friendlyError(msg.err))))
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,320 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Kinds of task changes that can be queued while offline
+const (
+	opComplete = "complete"
+	opCreate   = "create"
+	opUpdate   = "update"
+	opDelete   = "delete"
+)
+
+// Statuses of queued operations
+const (
+	// opPending operations are waiting to be sent to Todoist
+	opPending = "pending"
+	// opFailed operations were rejected by Todoist and are kept until retried or cleared
+	opFailed = "failed"
+)
+
+// pendingOperation is a task change made while offline, stored until it can be sent to Todoist
+type pendingOperation struct {
+	// ID is the queue position of the operation
+	ID int64
+	// Kind is one of opComplete, opCreate, opUpdate or opDelete
+	Kind string
+	// TaskID is the affected task, empty for created tasks
+	TaskID string
+	// Summary is the task content shown in the sync panel
+	Summary string
+	// Payload holds the operation's arguments as JSON
+	Payload string
+	// Status is opPending or opFailed
+	Status string
+	// Error is the reason the last attempt failed
+	Error string
+	// CreatedAt is when the change was made
+	CreatedAt time.Time
+}
+
+// operationPayload holds the arguments of create and update operations
+type operationPayload struct {
+	Task      *NewTaskRequest    `json:"task,omitempty"`
+	Update    *UpdateTaskRequest `json:"update,omitempty"`
+	ProjectID string             `json:"project_id,omitempty"`
+	SectionID string             `json:"section_id,omitempty"`
+	// RequestID is the X-Request-Id a create was first sent with, so Todoist drops it if that got through
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// pendingOperationsLoadedMsg is sent with the current contents of the operation queue
+type pendingOperationsLoadedMsg []pendingOperation
+
+// operationsReplayedMsg is sent after queued operations were sent to Todoist
+type operationsReplayedMsg struct {
+	ops      []pendingOperation // The remaining queue
+	replayed int                // How many operations were applied
+	err      error              // Network error that stopped the replay, if any
+}
+
+// newOperation creates a pending operation with its payload encoded as JSON
+func newOperation(kind, taskID, summary string, payload operationPayload) pendingOperation {
+	data, _ := json.Marshal(payload)
+	return pendingOperation{
+		Kind:    kind,
+		TaskID:  taskID,
+		Summary: summary,
+		Payload: string(data),
+		Status:  opPending,
+	}
+}
+
+// apply sends the operation to Todoist
+func (op pendingOperation) apply(ctx context.Context, client *TodoistClient) error {
+	var payload operationPayload
+	if op.Payload != "" {
+		if err := json.Unmarshal([]byte(op.Payload), &payload); err != nil {
+			return fmt.Errorf("failed to decode queued %s: %w", op.Kind, err)
+		}
+	}
+
+	switch op.Kind {
+	case opComplete:
+		return client.CompleteTask(ctx, op.TaskID)
+	case opDelete:
+		return client.DeleteTask(ctx, op.TaskID)
+	case opCreate:
+		if payload.Task == nil {
+			return fmt.Errorf("queued create has no task")
+		}
+		_, err := client.CreateTask(withRequestID(ctx, payload.RequestID), *payload.Task)
+		return err
+	case opUpdate:
+		if payload.Update == nil {
+			return fmt.Errorf("queued update has no changes")
+		}
+		updatedTask, err := client.UpdateTask(ctx, op.TaskID, *payload.Update)
+		if err != nil {
+			return err
+		}
+		// Move the task if a different project or section was selected
+		return client.PlaceTask(ctx, updatedTask, payload.ProjectID, payload.SectionID)
+	default:
+		return fmt.Errorf("unknown queued operation %q", op.Kind)
+	}
+}
+
+// QueueOperation stores a task change in the pending operations queue
+// Completed and deleted tasks are dropped from the cached tasks so they stay gone while offline
+func (c *CacheDB) QueueOperation(op pendingOperation) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
+		INSERT INTO pending_operations (kind, task_id, summary, payload, status)
+		VALUES (?, ?, ?, ?, ?)
+	`, op.Kind, op.TaskID, op.Summary, op.Payload, op.Status)
+	if err != nil {
+		return err
+	}
+
+	if op.Kind == opComplete || op.Kind == opDelete {
+		if _, err := tx.Exec("DELETE FROM tasks WHERE id = ?", op.TaskID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LoadPendingOperations loads the queued operations in the order they were made
+func (c *CacheDB) LoadPendingOperations() ([]pendingOperation, error) {
+	rows, err := c.db.Query(`
+		SELECT id, kind, task_id, summary, payload, status, error, created_at
+		FROM pending_operations ORDER BY id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ops []pendingOperation
+	for rows.Next() {
+		var op pendingOperation
+		if err := rows.Scan(&op.ID, &op.Kind, &op.TaskID, &op.Summary, &op.Payload, &op.Status, &op.Error, &op.CreatedAt); err != nil {
+			return nil, err
+		}
+		ops = append(ops, op)
+	}
+
+	return ops, rows.Err()
+}
+
+// SetOperationStatus records the outcome of an attempt to send a queued operation
+func (c *CacheDB) SetOperationStatus(id int64, status, errText string) error {
+	_, err := c.db.Exec("UPDATE pending_operations SET status = ?, error = ? WHERE id = ?", status, errText, id)
+	return err
+}
+
+// RemoveOperation drops an operation from the queue
+func (c *CacheDB) RemoveOperation(id int64) error {
+	_, err := c.db.Exec("DELETE FROM pending_operations WHERE id = ?", id)
+	return err
+}
+
+// RetryFailedOperations marks failed operations pending again
+func (c *CacheDB) RetryFailedOperations() error {
+	_, err := c.db.Exec("UPDATE pending_operations SET status = ?, error = '' WHERE status = ?", opPending, opFailed)
+	return err
+}
+
+// ClearOperations drops every queued operation without sending it
+func (c *CacheDB) ClearOperations() error {
+	_, err := c.db.Exec("DELETE FROM pending_operations")
+	return err
+}
+
+// loadPendingOperations creates a command that reads the operation queue from the cache
+func loadPendingOperations(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		ops, err := cache.LoadPendingOperations()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load pending operations: %w", err))
+		}
+		return pendingOperationsLoadedMsg(ops)
+	})
+}
+
+// queueOperation creates a command that stores a task change until Todoist is reachable
+func queueOperation(cache *CacheDB, op pendingOperation) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.QueueOperation(op); err != nil {
+			return errorMsg(fmt.Errorf("failed to queue %s: %w", op.Kind, err))
+		}
+		return loadPendingOperations(cache)()
+	})
+}
+
+// replayPendingOperations creates a command that sends queued operations to Todoist in order
+// Operations rejected by Todoist are marked failed; a network error stops the replay
+func replayPendingOperations(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		ops, err := cache.LoadPendingOperations()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load pending operations: %w", err))
+		}
+
+		var result operationsReplayedMsg
+		for _, op := range ops {
+			if op.Status != opPending {
+				continue
+			}
+			if err := op.apply(ctx, client); err != nil {
+				if isNetworkError(err) {
+					result.err = err
+					break
+				}
+				_ = cache.SetOperationStatus(op.ID, opFailed, err.Error())
+				continue
+			}
+			_ = cache.RemoveOperation(op.ID)
+			result.replayed++
+		}
+
+		result.ops, err = cache.LoadPendingOperations()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load pending operations: %w", err))
+		}
+		return result
+	})
+}
+
+// retryOperations creates a command that marks failed operations pending again and replays the queue
+func retryOperations(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.RetryFailedOperations(); err != nil {
+			return errorMsg(fmt.Errorf("failed to retry operations: %w", err))
+		}
+		return replayPendingOperations(ctx, client, cache)()
+	})
+}
+
+// clearOperations creates a command that empties the operation queue
+func clearOperations(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.ClearOperations(); err != nil {
+			return errorMsg(fmt.Errorf("failed to clear pending operations: %w", err))
+		}
+		return pendingOperationsLoadedMsg(nil)
+	})
+}
+
+// writeOrQueue runs a write command, or queues the equivalent operation while offline
+// Completions and deletions are applied to the list right away when queued
+func (m *model) writeOrQueue(op pendingOperation, cmd tea.Cmd) tea.Cmd {
+	if !m.offline {
+		return cmd
+	}
+
+	queueCmd := queueOperation(m.cache, op)
+	switch op.Kind {
+	case opComplete:
+		return tea.Batch(queueCmd, func() tea.Msg { return taskCompletedMsg(op.TaskID) })
+	case opDelete:
+		return tea.Batch(queueCmd, func() tea.Msg { return taskDeletedMsg(op.TaskID) })
+	default:
+		return queueCmd
+	}
+}
+
+// hasPendingOperations reports whether any queued operation is waiting to be sent
+func (m model) hasPendingOperations() bool {
+	for _, op := range m.pendingOps {
+		if op.Status == opPending {
+			return true
+		}
+	}
+	return false
+}
+
+// taskSummary returns the content of a listed task for describing queued operations
+func (m model) taskSummary(taskID string) string {
+	for _, task := range m.tasks {
+		if task.ID == taskID {
+			return task.Content
+		}
+	}
+	return taskID
+}
+
+// renderPendingOperations lists the queued operations and their status for the sync panel
+func (m model) renderPendingOperations() string {
+	if len(m.pendingOps) == 0 {
+		return "none\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("\n")
+	for _, op := range m.pendingOps {
+		line := fmt.Sprintf("%s  %-8s %s", op.CreatedAt.Local().Format("15:04:05"), op.Kind, op.Summary)
+		if op.Status == opFailed {
+			b.WriteString(errorStyle.MarginLeft(0).Render(line + " — failed: " + op.Error))
+		} else {
+			b.WriteString(staleStyle.MarginLeft(0).Render(line + " — " + op.Status))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
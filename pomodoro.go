@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultPomodoroLength is how long a pomodoro lasts unless the config says otherwise
+const defaultPomodoroLength = 25 * time.Minute
+
+// pomodoroTimer is a running pomodoro for a task
+type pomodoroTimer struct {
+	// id tells the ticks of this timer apart from those of stopped ones
+	id   int
+	task TodoistTask
+	// started and ends are when the pomodoro began and when it rings
+	started time.Time
+	ends    time.Time
+}
+
+// pomodoroTickMsg is sent every second while a pomodoro runs, to count down and ring at its end
+type pomodoroTickMsg int
+
+// pomodoroCountsLoadedMsg is sent with the number of completed pomodoros of each task, keyed by task ID
+type pomodoroCountsLoadedMsg map[string]int
+
+// parsePomodoroLength reads the configured pomodoro length, e.g. "25m"; empty means the default
+func parsePomodoroLength(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultPomodoroLength, nil
+	}
+	length, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pomodoro length %q: %w", value, err)
+	}
+	if length < time.Minute {
+		return 0, fmt.Errorf("invalid pomodoro length %q: must be at least 1m", value)
+	}
+	return length, nil
+}
+
+// RecordPomodoro logs a completed pomodoro of a task
+func (c *CacheDB) RecordPomodoro(task TodoistTask, started, completed time.Time) error {
+	_, err := c.db.Exec(`
+		INSERT INTO pomodoros (task_id, content, started_at, completed_at)
+		VALUES (?, ?, ?, ?)
+	`, task.ID, task.Content, started.Format(time.RFC3339), completed.Format(time.RFC3339))
+	return err
+}
+
+// LoadPomodoroCounts returns the number of completed pomodoros of each task
+func (c *CacheDB) LoadPomodoroCounts() (map[string]int, error) {
+	rows, err := c.db.Query(`SELECT task_id, COUNT(*) FROM pomodoros GROUP BY task_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var taskID string
+		var count int
+		if err := rows.Scan(&taskID, &count); err != nil {
+			return nil, err
+		}
+		counts[taskID] = count
+	}
+	return counts, rows.Err()
+}
+
+// loadPomodoroCounts creates a command that reads the pomodoro counts of the tasks from the cache
+func loadPomodoroCounts(cache *CacheDB) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		counts, err := cache.LoadPomodoroCounts()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load pomodoros: %w", err))
+		}
+		return pomodoroCountsLoadedMsg(counts)
+	})
+}
+
+// recordPomodoro creates a command that logs a completed pomodoro and reloads the counts
+func recordPomodoro(cache *CacheDB, timer pomodoroTimer, completed time.Time) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.RecordPomodoro(timer.task, timer.started, completed); err != nil {
+			return errorMsg(fmt.Errorf("failed to record pomodoro: %w", err))
+		}
+		counts, err := cache.LoadPomodoroCounts()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load pomodoros: %w", err))
+		}
+		return pomodoroCountsLoadedMsg(counts)
+	})
+}
+
+// ringPomodoro creates a command that rings the terminal bell and sends a desktop notification
+func ringPomodoro(task TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		_, _ = os.Stdout.WriteString("\a")
+		_ = sendDesktopNotification("🍅 Pomodoro done", task.Content+" • time for a break")
+		return nil
+	})
+}
+
+// schedulePomodoroTick returns a command that ticks the running pomodoro a second from now
+func schedulePomodoroTick(id int) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return pomodoroTickMsg(id)
+	})
+}
+
+// togglePomodoro starts a pomodoro for the selected task, or stops the running one
+func (m *model) togglePomodoro() tea.Cmd {
+	if m.pomodoro != nil {
+		task := m.pomodoro.task
+		m.pomodoro = nil
+		return tea.Batch(
+			m.notify(toastInfo, "Pomodoro stopped: "+task.Content),
+			m.sendSlackStatus(slackStatus{}),
+		)
+	}
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+
+	task := m.allTasks[m.selectedIndex]
+	now := time.Now()
+	m.pomodoroSeq++
+	m.pomodoro = &pomodoroTimer{id: m.pomodoroSeq, task: task, started: now, ends: now.Add(m.pomodoroLength)}
+	return tea.Batch(
+		m.notify(toastInfo, fmt.Sprintf("Pomodoro started: %s (%s)", task.Content, formatDuration(m.pomodoroLength))),
+		schedulePomodoroTick(m.pomodoroSeq),
+		m.sendSlackStatus(focusStatus(m.pomodoro.ends)),
+	)
+}
+
+// handlePomodoroTick counts the running pomodoro down, logging it and ringing once its time is up
+// Ticks of a stopped pomodoro are dropped, so stopping and starting another never doubles the ticks
+func (m *model) handlePomodoroTick(id int) tea.Cmd {
+	if m.pomodoro == nil || m.pomodoro.id != id {
+		return nil
+	}
+	now := time.Now()
+	if now.Before(m.pomodoro.ends) {
+		return schedulePomodoroTick(id)
+	}
+
+	timer := *m.pomodoro
+	m.pomodoro = nil
+	return tea.Batch(
+		m.notify(toastSuccess, "🍅 Pomodoro done: "+timer.task.Content+" • time for a break"),
+		ringPomodoro(timer.task),
+		recordPomodoro(m.cache, timer, now),
+		m.sendSlackStatus(slackStatus{}),
+	)
+}
+
+// formatCountdown writes the time left as minutes and seconds, e.g. "24:59"
+func formatCountdown(left time.Duration) string {
+	seconds := int(left.Round(time.Second).Seconds())
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// renderPomodoro shows the running pomodoro's countdown and task in the footer
+func (m model) renderPomodoro() string {
+	if m.pomodoro == nil {
+		return ""
+	}
+	left := max(time.Until(m.pomodoro.ends), 0)
+	return loadingStyle.Render(fmt.Sprintf("🍅 %s left • %s • P: stop", formatCountdown(left), m.pomodoro.task.Content))
+}
+
+// renderPomodoroCount writes a task's completed pomodoros for the task popup, and whether one is running
+func (m model) renderPomodoroCount(task TodoistTask) string {
+	count := m.pomodoroCounts[task.ID]
+	running := m.pomodoro != nil && m.pomodoro.task.ID == task.ID
+	if count == 0 && !running {
+		return ""
+	}
+	text := fmt.Sprintf("%d", count)
+	if count > 0 {
+		text += " " + strings.Repeat("🍅", min(count, 10))
+	}
+	if running {
+		text += fmt.Sprintf(" • one running, %s left", formatCountdown(max(time.Until(m.pomodoro.ends), 0)))
+	}
+	return text
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// profileNamePattern limits profile names to characters that are safe in a cache file name
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Profile is a Todoist account the app can run as, with its own cache
+type Profile struct {
+	// Name identifies the profile for --profile and the profile switcher, e.g. "work"
+	Name string `json:"name"`
+	// Token is the account's API token
+	Token string `json:"token,omitempty"`
+	// TokenCommand prints the API token instead, e.g. ["pass", "show", "todoist/work"]
+	TokenCommand []string `json:"token_command,omitempty"`
+	// Keychain reads the API token from the OS keychain, stored under the profile's name as the account
+	Keychain bool `json:"keychain,omitempty"`
+	// APIURL sends the profile's requests to another server, overriding the top-level api_url
+	APIURL string `json:"api_url,omitempty"`
+	// References are the IDs of the projects and labels the settings name in this account, recorded by the app
+	References *NameReferences `json:"references,omitempty"`
+}
+
+// token returns the profile's API token, running its token command or reading the keychain when set
+func (p Profile) token() (string, error) {
+	switch {
+	case len(p.TokenCommand) > 0:
+		token, err := runTokenCommand(p.TokenCommand)
+		if err != nil {
+			return "", fmt.Errorf("failed to run token command of profile %q: %w", p.Name, err)
+		}
+		return token, nil
+	case p.Keychain:
+		token, err := keychainToken(p.Name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token of profile %q from the keychain: %w", p.Name, err)
+		}
+		return token, nil
+	}
+	return p.Token, nil
+}
+
+// validateProfiles checks that profile names are unique and usable in file names, and that each has a token
+func validateProfiles(profiles []Profile) error {
+	seen := make(map[string]bool)
+	for _, profile := range profiles {
+		if !profileNamePattern.MatchString(profile.Name) {
+			return fmt.Errorf("profile name %q may only contain letters, digits, - and _", profile.Name)
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("profile %q is defined more than once", profile.Name)
+		}
+		seen[profile.Name] = true
+		if profile.Token == "" && len(profile.TokenCommand) == 0 && !profile.Keychain {
+			return fmt.Errorf("profile %q needs a token, token_command or keychain", profile.Name)
+		}
+	}
+	return nil
+}
+
+// selectProfile picks the profile to run as: the named one, or without a name the first profile,
+// unless TODOIST_TOKEN is set, which keeps working as before with the default cache
+func (c *Config) selectProfile(name string) error {
+	if name == "" {
+		if os.Getenv("TODOIST_TOKEN") == "" && len(c.Profiles) > 0 {
+			c.profile = c.Profiles[0].Name
+		}
+		return nil
+	}
+	for _, profile := range c.Profiles {
+		if profile.Name == name {
+			c.profile = name
+			return nil
+		}
+	}
+	if len(c.Profiles) == 0 {
+		return fmt.Errorf("unknown profile %q, no profiles are configured", name)
+	}
+	return fmt.Errorf("unknown profile %q, configured profiles are: %s", name, strings.Join(c.profileNames(), ", "))
+}
+
+// profileNames returns the names of the configured profiles
+func (c *Config) profileNames() []string {
+	var names []string
+	for _, profile := range c.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// activeProfile returns the profile the app runs as, or nil when the token comes from TODOIST_TOKEN
+func (c *Config) activeProfile() *Profile {
+	if c == nil || c.profile == "" {
+		return nil
+	}
+	for i := range c.Profiles {
+		if c.Profiles[i].Name == c.profile {
+			return &c.Profiles[i]
+		}
+	}
+	return nil
+}
+
+// cacheFileName returns the cache database file of a profile, keeping the original name without one
+func cacheFileName(profile string) string {
+	if profile == "" {
+		return "cache.db"
+	}
+	return "cache-" + profile + ".db"
+}
+
+// openProfiles shows the profile switcher with the active profile selected
+func (m *model) openProfiles() {
+	m.showingProfiles = true
+	m.profileIdx = 0
+	for i, profile := range m.config.Profiles {
+		if profile.Name == m.config.profile {
+			m.profileIdx = i
+		}
+	}
+}
+
+// renderProfiles creates the popup for switching to another profile
+func (m model) renderProfiles() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("👤 Switch Profile"))
+	content.WriteString("\n\n")
+
+	if len(m.config.Profiles) == 0 {
+		content.WriteString("No profiles configured. Add a \"profiles\" section to the config file")
+		content.WriteString("\n\n")
+		content.WriteString("Press ESC to close")
+	} else {
+		for i, profile := range m.config.Profiles {
+			text := profile.Name
+			if profile.Name == m.config.profile {
+				text += " (current)"
+			}
+			if i == m.profileIdx {
+				content.WriteString(lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor).Render("→ " + text))
+			} else {
+				content.WriteString("  " + text)
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString("\n")
+
+		// Instructions
+		content.WriteString("↑/↓: select • Enter: switch • ESC: cancel")
+	}
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleProfilesInput handles keyboard input when the profile switcher is visible
+// Switching quits this session and main starts a new one with the other profile's token and cache
+func (m model) handleProfilesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	profiles := m.config.Profiles
+	switch msg.String() {
+	case "esc", "escape":
+		m.showingProfiles = false
+	case "up", "k":
+		if len(profiles) > 0 {
+			m.profileIdx = (m.profileIdx + len(profiles) - 1) % len(profiles)
+		}
+	case "down", "j":
+		if len(profiles) > 0 {
+			m.profileIdx = (m.profileIdx + 1) % len(profiles)
+		}
+	case "enter":
+		m.showingProfiles = false
+		if m.profileIdx < len(profiles) && profiles[m.profileIdx].Name != m.config.profile {
+			m.switchProfile = profiles[m.profileIdx].Name
+			// Keep changes still on their way in this profile's queue, to be sent when it's used again
+			m.queueUnsentChanges()
+			m.requests.stop()
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
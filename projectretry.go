@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Delays between attempts to load projects after they failed to load
+const (
+	projectsRetryInitialDelay = 30 * time.Second
+	projectsRetryMaxDelay     = 5 * time.Minute
+)
+
+// projectsRetryTickMsg is sent when it's time to try loading the projects again
+type projectsRetryTickMsg struct{}
+
+// projectsRetriedMsg is sent when a background attempt to load the projects finished, a retry or the fetch
+// running alongside the tasks
+type projectsRetriedMsg struct {
+	projects []TodoistProject
+	err      error
+}
+
+// HasProjectNames reports whether any project names have been loaded
+func (c *TodoistClient) HasProjectNames() bool {
+	names := c.projects.Load()
+	return names != nil && len(*names) > 0
+}
+
+// fetchProjects creates a command that loads the projects from the API and caches them
+func fetchProjects(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		projects, err := client.GetProjects(ctx)
+		if err != nil {
+			return projectsRetriedMsg{err: fmt.Errorf("failed to load projects: %w", err)}
+		}
+		if cache != nil {
+			_ = cache.SaveProjects(projects)
+		}
+		return projectsRetriedMsg{projects: projects}
+	})
+}
+
+// startProjectsRetry schedules another attempt to load the projects, unless one is already scheduled
+func (m *model) startProjectsRetry() tea.Cmd {
+	if m.retryingProjects {
+		return nil
+	}
+	m.retryingProjects = true
+	m.projectsRetryDelay = projectsRetryInitialDelay
+	return scheduleProjectsRetry(m.projectsRetryDelay)
+}
+
+// scheduleProjectsRetry returns a command that fires the next projects retry after a delay
+func scheduleProjectsRetry(delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return projectsRetryTickMsg{}
+	})
+}
+
+// handleProjectsLoaded applies projects loaded in the background, or backs off before trying again
+func (m *model) handleProjectsLoaded(msg projectsRetriedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.recordSyncError(msg.err)
+		// Projects fetched alongside the tasks failed, so start retrying them
+		if !m.retryingProjects {
+			return m.startProjectsRetry()
+		}
+		m.projectsRetryDelay = min(m.projectsRetryDelay*2, projectsRetryMaxDelay)
+		return scheduleProjectsRetry(m.projectsRetryDelay)
+	}
+
+	m.retryingProjects = false
+	delete(m.staleResources, resourceProjects)
+	m.applyProjects(msg.projects)
+	m.lastProjectsSync = time.Now()
+	return nil
+}
+
+// applyProjects stores the loaded projects for name lookups and the project pickers
+func (m *model) applyProjects(projects []TodoistProject) {
+	m.projects = projects
+	m.client.LoadProjectsFromCache(projects)
+	m.createTaskForm.filteredProjects = projects
+
+	// Default new tasks to the Inbox if no project was picked yet
+	if len(projects) > 0 && m.createTaskForm.projectID == "" {
+		m.selectInboxProject()
+	}
+}
+
+// renderProjectsWarning explains why project IDs are shown instead of names
+func (m model) renderProjectsWarning() string {
+	if !m.retryingProjects || m.client.HasProjectNames() {
+		return ""
+	}
+	return staleStyle.Render("⚠️ Projects couldn't be loaded — showing project IDs, retrying in the background")
+}
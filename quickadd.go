@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// quickAddPriorityPattern matches a Todoist priority token, p1 (urgent) to p4 (low)
+var quickAddPriorityPattern = regexp.MustCompile(`^[pP]([1-4])$`)
+
+// quickAddMaxDateWords is the longest due date phrase looked for at the end of the content
+const quickAddMaxDateWords = 5
+
+// quickAdd holds the fields parsed out of task content written in Todoist's quick-add syntax
+type quickAdd struct {
+	content  string          // Content with the parsed tokens removed
+	project  *TodoistProject // Project named with #Project, nil when none
+	labels   []string        // Labels named with @label
+	priority int             // API priority from p1-p4 (4=urgent), 0 when none
+	due      string          // Due date phrase found at the end of the content
+	// assignee is the collaborator named with +Name, nil when none
+	assignee *TodoistCollaborator
+}
+
+// parseQuickAdd parses quick-add tokens out of task content, e.g. "Buy milk #Groceries @errand p2 +Alex tomorrow"
+// #Project must name an existing project (underscores stand for spaces) and is left in the content otherwise;
+// +Name must name one person sharing the task's project, the one named with #Project or else projectID,
+// as looked up by people, and is left in the content otherwise;
+// the due date is the longest phrase at the end the due date preview understands
+func parseQuickAdd(input string, projects []TodoistProject, projectID string, people func(projectID string) []TodoistCollaborator, now time.Time) quickAdd {
+	var q quickAdd
+	var words []string
+	for _, word := range strings.Fields(input) {
+		switch {
+		case strings.HasPrefix(word, "#") && len(word) > 1:
+			name := strings.ReplaceAll(word[1:], "_", " ")
+			if project := findProjectByName(projects, name); project != nil {
+				q.project = project
+				continue
+			}
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			q.labels = append(q.labels, word[1:])
+			continue
+		case quickAddPriorityPattern.MatchString(word):
+			q.priority = 5 - int(word[1]-'0')
+			continue
+		}
+		words = append(words, word)
+	}
+
+	// Assign the task to the first person named who shares its project, once the project is known
+	if q.project != nil {
+		projectID = q.project.ID
+	}
+	for i, word := range words {
+		if !strings.HasPrefix(word, "+") || len(word) == 1 || people == nil {
+			continue
+		}
+		if person := findCollaborator(people(projectID), strings.ReplaceAll(word[1:], "_", " ")); person != nil {
+			q.assignee = person
+			words = append(words[:i], words[i+1:]...)
+			break
+		}
+	}
+
+	// Look for a due date at the end, keeping at least one word of content
+	for n := min(quickAddMaxDateWords, len(words)-1); n > 0; n-- {
+		phrase := strings.Join(words[len(words)-n:], " ")
+		if _, ok := parseDueString(phrase, now); ok {
+			q.due = phrase
+			words = words[:len(words)-n]
+			break
+		}
+	}
+
+	q.content = strings.Join(words, " ")
+	return q
+}
+
+// findProjectByName returns the project with the given name, ignoring case, or nil
+func findProjectByName(projects []TodoistProject, name string) *TodoistProject {
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, name) {
+			return &projects[i]
+		}
+	}
+	return nil
+}
+
+// findCollaborator returns the person a +Name token names, ignoring case: their full name, first name
+// or the part of their email address before the @
+// Returns nil when nobody or more than one person matches, unless one has exactly that full name
+func findCollaborator(people []TodoistCollaborator, name string) *TodoistCollaborator {
+	var matches []*TodoistCollaborator
+	for i := range people {
+		person := &people[i]
+		if strings.EqualFold(person.FullName, name) {
+			return person
+		}
+		firstName, _, _ := strings.Cut(person.FullName, " ")
+		user, _, _ := strings.Cut(person.Email, "@")
+		if strings.EqualFold(firstName, name) || strings.EqualFold(user, name) {
+			matches = append(matches, person)
+		}
+	}
+	if len(matches) != 1 {
+		return nil
+	}
+	return matches[0]
+}
+
+// found reports whether any quick-add tokens were parsed
+func (q quickAdd) found() bool {
+	return q.project != nil || len(q.labels) > 0 || q.priority != 0 || q.due != "" || q.assignee != nil
+}
+
+// describe lists the parsed fields for the preview under the content field
+func (q quickAdd) describe() string {
+	var parts []string
+	if q.project != nil {
+		parts = append(parts, "#"+q.project.Name)
+	}
+	for _, label := range q.labels {
+		parts = append(parts, "@"+label)
+	}
+	if q.priority != 0 {
+		parts = append(parts, fmt.Sprintf("P%d", 5-q.priority))
+	}
+	if q.assignee != nil {
+		parts = append(parts, "+"+q.assignee.FullName)
+	}
+	if q.due != "" {
+		parts = append(parts, "due "+q.due)
+	}
+	return strings.Join(parts, " • ")
+}
+
+// renderQuickAddPreview shows the quick-add tokens found in the content, applied when leaving the field
+// Only new tasks are parsed; edited content is saved as typed
+func (m model) renderQuickAddPreview() string {
+	form := m.createTaskForm
+	if form.editingTaskID != "" || form.activeField != fieldContent {
+		return ""
+	}
+	q := parseQuickAdd(form.content.Value(), m.projects, form.projectID, m.collaboratorsOf, time.Now())
+	if !q.found() {
+		return ""
+	}
+	return projectStyle.Render("Quick add: " + q.describe() + " (Tab/Enter applies)")
+}
+
+// applyQuickAdd moves quick-add tokens from the content of a new task into the other form fields
+func (m *model) applyQuickAdd() {
+	form := &m.createTaskForm
+	if form.editingTaskID != "" {
+		return
+	}
+	q := parseQuickAdd(form.content.Value(), m.projects, form.projectID, m.collaboratorsOf, time.Now())
+	if !q.found() {
+		return
+	}
+
+	form.content.SetValue(q.content)
+	if q.project != nil && q.project.ID != form.projectID {
+		form.projectID = q.project.ID
+		form.projectName = q.project.Name
+		form.projectSearch.SetValue("")
+		form.filteredProjects = m.projects
+		form.selectedProjectIdx = -1
+		for i, project := range m.projects {
+			if project.ID == q.project.ID {
+				form.selectedProjectIdx = i
+			}
+		}
+		// Sections belong to a project, so the old choice no longer applies
+		form.sectionID = ""
+	}
+	if len(q.labels) > 0 {
+		labels := parseLabels(form.labels)
+		for _, label := range q.labels {
+			if !containsFold(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+		form.labels = strings.Join(labels, ", ")
+	}
+	if q.priority != 0 {
+		form.priority = q.priority
+	}
+	if q.due != "" {
+		form.deadline.SetValue(q.due)
+	}
+	if q.assignee != nil {
+		form.assigneeID = q.assignee.ID
+	}
+}
+
+// formAssigneeID returns the person the new task is assigned to, as long as they share the chosen project
+// Choosing another project after naming someone with +Name leaves the task unassigned
+func (m model) formAssigneeID() string {
+	form := m.createTaskForm
+	for _, person := range m.collaboratorsOf(form.projectID) {
+		if person.ID == form.assigneeID {
+			return person.ID
+		}
+	}
+	return ""
+}
+
+// containsFold reports whether a list contains a string, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Completions are sent together once none has been added for a moment, or as soon as a batch is full
+const (
+	completionFlushDelay = 1500 * time.Millisecond
+	completionBatchLimit = maxSyncCommands
+)
+
+// completionFlushMsg is sent when it's time to send the completions waiting in the queue
+type completionFlushMsg struct {
+	// generation tells apart the flushes scheduled before the last completion was added
+	generation int
+}
+
+// queuedCompletion is a task completed on screen and not yet in Todoist
+type queuedCompletion struct {
+	task TodoistTask
+	// view identifies the view the task was completed in, to put it back there if the completion fails
+	view string
+}
+
+// completionsSentMsg is sent when a batch of completions was sent to Todoist
+type completionsSentMsg struct {
+	// failed holds the tasks that could not be completed, with the reason
+	failed map[string]error
+	// err is set when the whole batch failed, e.g. without a connection
+	err error
+}
+
+// sendCompletions creates a command that completes tasks in one Sync API batch
+func sendCompletions(ctx context.Context, client *TodoistClient, completions []queuedCompletion) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		commands := make([]syncCommand, len(completions))
+		for i, completion := range completions {
+			commands[i] = newSyncCommand("item_close", map[string]any{"id": completion.task.ID})
+		}
+		failedCommands, err := client.runSyncCommandBatch(ctx, commands)
+		if err != nil {
+			return completionsSentMsg{err: fmt.Errorf("failed to complete tasks: %w", err)}
+		}
+		failed := make(map[string]error)
+		for i, command := range commands {
+			if err := failedCommands[command.UUID]; err != nil {
+				failed[completions[i].task.ID] = err
+			}
+		}
+		return completionsSentMsg{failed: failed}
+	})
+}
+
+// scheduleCompletionFlush returns a command that flushes the completion queue after a delay
+func scheduleCompletionFlush(generation int) tea.Cmd {
+	return tea.Tick(completionFlushDelay, func(time.Time) tea.Msg {
+		return completionFlushMsg{generation: generation}
+	})
+}
+
+// completeOptimistically completes a task on screen right away and queues it to be sent with the next batch
+func (m *model) completeOptimistically(task TodoistTask) tea.Cmd {
+	toast := m.notify(toastSuccess, "Task completed: "+task.Content)
+	record := m.handleTaskCompleted(task.ID)
+	m.completionQueue = append(m.completionQueue, queuedCompletion{task: task, view: m.viewKey()})
+
+	// Wait for more completions to send with it, unless the batch is full
+	if len(m.completionQueue) >= completionBatchLimit {
+		return tea.Batch(toast, record, m.flushCompletions())
+	}
+	m.completionGeneration++
+	return tea.Batch(toast, record, scheduleCompletionFlush(m.completionGeneration))
+}
+
+// flushCompletions sends the queued completions, one batch at a time
+// Completions queued while a batch is on its way are sent once it's back
+func (m *model) flushCompletions() tea.Cmd {
+	if len(m.completionQueue) == 0 || len(m.completionsSending) > 0 {
+		return nil
+	}
+	batch := m.completionQueue[:min(len(m.completionQueue), completionBatchLimit)]
+	m.completionsSending = batch
+	m.completionQueue = m.completionQueue[len(batch):]
+	return sendCompletions(m.requests.base(), m.client, batch)
+}
+
+// handleCompletionFlush sends the queued completions unless another one was added since the flush was scheduled
+func (m *model) handleCompletionFlush(msg completionFlushMsg) tea.Cmd {
+	if msg.generation != m.completionGeneration {
+		return nil
+	}
+	return m.flushCompletions()
+}
+
+// handleCompletionsSent brings back the tasks whose completion Todoist refused, then sends what was queued meanwhile
+// When Todoist couldn't be reached the completions stay, queued until the app is back online
+func (m *model) handleCompletionsSent(msg completionsSentMsg) tea.Cmd {
+	if msg.err != nil && isNetworkError(msg.err) && m.cache != nil {
+		count := len(m.completionsSending) + len(m.completionQueue)
+		m.queueUnsentCompletions()
+		return tea.Batch(
+			func() tea.Msg { return offlineMsg{err: msg.err} },
+			loadPendingOperations(m.cache),
+			m.notify(toastInfo, fmt.Sprintf("Offline: %d completion(s) queued until Todoist is reachable", count)),
+		)
+	}
+
+	sent := m.completionsSending
+	m.completionsSending = nil
+
+	var restored []string
+	var reason error
+	for _, completion := range sent {
+		err := msg.err
+		if err == nil {
+			err = msg.failed[completion.task.ID]
+		}
+		if err == nil {
+			continue
+		}
+		reason = err
+		restored = append(restored, completion.task.Content)
+		m.restoreCompletion(completion)
+	}
+
+	cmds := []tea.Cmd{m.flushCompletions()}
+	if len(restored) > 0 {
+		m.recordSyncError(reason)
+		cmds = append(cmds,
+			m.notify(toastError, fmt.Sprintf("Couldn't complete %s, restored: %s", strings.Join(restored, ", "), friendlyError(reason))),
+			m.reloadCurrentView())
+	}
+	return tea.Batch(cmds...)
+}
+
+// restoreCompletion undoes a completion Todoist refused: the task goes back into the list it was completed in,
+// the cache and out of the history
+// The list is reloaded afterwards too, but that keeps the current tasks when Todoist can't be reached
+func (m *model) restoreCompletion(completion queuedCompletion) {
+	task := completion.task
+	delete(m.completedTasks, task.ID)
+	if completion.view == m.viewKey() && !slices.ContainsFunc(m.tasks, func(listed TodoistTask) bool { return listed.ID == task.ID }) {
+		selectedID := m.selectedTaskID()
+		m.setTasks(append(slices.Clone(m.tasks), task))
+		m.restoreSelection(selectedID)
+	}
+	if m.cache == nil {
+		return
+	}
+	_ = m.cache.SaveTask(task)
+	_ = m.cache.ForgetCompletion(task.ID, time.Now())
+}
+
+// viewKey identifies the current view, including the project or label it shows
+func (m model) viewKey() string {
+	return fmt.Sprintf("%d %s %s", m.view, m.viewProjectID, m.viewLabelName)
+}
+
+// ForgetCompletion removes a task from the history of a day, after its completion failed
+func (c *CacheDB) ForgetCompletion(taskID string, day time.Time) error {
+	_, err := c.db.Exec(`DELETE FROM completion_history WHERE task_id = ? AND day = ?`, taskID, dayOf(day).Format("2006-01-02"))
+	return err
+}
+
+// isCompletionPending reports whether a task was completed on screen but not yet in Todoist
+// Such tasks stay hidden when a refresh brings them back meanwhile
+func (m model) isCompletionPending(taskID string) bool {
+	for _, completion := range slices.Concat(m.completionsSending, m.completionQueue) {
+		if completion.task.ID == taskID {
+			return true
+		}
+	}
+	return false
+}
+
+// queueUnsentCompletions keeps the completions not yet confirmed by Todoist when the app quits,
+// as pending operations sent at the next start
+// A batch on its way is kept too, since quitting cancels it; if it got through, the repeat at worst shows as a failed operation
+func (m *model) queueUnsentCompletions() {
+	if m.cache == nil {
+		return
+	}
+	for _, completion := range slices.Concat(m.completionsSending, m.completionQueue) {
+		_ = m.cache.QueueOperation(newOperation(opComplete, completion.task.ID, completion.task.Content, operationPayload{}))
+	}
+	m.completionsSending = nil
+	m.completionQueue = nil
+}
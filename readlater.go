@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Read-later services a link can be sent to
+const (
+	readLaterPocket     = "pocket"
+	readLaterInstapaper = "instapaper"
+	readLaterRaindrop   = "raindrop"
+	readLaterWebhook    = "webhook"
+)
+
+// readLaterServiceNames are the names shown for the services in toasts
+var readLaterServiceNames = map[string]string{
+	readLaterPocket:     "Pocket",
+	readLaterInstapaper: "Instapaper",
+	readLaterRaindrop:   "Raindrop",
+	readLaterWebhook:    "read later",
+}
+
+// readLaterTimeout bounds how long sending a link to the service may take
+const readLaterTimeout = 30 * time.Second
+
+// linkTaskPattern matches a task that is just a URL
+var linkTaskPattern = regexp.MustCompile(`^https?://\S+$`)
+
+// markdownLinkTaskPattern matches a task that is just a Markdown link, as Todoist stores shared pages
+var markdownLinkTaskPattern = regexp.MustCompile(`^\[([^\]]*)\]\((https?://[^)\s]+)\)$`)
+
+// ReadLaterConfig configures the read-later service link tasks are sent to
+// Values may read environment variables written as ${NAME}, so secrets can stay out of the config file
+type ReadLaterConfig struct {
+	// Service is pocket, instapaper, raindrop or webhook
+	Service string `json:"service"`
+	// Token is the Pocket access token or the Raindrop API token
+	Token string `json:"token,omitempty"`
+	// ConsumerKey is the Pocket consumer key
+	ConsumerKey string `json:"consumer_key,omitempty"`
+	// Username is the Instapaper username or email
+	Username string `json:"username,omitempty"`
+	// Password is the Instapaper password, if the account has one
+	Password string `json:"password,omitempty"`
+	// URL is where the webhook posts links to
+	URL string `json:"url,omitempty"`
+}
+
+// readLaterSentMsg is sent when a link task has been sent to the read-later service
+type readLaterSentMsg struct {
+	task    TodoistTask
+	service string
+}
+
+// validateReadLater checks that the read-later service is known and has the settings it needs
+func validateReadLater(r *ReadLaterConfig) error {
+	if r == nil {
+		return nil
+	}
+	var missing string
+	switch r.Service {
+	case readLaterPocket:
+		if r.ConsumerKey == "" {
+			missing = "consumer_key"
+		}
+		if r.Token == "" {
+			missing = "token"
+		}
+	case readLaterInstapaper:
+		if r.Username == "" {
+			missing = "username"
+		}
+	case readLaterRaindrop:
+		if r.Token == "" {
+			missing = "token"
+		}
+	case readLaterWebhook:
+		if r.URL == "" {
+			missing = "url"
+		} else if parsed, err := url.Parse(os.ExpandEnv(r.URL)); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("url %q must be an http or https URL", r.URL)
+		}
+	default:
+		return fmt.Errorf("unknown service %q, use %s, %s, %s or %s", r.Service, readLaterPocket, readLaterInstapaper, readLaterRaindrop, readLaterWebhook)
+	}
+	if missing != "" {
+		return fmt.Errorf("the %s service needs %s", r.Service, missing)
+	}
+	return nil
+}
+
+// readLaterLink returns the URL of a task that is just a link, with the link's title when it's a Markdown link
+// Returns false for tasks with anything else in them, which are more than something to read
+func readLaterLink(task TodoistTask) (link, title string, ok bool) {
+	content := strings.TrimSpace(task.Content)
+	if match := markdownLinkTaskPattern.FindStringSubmatch(content); match != nil {
+		return match[2], strings.TrimSpace(match[1]), true
+	}
+	if linkTaskPattern.MatchString(content) {
+		return content, "", true
+	}
+	return "", "", false
+}
+
+// sendToReadLater saves a link with the configured service
+func sendToReadLater(ctx context.Context, r *ReadLaterConfig, link, title string) error {
+	ctx, cancel := context.WithTimeout(ctx, readLaterTimeout)
+	defer cancel()
+
+	// Build the service's request
+	var req *http.Request
+	var err error
+	switch r.Service {
+	case readLaterPocket:
+		req, err = newJSONRequest(ctx, "https://getpocket.com/v3/add", map[string]string{
+			"url":          link,
+			"title":        title,
+			"consumer_key": os.ExpandEnv(r.ConsumerKey),
+			"access_token": os.ExpandEnv(r.Token),
+		})
+		if err == nil {
+			req.Header.Set("X-Accept", "application/json")
+		}
+	case readLaterInstapaper:
+		form := url.Values{}
+		form.Set("url", link)
+		if title != "" {
+			form.Set("title", title)
+		}
+		req, err = http.NewRequestWithContext(ctx, "POST", "https://www.instapaper.com/api/add", strings.NewReader(form.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			req.SetBasicAuth(os.ExpandEnv(r.Username), os.ExpandEnv(r.Password))
+		}
+	case readLaterRaindrop:
+		body := map[string]any{"link": link, "pleaseParse": map[string]any{}}
+		if title != "" {
+			body["title"] = title
+		}
+		req, err = newJSONRequest(ctx, "https://api.raindrop.io/rest/v1/raindrop", body)
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+os.ExpandEnv(r.Token))
+		}
+	default:
+		req, err = newJSONRequest(ctx, os.ExpandEnv(r.URL), map[string]string{"url": link, "title": title})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Send it outside the Todoist client, so its headers and request log stay with Todoist
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Instapaper answers 201 Created, the others 200 OK; webhooks may answer with any success status
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s request failed with status %d", readLaterServiceNames[r.Service], resp.StatusCode)
+	}
+	return nil
+}
+
+// newJSONRequest creates a POST request with a JSON body
+func newJSONRequest(ctx context.Context, target string, body any) (*http.Request, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", target, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// readLater creates a command that sends a link task to the read-later service
+func readLater(ctx context.Context, r *ReadLaterConfig, task TodoistTask, link, title string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := sendToReadLater(ctx, r, link, title); err != nil {
+			return errorMsg(fmt.Errorf("failed to send link to %s: %w", readLaterServiceNames[r.Service], err))
+		}
+		return readLaterSentMsg{task: task, service: r.Service}
+	})
+}
+
+// readLaterSelected sends the selected task's link to the read-later service
+// The task is completed once the service has the link, so nothing is lost if sending fails
+func (m *model) readLaterSelected() tea.Cmd {
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	if m.config == nil || m.config.ReadLater == nil {
+		return m.notify(toastError, "No read-later service configured: add read_later to the config")
+	}
+	task := m.allTasks[m.selectedIndex]
+	link, title, ok := readLaterLink(task)
+	if !ok {
+		return m.notify(toastError, "Only tasks that are just a link can be sent to read later")
+	}
+	return readLater(m.requests.base(), m.config.ReadLater, task, link, title)
+}
+
+// handleReadLaterSent completes a task whose link the read-later service now has
+func (m *model) handleReadLaterSent(msg readLaterSentMsg) tea.Cmd {
+	return tea.Batch(
+		m.notify(toastSuccess, fmt.Sprintf("Sent to %s: %s", readLaterServiceNames[msg.service], msg.task.Content)),
+		m.completeSelected(msg.task),
+	)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// errReadOnly is returned for requests that would change something while the app runs read-only
+var errReadOnly = errors.New("read-only mode: changes are not sent to Todoist")
+
+// readOnlyNotice explains why a key that would change a task did nothing
+const readOnlyNotice = "Read-only mode: tasks can't be changed"
+
+// readOnlyActions are the actions that change tasks or projects, disabled in read-only mode
+// Actions that only change what's shown or stay on this computer, like parking or tracking time, keep working
+var readOnlyActions = map[keyAction]bool{
+	actionComplete:   true,
+	actionReschedule: true,
+	actionEdit:       true,
+	actionNewTask:    true,
+	actionNewProject: true,
+	actionSkip:       true,
+	actionReadLater:  true,
+	actionCut:        true,
+	actionPromote:    true,
+	actionDemote:     true,
+	actionDuplicates: true,
+	actionStaleSweep: true,
+	actionReplace:    true,
+	actionTriage:     true,
+	actionMove:       true,
+	actionLabel:      true,
+}
+
+// isReadOnly reports whether the app runs read-only, from --read-only or read_only in the config
+func (c *Config) isReadOnly() bool {
+	return c != nil && (c.ReadOnly || c.readOnly)
+}
+
+// syncReadKey marks the context of a Sync API request that only reads
+type syncReadKey struct{}
+
+// withSyncRead marks a Sync API request as a read, which read-only mode lets through even though it's a POST
+func withSyncRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, syncReadKey{}, true)
+}
+
+// refuseWrites is middleware refusing every request but GETs and Sync API reads, for read-only mode
+// It's the safety net behind the disabled actions: nothing that slips past them reaches Todoist
+func refuseWrites(next requestHandler) requestHandler {
+	return func(req *http.Request) (*http.Response, error) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			if read, _ := req.Context().Value(syncReadKey{}).(bool); !read {
+				return nil, errReadOnly
+			}
+		}
+		return next(req)
+	}
+}
+
+// refuseInReadOnly reports a key whose action changes something while the app runs read-only
+// Returns nil when the key may be handled
+func (m *model) refuseInReadOnly(context string, msg tea.KeyMsg) tea.Cmd {
+	if !m.config.isReadOnly() {
+		return nil
+	}
+	if !isDeleteKey(msg) && !readOnlyActions[m.keys.action(context, msg.String())] {
+		return nil
+	}
+	return m.notify(toastInfo, readOnlyNotice)
+}
+
+// renderReadOnlyBanner renders the notice that changes are disabled
+func (m model) renderReadOnlyBanner() string {
+	if !m.config.isReadOnly() {
+		return ""
+	}
+	return staleStyle.Render("🔒 Read-only: browsing only, nothing is changed in Todoist")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// recurringIcon marks recurring tasks in the list
+const recurringIcon = "🔁 "
+
+// EndRecurrence turns a recurring task into a one-off task due on its current date
+// Completing it afterwards closes it for good instead of moving it to the next occurrence
+func (c *TodoistClient) EndRecurrence(ctx context.Context, task TodoistTask) error {
+	_, err := c.UpdateTaskDue(ctx, task.ID, oneOffDueString(task))
+	return err
+}
+
+// oneOffDueString returns a due string for the task's current date without the recurrence
+func oneOffDueString(task TodoistTask) string {
+	if task.Due == nil {
+		return "no date"
+	}
+	return task.Due.Date
+}
+
+// completeTaskForever creates a command that ends a task's recurrence and then completes it
+func completeTaskForever(ctx context.Context, client *TodoistClient, task TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := client.EndRecurrence(ctx, task); err != nil {
+			return errorMsg(fmt.Errorf("failed to end recurrence: %w", err))
+		}
+		if err := client.CompleteTask(ctx, task.ID); err != nil {
+			return errorMsg(err)
+		}
+		return taskCompletedMsg(task.ID)
+	})
+}
+
+// completeSelected completes a task, first asking whether to close only this occurrence when it recurs
+// Online, the task is completed on screen right away and sent to Todoist with the next batch
+func (m *model) completeSelected(task TodoistTask) tea.Cmd {
+	if m.completedTasks[task.ID] || m.isCompletionPending(task.ID) {
+		return nil
+	}
+	if isRecurring(task) {
+		m.showingCompleteChoice = true
+		m.completeChoiceTask = task
+		return nil
+	}
+	if !m.offline && m.client != nil {
+		return m.completeOptimistically(task)
+	}
+	return m.writeOrQueue(newOperation(opComplete, task.ID, task.Content, operationPayload{}),
+		completeTask(m.requests.base(), m.client, task.ID))
+}
+
+// renderCompleteChoice creates the dialog choosing how to complete a recurring task
+func (m model) renderCompleteChoice() string {
+	var content strings.Builder
+	task := m.completeChoiceTask
+
+	// Dialog title
+	content.WriteString(popupTitleStyle.Render("🔁 Complete Recurring Task"))
+	content.WriteString("\n\n")
+
+	// Task and its recurrence
+	content.WriteString(popupFieldStyle.Render("Task: "))
+	content.WriteString(task.Content)
+	content.WriteString("\n")
+	content.WriteString(popupFieldStyle.Render("Repeats: "))
+	content.WriteString(task.Due.String)
+	content.WriteString("\n\n")
+
+	// Choices
+	content.WriteString("o: complete this occurrence (the next one appears after a refresh)")
+	content.WriteString("\n")
+	content.WriteString("f: complete forever (end the recurrence)")
+	content.WriteString("\n\n")
+
+	// Instructions
+	content.WriteString("Press 'o' or Enter for this occurrence • 'f' for forever • ESC to cancel")
+
+	// Calculate popup size and position
+	maxWidth := 60
+	if m.width < 70 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleCompleteChoiceInput handles keyboard input when the recurring completion dialog is visible
+func (m model) handleCompleteChoiceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	task := m.completeChoiceTask
+	switch msg.String() {
+	case "o", "O", "enter":
+		// Close this occurrence; Todoist moves the task to its next date, picked up by reloading
+		m.showingCompleteChoice = false
+		cmd := m.writeOrQueue(newOperation(opComplete, task.ID, task.Content, operationPayload{}),
+			completeTask(m.requests.base(), m.client, task.ID))
+		if m.offline {
+			return m, cmd
+		}
+		return m, tea.Sequence(cmd, m.reloadCurrentView())
+	case "f", "F":
+		// Drop the recurrence first so completing it closes the task for good
+		m.showingCompleteChoice = false
+		update := UpdateTaskRequest{DueString: oneOffDueString(task)}
+		return m, tea.Sequence(
+			m.writeOrQueue(newOperation(opUpdate, task.ID, task.Content, operationPayload{Update: &update}), nil),
+			m.writeOrQueue(newOperation(opComplete, task.ID, task.Content, operationPayload{}),
+				completeTaskForever(m.requests.base(), m.client, task)))
+	case "esc", "escape", "n", "N":
+		// Leave the task as it is
+		m.showingCompleteChoice = false
+	}
+	return m, nil
+}
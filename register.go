@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// cutTask takes the selected task out of the list into the register, to be pasted into another view with p
+// In the today and upcoming views its due date is removed, so it leaves the day like a cut line;
+// in the project and label views it is only hidden until pasted;
+// recurring tasks keep their schedule and are only hidden, since removing the date would end the recurrence
+func (m *model) cutTask() tea.Cmd {
+	if m.client == nil || m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	task := m.allTasks[m.selectedIndex]
+	m.register = &task
+	m.removeTask(task.ID)
+
+	if m.view == viewProject || m.view == viewLabel || m.view == viewSomeday || task.Due == nil || task.Due.IsRecurring {
+		return nil
+	}
+	return rescheduleTask(m.requests.base(), m.client, task.ID, "no date")
+}
+
+// pasteTask files the task in the register into the current view: the viewed project or label,
+// today, or the selected task's day in the upcoming view
+func (m *model) pasteTask() tea.Cmd {
+	if m.client == nil || m.register == nil {
+		return nil
+	}
+	task := *m.register
+	m.register = nil
+
+	switch m.view {
+	case viewProject:
+		return moveTaskToProject(m.requests.base(), m.client, task, m.viewProjectID)
+	case viewLabel:
+		return addLabelToTask(m.requests.base(), m.client, task, m.viewLabelName)
+	case viewSomeday:
+		return addLabelToTask(m.requests.base(), m.client, task, m.someday.label)
+	case viewUpcoming:
+		date := dayDate(0)
+		if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) && m.allTasks[m.selectedIndex].Due != nil {
+			date = m.allTasks[m.selectedIndex].Due.Date
+		}
+		return rescheduleTask(m.requests.base(), m.client, task.ID, pasteDueString(task, date))
+	default:
+		return rescheduleTask(m.requests.base(), m.client, task.ID, pasteDueString(task, dayDate(0)))
+	}
+}
+
+// pasteDueString returns the due string that puts a task on the given day
+// Recurring tasks keep their recurrence and start from that day instead
+func pasteDueString(task TodoistTask, date string) string {
+	if task.Due == nil || !task.Due.IsRecurring {
+		return date
+	}
+	recurrence, _, _ := strings.Cut(task.Due.String, " starting ")
+	return recurrence + " starting " + date
+}
+
+// moveTaskToProject creates a command that moves a task to a project, keeping its due date
+// Returns the moved task so the list updates like after an edit
+func moveTaskToProject(ctx context.Context, client *TodoistClient, task TodoistTask, projectID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if task.ProjectID != projectID {
+			if err := client.MoveTask(ctx, task.ID, projectID); err != nil {
+				return errorMsg(fmt.Errorf("failed to paste task: %w", err))
+			}
+		}
+
+		// Fetch the task again to pick up its new project and any due date change from cutting
+		tasks, err := client.GetTasksByIDs(ctx, []string{task.ID})
+		if err != nil {
+			return errorMsg(err)
+		}
+		if len(tasks) == 0 {
+			return errorMsg(fmt.Errorf("task %q not found after pasting", task.Content))
+		}
+		return taskUpdatedMsg(tasks[0])
+	})
+}
+
+// isCut reports whether a task is in the register, waiting to be pasted
+func (m model) isCut(taskID string) bool {
+	return m.register != nil && m.register.ID == taskID
+}
+
+// renderRegisterStatus shows the cut task and where pasting will put it
+func (m model) renderRegisterStatus() string {
+	if m.register == nil {
+		return ""
+	}
+	var target string
+	switch m.view {
+	case viewProject:
+		target = "into " + m.client.GetProjectName(m.viewProjectID)
+	case viewLabel:
+		target = "with @" + m.viewLabelName
+	case viewSomeday:
+		target = "into someday"
+	case viewUpcoming:
+		target = "on the selected day"
+	default:
+		target = "on today"
+	}
+	return staleStyle.Render("✂️ Cut \"" + m.register.Content + "\" — press " + m.keys.keyFor(keyContextMain, actionPaste) + " to paste it " + target +
+		" • " + m.keys.keyFor(keyContextMain, actionProjects) + ": projects")
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reminderTickInterval is how often the reminder ticker checks for due notifications
+const reminderTickInterval = 30 * time.Second
+
+// NotificationRule maps a label to the notifications sent before a task with that label is due
+type NotificationRule struct {
+	// Label is the task label the rule applies to, with or without the leading @
+	Label string `json:"label"`
+	// MinutesBefore lists when to notify, in minutes before the due time
+	MinutesBefore []int `json:"minutes_before"`
+}
+
+// reminderTickMsg is sent by the reminder ticker with the time of the tick
+type reminderTickMsg time.Time
+
+// reminder is a single notification that became due
+type reminder struct {
+	title string
+	body  string
+}
+
+// scheduleReminderTick returns a command that fires the next reminder tick
+func scheduleReminderTick() tea.Cmd {
+	return tea.Tick(reminderTickInterval, func(t time.Time) tea.Msg {
+		return reminderTickMsg(t)
+	})
+}
+
+// parseDueTime returns the exact due time of a task, or false if it only has a due date
+// Todoist sends UTC datetimes with a Z suffix and floating datetimes without one
+func parseDueTime(due *Due) (time.Time, bool) {
+	if due == nil || due.Datetime == "" {
+		return time.Time{}, false
+	}
+	if dueTime, err := time.Parse(time.RFC3339Nano, due.Datetime); err == nil {
+		return dueTime, true
+	}
+	if dueTime, err := time.ParseInLocation("2006-01-02T15:04:05", due.Datetime, todayLocation()); err == nil {
+		return dueTime, true
+	}
+	return time.Time{}, false
+}
+
+// matchingRules returns the notification rules that apply to a task's labels
+func matchingRules(task TodoistTask, rules []NotificationRule) []NotificationRule {
+	var matched []NotificationRule
+	for _, rule := range rules {
+		ruleLabel := strings.TrimPrefix(rule.Label, "@")
+		for _, label := range task.Labels {
+			if strings.EqualFold(label, ruleLabel) {
+				matched = append(matched, rule)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// dueReminders returns the reminders whose notification time falls in (since, now]
+// Using a window instead of remembering sent reminders keeps each one from firing twice
+func dueReminders(tasks []TodoistTask, rules []NotificationRule, since, now time.Time) []reminder {
+	var reminders []reminder
+	for _, task := range tasks {
+		dueTime, ok := parseDueTime(task.Due)
+		if !ok {
+			continue
+		}
+		for _, rule := range matchingRules(task, rules) {
+			for _, minutes := range rule.MinutesBefore {
+				notifyAt := dueTime.Add(-time.Duration(minutes) * time.Minute)
+				if notifyAt.After(since) && !notifyAt.After(now) {
+					reminders = append(reminders, reminder{
+						title: "⏰ " + task.Content,
+						body:  fmt.Sprintf("Due in %d minutes (%s)", minutes, dueTime.Local().Format("15:04")),
+					})
+				}
+			}
+		}
+	}
+	return reminders
+}
+
+// sendReminders creates a command that notifies about cached tasks whose reminders became due,
+// and when dueNow is set, about the tasks whose due time arrived
+func sendReminders(cache *CacheDB, rules []NotificationRule, dueNow bool, since, now time.Time) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		// The cache holds every active task, not just the ones on screen
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load tasks for reminders: %w", err))
+		}
+		reminders := dueReminders(tasks, rules, since, now)
+		if dueNow {
+			reminders = append(reminders, dueNowReminders(unparkedTasks(cache, tasks), since, now)...)
+		}
+		for _, r := range reminders {
+			_ = sendDesktopNotification(r.title, r.body)
+		}
+		return nil
+	})
+}
+
+// startReminderTicker returns a command starting the reminder ticker,
+// or nil if neither notification rules nor due notifications are configured
+func startReminderTicker(config *Config) tea.Cmd {
+	if config == nil || (len(config.NotificationRules) == 0 && !config.Notify.notifiesDue()) {
+		return nil
+	}
+	return scheduleReminderTick()
+}
+
+// handleReminderTick sends the reminders that became due since the previous tick and schedules the next one
+func (m *model) handleReminderTick(now time.Time) tea.Cmd {
+	since := m.lastReminderCheck
+	if since.IsZero() {
+		// First tick covers the interval since startup
+		since = now.Add(-reminderTickInterval)
+	}
+	m.lastReminderCheck = now
+	return tea.Batch(sendReminders(m.cache, m.currentSettings().NotificationRules, m.config.Notify.notifiesDue(), since, now), scheduleReminderTick())
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// referenceKind is the kind of Todoist object a setting can name
+type referenceKind string
+
+const (
+	// referenceProject is a project, named by its name or ID
+	referenceProject referenceKind = "project"
+	// referenceLabel is a personal label, named with or without the leading @
+	referenceLabel referenceKind = "label"
+)
+
+// knownNames returns the last seen name of each project or label, keyed by ID
+func (c *CacheDB) knownNames(kind referenceKind) (map[string]string, error) {
+	rows, err := c.db.Query("SELECT id, name FROM known_names WHERE kind = ?", string(kind))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var id, name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, err
+		}
+		names[id] = name
+	}
+	return names, rows.Err()
+}
+
+// saveKnownNames replaces the remembered names of a kind with the current ones
+func (c *CacheDB) saveKnownNames(kind referenceKind, names map[string]string) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM known_names WHERE kind = ?", string(kind)); err != nil {
+		return err
+	}
+	for id, name := range names {
+		if _, err := tx.Exec("INSERT INTO known_names (kind, id, name) VALUES (?, ?, ?)", string(kind), id, name); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// detectRenames compares fetched names with the remembered ones, returning new names keyed by old name
+// The current names are remembered for next time; nothing is reported the first time a kind is seen
+func (c *CacheDB) detectRenames(kind referenceKind, current map[string]string) (map[string]string, error) {
+	known, err := c.knownNames(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	renames := make(map[string]string)
+	for id, name := range current {
+		if old, ok := known[id]; ok && old != name {
+			renames[old] = name
+		}
+	}
+	if err := c.saveKnownNames(kind, current); err != nil {
+		return nil, err
+	}
+	return renames, nil
+}
+
+// RenameTaskLabels replaces renamed labels on the cached tasks, so label views and filters work before the next sync
+func (c *CacheDB) RenameTaskLabels(renames map[string]string) error {
+	tasks, err := c.LoadTasks()
+	if err != nil {
+		return err
+	}
+	for _, task := range tasks {
+		if renamed, changed := renameTaskLabels(task, renames); changed {
+			if err := c.SaveTask(renamed); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renameTaskLabels returns the task with its renamed labels replaced, and whether any were
+func renameTaskLabels(task TodoistTask, renames map[string]string) (TodoistTask, bool) {
+	changed := false
+	labels := make([]string, len(task.Labels))
+	for i, label := range task.Labels {
+		labels[i] = label
+		if name, ok := renamed(label, renames); ok {
+			labels[i] = name
+			changed = true
+		}
+	}
+	if changed {
+		task.Labels = labels
+	}
+	return task, changed
+}
+
+// renamed returns the new name of a renamed project or label, matching the old name regardless of case
+func renamed(name string, renames map[string]string) (string, bool) {
+	for old, current := range renames {
+		if strings.EqualFold(name, old) {
+			return current, true
+		}
+	}
+	return "", false
+}
+
+// renameReference updates a setting naming a project or label, keeping a leading @ on labels
+// IDs never change, so settings naming an ID are left alone
+func renameReference(value *string, renames map[string]string) bool {
+	name := strings.TrimSpace(*value)
+	prefix := ""
+	if strings.HasPrefix(name, "@") {
+		prefix, name = "@", name[1:]
+	}
+	current, ok := renamed(name, renames)
+	if !ok {
+		return false
+	}
+	*value = prefix + current
+	return true
+}
+
+// NameReferences remembers the ID of each project and label named in the settings, keyed by the name as written
+// in lower case, so the settings follow them when they're renamed in Todoist without being rewritten
+type NameReferences struct {
+	Projects map[string]string `json:"projects,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// of returns the recorded IDs of a kind
+func (r *NameReferences) of(kind referenceKind) map[string]string {
+	switch {
+	case r == nil:
+		return nil
+	case kind == referenceProject:
+		return r.Projects
+	}
+	return r.Labels
+}
+
+// currentName returns what the project or label a setting names is called now, following the ID recorded for it
+// Names without a recorded ID, or whose project or label is gone, are returned as written
+func (r *NameReferences) currentName(kind referenceKind, written string, current map[string]string) string {
+	name := strings.TrimSpace(written)
+	prefix := ""
+	if strings.HasPrefix(name, "@") {
+		prefix, name = "@", name[1:]
+	}
+	if id, ok := r.of(kind)[strings.ToLower(name)]; ok {
+		if now, ok := current[id]; ok {
+			return prefix + now
+		}
+	}
+	return written
+}
+
+// references returns the references of the account the app runs as, nil when none were recorded
+// IDs differ between accounts, so each profile keeps its own
+func (c *Config) references() *NameReferences {
+	if c == nil {
+		return nil
+	}
+	for _, profile := range c.Profiles {
+		if c.profile != "" && profile.Name == c.profile {
+			return profile.References
+		}
+	}
+	return c.References
+}
+
+// setReferences replaces the recorded IDs of a kind for the account the app runs as
+func (c *Config) setReferences(kind referenceKind, ids map[string]string) {
+	references := &c.References
+	for i := range c.Profiles {
+		if c.profile != "" && c.Profiles[i].Name == c.profile {
+			references = &c.Profiles[i].References
+		}
+	}
+	// Copied, so a config being saved meanwhile keeps the references it was given
+	updated := &NameReferences{}
+	if *references != nil {
+		*updated = **references
+	}
+	if kind == referenceProject {
+		updated.Projects = ids
+	} else {
+		updated.Labels = ids
+	}
+	*references = updated
+}
+
+// eachReference calls visit with every setting naming a project or label of the kind, which it may change
+// Format rule conditions are visited one comparison at a time, and abbreviations by the project they're for
+func (c *Config) eachReference(kind referenceKind, visit func(name *string)) {
+	visitChanged := func(value *string) {
+		if *value == "" {
+			return
+		}
+		name := *value
+		visit(&name)
+		if name != *value {
+			*value = name
+		}
+	}
+
+	for i := range c.FormatRules {
+		parts := strings.Split(c.FormatRules[i].If, " and ")
+		changed := false
+		for j, part := range parts {
+			for _, op := range []string{"!=", "="} {
+				field, value, found := strings.Cut(part, op)
+				if !found || !strings.EqualFold(strings.TrimSpace(field), string(kind)) {
+					continue
+				}
+				name := strings.TrimSpace(value)
+				visit(&name)
+				if name != strings.TrimSpace(value) {
+					parts[j] = strings.TrimSpace(field) + op + name
+					changed = true
+				}
+				break
+			}
+		}
+		if changed {
+			c.FormatRules[i].If = strings.Join(parts, " and ")
+		}
+	}
+
+	switch kind {
+	case referenceProject:
+		// Rebuilt only when a name changed, so swapped names move each abbreviation exactly once
+		abbreviations := make(map[string]string, len(c.ProjectAbbreviations))
+		changed := false
+		for name, abbreviation := range c.ProjectAbbreviations {
+			current := name
+			visit(&current)
+			changed = changed || current != name
+			abbreviations[current] = abbreviation
+		}
+		if changed {
+			c.ProjectAbbreviations = abbreviations
+		}
+		if c.Someday != nil {
+			visitChanged(&c.Someday.Project)
+		}
+		for i := range c.GitContexts {
+			visitChanged(&c.GitContexts[i].Project)
+		}
+		if c.IssueSync != nil {
+			for i := range c.IssueSync.Sources {
+				visitChanged(&c.IssueSync.Sources[i].Project)
+			}
+		}
+	case referenceLabel:
+		for i := range c.NotificationRules {
+			visitChanged(&c.NotificationRules[i].Label)
+		}
+		if c.StaleSweep != nil {
+			visitChanged(&c.StaleSweep.Label)
+		}
+		if c.Someday != nil {
+			visitChanged(&c.Someday.Label)
+		}
+		for i := range c.GitContexts {
+			visitChanged(&c.GitContexts[i].Label)
+		}
+	}
+}
+
+// recordReferences remembers the ID of every project or label of the kind named in the settings, given the
+// current names by ID, and forgets the names no setting uses anymore; reports whether any changed
+// A recorded name keeps its ID as long as it exists, so it follows the project or label through renames;
+// settings naming an ID need no reference
+func (c *Config) recordReferences(kind referenceKind, current map[string]string) bool {
+	written := make(map[string]string)
+	c.eachReference(kind, func(value *string) {
+		if name := strings.TrimPrefix(strings.TrimSpace(*value), "@"); name != "" {
+			written[strings.ToLower(name)] = name
+		}
+	})
+
+	known := c.references().of(kind)
+	ids := make(map[string]string)
+	for key, name := range written {
+		if id, ok := known[key]; ok {
+			if _, exists := current[id]; exists {
+				ids[key] = id
+				continue
+			}
+		}
+		if _, isID := current[name]; isID {
+			continue
+		}
+		for id, now := range current {
+			if strings.EqualFold(now, name) {
+				ids[key] = id
+				break
+			}
+		}
+	}
+	if maps.Equal(ids, known) {
+		return false
+	}
+	c.setReferences(kind, ids)
+	return true
+}
+
+// withCurrentNames returns a copy of the config whose settings name projects and labels as they're called now,
+// given the current names of each kind by ID; the config itself keeps the names as written
+func (c *Config) withCurrentNames(current map[referenceKind]map[string]string) *Config {
+	resolved := *c
+	resolved.FormatRules = slices.Clone(c.FormatRules)
+	resolved.NotificationRules = slices.Clone(c.NotificationRules)
+	resolved.GitContexts = slices.Clone(c.GitContexts)
+	if c.IssueSync != nil {
+		issueSync := *c.IssueSync
+		issueSync.Sources = slices.Clone(c.IssueSync.Sources)
+		resolved.IssueSync = &issueSync
+	}
+	if c.Someday != nil {
+		someday := *c.Someday
+		resolved.Someday = &someday
+	}
+	if c.StaleSweep != nil {
+		sweep := *c.StaleSweep
+		resolved.StaleSweep = &sweep
+	}
+
+	references := c.references()
+	for kind, names := range current {
+		resolved.eachReference(kind, func(name *string) {
+			*name = references.currentName(kind, *name, names)
+		})
+	}
+	return &resolved
+}
+
+// describeRenames lists renames as "old → new" in a stable order for messages
+func describeRenames(renames map[string]string) string {
+	var changes []string
+	for old, current := range renames {
+		changes = append(changes, fmt.Sprintf("%s → %s", old, current))
+	}
+	sort.Strings(changes)
+	return strings.Join(changes, ", ")
+}
+
+// applyRenames reports projects and labels renamed in Todoist and keeps an open label view on its label
+// The settings naming them follow their IDs, see followReferences
+func (m *model) applyRenames(renames map[referenceKind]map[string]string) tea.Cmd {
+	if m.view == viewLabel {
+		renameReference(&m.viewLabelName, renames[referenceLabel])
+	}
+
+	var cmds []tea.Cmd
+	for _, kind := range []referenceKind{referenceProject, referenceLabel} {
+		if len(renames[kind]) > 0 {
+			cmds = append(cmds, m.notify(toastInfo, fmt.Sprintf("Renamed %s: %s", kind, describeRenames(renames[kind]))))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// currentNames returns the names of the fetched projects or labels by ID
+func (m model) currentNames(kind referenceKind) map[string]string {
+	names := make(map[string]string)
+	if kind == referenceProject {
+		for _, project := range m.projects {
+			names[project.ID] = project.Name
+		}
+		return names
+	}
+	for _, label := range m.labels {
+		names[label.ID] = label.Name
+	}
+	return names
+}
+
+// currentName returns what the project or label a setting names is called now
+func (m model) currentName(kind referenceKind, name string) string {
+	return m.config.references().currentName(kind, name, m.currentNames(kind))
+}
+
+// currentSettings returns the config with the projects and labels its settings name called as they are now
+func (m model) currentSettings() *Config {
+	return m.config.withCurrentNames(map[referenceKind]map[string]string{
+		referenceProject: m.currentNames(referenceProject),
+		referenceLabel:   m.currentNames(referenceLabel),
+	})
+}
+
+// followReferences records the IDs of the projects and labels the settings name once they were fetched, saving
+// the config when any changed, and rebuilds the settings derived from them with the names they have now
+func (m *model) followReferences() tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+
+	// A kind not fetched yet would forget every reference of it
+	changed := false
+	if len(m.projects) > 0 {
+		changed = m.config.recordReferences(referenceProject, m.currentNames(referenceProject))
+	}
+	if len(m.labels) > 0 {
+		changed = m.config.recordReferences(referenceLabel, m.currentNames(referenceLabel)) || changed
+	}
+
+	current := m.currentSettings()
+	m.someday = newSomedaySettings(current.Someday)
+	if sweep, err := parseStaleSweep(current.StaleSweep, m.someday.label); err == nil {
+		m.staleSweep = sweep
+	}
+	if rules, err := compileFormatRules(current.FormatRules); err == nil {
+		m.formatRules = rules
+	}
+	if changed {
+		return saveConfig(*m.config)
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxReplacePreview is the number of changes listed in the find and replace preview
+const maxReplacePreview = 10
+
+// replaceField represents the input fields of the find and replace screen
+type replaceField int
+
+const (
+	replaceFieldFind replaceField = iota
+	replaceFieldWith
+)
+
+// Styles for the removed and added lines of the replace preview, set by applyTheme
+var (
+	diffRemovedStyle lipgloss.Style
+	diffAddedStyle   lipgloss.Style
+)
+
+// contentChange is a task whose content would be rewritten by find and replace
+type contentChange struct {
+	task       TodoistTask
+	newContent string
+}
+
+// tasksReplacedMsg is sent when find and replace has finished updating tasks
+type tasksReplacedMsg struct {
+	updated []TodoistTask
+	failed  int
+	err     error // First error encountered, if any task failed
+}
+
+// findPattern builds a case-insensitive pattern matching the literal search text
+func findPattern(find string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)" + regexp.QuoteMeta(find))
+}
+
+// planReplacements returns the tasks whose content contains the search text, with their rewritten content
+func planReplacements(tasks []TodoistTask, find, with string) []contentChange {
+	if find == "" {
+		return nil
+	}
+	pattern := findPattern(find)
+
+	var changes []contentChange
+	for _, task := range tasks {
+		if !pattern.MatchString(task.Content) {
+			continue
+		}
+		newContent := pattern.ReplaceAllLiteralString(task.Content, with)
+		// Replacing text with itself changes nothing
+		if newContent != task.Content {
+			changes = append(changes, contentChange{task: task, newContent: newContent})
+		}
+	}
+	return changes
+}
+
+// replaceInTasks creates a command that rewrites the content of each task
+// A failing task doesn't stop the others; the failures are reported together
+func replaceInTasks(ctx context.Context, client *TodoistClient, changes []contentChange) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		var result tasksReplacedMsg
+		for _, change := range changes {
+			updatedTask, err := client.UpdateTask(ctx, change.task.ID, UpdateTaskRequest{Content: change.newContent})
+			if err != nil {
+				result.failed++
+				if result.err == nil {
+					result.err = fmt.Errorf("failed to rename %q: %w", change.task.Content, err)
+				}
+				continue
+			}
+			result.updated = append(result.updated, *updatedTask)
+		}
+		return result
+	})
+}
+
+// openReplace shows the find and replace screen over all open tasks in the cache
+func (m *model) openReplace() tea.Cmd {
+	tasks, err := m.cache.LoadTasks()
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf("failed to load tasks for find and replace: %v", err))
+	}
+	m.showingReplace = true
+	m.replaceTasks = tasks
+	m.replaceFind = ""
+	m.replaceWith = ""
+	m.replaceField = replaceFieldFind
+	m.replaceConfirm = false
+	return nil
+}
+
+// replaceChanges returns the changes find and replace would currently make
+func (m model) replaceChanges() []contentChange {
+	return planReplacements(m.replaceTasks, m.replaceFind, m.replaceWith)
+}
+
+// renderReplaceScreen creates the find and replace screen with a preview of the changes
+func (m model) renderReplaceScreen() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🔁 Find and Replace"))
+	content.WriteString("\n\n")
+
+	// Input fields, with a cursor on the active one
+	fields := []struct {
+		label string
+		value string
+		field replaceField
+	}{
+		{"Find:    ", m.replaceFind, replaceFieldFind},
+		{"Replace: ", m.replaceWith, replaceFieldWith},
+	}
+	for _, f := range fields {
+		value := f.value
+		if f.field == m.replaceField && !m.replaceConfirm {
+			value += "│"
+		}
+		content.WriteString(popupFieldStyle.Render(f.label))
+		content.WriteString(value)
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Preview of the rewritten tasks
+	changes := m.replaceChanges()
+	switch {
+	case m.replaceFind == "":
+		content.WriteString("Type the text to find in task contents")
+	case len(changes) == 0:
+		content.WriteString("No open tasks match")
+	default:
+		content.WriteString(popupFieldStyle.Render(fmt.Sprintf("%d task(s) will change:", len(changes))))
+		content.WriteString("\n")
+		for i, change := range changes {
+			if i == maxReplacePreview {
+				content.WriteString(fmt.Sprintf("…and %d more\n", len(changes)-maxReplacePreview))
+				break
+			}
+			content.WriteString(diffRemovedStyle.Render("- " + change.task.Content))
+			content.WriteString("\n")
+			content.WriteString(diffAddedStyle.Render("+ " + change.newContent))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n\n")
+
+	// Instructions
+	if m.replaceConfirm {
+		content.WriteString(staleStyle.MarginLeft(0).Render(fmt.Sprintf("Rename %d task(s) in Todoist? y: confirm • n/ESC: back", len(changes))))
+	} else {
+		content.WriteString("Tab: switch field • Enter: review • ESC: close")
+	}
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleReplaceInput handles keyboard input when the find and replace screen is visible
+func (m model) handleReplaceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Confirmation step before anything is sent
+	if m.replaceConfirm {
+		switch msg.String() {
+		case "y", "Y":
+			changes := m.replaceChanges()
+			m.showingReplace = false
+			m.replaceConfirm = false
+			m.replaceTasks = nil
+
+			// Queue one update per task while offline
+			if m.offline {
+				var cmds []tea.Cmd
+				for _, change := range changes {
+					update := UpdateTaskRequest{Content: change.newContent}
+					op := newOperation(opUpdate, change.task.ID, change.task.Content, operationPayload{Update: &update})
+					cmds = append(cmds, queueOperation(m.cache, op))
+				}
+				return m, tea.Sequence(cmds...)
+			}
+			m.loading = true
+			return m, replaceInTasks(m.requests.base(), m.client, changes)
+		case "n", "N", "esc", "escape":
+			m.replaceConfirm = false
+		}
+		return m, nil
+	}
+
+	// Pick the field being typed into
+	value := &m.replaceFind
+	if m.replaceField == replaceFieldWith {
+		value = &m.replaceWith
+	}
+
+	switch msg.String() {
+	case "esc", "escape":
+		// Close without changing anything
+		m.showingReplace = false
+		m.replaceTasks = nil
+	case "tab", "shift+tab", "up", "down":
+		// Switch between the two fields
+		if m.replaceField == replaceFieldFind {
+			m.replaceField = replaceFieldWith
+		} else {
+			m.replaceField = replaceFieldFind
+		}
+	case "enter":
+		// Ask for confirmation when something would change
+		if len(m.replaceChanges()) > 0 {
+			m.replaceConfirm = true
+		}
+	case "backspace":
+		// Remove the last character of the active field
+		if len(*value) > 0 {
+			runes := []rune(*value)
+			*value = string(runes[:len(runes)-1])
+		}
+	default:
+		// Add typed characters to the active field
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			*value += msg.String()
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Request groups whose newer requests replace older ones still in flight
+const (
+	// requestGroupView covers loading the tasks of the active view
+	requestGroupView = "view"
+	// requestGroupRefresh covers background refreshes of the cache
+	requestGroupRefresh = "refresh"
+)
+
+// requestContexts hands out the contexts API requests run under
+// It is shared by every copy of the model, so a request started from any handler can be cancelled later
+type requestContexts struct {
+	// root is cancelled when the app quits, stopping every request
+	root   context.Context
+	cancel context.CancelFunc
+
+	// mu guards latest, which is used from concurrent handlers
+	mu sync.Mutex
+	// latest holds the cancel function of the newest request in each group
+	latest map[string]context.CancelFunc
+}
+
+// newRequestContexts creates the request contexts for a new app session
+func newRequestContexts() *requestContexts {
+	root, cancel := context.WithCancel(context.Background())
+	return &requestContexts{
+		root:   root,
+		cancel: cancel,
+		latest: make(map[string]context.CancelFunc),
+	}
+}
+
+// base returns the context for one-off requests, which only stop when the app quits
+func (r *requestContexts) base() context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.root
+}
+
+// replace cancels the group's request still in flight and returns the context for its replacement
+func (r *requestContexts) replace(group string) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cancel, ok := r.latest[group]; ok {
+		cancel()
+	}
+	ctx, cancel := context.WithCancel(r.root)
+	r.latest[group] = cancel
+	return ctx
+}
+
+// stop cancels every request in flight, used when the app quits
+func (r *requestContexts) stop() {
+	if r != nil {
+		r.cancel()
+	}
+}
+
+// isCanceled reports whether an error comes from a request that was cancelled on purpose
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reschedulePreset is a quick due date choice in the reschedule popup
+type reschedulePreset struct {
+	// label is shown in the popup
+	label string
+	// dueString is sent to Todoist, which parses it like the quick add field
+	// When empty, the preset is a day relative to the app's today, sent as an explicit date
+	dueString string
+	// days is how many days after the app's today a preset without a due string is
+	days int
+}
+
+// due returns the due string the preset sends to Todoist
+func (p reschedulePreset) due() string {
+	if p.dueString == "" {
+		return dayDate(p.days)
+	}
+	return p.dueString
+}
+
+// reschedulePresets are the choices offered by the reschedule popup, followed by a custom entry
+var reschedulePresets = []reschedulePreset{
+	{label: "Today", days: 0},
+	{label: "Tomorrow", days: 1},
+	{label: "Next week", dueString: "next monday"},
+	{label: "Weekend", dueString: "saturday"},
+}
+
+// UpdateTaskDue changes the due date of a task using a human-readable date string
+func (c *TodoistClient) UpdateTaskDue(ctx context.Context, taskID, dueString string) (*TodoistTask, error) {
+	return c.UpdateTask(ctx, taskID, UpdateTaskRequest{DueString: dueString})
+}
+
+// rescheduleTask creates a command that moves a task to a new due date
+func rescheduleTask(ctx context.Context, client *TodoistClient, taskID, dueString string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		updatedTask, err := client.UpdateTaskDue(ctx, taskID, dueString)
+		if err != nil {
+			// Return error message if API call fails
+			return errorMsg(err)
+		}
+		// Reuse the edit flow to refresh the list
+		return taskUpdatedMsg(*updatedTask)
+	})
+}
+
+// openReschedule shows the reschedule popup for a task
+func (m *model) openReschedule(task TodoistTask) {
+	m.showingReschedule = true
+	m.rescheduleTaskID = task.ID
+	m.rescheduleIdx = 0
+	m.rescheduleCustom = ""
+}
+
+// isCustomReschedule reports whether the custom date entry is selected
+func (m model) isCustomReschedule() bool {
+	return m.rescheduleIdx == len(reschedulePresets)
+}
+
+// renderReschedule creates the popup for picking a new due date
+func (m model) renderReschedule() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("📆 Reschedule"))
+	content.WriteString("\n\n")
+	if m.rescheduleTaskID == "" {
+		// Rescheduling the tasks selected in visual-select mode
+		content.WriteString(fmt.Sprintf("%d selected task(s)", len(m.markedTaskIDs())))
+	} else {
+		content.WriteString(m.taskSummary(m.rescheduleTaskID))
+	}
+	content.WriteString("\n\n")
+
+	// Preset choices followed by the custom entry
+	selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+	for i, preset := range reschedulePresets {
+		if i == m.rescheduleIdx {
+			content.WriteString(selected.Render("→ " + preset.label))
+		} else {
+			content.WriteString("  " + preset.label)
+		}
+		content.WriteString("\n")
+	}
+	custom := "Custom: " + m.rescheduleCustom
+	if m.isCustomReschedule() {
+		content.WriteString(selected.Render("→ " + custom + "│"))
+	} else {
+		content.WriteString("  " + custom)
+	}
+	content.WriteString("\n\n")
+
+	// Instructions
+	content.WriteString("↑/↓: select • type a date for custom (e.g. \"fri 5pm\") • Enter: reschedule • ESC: cancel")
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleRescheduleInput handles keyboard input when the reschedule popup is visible
+func (m model) handleRescheduleInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the popup without changing the task
+		m.showingReschedule = false
+	case "up":
+		// Move selection up with wrap around
+		if m.rescheduleIdx <= 0 {
+			m.rescheduleIdx = len(reschedulePresets)
+		} else {
+			m.rescheduleIdx--
+		}
+	case "down", "tab":
+		// Move selection down with wrap around
+		if m.rescheduleIdx >= len(reschedulePresets) {
+			m.rescheduleIdx = 0
+		} else {
+			m.rescheduleIdx++
+		}
+	case "backspace":
+		// Remove the last character of the custom date
+		if m.isCustomReschedule() && len(m.rescheduleCustom) > 0 {
+			m.rescheduleCustom = m.rescheduleCustom[:len(m.rescheduleCustom)-1]
+		}
+	case "enter":
+		// Send the chosen due date
+		dueString := strings.TrimSpace(m.rescheduleCustom)
+		label := dueString
+		if !m.isCustomReschedule() {
+			dueString = reschedulePresets[m.rescheduleIdx].due()
+			label = strings.ToLower(reschedulePresets[m.rescheduleIdx].label)
+		}
+		if dueString == "" {
+			return m, nil
+		}
+		m.showingReschedule = false
+		if m.rescheduleTaskID == "" {
+			m.confirmBulk(bulkReschedule, dueString, label)
+			return m, nil
+		}
+		update := UpdateTaskRequest{DueString: dueString}
+		op := newOperation(opUpdate, m.rescheduleTaskID, m.taskSummary(m.rescheduleTaskID), operationPayload{Update: &update})
+		return m, m.writeOrQueue(op, rescheduleTask(m.requests.base(), m.client, m.rescheduleTaskID, dueString))
+	default:
+		// Typing goes into the custom date, selecting it
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.rescheduleIdx = len(reschedulePresets)
+			m.rescheduleCustom += msg.String()
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultMaxRetries is how many times a rate limited or failed request is retried when not configured
+const defaultMaxRetries = 3
+
+// Backoff between retries of a failed request, doubling from the base up to the cap
+const (
+	retryBaseDelay = 500 * time.Millisecond
+	retryMaxDelay  = 8 * time.Second
+	// retryAfterCap keeps a long Retry-After from freezing a command for minutes
+	retryAfterCap = time.Minute
+)
+
+// maxRetries returns the configured number of retries, or the default when not set
+func (c *Config) maxRetries() int {
+	if c == nil || c.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+	return max(*c.MaxRetries, 0)
+}
+
+// isRetryableStatus reports whether a response status is worth retrying
+// 429 means the rate limit was hit, and these 5xx codes are usually transient
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay works out how long to wait before retrying a request
+// A Retry-After header is respected; otherwise the backoff doubles each attempt, with jitter
+// so concurrent commands don't retry in lockstep
+func retryDelay(resp *http.Response, attempt int) time.Duration {
+	if after, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		return min(after, retryAfterCap)
+	}
+	backoff := min(retryBaseDelay<<attempt, retryMaxDelay)
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// requestIDKey keys the X-Request-Id a write is sent with in its context
+type requestIDKey struct{}
+
+// withRequestID sends the writes made with a context under a fixed X-Request-Id, so Todoist drops
+// a write sent again later, e.g. from the offline queue, that had already got through
+func withRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// sendWithRetries sends a request, retrying rate limited and transient server failures
+// The response of the last attempt is returned, so callers report its status as before
+func (c *TodoistClient) sendWithRetries(req *http.Request) (*http.Response, error) {
+	// Let Todoist drop duplicates if a retried write was already applied
+	if req.Method == http.MethodPost && req.Header.Get("X-Request-Id") == "" {
+		requestID, ok := req.Context().Value(requestIDKey{}).(string)
+		if !ok {
+			requestID = newUUID()
+		}
+		req.Header.Set("X-Request-Id", requestID)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(req)
+		if err != nil || !isRetryableStatus(resp.StatusCode) || attempt >= c.maxRetries {
+			return resp, err
+		}
+
+		// A request body can only be resent if it can be recreated
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+		delay := retryDelay(resp, attempt)
+		_ = resp.Body.Close()
+
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, fmt.Errorf("gave up retrying: %w", req.Context().Err())
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resend request body: %w", err)
+			}
+			req.Body = body
+		}
+	}
+}
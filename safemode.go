@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// safeModeConfig returns the default config used by --safe-mode
+// Nothing is read from the config file, so no rules, hooks, theme overrides or announce commands run
+func safeModeConfig() *Config {
+	return &Config{safeMode: true}
+}
+
+// NewTempCacheDB creates an empty cache in a temporary directory, removed when the cache is closed
+func NewTempCacheDB() (*CacheDB, error) {
+	dir, err := os.MkdirTemp("", "todoist-tui-safe-mode-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary cache directory: %w", err)
+	}
+
+	cache, err := openCacheDB(filepath.Join(dir, "cache.db"))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	cache.tempDir = dir
+	return cache, nil
+}
+
+// newCacheFor opens the active profile's cache, or a throwaway one in safe mode so the existing cache is neither read nor changed
+func newCacheFor(config *Config) (*CacheDB, error) {
+	if config.safeMode {
+		return NewTempCacheDB()
+	}
+	return NewCacheDB(config.profile)
+}
+
+// renderSafeModeBanner renders the notice that the app runs without the user's config and cache
+func (m model) renderSafeModeBanner() string {
+	// The tutorial runs in safe mode too, but shows its own prompt instead
+	if m.config == nil || !m.config.safeMode || m.tutorial {
+		return ""
+	}
+	return staleStyle.Render("🛟 Safe mode: default config, empty cache. Settings changed now are not saved")
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSearchResults is the number of search results shown at once
+const maxSearchResults = 15
+
+// searchTasks returns the tasks whose content or description matches the query
+// In regex mode the query is a Go regular expression; otherwise it is a case-insensitive substring
+func searchTasks(tasks []TodoistTask, query string, useRegex bool) ([]TodoistTask, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	matches := func(text string) bool {
+		return strings.Contains(strings.ToLower(text), strings.ToLower(query))
+	}
+	if useRegex {
+		pattern, err := regexp.Compile(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		matches = pattern.MatchString
+	}
+
+	var results []TodoistTask
+	for _, task := range tasks {
+		if matches(task.Content) || matches(task.Description) {
+			results = append(results, task)
+		}
+	}
+	return results, nil
+}
+
+// openSearch shows the search screen over all open tasks in the cache
+func (m *model) openSearch() tea.Cmd {
+	tasks, err := m.cache.LoadTasks()
+	if err != nil {
+		return m.notify(toastError, fmt.Sprintf("failed to load tasks for search: %v", err))
+	}
+	m.showingSearch = true
+	m.searchTasks = tasks
+	m.searchQuery = ""
+	m.searchIdx = 0
+	return nil
+}
+
+// searchResults returns the tasks matching the current search
+func (m model) searchResults() ([]TodoistTask, error) {
+	return searchTasks(m.searchTasks, m.searchQuery, m.searchRegex)
+}
+
+// renderSearchScreen creates the search screen listing matching tasks
+func (m model) renderSearchScreen() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🔍 Search Tasks"))
+	content.WriteString("\n\n")
+
+	// Query with the active mode
+	mode := "text"
+	if m.searchRegex {
+		mode = "regex"
+	}
+	content.WriteString(popupFieldStyle.Render(fmt.Sprintf("Search (%s): ", mode)))
+	content.WriteString(m.searchQuery + "│")
+	content.WriteString("\n\n")
+
+	results, err := m.searchResults()
+	switch {
+	case err != nil:
+		content.WriteString(errorStyle.MarginLeft(0).Render(err.Error()))
+	case m.searchQuery == "":
+		content.WriteString("Type to search task contents and descriptions")
+	case len(results) == 0:
+		content.WriteString("No open tasks match")
+	default:
+		content.WriteString(fmt.Sprintf("%d match(es)", len(results)))
+		content.WriteString("\n")
+
+		// Keep the selected result in the visible window
+		start := max(0, m.searchIdx-maxSearchResults+1)
+		end := min(len(results), start+maxSearchResults)
+		selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+		for i := start; i < end; i++ {
+			task := results[i]
+			line := fmt.Sprintf("%s (%s)", task.Content, m.client.GetProjectName(task.ProjectID))
+			if i == m.searchIdx {
+				content.WriteString(selected.Render("→ " + line))
+			} else {
+				content.WriteString("  " + line)
+			}
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n\n")
+
+	// Instructions
+	content.WriteString("↑/↓: select • Enter: jump to task • Ctrl+R: toggle regex • ESC: close")
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleSearchInput handles keyboard input when the search screen is visible
+func (m model) handleSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	results, _ := m.searchResults()
+
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the search screen
+		m.showingSearch = false
+		m.searchTasks = nil
+	case "ctrl+r":
+		// Switch between text and regex matching
+		m.searchRegex = !m.searchRegex
+		m.searchIdx = 0
+	case "up":
+		// Move selection up
+		if m.searchIdx > 0 {
+			m.searchIdx--
+		}
+	case "down":
+		// Move selection down
+		if m.searchIdx < len(results)-1 {
+			m.searchIdx++
+		}
+	case "enter":
+		// Jump to the selected result
+		if m.searchIdx >= 0 && m.searchIdx < len(results) {
+			task := results[m.searchIdx]
+			m.showingSearch = false
+			m.searchTasks = nil
+			return m, m.jumpToTask(task.ID, task.ProjectID)
+		}
+	case "backspace":
+		// Remove the last character of the query
+		if len(m.searchQuery) > 0 {
+			runes := []rune(m.searchQuery)
+			m.searchQuery = string(runes[:len(runes)-1])
+			m.searchIdx = 0
+		}
+	default:
+		// Add typed characters to the query
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.searchQuery += msg.String()
+			m.searchIdx = 0
+		}
+	}
+	return m, nil
+}
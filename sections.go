@@ -0,0 +1,218 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TodoistSection represents a section within a Todoist project
+type TodoistSection struct {
+	// ID is the unique identifier for the section
+	ID string `json:"id"`
+	// ProjectID is the ID of the project containing this section
+	ProjectID string `json:"project_id"`
+	// Order is the section's position within its project
+	Order int `json:"order"`
+	// Name is the section name
+	Name string `json:"name"`
+}
+
+// sectionsLoadedMsg is sent when the sections of all projects have been fetched
+// Failing to load sections only disables grouping and the section picker, so the error is kept separate
+type sectionsLoadedMsg struct {
+	sections []TodoistSection
+	err      error
+}
+
+// GetSections fetches the sections of all projects from Todoist
+func (c *TodoistClient) GetSections(ctx context.Context) ([]TodoistSection, error) {
+	// Create HTTP GET request for sections endpoint
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiBase+"/sections", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// A conditional request is answered without data when nothing changed
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+
+	// Check for successful response
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse JSON response into sections slice, one section at a time
+	sections := []TodoistSection{}
+	if err := decodeJSONArray(resp.Body, func(section TodoistSection) { sections = append(sections, section) }); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return sections, nil
+}
+
+// MoveTaskToSection moves a task into a section, which also moves it to the section's project
+func (c *TodoistClient) MoveTaskToSection(ctx context.Context, taskID, sectionID string) error {
+	return c.runSyncCommands(ctx, []syncCommand{
+		newSyncCommand("item_move", map[string]any{
+			"id":         taskID,
+			"section_id": sectionID,
+		}),
+	})
+}
+
+// PlaceTask moves a task to the given project and section when either differs from where it is
+// An empty projectID leaves the task where it is; an empty sectionID places it outside any section
+func (c *TodoistClient) PlaceTask(ctx context.Context, task *TodoistTask, projectID, sectionID string) error {
+	if projectID == "" || (projectID == task.ProjectID && sectionID == task.SectionID) {
+		return nil
+	}
+
+	if sectionID != "" {
+		if err := c.MoveTaskToSection(ctx, task.ID, sectionID); err != nil {
+			return err
+		}
+	} else if err := c.MoveTask(ctx, task.ID, projectID); err != nil {
+		// Moving to a project drops the task out of its section, even within the same project
+		return err
+	}
+	task.ProjectID = projectID
+	task.SectionID = sectionID
+	return nil
+}
+
+// loadSections creates a command that fetches the sections in the background
+func loadSections(ctx context.Context, client *TodoistClient) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		sections, err := client.GetSections(ctx)
+		if err != nil {
+			return sectionsLoadedMsg{err: fmt.Errorf("failed to load sections: %w", err)}
+		}
+		return sectionsLoadedMsg{sections: sections}
+	})
+}
+
+// projectSections returns the sections of a project in their Todoist order
+func (m model) projectSections(projectID string) []TodoistSection {
+	var sections []TodoistSection
+	for _, section := range m.sections {
+		if section.ProjectID == projectID {
+			sections = append(sections, section)
+		}
+	}
+	sort.SliceStable(sections, func(i, j int) bool {
+		return sections[i].Order < sections[j].Order
+	})
+	return sections
+}
+
+// sectionName returns the name of a section, or an empty string when it is unknown
+func (m model) sectionName(sectionID string) string {
+	for _, section := range m.sections {
+		if section.ID == sectionID {
+			return section.Name
+		}
+	}
+	return ""
+}
+
+// sortBySection orders a project's tasks by section, with tasks outside any section first
+// Tasks keep their relative order within a section
+func (m model) sortBySection(tasks []TodoistTask) []TodoistTask {
+	order := make(map[string]int)
+	for _, section := range m.sections {
+		order[section.ID] = section.Order
+	}
+	rank := func(task TodoistTask) int {
+		if task.SectionID == "" {
+			return -1
+		}
+		if o, ok := order[task.SectionID]; ok {
+			return o
+		}
+		// Sections that haven't loaded go last
+		return math.MaxInt
+	}
+
+	sorted := append([]TodoistTask(nil), tasks...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return rank(sorted[i]) < rank(sorted[j])
+	})
+	return sorted
+}
+
+// renderSectionHeader renders the header above a section's tasks in the project view
+func (m model) renderSectionHeader(sectionID string) string {
+	name := m.sectionName(sectionID)
+	if sectionID == "" {
+		name = "No section"
+	} else if name == "" {
+		name = "Unknown section"
+	}
+	return titleStyle.Render("§ " + name)
+}
+
+// formSectionID returns the section picked in the task form, if it belongs to the picked project
+func (m model) formSectionID() string {
+	form := m.createTaskForm
+	for _, section := range m.projectSections(form.projectID) {
+		if section.ID == form.sectionID {
+			return section.ID
+		}
+	}
+	return ""
+}
+
+// cycleFormSection selects the next or previous section of the picked project, including no section
+func (m *model) cycleFormSection(delta int) {
+	sections := m.projectSections(m.createTaskForm.projectID)
+	if len(sections) == 0 {
+		m.createTaskForm.sectionID = ""
+		return
+	}
+
+	// Option 0 is no section, followed by the project's sections
+	current := 0
+	for i, section := range sections {
+		if section.ID == m.formSectionID() {
+			current = i + 1
+		}
+	}
+	next := (current + delta + len(sections) + 1) % (len(sections) + 1)
+	if next == 0 {
+		m.createTaskForm.sectionID = ""
+	} else {
+		m.createTaskForm.sectionID = sections[next-1].ID
+	}
+}
+
+// renderSectionField renders the value of the section field in the task form
+func (m model) renderSectionField(active bool) string {
+	sections := m.projectSections(m.createTaskForm.projectID)
+	name := "(none)"
+	if id := m.formSectionID(); id != "" {
+		name = m.sectionName(id)
+	}
+	if !active {
+		return name
+	}
+	if len(sections) == 0 {
+		return "No sections in this project"
+	}
+	return fmt.Sprintf("◀ %s ▶ (%d sections)", name, len(sections))
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// sessionCounts counts the tasks created and rescheduled in the app during a run
+// Completions and focus time come from the history in the cache instead
+type sessionCounts struct {
+	created     int
+	rescheduled int
+}
+
+// noteUpdate counts an update of a task as a reschedule when its due date or time changed
+func (s *sessionCounts) noteUpdate(before, after TodoistTask) {
+	if dueKey(before.Due) != dueKey(after.Due) {
+		s.rescheduled++
+	}
+}
+
+// dueKey identifies a due date and time for comparing them
+func dueKey(due *Due) string {
+	if due == nil {
+		return ""
+	}
+	return due.Date + " " + due.Datetime
+}
+
+// sessionSummary sums up what was done since the app started, across profile switches
+type sessionSummary struct {
+	started     time.Time
+	completed   int
+	created     int
+	rescheduled int
+	// longestFocus is the longest pomodoro or tracked stretch of time, with its task
+	longestFocus     time.Duration
+	longestFocusTask string
+}
+
+// CompletionsSince counts the tasks completed since a time, in the app or seen completed by a sync
+func (c *CacheDB) CompletionsSince(since time.Time) (int, error) {
+	// The day narrows the rows down; completion times are compared parsed, since their offsets can differ
+	rows, err := c.db.Query(`SELECT completed_at FROM completion_history WHERE day >= ?`, since.Local().Format("2006-01-02"))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	count := 0
+	for rows.Next() {
+		var completedAt string
+		if err := rows.Scan(&completedAt); err != nil {
+			return 0, err
+		}
+		if completed, err := time.Parse(time.RFC3339, completedAt); err == nil && !completed.Before(since) {
+			count++
+		}
+	}
+	return count, rows.Err()
+}
+
+// LongestFocusSince returns the longest pomodoro or tracked stretch of time since a time, and its task
+// A stretch that started earlier counts from that time, and one still running counts until now
+func (c *CacheDB) LongestFocusSince(since, now time.Time) (time.Duration, string, error) {
+	rows, err := c.db.Query(`
+		SELECT content, started_at, completed_at FROM pomodoros
+		UNION ALL
+		SELECT content, started_at, ended_at FROM time_entries
+	`)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var longest time.Duration
+	var task string
+	for rows.Next() {
+		var content, startedAt, endedAt string
+		if err := rows.Scan(&content, &startedAt, &endedAt); err != nil {
+			return 0, "", err
+		}
+		started, err := time.Parse(time.RFC3339, startedAt)
+		if err != nil {
+			continue
+		}
+		ended := now
+		if endedAt != "" {
+			if ended, err = time.Parse(time.RFC3339, endedAt); err != nil {
+				continue
+			}
+		}
+		if started.Before(since) {
+			started = since
+		}
+		if focus := ended.Sub(started); focus > longest {
+			longest, task = focus, content
+		}
+	}
+	return longest, task, rows.Err()
+}
+
+// add counts a finished run of the app into the summary, reading its completions and focus time from the cache
+// Each run after a profile switch uses the cache of its profile, and counts from when it started
+func (s *sessionSummary) add(counts sessionCounts, cache *CacheDB, runStarted time.Time) error {
+	s.created += counts.created
+	s.rescheduled += counts.rescheduled
+	if cache == nil {
+		return nil
+	}
+
+	completed, err := cache.CompletionsSince(runStarted)
+	if err != nil {
+		return fmt.Errorf("failed to count completed tasks: %w", err)
+	}
+	s.completed += completed
+	focus, task, err := cache.LongestFocusSince(runStarted, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to find the longest focus session: %w", err)
+	}
+	if focus > s.longestFocus {
+		s.longestFocus, s.longestFocusTask = focus, task
+	}
+	return nil
+}
+
+// String writes the summary on one line, e.g. "Session 1h05m • 3 completed • 1 created • 2 rescheduled • longest focus 25m (Write the report)"
+func (s sessionSummary) String() string {
+	parts := []string{
+		"Session " + formatDuration(time.Since(s.started).Truncate(time.Minute)),
+		fmt.Sprintf("%d completed", s.completed),
+		fmt.Sprintf("%d created", s.created),
+		fmt.Sprintf("%d rescheduled", s.rescheduled),
+	}
+	if s.longestFocus >= time.Minute {
+		parts = append(parts, fmt.Sprintf("longest focus %s (%s)", formatDuration(s.longestFocus.Truncate(time.Minute)), s.longestFocusTask))
+	}
+	return strings.Join(parts, " • ")
+}
+
+// reportSession prints the summary after the app exits, or appends it to the file named by session_summary
+// "off" reports nothing
+func reportSession(s sessionSummary, setting string, stdout io.Writer) error {
+	switch setting {
+	case "off":
+		return nil
+	case "":
+		_, err := fmt.Fprintln(stdout, s.String())
+		return err
+	}
+
+	file, err := os.OpenFile(setting, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to open session log: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	_, err = fmt.Fprintf(file, "%s %s\n", s.started.Format(time.RFC3339), s.String())
+	return err
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SkipOccurrence advances a recurring task to its next occurrence without completing it
+// The REST API only offers closing the task, which is recorded as a completion,
+// so this goes through the Sync API's date update instead
+func (c *TodoistClient) SkipOccurrence(ctx context.Context, task TodoistTask) error {
+	if task.Due == nil || !task.Due.IsRecurring {
+		return fmt.Errorf("task %q is not recurring", task.Content)
+	}
+
+	// The current due date is the base the next occurrence is calculated from
+	due := map[string]any{
+		"date":   task.Due.Date,
+		"string": task.Due.String,
+	}
+	if task.Due.Datetime != "" {
+		due["date"] = task.Due.Datetime
+	}
+	if task.Due.Timezone != "" {
+		due["timezone"] = task.Due.Timezone
+	}
+
+	return c.runSyncCommands(ctx, []syncCommand{
+		newSyncCommand("item_update_date_complete", map[string]any{
+			"id":             task.ID,
+			"due":            due,
+			"is_forward":     1,
+			"reset_subtasks": 0,
+		}),
+	})
+}
+
+// skipOccurrence creates a command that skips a recurring task's current occurrence
+// Returns the task with its new due date so the list updates like after an edit
+func skipOccurrence(ctx context.Context, client *TodoistClient, task TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := client.SkipOccurrence(ctx, task); err != nil {
+			return errorMsg(fmt.Errorf("failed to skip occurrence: %w", err))
+		}
+
+		// Fetch the task again to pick up the next due date
+		tasks, err := client.GetTasksByIDs(ctx, []string{task.ID})
+		if err != nil {
+			return errorMsg(err)
+		}
+		if len(tasks) == 0 {
+			return errorMsg(fmt.Errorf("task %q not found after skipping", task.Content))
+		}
+		return taskUpdatedMsg(tasks[0])
+	})
+}
+
+// isRecurring reports whether a task repeats
+func isRecurring(task TodoistTask) bool {
+	return task.Due != nil && task.Due.IsRecurring
+}
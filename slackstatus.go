@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// slackProfileSetURL is the Slack Web API method that sets the status of the token's user
+const slackProfileSetURL = "https://slack.com/api/users.profile.set"
+
+// slackFocusEmoji is the status emoji shown while a pomodoro runs, 🔴
+const slackFocusEmoji = ":red_circle:"
+
+// slackStatusTimeout bounds how long setting or clearing the status may take
+const slackStatusTimeout = 10 * time.Second
+
+// SlackStatusConfig sets a "focusing until HH:MM" Slack status while a pomodoro runs
+// Values may read environment variables written as ${NAME}, so secrets can stay out of the config file
+type SlackStatusConfig struct {
+	// Token is a Slack user token with the users.profile:write scope, setting the status through the Web API
+	Token string `json:"token,omitempty"`
+	// WebhookURL receives the status as JSON instead, e.g. a Slack workflow or a relay setting it
+	WebhookURL string `json:"webhook_url,omitempty"`
+}
+
+// slackStatus is a Slack status, empty to clear it
+type slackStatus struct {
+	Text  string `json:"status_text"`
+	Emoji string `json:"status_emoji"`
+	// Expiration is when Slack clears the status by itself as a Unix time, 0 for never
+	Expiration int64 `json:"status_expiration"`
+}
+
+// slackStatusMsg is sent when the Slack status was set or cleared
+type slackStatusMsg struct {
+	err error
+}
+
+// validateSlackStatus checks that the Slack status has either a token or a webhook URL
+func validateSlackStatus(s *SlackStatusConfig) error {
+	if s == nil {
+		return nil
+	}
+	switch {
+	case s.Token == "" && s.WebhookURL == "":
+		return fmt.Errorf("set token or webhook_url")
+	case s.Token != "" && s.WebhookURL != "":
+		return fmt.Errorf("set either token or webhook_url, not both")
+	case s.WebhookURL != "":
+		if parsed, err := url.Parse(os.ExpandEnv(s.WebhookURL)); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return fmt.Errorf("webhook_url %q must be an http or https URL", s.WebhookURL)
+		}
+	}
+	return nil
+}
+
+// slackFocusStatus returns the Slack status settings to use, or nil when the status is left alone:
+// when none are configured, and in read-only and safe mode, which change nothing outside the app
+func (c *Config) slackFocusStatus() *SlackStatusConfig {
+	if c == nil || c.safeMode || c.isReadOnly() {
+		return nil
+	}
+	return c.SlackStatus
+}
+
+// focusStatus returns the status shown while a pomodoro runs until the given time
+// It expires then too, so it's cleared even if the app is killed before clearing it
+func focusStatus(ends time.Time) slackStatus {
+	return slackStatus{Text: "focusing until " + ends.Format("15:04"), Emoji: slackFocusEmoji, Expiration: ends.Unix()}
+}
+
+// setSlackStatus sets or clears the Slack status through the Web API or the webhook
+func setSlackStatus(ctx context.Context, s *SlackStatusConfig, status slackStatus) error {
+	ctx, cancel := context.WithTimeout(ctx, slackStatusTimeout)
+	defer cancel()
+
+	// Build the request
+	var req *http.Request
+	var err error
+	if s.Token != "" {
+		req, err = newJSONRequest(ctx, slackProfileSetURL, map[string]any{"profile": status})
+		if err == nil {
+			req.Header.Set("Authorization", "Bearer "+os.ExpandEnv(s.Token))
+		}
+	} else {
+		req, err = newJSONRequest(ctx, os.ExpandEnv(s.WebhookURL), status)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Send it outside the Todoist client, so its headers and request log stay with Todoist
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("slack request failed with status %d", resp.StatusCode)
+	}
+	if s.Token == "" {
+		return nil
+	}
+
+	// The Web API answers 200 OK to failures too, telling them apart in the body
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack refused the status: %s", result.Error)
+	}
+	return nil
+}
+
+// updateSlackStatus creates a command that sets or clears the Slack status
+func updateSlackStatus(ctx context.Context, s *SlackStatusConfig, status slackStatus) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		return slackStatusMsg{err: setSlackStatus(ctx, s, status)}
+	})
+}
+
+// sendSlackStatus sets or clears the Slack status once the update on its way, if any, has arrived, so a status
+// cleared and set again right away never ends up cleared; only the latest of the waiting statuses is sent
+func (m *model) sendSlackStatus(status slackStatus) tea.Cmd {
+	s := m.config.slackFocusStatus()
+	if s == nil {
+		return nil
+	}
+	if m.slackStatusSending {
+		m.slackStatusNext = &status
+		return nil
+	}
+	m.slackStatusSending = true
+	return updateSlackStatus(m.requests.base(), s, status)
+}
+
+// handleSlackStatus sends the status that waited for this one, and reports a status Slack couldn't be given;
+// the pomodoro runs on either way
+func (m *model) handleSlackStatus(msg slackStatusMsg) tea.Cmd {
+	m.slackStatusSending = false
+	var next tea.Cmd
+	if m.slackStatusNext != nil {
+		status := *m.slackStatusNext
+		m.slackStatusNext = nil
+		next = m.sendSlackStatus(status)
+	}
+	if msg.err == nil || isCanceled(msg.err) {
+		return next
+	}
+	return tea.Batch(m.notify(toastError, fmt.Sprintf("Couldn't update the Slack status: %v", msg.err)), next)
+}
+
+// clearSlackStatusOnExit clears the focus status of a pomodoro still running when the app quits
+// Runs after the interface is gone, so it waits for Slack; failures are printed like other exit errors
+// Not called when switching profiles, which keeps the pomodoro running
+func clearSlackStatusOnExit(final model) {
+	s := final.config.slackFocusStatus()
+	if s == nil || final.pomodoro == nil {
+		return
+	}
+	if err := setSlackStatus(context.Background(), s, slackStatus{}); err != nil {
+		fmt.Printf("Error clearing Slack status: %v\n", err)
+	}
+}
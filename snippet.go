@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// snippetCopiedMsg is sent when tasks have been copied to the clipboard as a Markdown checklist
+type snippetCopiedMsg struct {
+	count int
+	err   error
+}
+
+// taskWebURL returns the link to a task in Todoist, falling back to one built from its ID
+// Tasks created optimistically have no URL until Todoist answers
+func taskWebURL(task TodoistTask) string {
+	if task.URL != "" {
+		return task.URL
+	}
+	return "https://todoist.com/showTask?id=" + task.ID
+}
+
+// markdownSnippet renders tasks as a Markdown checklist linking back to Todoist, for meeting notes,
+// PR descriptions or standup messages
+// Subtasks copied along with their parent are indented under it
+func markdownSnippet(tasks []TodoistTask, client *TodoistClient) string {
+	copied := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		copied[task.ID] = true
+	}
+	depths := make(map[string]int, len(tasks))
+
+	var b strings.Builder
+	for _, task := range tasks {
+		depth := 0
+		if copied[task.ParentID] {
+			depth = depths[task.ParentID] + 1
+		}
+		depths[task.ID] = depth
+
+		check := " "
+		if task.IsCompleted {
+			check = "x"
+		}
+		// Content that already holds a Markdown link can't be wrapped in another one
+		item := fmt.Sprintf("[%s](%s)", task.Content, taskWebURL(task))
+		if strings.Contains(task.Content, "](") {
+			item = fmt.Sprintf("%s ([Todoist](%s))", task.Content, taskWebURL(task))
+		}
+
+		var details []string
+		if task.Due != nil {
+			details = append(details, "due "+task.Due.Date)
+		}
+		if task.ProjectID != "" {
+			details = append(details, client.GetProjectName(task.ProjectID))
+		}
+		for _, label := range task.Labels {
+			details = append(details, "@"+label)
+		}
+
+		b.WriteString(fmt.Sprintf("%s- [%s] %s", strings.Repeat("  ", depth), check, item))
+		if len(details) > 0 {
+			b.WriteString(" — " + strings.Join(details, " · "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// copySnippet creates a command that copies tasks to the clipboard as a Markdown checklist
+func copySnippet(tasks []TodoistTask, client *TodoistClient) tea.Cmd {
+	snippet := markdownSnippet(tasks, client)
+	return tea.Cmd(func() tea.Msg {
+		return snippetCopiedMsg{count: len(tasks), err: clipboard.WriteAll(snippet)}
+	})
+}
+
+// copySelectedSnippet copies the tasks selected in visual-select mode, or else the selected task
+func (m model) copySelectedSnippet() tea.Cmd {
+	var tasks []TodoistTask
+	if m.hasMarkedTasks() {
+		for _, id := range m.markedTaskIDs() {
+			if task, ok := m.localTask(id); ok {
+				tasks = append(tasks, task)
+			}
+		}
+	} else if m.selectedIndex >= 0 && m.selectedIndex < len(m.allTasks) {
+		tasks = append(tasks, m.allTasks[m.selectedIndex])
+	}
+	if len(tasks) == 0 {
+		return nil
+	}
+	return copySnippet(tasks, m.client)
+}
+
+// handleSnippetCopied reports whether the tasks made it to the clipboard
+func (m *model) handleSnippetCopied(msg snippetCopiedMsg) tea.Cmd {
+	if msg.err != nil {
+		return m.notify(toastError, fmt.Sprintf("Couldn't copy to the clipboard: %v", msg.err))
+	}
+	return m.notify(toastSuccess, fmt.Sprintf("Copied %d task(s) as a Markdown checklist", msg.count))
+}
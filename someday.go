@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// defaultSomedayLabel is the label of someday/maybe tasks unless the config names another
+const defaultSomedayLabel = "someday"
+
+// SomedayConfig sets which tasks make up the someday/maybe backlog
+type SomedayConfig struct {
+	// Label is the label of someday tasks, "someday" by default
+	Label string `json:"label,omitempty"`
+	// Project is the name or ID of a backlog project whose tasks are all someday tasks
+	Project string `json:"project,omitempty"`
+}
+
+// somedaySettings is the someday/maybe backlog's label and backlog project
+type somedaySettings struct {
+	label string
+	// project is the backlog project's name or ID, "" when there's none
+	project string
+}
+
+// newSomedaySettings fills in the defaults of the configured someday backlog
+func newSomedaySettings(config *SomedayConfig) somedaySettings {
+	settings := somedaySettings{label: defaultSomedayLabel}
+	if config == nil {
+		return settings
+	}
+	if label := strings.TrimPrefix(strings.TrimSpace(config.Label), "@"); label != "" {
+		settings.label = label
+	}
+	settings.project = strings.TrimSpace(config.Project)
+	return settings
+}
+
+// somedayTasksLoadedMsg is sent with the tasks of the someday/maybe backlog
+type somedayTasksLoadedMsg []TodoistTask
+
+// somedayMovedMsg is sent when a task was promoted out of the backlog, or demoted into it
+type somedayMovedMsg struct {
+	task     TodoistTask
+	promoted bool
+}
+
+// somedayProjectID returns the ID of the backlog project, or "" when none is configured or it doesn't exist
+func (m model) somedayProjectID() string {
+	if m.someday.project == "" {
+		return ""
+	}
+	for _, project := range m.projects {
+		if project.ID == m.someday.project || strings.EqualFold(project.Name, m.someday.project) {
+			return project.ID
+		}
+	}
+	return ""
+}
+
+// isSomeday reports whether a task is in the someday/maybe backlog, by its label or its project
+func isSomeday(task TodoistTask, label, projectID string) bool {
+	if projectID != "" && task.ProjectID == projectID {
+		return true
+	}
+	return slices.ContainsFunc(task.Labels, func(existing string) bool { return strings.EqualFold(existing, label) })
+}
+
+// loadSomedayTasks creates a command that picks the someday/maybe tasks from the cache
+// The cache holds every open task after a sync, so the backlog needs no request of its own and works offline
+func loadSomedayTasks(cache *CacheDB, label, projectID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load the someday backlog: %w", err))
+		}
+		var someday []TodoistTask
+		for _, task := range tasks {
+			if isSomeday(task, label, projectID) {
+				someday = append(someday, task)
+			}
+		}
+		return somedayTasksLoadedMsg(someday)
+	})
+}
+
+// reloadSomeday returns a command that reloads the someday/maybe backlog from the cache
+func (m model) reloadSomeday() tea.Cmd {
+	return loadSomedayTasks(m.cache, m.someday.label, m.somedayProjectID())
+}
+
+// switchToSomeday shows the someday/maybe backlog
+func (m *model) switchToSomeday() tea.Cmd {
+	m.view = viewSomeday
+	m.viewProjectID = ""
+	m.tasks = nil
+	m.allTasks = nil
+	m.selectedIndex = -1
+	m.hScroll = 0
+	m.loading = true
+	return m.reloadSomeday()
+}
+
+// inboxProjectID returns the ID of the Inbox, where promoted tasks of the backlog project go
+func (m model) inboxProjectID() string {
+	if index := inboxProjectIndex(m.projects); index >= 0 {
+		return m.projects[index].ID
+	}
+	return ""
+}
+
+// promoteTask creates a command that takes a task out of the backlog and schedules it for today:
+// the someday label is removed, and tasks of the backlog project move to the Inbox
+func promoteTask(ctx context.Context, client *TodoistClient, task TodoistTask, label, inboxID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if inboxID != "" && task.ProjectID != inboxID {
+			if err := client.MoveTask(ctx, task.ID, inboxID); err != nil {
+				return errorMsg(fmt.Errorf("failed to promote task: %w", err))
+			}
+		}
+		labels, _ := withLabel(task.Labels, label, false)
+		updated, err := client.UpdateTask(ctx, task.ID, UpdateTaskRequest{Labels: &labels, DueString: dayDate(0)})
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to promote task: %w", err))
+		}
+		return somedayMovedMsg{task: *updated, promoted: true}
+	})
+}
+
+// demoteTask creates a command that puts a task in the backlog: it gets the someday label and loses its due date
+func demoteTask(ctx context.Context, client *TodoistClient, task TodoistTask, label string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		labels, _ := withLabel(task.Labels, label, true)
+		update := UpdateTaskRequest{Labels: &labels}
+		if task.Due != nil {
+			update.DueString = "no date"
+		}
+		updated, err := client.UpdateTask(ctx, task.ID, update)
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to move task to someday: %w", err))
+		}
+		return somedayMovedMsg{task: *updated}
+	})
+}
+
+// promoteSelected schedules the selected backlog task for today, taking it out of the backlog
+func (m *model) promoteSelected() tea.Cmd {
+	if m.client == nil || m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	task := m.allTasks[m.selectedIndex]
+	projectID := m.somedayProjectID()
+	if !isSomeday(task, m.someday.label, projectID) {
+		return m.notify(toastInfo, "Not a someday task: "+task.Content)
+	}
+	inboxID := ""
+	if projectID != "" && task.ProjectID == projectID {
+		inboxID = m.inboxProjectID()
+	}
+	return promoteTask(m.requests.base(), m.client, task, m.someday.label, inboxID)
+}
+
+// demoteSelected moves the selected task to the someday/maybe backlog
+func (m *model) demoteSelected() tea.Cmd {
+	if m.client == nil || m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) {
+		return nil
+	}
+	task := m.allTasks[m.selectedIndex]
+	if isSomeday(task, m.someday.label, m.somedayProjectID()) {
+		return m.notify(toastInfo, "Already a someday task: "+task.Content)
+	}
+	if isRecurring(task) {
+		// Clearing the date would end the recurrence
+		return m.notify(toastError, "Recurring tasks can't move to someday: "+task.Content)
+	}
+	return demoteTask(m.requests.base(), m.client, task, m.someday.label)
+}
+
+// handleSomedayMoved saves a promoted or demoted task and reloads the view, which it may have left
+func (m *model) handleSomedayMoved(msg somedayMovedMsg) tea.Cmd {
+	for i := range m.allTasks {
+		if m.allTasks[i].ID == msg.task.ID {
+			m.allTasks[i] = msg.task
+		}
+	}
+	_ = m.cache.SaveTask(msg.task)
+
+	text := "Moved to someday: " + msg.task.Content
+	if msg.promoted {
+		m.session.rescheduled++
+		text = "Promoted to today: " + msg.task.Content
+	}
+	return tea.Batch(m.notify(toastSuccess, text), m.reloadCurrentView())
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sortMode is an order the task list can be sorted in; the names are used in the "sort" config setting
+type sortMode string
+
+const (
+	// sortPriority puts the highest priority first, then the earliest due date
+	sortPriority sortMode = "priority"
+	// sortDue puts the earliest due date first, then the highest priority
+	sortDue sortMode = "due"
+	// sortProject groups tasks by project name, in Todoist's order within a project
+	sortProject sortMode = "project"
+	// sortCreated puts the most recently created tasks first
+	sortCreated sortMode = "created"
+	// sortAlphabetical orders tasks by their content, ignoring case
+	sortAlphabetical sortMode = "alphabetical"
+	// sortManual keeps the order the tasks were arranged in within Todoist
+	sortManual sortMode = "manual"
+)
+
+// sortModes lists the sort modes in the order the sort key cycles through them
+var sortModes = []sortMode{sortPriority, sortDue, sortProject, sortCreated, sortAlphabetical, sortManual}
+
+// sortModeLabels describe the sort modes in messages and the heading
+var sortModeLabels = map[sortMode]string{
+	sortPriority:     "priority",
+	sortDue:          "due date",
+	sortProject:      "project",
+	sortCreated:      "newest first",
+	sortAlphabetical: "A-Z",
+	sortManual:       "Todoist order",
+}
+
+// Views whose sort order can be configured, as named in the "sort" config setting
+const (
+	sortViewToday    = "today"
+	sortViewUpcoming = "upcoming"
+	sortViewProject  = "project"
+	sortViewLabel    = "label"
+	sortViewSomeday  = "someday"
+)
+
+// sortKey returns the name of the view in the "sort" config setting
+func (v viewMode) sortKey() string {
+	switch v {
+	case viewProject:
+		return sortViewProject
+	case viewUpcoming:
+		return sortViewUpcoming
+	case viewLabel:
+		return sortViewLabel
+	case viewSomeday:
+		return sortViewSomeday
+	default:
+		return sortViewToday
+	}
+}
+
+// defaultSortMode returns the order a view is sorted in until another one is chosen
+// Projects follow their Todoist order, the other views put what's due first on top
+func defaultSortMode(view viewMode) sortMode {
+	if view == viewProject {
+		return sortManual
+	}
+	return sortDue
+}
+
+// validateSortModes checks that the configured sort orders name known views and modes
+func validateSortModes(sorts map[string]string) error {
+	for view, mode := range sorts {
+		if view != sortViewToday && view != sortViewUpcoming && view != sortViewProject && view != sortViewLabel && view != sortViewSomeday {
+			return fmt.Errorf("unknown view %q, use %q, %q, %q, %q or %q", view, sortViewToday, sortViewUpcoming, sortViewProject, sortViewLabel, sortViewSomeday)
+		}
+		if !slices.Contains(sortModes, sortMode(mode)) {
+			names := make([]string, len(sortModes))
+			for i, known := range sortModes {
+				names[i] = string(known)
+			}
+			return fmt.Errorf("unknown sort %q for the %s view, valid sorts are: %s", mode, view, strings.Join(names, ", "))
+		}
+	}
+	return nil
+}
+
+// sortTasks sorts tasks in place in the given mode, keeping the order of tasks that compare equal
+// projectName looks up project names for sorting by project, and may be nil for the other modes
+func sortTasks(tasks []TodoistTask, mode sortMode, projectName func(projectID string) string) {
+	slices.SortStableFunc(tasks, func(a, b TodoistTask) int {
+		return compareTasks(a, b, mode, projectName)
+	})
+}
+
+// compareTasks compares two tasks in the given mode, returning a negative number when a comes first
+func compareTasks(a, b TodoistTask, mode sortMode, projectName func(projectID string) string) int {
+	switch mode {
+	case sortPriority:
+		return cmp.Or(cmp.Compare(b.Priority, a.Priority), compareDueDates(a, b))
+	case sortProject:
+		nameA, nameB := a.ProjectID, b.ProjectID
+		if projectName != nil {
+			nameA, nameB = projectName(a.ProjectID), projectName(b.ProjectID)
+		}
+		return cmp.Or(strings.Compare(strings.ToLower(nameA), strings.ToLower(nameB)), cmp.Compare(a.Order, b.Order))
+	case sortCreated:
+		return b.CreatedAt.Compare(a.CreatedAt)
+	case sortAlphabetical:
+		return strings.Compare(strings.ToLower(a.Content), strings.ToLower(b.Content))
+	case sortManual:
+		return cmp.Compare(a.Order, b.Order)
+	default:
+		// Within a day the priority decides, before the time of day
+		return cmp.Or(compareDueDates(a, b), cmp.Compare(b.Priority, a.Priority), compareDueTimes(a, b))
+	}
+}
+
+// compareDueDates compares the due dates of two tasks, putting tasks without one last
+func compareDueDates(a, b TodoistTask) int {
+	switch {
+	case a.Due == nil && b.Due == nil:
+		return 0
+	case a.Due == nil:
+		return 1
+	case b.Due == nil:
+		return -1
+	}
+	// Dates in YYYY-MM-DD format compare correctly as strings
+	return strings.Compare(a.Due.Date, b.Due.Date)
+}
+
+// compareDueTimes compares the due times of two tasks due the same day, putting all-day tasks first
+func compareDueTimes(a, b TodoistTask) int {
+	if a.Due == nil || b.Due == nil {
+		return 0
+	}
+	return strings.Compare(a.Due.Datetime, b.Due.Datetime)
+}
+
+// sortMode returns the order the current view's tasks are sorted in
+func (m model) sortMode() sortMode {
+	if m.config != nil {
+		if mode := sortMode(m.config.Sort[m.view.sortKey()]); mode != "" {
+			return mode
+		}
+	}
+	return defaultSortMode(m.view)
+}
+
+// sortForView orders tasks in the current view's sort mode, keeping the groups the view renders them in
+// Navigation follows the task order, so it has to match the rendered order: overdue tasks before today's,
+// upcoming tasks by day and project tasks by section; label views have no groups
+func (m model) sortForView(tasks []TodoistTask) []TodoistTask {
+	sorted := slices.Clone(tasks)
+	sortTasks(sorted, m.sortMode(), m.client.GetProjectName)
+
+	switch m.view {
+	case viewProject:
+		return m.sortBySection(sorted)
+	case viewLabel, viewSomeday:
+		// Label views and the backlog are a single table without groups
+	case viewUpcoming:
+		slices.SortStableFunc(sorted, compareDueDates)
+	default:
+		overdueFirst := func(task TodoistTask) int {
+			if isTaskOverdue(task) {
+				return 0
+			}
+			return 1
+		}
+		slices.SortStableFunc(sorted, func(a, b TodoistTask) int {
+			return cmp.Compare(overdueFirst(a), overdueFirst(b))
+		})
+	}
+	return sorted
+}
+
+// cycleSort sorts the current view in the next sort mode, remembering the choice for the view in the config
+func (m *model) cycleSort() tea.Cmd {
+	next := sortModes[(slices.Index(sortModes, m.sortMode())+1)%len(sortModes)]
+	if m.config.Sort == nil {
+		m.config.Sort = make(map[string]string)
+	}
+	m.config.Sort[m.view.sortKey()] = string(next)
+
+	// Keep the same task selected wherever it moved
+	selectedID := m.selectedTaskID()
+	m.setTasks(m.tasks)
+	m.restoreSelection(selectedID)
+
+	return tea.Batch(saveConfig(*m.config), m.notify(toastInfo, "Sorted by "+sortModeLabels[next]))
+}
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Defaults of the stale task sweep
+const (
+	defaultStaleMonths = 6
+	defaultStaleRemind = 7 * 24 * time.Hour
+)
+
+// staleRemindedKey is the cache_metadata key holding when the app last reminded of stale tasks
+const staleRemindedKey = "stale_sweep_reminded"
+
+// StaleSweepConfig configures which tasks count as stale and how often the app reminds of them
+type StaleSweepConfig struct {
+	// Months is how long an undated task has to go untouched to be stale
+	Months int `json:"months,omitempty"`
+	// Label is the label tasks are put away with for some day, the someday backlog's label by default
+	Label string `json:"label,omitempty"`
+	// RemindEvery is how often the app says on startup that stale tasks are waiting, e.g. "168h"; "off" never does
+	RemindEvery string `json:"remind_every,omitempty"`
+}
+
+// staleSweep is the checked stale task sweep settings
+type staleSweep struct {
+	months int
+	label  string
+	// remind is how often to remind of stale tasks, 0 when never
+	remind time.Duration
+}
+
+// parseStaleSweep checks the configured stale task sweep, filling in the defaults
+// Tasks are put away with the someday backlog's label unless the sweep names its own
+func parseStaleSweep(config *StaleSweepConfig, somedayLabel string) (staleSweep, error) {
+	sweep := staleSweep{months: defaultStaleMonths, label: somedayLabel, remind: defaultStaleRemind}
+	if config == nil {
+		return sweep, nil
+	}
+	if config.Months < 0 {
+		return staleSweep{}, fmt.Errorf("invalid stale_sweep months %d: must be at least 1", config.Months)
+	}
+	if config.Months > 0 {
+		sweep.months = config.Months
+	}
+	if label := strings.TrimPrefix(strings.TrimSpace(config.Label), "@"); label != "" {
+		sweep.label = label
+	}
+	switch config.RemindEvery {
+	case "":
+	case "0", "off":
+		sweep.remind = 0
+	default:
+		remind, err := time.ParseDuration(config.RemindEvery)
+		if err != nil {
+			return staleSweep{}, fmt.Errorf("invalid stale_sweep remind_every %q: %w", config.RemindEvery, err)
+		}
+		if remind < time.Hour {
+			return staleSweep{}, fmt.Errorf("invalid stale_sweep remind_every %q: must be at least 1h", config.RemindEvery)
+		}
+		sweep.remind = remind
+	}
+	return sweep, nil
+}
+
+// staleTasksFoundMsg is sent with the stale tasks among the cached ones, longest untouched first
+type staleTasksFoundMsg []TodoistTask
+
+// staleReminderMsg is sent on startup with the number of stale tasks, when it's time to remind of them
+type staleReminderMsg int
+
+// staleTaskSweptMsg is sent when a stale task was deleted, put away for some day or scheduled, or that failed
+type staleTaskSweptMsg struct {
+	task TodoistTask
+	// updated is the task as changed, nil when it was deleted
+	updated *TodoistTask
+	// done describes what happened to the task, e.g. "Deleted"
+	done string
+	err  error
+}
+
+// lastTouched returns when a task was last changed, or created if it never was
+// The REST API doesn't say when tasks changed, so tasks fetched from it count from their creation
+func lastTouched(task TodoistTask) time.Time {
+	if task.UpdatedAt.After(task.CreatedAt) {
+		return task.UpdatedAt
+	}
+	return task.CreatedAt
+}
+
+// findStaleTasks picks the undated tasks untouched for the configured months, leaving out those already put away
+// Tasks without a creation time can't be told apart from fresh ones, so they never count as stale
+func findStaleTasks(tasks []TodoistTask, sweep staleSweep, now time.Time) []TodoistTask {
+	cutoff := now.AddDate(0, -sweep.months, 0)
+	var stale []TodoistTask
+	for _, task := range tasks {
+		touched := lastTouched(task)
+		if task.Due != nil || touched.IsZero() || !touched.Before(cutoff) {
+			continue
+		}
+		if slices.ContainsFunc(task.Labels, func(label string) bool { return strings.EqualFold(label, sweep.label) }) {
+			continue
+		}
+		stale = append(stale, task)
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		return lastTouched(stale[i]).Before(lastTouched(stale[j]))
+	})
+	return stale
+}
+
+// scanStaleTasks creates a command that looks for stale tasks among the cached ones
+func scanStaleTasks(cache *CacheDB, sweep staleSweep) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to scan for stale tasks: %w", err))
+		}
+		return staleTasksFoundMsg(findStaleTasks(tasks, sweep, time.Now()))
+	})
+}
+
+// remindOfStaleTasks creates a command that counts the stale tasks on startup, at most once per reminder interval
+func remindOfStaleTasks(cache *CacheDB, sweep staleSweep) tea.Cmd {
+	if cache == nil || sweep.remind == 0 {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		var reminded string
+		if err := cache.db.QueryRow("SELECT value FROM cache_metadata WHERE key = ?", staleRemindedKey).Scan(&reminded); err == nil {
+			if last, err := time.Parse(time.RFC3339, reminded); err == nil && time.Since(last) < sweep.remind {
+				return nil
+			}
+		}
+
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to scan for stale tasks: %w", err))
+		}
+		stale := findStaleTasks(tasks, sweep, time.Now())
+		if len(stale) == 0 {
+			return nil
+		}
+		if _, err := cache.db.Exec("INSERT OR REPLACE INTO cache_metadata (key, value) VALUES (?, ?)",
+			staleRemindedKey, time.Now().Format(time.RFC3339)); err != nil {
+			return errorMsg(fmt.Errorf("failed to save stale task reminder: %w", err))
+		}
+		return staleReminderMsg(len(stale))
+	})
+}
+
+// deleteStaleTask creates a command that deletes a stale task
+func deleteStaleTask(ctx context.Context, client *TodoistClient, cache *CacheDB, task TodoistTask) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := client.DeleteTask(ctx, task.ID); err != nil {
+			return staleTaskSweptMsg{task: task, err: fmt.Errorf("failed to delete task: %w", err)}
+		}
+		_ = cache.RemoveTasks([]string{task.ID})
+		return staleTaskSweptMsg{task: task, done: "Deleted"}
+	})
+}
+
+// shelveStaleTask creates a command that puts a stale task away for some day by adding the sweep's label
+func shelveStaleTask(ctx context.Context, client *TodoistClient, cache *CacheDB, task TodoistTask, label string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		labels := append(slices.Clone(task.Labels), label)
+		updated, err := client.UpdateTask(ctx, task.ID, UpdateTaskRequest{Labels: &labels})
+		if err != nil {
+			return staleTaskSweptMsg{task: task, err: fmt.Errorf("failed to label task: %w", err)}
+		}
+		_ = cache.SaveTask(*updated)
+		return staleTaskSweptMsg{task: task, updated: updated, done: "Labeled @" + label}
+	})
+}
+
+// scheduleStaleTask creates a command that gives a stale task a due date
+func scheduleStaleTask(ctx context.Context, client *TodoistClient, cache *CacheDB, task TodoistTask, dueString string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		updated, err := client.UpdateTaskDue(ctx, task.ID, dueString)
+		if err != nil {
+			return staleTaskSweptMsg{task: task, err: fmt.Errorf("failed to schedule task: %w", err)}
+		}
+		_ = cache.SaveTask(*updated)
+		return staleTaskSweptMsg{task: task, updated: updated, done: "Scheduled for " + dueString}
+	})
+}
+
+// openStaleSweep shows the stale task sweep and scans the cache for stale tasks
+func (m *model) openStaleSweep() tea.Cmd {
+	m.showingStaleSweep = true
+	m.staleTasks = nil
+	m.staleIndex = 0
+	m.staleScanned = false
+	m.staleBusy = false
+	m.staleConfirmDelete = false
+	m.staleScheduling = false
+	return scanStaleTasks(m.cache, m.staleSweep)
+}
+
+// handleStaleTasksFound shows the stale tasks found by the scan
+func (m *model) handleStaleTasksFound(msg staleTasksFoundMsg) {
+	m.staleTasks = msg
+	m.staleIndex = 0
+	m.staleScanned = true
+}
+
+// handleStaleTaskSwept drops a swept task from the sweep and brings the task list up to date
+// A failed action leaves the task shown, to try again or skip
+func (m *model) handleStaleTaskSwept(msg staleTaskSweptMsg) tea.Cmd {
+	m.staleBusy = false
+	if msg.err != nil {
+		return m.notify(toastError, msg.err.Error())
+	}
+
+	if msg.updated == nil {
+		m.removeTask(msg.task.ID)
+	} else {
+		m.session.noteUpdate(msg.task, *msg.updated)
+		for i := range m.allTasks {
+			if m.allTasks[i].ID == msg.task.ID {
+				m.allTasks[i] = *msg.updated
+			}
+		}
+	}
+	for i, task := range m.staleTasks {
+		if task.ID == msg.task.ID {
+			m.staleTasks = slices.Delete(m.staleTasks, i, i+1)
+			break
+		}
+	}
+	return m.notify(toastSuccess, fmt.Sprintf("%s: %s", msg.done, msg.task.Content))
+}
+
+// handleStaleReminder says on startup how many stale tasks are waiting to be swept
+func (m *model) handleStaleReminder(count int) tea.Cmd {
+	return m.notify(toastInfo, fmt.Sprintf("%d task(s) untouched and undated for over %d months • %s: sweep them",
+		count, m.staleSweep.months, m.keys.keyFor(keyContextMain, actionStaleSweep)))
+}
+
+// formatAge writes how long ago a time was in months or years, e.g. "8 months" or "2 years"
+func formatAge(then, now time.Time) string {
+	months := (now.Year()-then.Year())*12 + int(now.Month()) - int(then.Month())
+	if now.Day() < then.Day() {
+		months--
+	}
+	switch {
+	case months >= 24:
+		return fmt.Sprintf("%d years", months/12)
+	case months == 1:
+		return "1 month"
+	default:
+		return fmt.Sprintf("%d months", months)
+	}
+}
+
+// renderStaleSweep creates the stale task sweep screen, walking through the stale tasks one at a time
+func (m model) renderStaleSweep() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🕸 Stale Tasks"))
+	content.WriteString("\n\n")
+
+	switch {
+	case !m.staleScanned:
+		content.WriteString(loadingStyle.MarginLeft(0).Render("Scanning open tasks..."))
+		content.WriteString("\n\n")
+		content.WriteString("ESC: close")
+	case m.staleIndex >= len(m.staleTasks):
+		if len(m.staleTasks) == 0 {
+			content.WriteString(fmt.Sprintf("No undated tasks untouched for over %d months", m.staleSweep.months))
+		} else {
+			content.WriteString("No more stale tasks to review")
+		}
+		content.WriteString("\n\n")
+		content.WriteString("p: previous • ESC: close")
+	default:
+		task := m.staleTasks[m.staleIndex]
+		content.WriteString(fmt.Sprintf("Task %d of %d • untouched for %s", m.staleIndex+1, len(m.staleTasks),
+			formatAge(lastTouched(task), time.Now())))
+		content.WriteString("\n\n")
+		priority := lipgloss.NewStyle().Foreground(priorityColors[task.Priority]).Render(getPriorityText(task.Priority))
+		content.WriteString(priority + " " + task.Content + "\n")
+		details := []string{"in " + m.client.GetProjectName(task.ProjectID)}
+		if len(task.Labels) > 0 {
+			details = append(details, "@"+strings.Join(task.Labels, " @"))
+		}
+		if !task.CreatedAt.IsZero() {
+			details = append(details, "created "+task.CreatedAt.Local().Format("Jan 2 2006"))
+		}
+		content.WriteString(projectStyle.MarginLeft(0).Render(strings.Join(details, " • ")) + "\n")
+		if description := strings.TrimSpace(task.Description); description != "" {
+			firstLine, _, _ := strings.Cut(description, "\n")
+			content.WriteString(compactTaskLine(firstLine, 60, 0) + "\n")
+		}
+		content.WriteString("\n")
+
+		switch {
+		case m.staleBusy:
+			content.WriteString(loadingStyle.MarginLeft(0).Render("Saving..."))
+			content.WriteString("\n\n")
+			content.WriteString("ESC: close")
+		case m.staleScheduling:
+			content.WriteString(popupFieldStyle.Render("Due: "))
+			content.WriteString(m.staleDueInput + "█")
+			content.WriteString("\n\n")
+			content.WriteString("Type a date like \"next monday\" • Enter: schedule • ESC: cancel")
+		case m.staleConfirmDelete:
+			content.WriteString(errorStyle.MarginLeft(0).Render("Delete this task for good?"))
+			content.WriteString("\n\n")
+			content.WriteString("x: delete • any other key: cancel")
+		default:
+			content.WriteString(fmt.Sprintf("x: delete • s: label @%s • t: schedule • n: skip • p: previous • ESC: close", m.staleSweep.label))
+		}
+	}
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleStaleSweepInput handles keyboard input when the stale task sweep is visible
+func (m model) handleStaleSweepInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	// Typing a due date for the task
+	if m.staleScheduling {
+		switch key {
+		case "esc", "escape":
+			m.staleScheduling = false
+		case "backspace":
+			if len(m.staleDueInput) > 0 {
+				m.staleDueInput = m.staleDueInput[:len(m.staleDueInput)-1]
+			}
+		case "enter":
+			dueString := strings.TrimSpace(m.staleDueInput)
+			if dueString == "" {
+				return m, nil
+			}
+			m.staleScheduling = false
+			m.staleBusy = true
+			return m, scheduleStaleTask(m.requests.base(), m.client, m.cache, m.staleTasks[m.staleIndex], dueString)
+		default:
+			if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+				m.staleDueInput += string(msg.Runes)
+			}
+		}
+		return m, nil
+	}
+
+	// Deleting asks to press x once more
+	if m.staleConfirmDelete {
+		m.staleConfirmDelete = false
+		if key == "x" {
+			m.staleBusy = true
+			return m, deleteStaleTask(m.requests.base(), m.client, m.cache, m.staleTasks[m.staleIndex])
+		}
+		return m, nil
+	}
+
+	if key == "esc" || key == "escape" || m.keys.action(keyContextMain, key) == actionStaleSweep {
+		// Close the sweep
+		m.showingStaleSweep = false
+		return m, nil
+	}
+	// Wait for the last action to finish before moving on
+	if m.staleBusy || m.staleIndex >= len(m.staleTasks) {
+		if key == "p" && !m.staleBusy && m.staleIndex > 0 {
+			m.staleIndex--
+		}
+		return m, nil
+	}
+
+	task := m.staleTasks[m.staleIndex]
+	switch key {
+	case "x":
+		m.staleConfirmDelete = true
+	case "s":
+		m.staleBusy = true
+		return m, shelveStaleTask(m.requests.base(), m.client, m.cache, task, m.staleSweep.label)
+	case "t":
+		m.staleScheduling = true
+		m.staleDueInput = ""
+	case "n", "right", "l":
+		// Leave the task as it is
+		m.staleIndex++
+	case "p", "left", "h":
+		if m.staleIndex > 0 {
+			m.staleIndex--
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// firstFrameBudget is how soon after launch the first frame should be rendered
+const firstFrameBudget = 50 * time.Millisecond
+
+// firstPaintDelay is one frame at Bubble Tea's default 60 FPS, long enough for the first frame to be flushed
+const firstPaintDelay = time.Second / 60
+
+// cacheOpenedMsg is sent when the cache database has been opened after the first frame
+type cacheOpenedMsg struct {
+	cache *CacheDB
+	err   error
+}
+
+// firstPaintMsg is sent once the first frame has been painted, so network calls can start
+type firstPaintMsg struct{}
+
+// startupEvent is a named point during startup and how long after launch it was reached
+type startupEvent struct {
+	name    string
+	elapsed time.Duration
+}
+
+// startupTrace records when startup milestones were first reached, for --profile-startup
+type startupTrace struct {
+	start  time.Time
+	mu     sync.Mutex
+	events []startupEvent
+}
+
+// startup traces this process, starting from package initialization
+var startup = &startupTrace{start: time.Now()}
+
+// mark records a milestone the first time it is reached
+func (s *startupTrace) mark(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range s.events {
+		if event.name == name {
+			return
+		}
+	}
+	s.events = append(s.events, startupEvent{name: name, elapsed: time.Since(s.start)})
+}
+
+// report writes the milestones in the order they were reached, flagging a slow first frame
+func (s *startupTrace) report(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = fmt.Fprintln(w, "Startup profile:")
+	for _, event := range s.events {
+		_, _ = fmt.Fprintf(w, "  %-16s %8.1fms\n", event.name, float64(event.elapsed.Microseconds())/1000)
+		if event.name == "first frame" && event.elapsed > firstFrameBudget {
+			_, _ = fmt.Fprintf(w, "  warning: first frame took longer than %s\n", firstFrameBudget)
+		}
+	}
+}
+
+// openCache creates a command that opens the cache database off the startup path
+// A requested full resync drops the cached data before anything is loaded from it
+func openCache(resync bool, config *Config) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		cache, err := newCacheFor(config)
+		if err != nil {
+			return cacheOpenedMsg{err: fmt.Errorf("failed to initialize cache: %w", err)}
+		}
+		if resync {
+			if err := cache.Clear(); err != nil {
+				_ = cache.Close()
+				return cacheOpenedMsg{err: fmt.Errorf("failed to clear cache: %w", err)}
+			}
+		}
+		startup.mark("cache opened")
+		return cacheOpenedMsg{cache: cache}
+	})
+}
+
+// afterFirstPaint creates a command that reports once the first frame has been painted
+func afterFirstPaint() tea.Cmd {
+	return tea.Tick(firstPaintDelay, func(time.Time) tea.Msg {
+		return firstPaintMsg{}
+	})
+}
+
+// handleCacheOpened starts loading the cached data, then the network data once the first frame is up
+func (m *model) handleCacheOpened(msg cacheOpenedMsg) tea.Cmd {
+	if msg.err != nil {
+		m.error = msg.err
+		m.loading = false
+		return nil
+	}
+	m.cache = msg.cache
+	m.client.useValidators(m.cache)
+	// Dates of the cached tasks are compared with today in the account's timezone, as last fetched
+	if m.config.timezoneSetting() == timezoneTodoist {
+		applyCachedAccountTimezone(m.cache)
+	}
+	return tea.Batch(loadFromCacheWithCmd(m.requests.replace(requestGroupView), m.client, m.cache), loadWatches(m.cache), loadPendingOperations(m.cache),
+		loadParkedTasks(m.cache), loadLinks(m.cache), startReminderTicker(m.config), scheduleAutoRefresh(m.refreshInterval), afterFirstPaint())
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// statsTopProjects is the number of projects listed in the per-project breakdown
+const statsTopProjects = 10
+
+// statsBarWidth is the length of the longest bar in the stats charts
+const statsBarWidth = 30
+
+// statsProjectWidth is the widest project name in the per-project chart; longer names are abbreviated
+const statsProjectWidth = 20
+
+// statsCount is one row of a stats bar chart
+type statsCount struct {
+	// label names the row, e.g. "P1" or a project name
+	label string
+	// count is the number of open tasks in the row
+	count int
+	// color is the bar color
+	color lipgloss.Color
+}
+
+// statsLoadedMsg is sent with the open task breakdown computed from the cache
+type statsLoadedMsg struct {
+	// total is the number of open tasks
+	total int
+	// byPriority has one row per priority, P1 first
+	byPriority []statsCount
+	// byProject has the projects with the most open tasks, most first
+	byProject []statsCount
+	// otherProjects is the number of open tasks in projects beyond the top ones
+	otherProjects int
+	// overdueHistory has the daily overdue counts of the trend, oldest first
+	overdueHistory []overdueDay
+}
+
+// loadStats creates a command that counts the open tasks in the cache by priority and by project
+func loadStats(cache *CacheDB, projectName func(string) string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load stats: %w", err))
+		}
+		stats := computeStats(tasks, projectName)
+		if stats.overdueHistory, err = cache.LoadOverdueHistory(overdueTrendStart(dayNow())); err != nil {
+			return errorMsg(fmt.Errorf("failed to load overdue history: %w", err))
+		}
+		return stats
+	})
+}
+
+// computeStats counts the open tasks by priority and by project, keeping the top projects
+func computeStats(tasks []TodoistTask, projectName func(string) string) statsLoadedMsg {
+	var stats statsLoadedMsg
+	priorities := make(map[int]int)
+	projects := make(map[string]int)
+	for _, task := range tasks {
+		if task.IsCompleted {
+			continue
+		}
+		stats.total++
+		priorities[task.Priority]++
+		projects[task.ProjectID]++
+	}
+
+	// API priority 4 is shown as P1
+	for priority := urgentPriority; priority >= 1; priority-- {
+		stats.byPriority = append(stats.byPriority, statsCount{
+			label: fmt.Sprintf("P%d", 5-priority),
+			count: priorities[priority],
+			color: priorityColors[priority],
+		})
+	}
+
+	for projectID, count := range projects {
+		stats.byProject = append(stats.byProject, statsCount{label: projectName(projectID), count: count})
+	}
+	sort.Slice(stats.byProject, func(i, j int) bool {
+		if stats.byProject[i].count != stats.byProject[j].count {
+			return stats.byProject[i].count > stats.byProject[j].count
+		}
+		return stats.byProject[i].label < stats.byProject[j].label
+	})
+	if len(stats.byProject) > statsTopProjects {
+		for _, row := range stats.byProject[statsTopProjects:] {
+			stats.otherProjects += row.count
+		}
+		stats.byProject = stats.byProject[:statsTopProjects]
+	}
+	return stats
+}
+
+// renderStatsChart draws a horizontal bar chart, scaling bars to the largest count
+// Each count is followed by its share of the total, unless the total is 0
+func renderStatsChart(rows []statsCount, total int) string {
+	labelWidth, maxCount := 0, 0
+	for _, row := range rows {
+		labelWidth = max(labelWidth, lipgloss.Width(row.label))
+		maxCount = max(maxCount, row.count)
+	}
+
+	var b strings.Builder
+	for _, row := range rows {
+		length := 0
+		if maxCount > 0 {
+			length = row.count * statsBarWidth / maxCount
+		}
+		// Keep a sliver visible for rows with only a few tasks
+		if length == 0 && row.count > 0 {
+			length = 1
+		}
+		bar := strings.Repeat("█", length)
+		if row.color != "" {
+			bar = lipgloss.NewStyle().Foreground(row.color).Render(bar)
+		}
+		// Pad by display width, since project names may contain emoji
+		b.WriteString(row.label + strings.Repeat(" ", labelWidth-lipgloss.Width(row.label)+1))
+		b.WriteString(bar)
+		b.WriteString(fmt.Sprintf(" %d", row.count))
+		if total > 0 {
+			b.WriteString(fmt.Sprintf(" (%d%%)", row.count*100/total))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// renderStats creates the stats screen
+func (m model) renderStats() string {
+	var content strings.Builder
+	stats := m.stats
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("📊 Stats"))
+	content.WriteString("\n\n")
+
+	content.WriteString(popupFieldStyle.Render("Open tasks: "))
+	content.WriteString(fmt.Sprintf("%d", stats.total))
+	content.WriteString("\n\n")
+
+	// Breakdown by priority, with the P1 limit when one is configured
+	content.WriteString(popupFieldStyle.Render("By priority:"))
+	content.WriteString("\n")
+	content.WriteString(renderStatsChart(stats.byPriority, stats.total))
+	if m.config != nil && m.config.MaxUrgentTasks > 0 && len(stats.byPriority) > 0 {
+		if p1 := stats.byPriority[0].count; p1 > m.config.MaxUrgentTasks {
+			content.WriteString(staleStyle.MarginLeft(0).Render(fmt.Sprintf("🚨 %d P1 tasks, over the limit of %d", p1, m.config.MaxUrgentTasks)))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Breakdown by project
+	content.WriteString(popupFieldStyle.Render(fmt.Sprintf("By project (top %d):", statsTopProjects)))
+	content.WriteString("\n")
+	if len(stats.byProject) == 0 {
+		content.WriteString("No open tasks")
+		content.WriteString("\n")
+	} else {
+		content.WriteString(renderStatsChart(stats.byProject, stats.total))
+		if stats.otherProjects > 0 {
+			content.WriteString(projectStyle.Render(fmt.Sprintf("%d more in other projects", stats.otherProjects)))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Whether the overdue backlog is shrinking or growing
+	content.WriteString(m.renderOverdueTrend())
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("ESC: close")
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// openStats shows the stats screen and loads its numbers from the cache
+func (m *model) openStats() tea.Cmd {
+	m.showingStats = true
+	m.stats = statsLoadedMsg{}
+	// A method value copies the model, so the command doesn't read it while it changes
+	columnText := m.projectColumnText
+	return loadStats(m.cache, func(projectID string) string {
+		return columnText(projectID, statsProjectWidth)
+	})
+}
+
+// handleStatsInput handles keyboard input when the stats screen is visible
+func (m model) handleStatsInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape", "G":
+		// Close the stats screen
+		m.showingStats = false
+	}
+	return m, nil
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// decodeJSONArray decodes a JSON array one element at a time, passing each to a callback,
+// so large responses like thousands of tasks are never buffered whole
+func decodeJSONArray[T any](r io.Reader, each func(T)) error {
+	return decodeArrayElements(json.NewDecoder(r), each)
+}
+
+// decodeArrayElements decodes the JSON array the decoder is at one element at a time
+// A null array is accepted as an empty one
+func decodeArrayElements[T any](decoder *json.Decoder, each func(T)) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected a JSON array, got %v", token)
+	}
+	for decoder.More() {
+		var element T
+		if err := decoder.Decode(&element); err != nil {
+			return err
+		}
+		each(element)
+	}
+	// Consume the closing bracket
+	_, err = decoder.Token()
+	return err
+}
+
+// decodeJSONObject walks the fields of a JSON object, letting a handler decode the value of each known field
+// Values of other fields are skipped
+func decodeJSONObject(r io.Reader, fields map[string]func(*json.Decoder) error) error {
+	decoder := json.NewDecoder(r)
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("expected a JSON object, got %v", token)
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		if handle, ok := fields[key]; ok {
+			if err := handle(decoder); err != nil {
+				return fmt.Errorf("field %q: %w", key, err)
+			}
+			continue
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return err
+		}
+	}
+	// Consume the closing brace
+	_, err = decoder.Token()
+	return err
+}
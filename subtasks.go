@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// subtaskCount is how many of a task's subtasks are done
+type subtaskCount struct {
+	done  int
+	total int
+}
+
+// subtaskProgressLoadedMsg is sent with the subtask progress of every parent task, keyed by task ID
+type subtaskProgressLoadedMsg map[string]subtaskCount
+
+// parentTaskFoundMsg is sent with the cached parent task whose last open subtask was completed
+type parentTaskFoundMsg TodoistTask
+
+// LoadCompletedSubtasks returns the IDs of the recorded completed subtasks of each parent task
+func (c *CacheDB) LoadCompletedSubtasks() (map[string][]string, error) {
+	rows, err := c.db.Query(`
+		SELECT DISTINCT parent_id, task_id FROM completion_history
+		WHERE parent_id != ''
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	completed := make(map[string][]string)
+	for rows.Next() {
+		var parentID, taskID string
+		if err := rows.Scan(&parentID, &taskID); err != nil {
+			return nil, err
+		}
+		completed[parentID] = append(completed[parentID], taskID)
+	}
+	return completed, rows.Err()
+}
+
+// subtaskProgress counts the open subtasks of each task, and the completed ones the history knows of
+// A completed subtask that is open again, like a reopened or recurring one, counts as open
+func subtaskProgress(tasks []TodoistTask, completed map[string][]string) map[string]subtaskCount {
+	progress := make(map[string]subtaskCount)
+	open := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		open[task.ID] = true
+		if task.ParentID != "" {
+			count := progress[task.ParentID]
+			count.total++
+			progress[task.ParentID] = count
+		}
+	}
+	for parentID, taskIDs := range completed {
+		// Only open parents show progress
+		if !open[parentID] {
+			continue
+		}
+		for _, taskID := range taskIDs {
+			if !open[taskID] {
+				count := progress[parentID]
+				count.done++
+				count.total++
+				progress[parentID] = count
+			}
+		}
+	}
+	return progress
+}
+
+// loadSubtaskProgress creates a command that counts the subtasks of the cached tasks
+func loadSubtaskProgress(cache *CacheDB) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load subtask progress: %w", err))
+		}
+		completed, err := cache.LoadCompletedSubtasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load subtask progress: %w", err))
+		}
+		return subtaskProgressLoadedMsg(subtaskProgress(tasks, completed))
+	})
+}
+
+// countCompletedSubtask moves a subtask completed in the app to the done side of its parent's progress
+// Recurring subtasks move to their next date instead, so they stay open
+// Returns true when that was the parent's last open subtask
+func (m *model) countCompletedSubtask(task TodoistTask) bool {
+	if task.ParentID == "" || isRecurring(task) {
+		return false
+	}
+	count, ok := m.subtaskProgress[task.ParentID]
+	if !ok || count.done >= count.total {
+		return false
+	}
+	count.done++
+	m.subtaskProgress[task.ParentID] = count
+	return count.done == count.total
+}
+
+// findParentTask creates a command that looks up a parent task in the cache, for parents not in the current list
+func findParentTask(cache *CacheDB, parentID string) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load parent task: %w", err))
+		}
+		for _, task := range tasks {
+			if task.ID == parentID {
+				return parentTaskFoundMsg(task)
+			}
+		}
+		return nil
+	})
+}
+
+// offerParentCompletion asks whether to complete the parent of a subtask that was its last open one
+// Only offered when complete_parents is set in the config
+func (m *model) offerParentCompletion(subtask TodoistTask) tea.Cmd {
+	if m.config == nil || !m.config.CompleteParents {
+		return nil
+	}
+	for _, task := range m.allTasks {
+		if task.ID == subtask.ParentID {
+			m.showParentCompletion(task)
+			return nil
+		}
+	}
+	return findParentTask(m.cache, subtask.ParentID)
+}
+
+// showParentCompletion shows the dialog offering to complete a parent task, unless it was completed meanwhile
+func (m *model) showParentCompletion(parent TodoistTask) {
+	if m.completedTasks[parent.ID] {
+		return
+	}
+	m.showingCompleteParent = true
+	m.completeParentTask = parent
+}
+
+// renderCompleteParent creates the dialog offering to complete a parent task whose subtasks are all done
+func (m model) renderCompleteParent() string {
+	var content strings.Builder
+	parent := m.completeParentTask
+
+	// Dialog title
+	content.WriteString(popupTitleStyle.Render("☑ All Subtasks Done"))
+	content.WriteString("\n\n")
+
+	// Parent task and its progress
+	content.WriteString(popupFieldStyle.Render("Task: "))
+	content.WriteString(parent.Content)
+	content.WriteString("\n")
+	if progress := m.renderSubtaskProgress(parent); progress != "" {
+		content.WriteString(popupFieldStyle.Render("Subtasks: "))
+		content.WriteString(progress + " done")
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString("Complete this task too?")
+	content.WriteString("\n\n")
+
+	// Instructions
+	content.WriteString("Press 'y' to complete it • 'n' or ESC to keep it open")
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleCompleteParentInput handles keyboard input when the dialog offering to complete a parent task is visible
+func (m model) handleCompleteParentInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		// Complete the parent like any other task, asking first for recurring ones
+		m.showingCompleteParent = false
+		return m, m.completeSelected(m.completeParentTask)
+	case "esc", "escape", "n", "N":
+		// Leave the parent open
+		m.showingCompleteParent = false
+	}
+	return m, nil
+}
+
+// renderSubtaskProgress writes how many of a task's subtasks are done, e.g. "3/7", or "" without subtasks
+func (m model) renderSubtaskProgress(task TodoistTask) string {
+	count, ok := m.subtaskProgress[task.ID]
+	if !ok || count.total == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d/%d", count.done, count.total)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// todoistSyncAPIBase is the base URL for Todoist Sync API v9
+// Used for operations the REST API does not support, such as moving tasks
+const todoistSyncAPIBase = "https://api.todoist.com/sync/v9"
+
+// syncCommand represents a single write command sent to the Sync API
+type syncCommand struct {
+	// Type is the command name (e.g., "item_move")
+	Type string `json:"type"`
+	// UUID identifies the command so its result can be matched in the response
+	UUID string `json:"uuid"`
+	// Args holds the command specific arguments
+	Args map[string]any `json:"args"`
+}
+
+// maxSyncCommands is the most commands Todoist accepts in a single Sync API request
+const maxSyncCommands = 100
+
+// syncCommandError represents a failed command in a Sync API response
+type syncCommandError struct {
+	// ErrorCode is the numeric Todoist error code
+	ErrorCode int `json:"error_code"`
+	// Error is the human readable error message
+	Error string `json:"error"`
+	// ErrorTag is Todoist's name for the error, e.g. "ITEM_NOT_FOUND"
+	ErrorTag string `json:"error_tag,omitempty"`
+	// HTTPCode is the HTTP status the command would have failed with on its own
+	HTTPCode int `json:"http_code,omitempty"`
+}
+
+// syncCommandsResponse represents the response to a batch of Sync API commands
+type syncCommandsResponse struct {
+	// SyncStatus maps each command UUID to "ok" or an error object
+	SyncStatus map[string]json.RawMessage `json:"sync_status"`
+}
+
+// newSyncCommand creates a Sync API command with a fresh UUID
+func newSyncCommand(commandType string, args map[string]any) syncCommand {
+	return syncCommand{
+		Type: commandType,
+		UUID: newUUID(),
+		Args: args,
+	}
+}
+
+// newUUID generates a random version 4 UUID for Sync API commands
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40 // Version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// runSyncCommands sends a batch of commands to the Sync API
+// Returns an error describing the first command that failed, if any
+func (c *TodoistClient) runSyncCommands(ctx context.Context, commands []syncCommand) error {
+	failed, err := c.runSyncCommandBatch(ctx, commands)
+	if err != nil {
+		return err
+	}
+	for _, command := range commands {
+		if err := failed[command.UUID]; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSyncCommandBatches sends commands to the Sync API in batches of at most maxSyncCommands
+// Returns the error of each command that failed by its UUID; when a batch fails as a whole, its commands
+// and those of the batches not sent yet all fail with that error, which is returned too
+func (c *TodoistClient) runSyncCommandBatches(ctx context.Context, commands []syncCommand) (map[string]error, error) {
+	failed := make(map[string]error)
+	for start := 0; start < len(commands); start += maxSyncCommands {
+		batch := commands[start:min(start+maxSyncCommands, len(commands))]
+		batchFailed, err := c.runSyncCommandBatch(ctx, batch)
+		if err != nil {
+			for _, command := range commands[start:] {
+				failed[command.UUID] = err
+			}
+			return failed, err
+		}
+		maps.Copy(failed, batchFailed)
+	}
+	return failed, nil
+}
+
+// runSyncCommandBatch sends a batch of commands to the Sync API
+// Returns the error of each command that failed by its UUID, or an error when the batch as a whole failed
+func (c *TodoistClient) runSyncCommandBatch(ctx context.Context, commands []syncCommand) (map[string]error, error) {
+	// Convert the commands to JSON
+	body, err := json.Marshal(map[string]any{"commands": commands})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal commands: %w", err)
+	}
+
+	// Create HTTP POST request for sync endpoint
+	req, err := http.NewRequestWithContext(ctx, "POST", c.syncAPIBase+"/sync", bytes.NewBuffer(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse the per-command status
+	var result syncCommandsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	// Collect the commands that did not succeed
+	failed := make(map[string]error)
+	for _, command := range commands {
+		status, ok := result.SyncStatus[command.UUID]
+		if !ok {
+			failed[command.UUID] = fmt.Errorf("%s: no status returned", command.Type)
+			continue
+		}
+		if string(status) == `"ok"` {
+			continue
+		}
+		var commandErr syncCommandError
+		if err := json.Unmarshal(status, &commandErr); err != nil {
+			failed[command.UUID] = fmt.Errorf("%s failed: %s", command.Type, string(status))
+			continue
+		}
+		failed[command.UUID] = fmt.Errorf("%s failed: %w", command.Type, &APIError{
+			Status: commandErr.HTTPCode, Code: commandErr.ErrorCode, ErrorTag: commandErr.ErrorTag, Message: commandErr.Error,
+		})
+	}
+
+	return failed, nil
+}
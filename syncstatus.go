@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSyncErrors is the number of recent sync errors kept for the status screen
+const maxSyncErrors = 5
+
+// syncErrorEntry records an error that occurred while talking to Todoist
+type syncErrorEntry struct {
+	// at is when the error occurred
+	at time.Time
+	// err is the error itself
+	err error
+}
+
+// syncStatusLoadedMsg is sent when the last sync timestamps have been read from the cache
+type syncStatusLoadedMsg struct {
+	tasksUpdated    time.Time
+	projectsUpdated time.Time
+}
+
+// loadSyncStatus creates a command that reads the last sync timestamps from the cache
+func loadSyncStatus(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		return syncStatusLoadedMsg{
+			tasksUpdated:    cache.LastUpdated("tasks"),
+			projectsUpdated: cache.LastUpdated("projects"),
+		}
+	})
+}
+
+// forceResync creates a command that re-downloads all tasks and projects from Todoist and replaces the cache with them
+// Everything is fetched before the cache is touched, so a resync failing halfway, e.g. offline, keeps the cached data
+func forceResync(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		delta, err := client.SyncTasks(ctx, fullSyncToken)
+		if err != nil {
+			return readErrorMsg(err)
+		}
+		projects, err := client.GetProjects(ctx)
+		if err != nil {
+			return readErrorMsg(err)
+		}
+		if err := cache.ReplaceAll(delta, projects); err != nil {
+			return errorMsg(fmt.Errorf("failed to replace cache: %w", err))
+		}
+		_ = cache.RecordCompletions(delta.completed)
+		return loadFromCacheWithCmd(ctx, client, cache)()
+	})
+}
+
+// startFullResync resets all synced state and returns a command that re-downloads it
+func (m *model) startFullResync() tea.Cmd {
+	m.loading = true
+	m.error = nil
+	m.refreshingInBackground = false
+
+	return forceResync(m.requests.replace(requestGroupRefresh), m.client, m.cache)
+}
+
+// recordSyncError remembers an error for display on the sync status screen
+func (m *model) recordSyncError(err error) {
+	m.syncErrors = append(m.syncErrors, syncErrorEntry{at: time.Now(), err: err})
+	// Keep only the most recent errors
+	if len(m.syncErrors) > maxSyncErrors {
+		m.syncErrors = m.syncErrors[len(m.syncErrors)-maxSyncErrors:]
+	}
+}
+
+// formatSyncTime renders a sync timestamp along with how long ago it was
+func formatSyncTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+
+	ago := time.Since(t).Round(time.Second)
+	return fmt.Sprintf("%s (%s ago)", t.Format("2006-01-02 15:04:05"), ago)
+}
+
+// renderSyncStatus creates the sync status screen
+func (m model) renderSyncStatus() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🔄 Sync Status"))
+	content.WriteString("\n\n")
+
+	// Last successful syncs
+	content.WriteString(popupFieldStyle.Render("Tasks synced: "))
+	content.WriteString(formatSyncTime(m.lastTasksSync))
+	content.WriteString("\n")
+	content.WriteString(popupFieldStyle.Render("Projects synced: "))
+	content.WriteString(formatSyncTime(m.lastProjectsSync))
+	content.WriteString("\n\n")
+
+	// Current activity
+	content.WriteString(popupFieldStyle.Render("Activity: "))
+	switch {
+	case m.loading:
+		content.WriteString("loading")
+	case m.refreshingInBackground:
+		content.WriteString("refreshing in background")
+	default:
+		content.WriteString("idle")
+	}
+	content.WriteString("\n\n")
+
+	// Rate limit budget
+	used, remaining := m.client.RateLimitBudget()
+	content.WriteString(popupFieldStyle.Render("Rate limit: "))
+	content.WriteString(fmt.Sprintf("%d used, %d of %d remaining (per %s)",
+		used, remaining, todoistRateLimit, todoistRateLimitWindow))
+	content.WriteString("\n\n")
+
+	// Recent errors, newest first
+	content.WriteString(popupFieldStyle.Render("Recent errors: "))
+	if len(m.syncErrors) == 0 {
+		content.WriteString("none")
+		content.WriteString("\n")
+	} else {
+		content.WriteString("\n")
+		for i := len(m.syncErrors) - 1; i >= 0; i-- {
+			entry := m.syncErrors[i]
+			line := fmt.Sprintf("%s  %v", entry.at.Format("15:04:05"), entry.err)
+			content.WriteString(errorStyle.MarginLeft(0).Render(line))
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Changes waiting to be sent to Todoist
+	content.WriteString(popupFieldStyle.Render("Pending changes: "))
+	content.WriteString(m.renderPendingOperations())
+	content.WriteString("\n")
+
+	// Instructions, or the question before queued changes are dropped for good
+	if m.confirmingClearOps {
+		content.WriteString(errorStyle.Render(fmt.Sprintf("Drop %d pending change(s) without sending them? This can't be undone (y/n)", len(m.pendingOps))))
+	} else {
+		content.WriteString("R: force full resync • r: retry pending • c: clear pending • ESC: close")
+	}
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleSyncStatusInput handles keyboard input when the sync status screen is visible
+func (m model) handleSyncStatusInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Answer the question before dropping the queued changes
+	if m.confirmingClearOps {
+		switch msg.String() {
+		case "y", "Y":
+			m.confirmingClearOps = false
+			if !m.replayingOps {
+				return m, clearOperations(m.cache)
+			}
+		case "n", "N", "esc", "escape":
+			m.confirmingClearOps = false
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc", "escape", "S":
+		// Close the sync status screen
+		m.showingSyncStatus = false
+	case "R":
+		// Drop everything cached and fetch it again
+		if !m.loading {
+			return m, m.startFullResync()
+		}
+	case "r":
+		// Send queued operations again, including failed ones
+		if len(m.pendingOps) > 0 && !m.replayingOps {
+			m.replayingOps = true
+			return m, retryOperations(m.requests.base(), m.client, m.cache)
+		}
+	case "c":
+		// Drop queued operations without sending them, once confirmed
+		if len(m.pendingOps) > 0 && !m.replayingOps {
+			m.confirmingClearOps = true
+		}
+	}
+	return m, nil
+}
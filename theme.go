@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultTheme is the theme used when none is configured
+const defaultTheme = "light"
+
+// Theme holds the colors of the interface as hex strings
+// The same struct is used for color overrides in the config file, where empty fields are left unchanged
+type Theme struct {
+	// Accent colors titles and popup titles
+	Accent string `json:"accent,omitempty"`
+	// Text is the color of task content and popup text
+	Text string `json:"text,omitempty"`
+	// Muted colors project names and status messages
+	Muted string `json:"muted,omitempty"`
+	// Header colors table headers
+	Header string `json:"header,omitempty"`
+	// Field colors field labels in popups
+	Field string `json:"field,omitempty"`
+	// Error colors error messages
+	Error string `json:"error,omitempty"`
+	// Warning colors offline, stale and other warnings
+	Warning string `json:"warning,omitempty"`
+	// PopupBorder is the border color of popups and screens
+	PopupBorder string `json:"popup_border,omitempty"`
+	// SelectionBg and SelectionFg highlight the selected task or entry
+	SelectionBg string `json:"selection_bg,omitempty"`
+	SelectionFg string `json:"selection_fg,omitempty"`
+	// ChangedBg highlights tasks that are new or changed after a refresh
+	ChangedBg string `json:"changed_bg,omitempty"`
+	// PriorityLow, PriorityNormal, PriorityHigh and PriorityUrgent color the P4 to P1 indicators
+	PriorityLow    string `json:"priority_low,omitempty"`
+	PriorityNormal string `json:"priority_normal,omitempty"`
+	PriorityHigh   string `json:"priority_high,omitempty"`
+	PriorityUrgent string `json:"priority_urgent,omitempty"`
+	// DiffRemoved and DiffAdded color the old and new lines of previews
+	DiffRemoved string `json:"diff_removed,omitempty"`
+	DiffAdded   string `json:"diff_added,omitempty"`
+}
+
+// builtinThemes are the themes selectable by name
+var builtinThemes = map[string]Theme{
+	// light is the original palette, tuned for light terminal backgrounds
+	"light": {
+		Accent:         "#7C3AED", // Purple
+		Text:           "#374151", // Dark gray
+		Muted:          "#6B7280", // Medium gray
+		Header:         "#374151",
+		Field:          "#4B5563",
+		Error:          "#EF4444", // Red
+		Warning:        "#F59E0B", // Amber
+		PopupBorder:    "#7C3AED",
+		SelectionBg:    "#EDE9FE", // Light purple background
+		SelectionFg:    "#5B21B6", // Dark purple foreground
+		ChangedBg:      "#D1FAE5", // Light green background
+		PriorityLow:    "#9CA3AF", // Light gray
+		PriorityNormal: "#6366F1", // Soft indigo
+		PriorityHigh:   "#F59E0B", // Amber
+		PriorityUrgent: "#F97316", // Orange (less harsh than red)
+		DiffRemoved:    "#EF4444",
+		DiffAdded:      "#10B981",
+	},
+	// dark keeps the same hues with lighter text for dark terminal backgrounds
+	"dark": {
+		Accent:         "#A78BFA",
+		Text:           "#E5E7EB",
+		Muted:          "#9CA3AF",
+		Header:         "#D1D5DB",
+		Field:          "#D1D5DB",
+		Error:          "#F87171",
+		Warning:        "#FBBF24",
+		PopupBorder:    "#A78BFA",
+		SelectionBg:    "#4C1D95",
+		SelectionFg:    "#EDE9FE",
+		ChangedBg:      "#064E3B",
+		PriorityLow:    "#6B7280",
+		PriorityNormal: "#818CF8",
+		PriorityHigh:   "#FBBF24",
+		PriorityUrgent: "#FB923C",
+		DiffRemoved:    "#F87171",
+		DiffAdded:      "#34D399",
+	},
+	// solarized uses the Solarized dark palette
+	"solarized": {
+		Accent:         "#6C71C4", // violet
+		Text:           "#839496", // base0
+		Muted:          "#586E75", // base01
+		Header:         "#93A1A1", // base1
+		Field:          "#93A1A1",
+		Error:          "#DC322F", // red
+		Warning:        "#B58900", // yellow
+		PopupBorder:    "#6C71C4",
+		SelectionBg:    "#073642", // base02
+		SelectionFg:    "#EEE8D5", // base2
+		ChangedBg:      "#2B3A12",
+		PriorityLow:    "#586E75",
+		PriorityNormal: "#268BD2", // blue
+		PriorityHigh:   "#B58900",
+		PriorityUrgent: "#CB4B16", // orange
+		DiffRemoved:    "#DC322F",
+		DiffAdded:      "#859900", // green
+	},
+}
+
+// themeNames returns the names of the built-in themes in alphabetical order
+func themeNames() []string {
+	names := make([]string, 0, len(builtinThemes))
+	for name := range builtinThemes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// loadTheme returns the named built-in theme with any configured color overrides applied
+func loadTheme(name string, overrides *Theme) (Theme, error) {
+	if name == "" {
+		name = defaultTheme
+	}
+	theme, ok := builtinThemes[strings.ToLower(name)]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q, valid themes are: %s", name, strings.Join(themeNames(), ", "))
+	}
+	if overrides != nil {
+		theme = theme.merge(*overrides)
+	}
+	return theme, nil
+}
+
+// merge returns the theme with every color set in overrides replaced
+func (t Theme) merge(overrides Theme) Theme {
+	for _, pair := range []struct{ dst, src *string }{
+		{&t.Accent, &overrides.Accent},
+		{&t.Text, &overrides.Text},
+		{&t.Muted, &overrides.Muted},
+		{&t.Header, &overrides.Header},
+		{&t.Field, &overrides.Field},
+		{&t.Error, &overrides.Error},
+		{&t.Warning, &overrides.Warning},
+		{&t.PopupBorder, &overrides.PopupBorder},
+		{&t.SelectionBg, &overrides.SelectionBg},
+		{&t.SelectionFg, &overrides.SelectionFg},
+		{&t.ChangedBg, &overrides.ChangedBg},
+		{&t.PriorityLow, &overrides.PriorityLow},
+		{&t.PriorityNormal, &overrides.PriorityNormal},
+		{&t.PriorityHigh, &overrides.PriorityHigh},
+		{&t.PriorityUrgent, &overrides.PriorityUrgent},
+		{&t.DiffRemoved, &overrides.DiffRemoved},
+		{&t.DiffAdded, &overrides.DiffAdded},
+	} {
+		if *pair.src != "" {
+			*pair.dst = *pair.src
+		}
+	}
+	return t
+}
+
+// applyTheme builds the global styles from a theme
+// Must be called before the program starts rendering
+func applyTheme(t Theme) {
+	titleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.Accent)).
+		MarginLeft(2)
+
+	headerStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.Header)).
+		MarginLeft(4).
+		MarginBottom(1)
+
+	taskStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Text)).
+		MarginLeft(4)
+
+	projectStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Muted)).
+		Italic(true)
+
+	priorityColors = map[int]lipgloss.Color{
+		1: lipgloss.Color(t.PriorityLow),
+		2: lipgloss.Color(t.PriorityNormal),
+		3: lipgloss.Color(t.PriorityHigh),
+		4: lipgloss.Color(t.PriorityUrgent),
+	}
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Error)).
+		MarginLeft(2)
+
+	staleStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Warning)).
+		MarginLeft(2)
+
+	loadingStyle = lipgloss.NewStyle().
+		Foreground(lipgloss.Color(t.Muted)).
+		MarginLeft(2)
+
+	popupStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(t.PopupBorder)).
+		Padding(1, 2).
+		Foreground(lipgloss.Color(t.Text))
+
+	popupTitleStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.Accent)).
+		MarginBottom(1)
+
+	popupFieldStyle = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color(t.Field))
+
+	selectionBgColor = lipgloss.Color(t.SelectionBg)
+	selectionFgColor = lipgloss.Color(t.SelectionFg)
+	changedBgColor = lipgloss.Color(t.ChangedBg)
+
+	diffRemovedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.DiffRemoved))
+	diffAddedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color(t.DiffAdded))
+
+	overdueAgeColors = newOverdueAgeColors(t)
+	applyMarkdownTheme(t)
+}
@@ -0,0 +1,506 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// timeReportVisibleRows is the most tasks listed on the time report
+const timeReportVisibleRows = 20
+
+// timeEntry is a stretch of time tracked on a task
+type timeEntry struct {
+	ID        int64
+	TaskID    string
+	Content   string
+	ProjectID string
+	StartedAt time.Time
+	// EndedAt is zero while the entry is running
+	EndedAt time.Time
+}
+
+// duration returns how long the entry ran, up to now while it still runs
+func (e timeEntry) duration(now time.Time) time.Duration {
+	end := e.EndedAt
+	if end.IsZero() {
+		end = now
+	}
+	return end.Sub(e.StartedAt)
+}
+
+// durationWithin returns how much of the entry falls between from and to
+func (e timeEntry) durationWithin(from, to, now time.Time) time.Duration {
+	start, end := e.StartedAt, e.EndedAt
+	if end.IsZero() {
+		end = now
+	}
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return max(end.Sub(start), 0)
+}
+
+// timeTrackingLoadedMsg is sent with the running entry and the time tracked on each task, keyed by task ID
+type timeTrackingLoadedMsg struct {
+	running *timeEntry
+	totals  map[string]time.Duration
+}
+
+// timeTrackingTickMsg is sent every second while time is tracked, to update the footer
+type timeTrackingTickMsg int64
+
+// timeReportLoadedMsg is sent with the entries of the day or week shown on the time report
+type timeReportLoadedMsg []timeEntry
+
+// timeReportExportedMsg is sent when the time report has been written to a CSV file
+type timeReportExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// scanTimeEntries reads time entry rows, whose times are stored as RFC 3339 in UTC
+func scanTimeEntries(rows *sql.Rows) ([]timeEntry, error) {
+	var entries []timeEntry
+	for rows.Next() {
+		var entry timeEntry
+		var startedAt, endedAt string
+		if err := rows.Scan(&entry.ID, &entry.TaskID, &entry.Content, &entry.ProjectID, &startedAt, &endedAt); err != nil {
+			return nil, err
+		}
+		entry.StartedAt, _ = time.Parse(time.RFC3339, startedAt)
+		if endedAt != "" {
+			entry.EndedAt, _ = time.Parse(time.RFC3339, endedAt)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// StartTimeEntry stops the running entry, if any, and starts tracking a task
+func (c *CacheDB) StartTimeEntry(task TodoistTask, at time.Time) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	stamp := at.UTC().Format(time.RFC3339)
+	if _, err := tx.Exec(`UPDATE time_entries SET ended_at = ? WHERE ended_at = ''`, stamp); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`
+		INSERT INTO time_entries (task_id, content, project_id, started_at)
+		VALUES (?, ?, ?, ?)
+	`, task.ID, task.Content, task.ProjectID, stamp); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// StopTimeEntry ends the running entry
+func (c *CacheDB) StopTimeEntry(at time.Time) error {
+	_, err := c.db.Exec(`UPDATE time_entries SET ended_at = ? WHERE ended_at = ''`, at.UTC().Format(time.RFC3339))
+	return err
+}
+
+// LoadTimeTracking returns the running entry, or nil, and the time tracked on each task by finished entries
+func (c *CacheDB) LoadTimeTracking() (*timeEntry, map[string]time.Duration, error) {
+	rows, err := c.db.Query(`
+		SELECT id, task_id, content, project_id, started_at, ended_at FROM time_entries
+	`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	entries, err := scanTimeEntries(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var running *timeEntry
+	totals := make(map[string]time.Duration)
+	for i, entry := range entries {
+		if entry.EndedAt.IsZero() {
+			running = &entries[i]
+			continue
+		}
+		totals[entry.TaskID] += entry.duration(entry.EndedAt)
+	}
+	return running, totals, nil
+}
+
+// LoadTimeEntries returns the entries overlapping from and to, oldest first
+func (c *CacheDB) LoadTimeEntries(from, to time.Time) ([]timeEntry, error) {
+	rows, err := c.db.Query(`
+		SELECT id, task_id, content, project_id, started_at, ended_at FROM time_entries
+		WHERE started_at < ? AND (ended_at = '' OR ended_at > ?)
+		ORDER BY started_at
+	`, to.UTC().Format(time.RFC3339), from.UTC().Format(time.RFC3339))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+	return scanTimeEntries(rows)
+}
+
+// loadTimeTracking creates a command that reads the running entry and tracked totals from the cache
+func loadTimeTracking(cache *CacheDB) tea.Cmd {
+	if cache == nil {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		running, totals, err := cache.LoadTimeTracking()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load tracked time: %w", err))
+		}
+		return timeTrackingLoadedMsg{running: running, totals: totals}
+	})
+}
+
+// startTracking creates a command that starts tracking a task, stopping the running entry
+func startTracking(cache *CacheDB, task TodoistTask, at time.Time) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.StartTimeEntry(task, at); err != nil {
+			return errorMsg(fmt.Errorf("failed to start tracking time: %w", err))
+		}
+		return loadTimeTracking(cache)()
+	})
+}
+
+// stopTracking creates a command that stops the running entry
+func stopTracking(cache *CacheDB, at time.Time) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.StopTimeEntry(at); err != nil {
+			return errorMsg(fmt.Errorf("failed to stop tracking time: %w", err))
+		}
+		return loadTimeTracking(cache)()
+	})
+}
+
+// scheduleTrackingTick returns a command that ticks the running entry a second from now
+func scheduleTrackingTick(id int64) tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return timeTrackingTickMsg(id)
+	})
+}
+
+// toggleTracking starts tracking time on the selected task, or stops tracking it
+// Starting on another task stops the running entry first, so only one task is tracked at a time
+func (m *model) toggleTracking() tea.Cmd {
+	if m.cache == nil {
+		return nil
+	}
+	now := time.Now()
+	if m.selectedIndex < 0 || m.selectedIndex >= len(m.allTasks) || (m.tracking != nil && m.tracking.TaskID == m.allTasks[m.selectedIndex].ID) {
+		if m.tracking == nil {
+			return nil
+		}
+		entry := *m.tracking
+		m.tracking = nil
+		return tea.Batch(
+			m.notify(toastInfo, fmt.Sprintf("Stopped tracking %s after %s", entry.Content, formatTracked(entry.duration(now)))),
+			stopTracking(m.cache, now),
+		)
+	}
+
+	task := m.allTasks[m.selectedIndex]
+	m.tracking = &timeEntry{TaskID: task.ID, Content: task.Content, ProjectID: task.ProjectID, StartedAt: now}
+	return tea.Batch(m.notify(toastInfo, "Tracking time: "+task.Content), startTracking(m.cache, task, now))
+}
+
+// handleTimeTrackingLoaded takes over the running entry and totals, ticking the footer while an entry runs
+func (m *model) handleTimeTrackingLoaded(msg timeTrackingLoadedMsg) tea.Cmd {
+	previous := m.tracking
+	m.trackedTotals = msg.totals
+	m.tracking = msg.running
+	// An entry that was already ticking keeps its ticks
+	if m.tracking == nil || (previous != nil && previous.ID == m.tracking.ID) {
+		return nil
+	}
+	return scheduleTrackingTick(m.tracking.ID)
+}
+
+// handleTrackingTick keeps ticking while the entry runs; ticks of stopped entries are dropped
+func (m *model) handleTrackingTick(id int64) tea.Cmd {
+	if m.tracking == nil || m.tracking.ID != id {
+		return nil
+	}
+	return scheduleTrackingTick(id)
+}
+
+// formatTracked writes tracked time briefly, e.g. "0m", "45m" or "2h05m"
+func formatTracked(d time.Duration) string {
+	d = d.Truncate(time.Minute)
+	if d <= 0 {
+		return "0m"
+	}
+	return formatDuration(d)
+}
+
+// formatStopwatch writes running time as hours, minutes and seconds, e.g. "0:12:34"
+func formatStopwatch(d time.Duration) string {
+	seconds := int(d.Truncate(time.Second).Seconds())
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+}
+
+// renderTracking shows the running entry's time and task in the footer
+func (m model) renderTracking() string {
+	if m.tracking == nil {
+		return ""
+	}
+	return loadingStyle.Render(fmt.Sprintf("⏱ %s • %s • T: stop", formatStopwatch(m.tracking.duration(time.Now())), m.tracking.Content))
+}
+
+// renderTrackedTime writes the time tracked on a task for the task popup, including a running entry
+func (m model) renderTrackedTime(task TodoistTask) string {
+	total := m.trackedTotals[task.ID]
+	running := m.tracking != nil && m.tracking.TaskID == task.ID
+	if running {
+		total += m.tracking.duration(time.Now())
+	}
+	if total <= 0 && !running {
+		return ""
+	}
+	text := formatTracked(total)
+	if running {
+		text += " • tracking now"
+	}
+	return text
+}
+
+// timeReportRange returns the day, or the week from Monday, shown on the time report
+// Days start at day_starts_at, like "today" does
+func (m model) timeReportRange() (time.Time, time.Time) {
+	day := m.timeReportDay
+	from := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()).Add(dayCutoff)
+	if !m.timeReportWeekly {
+		return from, from.AddDate(0, 0, 1)
+	}
+	// Weeks start on Monday
+	from = from.AddDate(0, 0, -((int(from.Weekday()) + 6) % 7))
+	return from, from.AddDate(0, 0, 7)
+}
+
+// loadTimeReport creates a command that reads the entries of the shown day or week
+func (m model) loadTimeReport() tea.Cmd {
+	cache := m.cache
+	from, to := m.timeReportRange()
+	return tea.Cmd(func() tea.Msg {
+		entries, err := cache.LoadTimeEntries(from, to)
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load time report: %w", err))
+		}
+		return timeReportLoadedMsg(entries)
+	})
+}
+
+// openTimeReport shows today's time report
+func (m *model) openTimeReport() tea.Cmd {
+	m.showingTimeReport = true
+	m.timeReportDay = dayNow()
+	m.timeReport = nil
+	m.timeReportResult = ""
+	return m.loadTimeReport()
+}
+
+// moveTimeReport shows the day or week the given number of days or weeks away, never past today
+func (m *model) moveTimeReport(steps int) tea.Cmd {
+	days := steps
+	if m.timeReportWeekly {
+		days *= 7
+	}
+	day := m.timeReportDay.AddDate(0, 0, days)
+	if day.Format("2006-01-02") > dayNow().Format("2006-01-02") {
+		return nil
+	}
+	m.timeReportDay = day
+	m.timeReportResult = ""
+	return m.loadTimeReport()
+}
+
+// timeReportRow is a task's time on the time report
+type timeReportRow struct {
+	taskID    string
+	content   string
+	projectID string
+	tracked   time.Duration
+}
+
+// timeReportRows adds up the time of each task between from and to, most time first
+func timeReportRows(entries []timeEntry, from, to, now time.Time) []timeReportRow {
+	var rows []timeReportRow
+	index := make(map[string]int)
+	for _, entry := range entries {
+		i, ok := index[entry.TaskID]
+		if !ok {
+			i = len(rows)
+			index[entry.TaskID] = i
+			rows = append(rows, timeReportRow{taskID: entry.TaskID, content: entry.Content, projectID: entry.ProjectID})
+		}
+		rows[i].tracked += entry.durationWithin(from, to, now)
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].tracked > rows[j].tracked
+	})
+	return rows
+}
+
+// timeReportPath suggests a file name for exporting the shown day or week
+func (m model) timeReportPath() string {
+	from, _ := m.timeReportRange()
+	if m.timeReportWeekly {
+		return "todoist-time-week-" + from.Format("2006-01-02") + ".csv"
+	}
+	return "todoist-time-" + from.Format("2006-01-02") + ".csv"
+}
+
+// exportTimeReport creates a command that writes the shown entries to a CSV file, one row per entry
+// Entries reaching past the day or week count only their time within it
+func (m model) exportTimeReport() tea.Cmd {
+	path := m.timeReportPath()
+	entries := m.timeReport
+	from, to := m.timeReportRange()
+	client := m.client
+	return tea.Cmd(func() tea.Msg {
+		file, err := os.Create(path)
+		if err != nil {
+			return timeReportExportedMsg{path: path, err: fmt.Errorf("failed to create export file: %w", err)}
+		}
+		writer := csv.NewWriter(file)
+		_ = writer.Write([]string{"task_id", "content", "project", "started_at", "ended_at", "minutes"})
+		now := time.Now()
+		for _, entry := range entries {
+			ended := ""
+			if !entry.EndedAt.IsZero() {
+				ended = entry.EndedAt.In(todayLocation()).Format(time.RFC3339)
+			}
+			minutes := entry.durationWithin(from, to, now).Minutes()
+			_ = writer.Write([]string{entry.TaskID, entry.Content, client.GetProjectName(entry.ProjectID),
+				entry.StartedAt.In(todayLocation()).Format(time.RFC3339), ended, strconv.FormatFloat(minutes, 'f', 1, 64)})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			_ = file.Close()
+			return timeReportExportedMsg{path: path, err: fmt.Errorf("failed to write export: %w", err)}
+		}
+		if err := file.Close(); err != nil {
+			return timeReportExportedMsg{path: path, err: err}
+		}
+		return timeReportExportedMsg{path: path, count: len(entries)}
+	})
+}
+
+// handleTimeReportExported shows the export result on the time report
+func (m *model) handleTimeReportExported(msg timeReportExportedMsg) {
+	if msg.err != nil {
+		m.timeReportResult = errorStyle.MarginLeft(0).Render(msg.err.Error())
+		return
+	}
+	m.timeReportResult = fmt.Sprintf("Exported %d entries to %s", msg.count, msg.path)
+}
+
+// renderTimeReport creates the time report screen for the shown day or week
+func (m model) renderTimeReport() string {
+	var content strings.Builder
+	from, to := m.timeReportRange()
+	now := time.Now()
+
+	// Screen title with the shown day or week
+	title := "⏱ Time: " + from.Format("Monday, Jan 2 2006")
+	if m.timeReportWeekly {
+		title = "⏱ Time: week of " + from.Format("Jan 2 2006")
+	}
+	if !now.Before(from) && now.Before(to) {
+		title += " (now)"
+	}
+	content.WriteString(popupTitleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	// Tasks by time tracked, most first
+	rows := timeReportRows(m.timeReport, from, to, now)
+	var total time.Duration
+	for _, row := range rows {
+		total += row.tracked
+	}
+	if len(rows) == 0 {
+		content.WriteString(projectStyle.Render("No time tracked"))
+		content.WriteString("\n")
+	}
+	for i, row := range rows {
+		if i == timeReportVisibleRows {
+			content.WriteString(projectStyle.Render(fmt.Sprintf("… and %d more", len(rows)-i)))
+			content.WriteString("\n")
+			break
+		}
+		mark := " "
+		if m.tracking != nil && m.tracking.TaskID == row.taskID {
+			mark = "⏱"
+		}
+		content.WriteString(fmt.Sprintf("%s %7s  %s ", mark, formatTracked(row.tracked), row.content))
+		content.WriteString(projectStyle.Render(m.client.GetProjectName(row.projectID)))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+	content.WriteString(popupFieldStyle.Render("Total: "))
+	content.WriteString(formatTracked(total))
+	content.WriteString("\n\n")
+
+	// Result of the last export
+	if m.timeReportResult != "" {
+		content.WriteString(m.timeReportResult)
+		content.WriteString("\n\n")
+	}
+
+	// Instructions
+	period, other := "day", "w: week"
+	if m.timeReportWeekly {
+		period, other = "week", "d: day"
+	}
+	content.WriteString(fmt.Sprintf("[: previous %s • ]: next %s • %s • e: export CSV • ESC: close", period, period, other))
+
+	// Calculate panel width
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleTimeReportInput handles keyboard input when the time report is visible
+func (m model) handleTimeReportInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the time report
+		m.showingTimeReport = false
+	case "[":
+		return m, m.moveTimeReport(-1)
+	case "]":
+		return m, m.moveTimeReport(1)
+	case "w", "d":
+		// Switch between the day and the week it's in
+		m.timeReportWeekly = msg.String() == "w"
+		m.timeReportResult = ""
+		return m, m.loadTimeReport()
+	case "e":
+		return m, m.exportTimeReport()
+	default:
+		if m.keys.action(keyContextMain, msg.String()) == actionTimeReport {
+			m.showingTimeReport = false
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	// Embed the time zone database, so zone names also work on systems without one, such as Windows
+	_ "time/tzdata"
+)
+
+// Special timezone settings; anything else is an IANA zone name such as "Europe/Berlin"
+const (
+	// timezoneLocal follows the computer's clock, the default
+	timezoneLocal = "local"
+	// timezoneTodoist follows the timezone set in the Todoist account
+	timezoneTodoist = "todoist"
+)
+
+// accountTimezoneKey is the cache metadata key of the last known timezone of the Todoist account
+const accountTimezoneKey = "account_timezone"
+
+// dayLocation is the location "today" is calculated in, or nil for the computer's timezone
+// Atomic, since the account's timezone can arrive while commands compute dates
+var dayLocation atomic.Pointer[time.Location]
+
+// accountTimezoneMsg is sent when the timezone of the Todoist account was fetched and cached
+type accountTimezoneMsg struct {
+	location *time.Location
+	err      error
+}
+
+// todoistUser holds the fields of the Todoist user this application reads
+type todoistUser struct {
+	// TzInfo describes the timezone set in the account
+	TzInfo struct {
+		// Timezone is the IANA zone name, e.g. "Europe/Berlin"
+		Timezone string `json:"timezone"`
+	} `json:"tz_info"`
+}
+
+// GetAccountTimezone fetches the timezone set in the Todoist account through the Sync API
+func (c *TodoistClient) GetAccountTimezone(ctx context.Context) (string, error) {
+	// Ask only for the user resource
+	body, err := json.Marshal(map[string]any{"sync_token": fullSyncToken, "resource_types": []string{"user"}})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal sync request: %w", err)
+	}
+
+	// Create HTTP POST request for sync endpoint
+	req, err := http.NewRequestWithContext(withSyncRead(ctx), "POST", c.syncAPIBase+"/sync", bytes.NewBuffer(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return "", newAPIError(resp)
+	}
+
+	// Parse the user
+	var result struct {
+		User todoistUser `json:"user"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	if result.User.TzInfo.Timezone == "" {
+		return "", fmt.Errorf("the account has no timezone set")
+	}
+
+	return result.User.TzInfo.Timezone, nil
+}
+
+// timezoneSetting returns the timezone "today" follows, from --timezone or the config
+func (c *Config) timezoneSetting() string {
+	if c.timezone != "" {
+		return c.timezone
+	}
+	return c.Timezone
+}
+
+// resolveTimezone returns the location that "today" is calculated in for a timezone setting
+// Returns nil for the computer's own timezone, and for the account's, which is only known once the cache is open
+func resolveTimezone(setting string) (*time.Location, error) {
+	switch setting {
+	case "", timezoneLocal, timezoneTodoist:
+		return nil, nil
+	}
+
+	location, err := time.LoadLocation(setting)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q, use an IANA name like Europe/Berlin, %q or %q", setting, timezoneLocal, timezoneTodoist)
+	}
+	return location, nil
+}
+
+// applyTimezone makes dayNow, and so every "today", follow the given location instead of the computer's timezone
+// nil goes back to the computer's timezone
+func applyTimezone(location *time.Location) {
+	dayLocation.Store(location)
+}
+
+// todayLocation returns the location "today" is calculated in
+func todayLocation() *time.Location {
+	if location := dayLocation.Load(); location != nil {
+		return location
+	}
+	return time.Local
+}
+
+// applyCachedAccountTimezone follows the account's timezone as last fetched, so startup never waits for Todoist
+// Until it was fetched once, the computer's timezone is used
+func applyCachedAccountTimezone(cache *CacheDB) {
+	if name := cache.metadata(accountTimezoneKey); name != "" {
+		if location, err := time.LoadLocation(name); err == nil {
+			applyTimezone(location)
+		}
+	}
+}
+
+// fetchAccountTimezone fetches the account's timezone and caches it for the next start
+func fetchAccountTimezone(ctx context.Context, client *TodoistClient, cache *CacheDB) (*time.Location, error) {
+	name, err := client.GetAccountTimezone(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the Todoist account timezone: %w", err)
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown Todoist account timezone %q: %w", name, err)
+	}
+	_ = cache.setMetadata(accountTimezoneKey, name)
+	return location, nil
+}
+
+// refreshAccountTimezone creates a command that fetches the account's timezone in the background,
+// when "today" follows it
+func refreshAccountTimezone(ctx context.Context, client *TodoistClient, cache *CacheDB, config *Config) tea.Cmd {
+	if client == nil || cache == nil || config.timezoneSetting() != timezoneTodoist {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		location, err := fetchAccountTimezone(ctx, client, cache)
+		return accountTimezoneMsg{location: location, err: err}
+	})
+}
+
+// handleAccountTimezone follows the account's timezone once fetched, reloading the view when it moved "today"
+func (m *model) handleAccountTimezone(msg accountTimezoneMsg) tea.Cmd {
+	if msg.err != nil {
+		// The cached timezone, or the computer's, stays in use
+		m.recordSyncError(msg.err)
+		return nil
+	}
+	before := dayNow().Format("2006-01-02")
+	applyTimezone(msg.location)
+	if dayNow().Format("2006-01-02") != before {
+		return m.reloadCurrentView()
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// How long toasts stay on screen; errors stay longer so there's time to read them
+const (
+	toastDuration      = 4 * time.Second
+	toastErrorDuration = 8 * time.Second
+)
+
+// toastHistoryLimit is the number of past messages kept for the message history popup
+const toastHistoryLimit = 50
+
+// toastHistoryVisible is the number of messages listed in the message history popup
+const toastHistoryVisible = 15
+
+// toastKind tells what a toast reports, which picks its icon and color
+type toastKind int
+
+const (
+	toastInfo toastKind = iota
+	toastSuccess
+	toastError
+)
+
+// toast is a short message shown in the status bar above the footer until it expires
+type toast struct {
+	// id tells a toast apart from the ones shown after it, so only its own timer dismisses it
+	id int
+	// kind is what the toast reports
+	kind toastKind
+	// text is the message
+	text string
+	// at is when the toast was shown
+	at time.Time
+}
+
+// toastExpiredMsg is sent when the toast with the given ID has been shown long enough
+type toastExpiredMsg int
+
+// icon returns the symbol shown before the toast's text
+func (t toast) icon() string {
+	switch t.kind {
+	case toastSuccess:
+		return "✓"
+	case toastError:
+		return "✗"
+	default:
+		return "ℹ"
+	}
+}
+
+// style returns the style of the toast's text in the status bar
+func (t toast) style() lipgloss.Style {
+	switch t.kind {
+	case toastSuccess:
+		return diffAddedStyle.MarginLeft(2)
+	case toastError:
+		return errorStyle
+	default:
+		return loadingStyle
+	}
+}
+
+// notify shows a toast and adds it to the message history, returning the command that dismisses it
+func (m *model) notify(kind toastKind, text string) tea.Cmd {
+	m.toastSeq++
+	shown := toast{id: m.toastSeq, kind: kind, text: text, at: time.Now()}
+	m.toast = &shown
+
+	m.toastHistory = append(m.toastHistory, shown)
+	if len(m.toastHistory) > toastHistoryLimit {
+		m.toastHistory = m.toastHistory[len(m.toastHistory)-toastHistoryLimit:]
+	}
+
+	duration := toastDuration
+	if kind == toastError {
+		duration = toastErrorDuration
+	}
+	return tea.Tick(duration, func(time.Time) tea.Msg { return toastExpiredMsg(shown.id) })
+}
+
+// dismissToast hides the toast with the given ID, unless a newer one replaced it
+func (m *model) dismissToast(id int) {
+	if m.toast != nil && m.toast.id == id {
+		m.toast = nil
+	}
+}
+
+// hasShownTasks reports whether a task list has been shown, so errors can be reported without hiding it
+func (m model) hasShownTasks() bool {
+	return len(m.allTasks) > 0 || !m.cachedAt.IsZero() || !m.lastTasksSync.IsZero()
+}
+
+// taskMessage names a listed task after a message, e.g. "Task completed: Buy milk"
+func (m model) taskMessage(message, taskID string) string {
+	for _, task := range m.allTasks {
+		if task.ID == taskID {
+			return message + ": " + task.Content
+		}
+	}
+	return message
+}
+
+// renderToast renders the status bar line: the current toast, or sync progress while refreshing
+// Returns "" when there's nothing to show
+func (m model) renderToast() string {
+	var line string
+	switch {
+	case m.toast != nil:
+		line = m.toast.style().Render(m.toast.icon() + " " + m.toast.text)
+	case m.refreshingInBackground:
+		line = loadingStyle.Render("⟳ Syncing with Todoist…")
+	case m.replayingOps:
+		line = loadingStyle.Render(fmt.Sprintf("⟳ Sending %d queued change(s)…", len(m.pendingOps)))
+	default:
+		return ""
+	}
+	return lipgloss.NewStyle().MaxWidth(m.width).Render(line)
+}
+
+// renderMessages creates the popup listing the recent messages, newest first
+func (m model) renderMessages() string {
+	var content strings.Builder
+
+	// Popup title
+	content.WriteString(popupTitleStyle.Render("🔔 Messages"))
+	content.WriteString("\n\n")
+
+	if len(m.toastHistory) == 0 {
+		content.WriteString("No messages yet")
+		content.WriteString("\n")
+	}
+	for i := len(m.toastHistory) - 1; i >= 0; i-- {
+		shown := len(m.toastHistory) - 1 - i
+		if shown == toastHistoryVisible {
+			content.WriteString(projectStyle.Render(fmt.Sprintf("… and %d older", i+1)))
+			content.WriteString("\n")
+			break
+		}
+		entry := m.toastHistory[i]
+		content.WriteString(projectStyle.Render(entry.at.Format("15:04:05")))
+		content.WriteString(" ")
+		content.WriteString(entry.style().UnsetMargins().Render(entry.icon() + " " + entry.text))
+		content.WriteString("\n")
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("Press ESC to close")
+
+	// Calculate popup size and position
+	maxWidth := 70
+	if m.width < 80 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleMessagesInput handles keyboard input when the message history popup is visible
+func (m model) handleMessagesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "escape" || m.keys.action(keyContextMain, msg.String()) == actionMessages {
+		m.showingMessages = false
+	}
+	return m, nil
+}
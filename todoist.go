@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sort"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -14,6 +19,18 @@ import (
 // todoistAPIBase is the base URL for Todoist REST API v2
 const todoistAPIBase = "https://api.todoist.com/rest/v2"
 
+// todoistAPIURLEnv names the environment variable that points the client at another server,
+// such as the mock server, instead of api.todoist.com; it takes precedence over api_url in the config
+const todoistAPIURLEnv = "TODOIST_API_URL"
+
+// Todoist allows a fixed number of requests per user within a rolling window
+const (
+	// todoistRateLimit is the maximum number of requests allowed per window
+	todoistRateLimit = 450
+	// todoistRateLimitWindow is the length of the rate limit window
+	todoistRateLimitWindow = 15 * time.Minute
+)
+
 // TodoistTask represents a task from the Todoist API
 type TodoistTask struct {
 	// ID is the unique task identifier
@@ -22,6 +39,8 @@ type TodoistTask struct {
 	ProjectID string `json:"project_id"`
 	// SectionID is the ID of the section containing this task
 	SectionID string `json:"section_id"`
+	// ParentID is the ID of the task this is a subtask of, empty for top-level tasks
+	ParentID string `json:"parent_id"`
 	// Content is the task title/content
 	Content string `json:"content"`
 	// Description is the additional task description
@@ -33,13 +52,15 @@ type TodoistTask struct {
 	// Priority is the priority level (1-4, where 4 is highest)
 	Priority int `json:"priority"`
 	// Assignee is the user ID of the assignee
-	Assignee string `json:"assignee"`
+	Assignee string `json:"assignee_id"`
 	// AssignerID is the user ID of who assigned the task
 	AssignerID string `json:"assigner_id"`
 	// CommentCount is the number of comments on the task
 	CommentCount int `json:"comment_count"`
 	// CreatedAt is when the task was created
 	CreatedAt time.Time `json:"created_at"`
+	// UpdatedAt is when the task was last changed, zero for tasks fetched from the REST API
+	UpdatedAt time.Time `json:"updated_at"`
 	// CreatorID is the user ID of task creator
 	CreatorID string `json:"creator_id"`
 	// Due contains due date information (optional)
@@ -48,6 +69,8 @@ type TodoistTask struct {
 	Duration *Duration `json:"duration"`
 	// URL is the URL to the task in Todoist
 	URL string `json:"url"`
+	// Order is the task's position in Todoist's manual order, within its project or parent task
+	Order int `json:"order"`
 }
 
 // Due represents due date information for a task
@@ -80,6 +103,8 @@ type TodoistProject struct {
 	Name string `json:"name"`
 	// Color is the project color
 	Color string `json:"color"`
+	// IsInboxProject is set on the Inbox, where tasks go when no project is given
+	IsInboxProject bool `json:"is_inbox_project,omitempty"`
 }
 
 // TodoistClient handles communication with the Todoist API
@@ -88,23 +113,164 @@ type TodoistClient struct {
 	token string
 	// httpClient is the HTTP client for making requests
 	httpClient *http.Client
-	// projects is a cache mapping project IDs to names
-	projects map[string]string
+	// projects is an immutable snapshot mapping project IDs to names
+	// Renders read it on every row without locking; reloads swap in a new map instead of mutating it
+	projects atomic.Pointer[map[string]string]
+	// mu guards requestTimes, which is updated from concurrent commands
+	mu sync.Mutex
+	// requestTimes records when recent requests were sent, for rate limit tracking
+	requestTimes []time.Time
+	// maxRetries is how many times a rate limited or transiently failed request is retried
+	maxRetries int
+	// apiBase is the REST API base URL, overridden by api_url or TODOIST_API_URL
+	apiBase string
+	// syncAPIBase is the Sync API base URL, overridden by api_url or TODOIST_API_URL
+	syncAPIBase string
+	// middleware wraps sending each request, outermost first
+	middleware []requestMiddleware
+	// validators is the cache keeping the ETag and Last-Modified of responses, nil until it's open
+	validators atomic.Pointer[CacheDB]
 }
 
-// NewTodoistClient creates a new Todoist API client with the given token
+// NewTodoistClient creates a new Todoist API client with the given token, talking to api.todoist.com
+// Use configure to apply the config, e.g. to send its requests to another server or add headers
 func NewTodoistClient(token string) *TodoistClient {
-	return &TodoistClient{
-		token:      token,
-		httpClient: &http.Client{Timeout: 30 * time.Second}, // 30 second timeout for API requests
-		projects:   make(map[string]string),                 // Initialize empty project cache
+	client := &TodoistClient{
+		token:       token,
+		httpClient:  &http.Client{Timeout: 30 * time.Second}, // 30 second timeout for API requests
+		maxRetries:  defaultMaxRetries,
+		apiBase:     todoistAPIBase,
+		syncAPIBase: todoistSyncAPIBase,
+	}
+	// Every attempt counts against the rate limit and shows up in --trace, and GET responses keep their
+	// validators for conditional requests
+	client.use(client.countRequests, traceRequests, client.conditionalRequests)
+	return client
+}
+
+// todoistToken returns the API token of the active profile, the configured token source, or TODOIST_TOKEN
+// A server set through TODOIST_API_URL doesn't check tokens, so a placeholder is used when none is set
+func todoistToken(config *Config) (string, error) {
+	if config == nil {
+		return lookupToken(nil)
+	}
+	if token, ok := config.tokens[config.profile]; ok {
+		return token, nil
+	}
+	token, err := lookupToken(config)
+	if err != nil {
+		return "", err
+	}
+	if config.tokens == nil {
+		config.tokens = make(map[string]string)
+	}
+	config.tokens[config.profile] = token
+	return token, nil
+}
+
+// lookupToken reads the API token from its source, without remembering it
+func lookupToken(config *Config) (string, error) {
+	if profile := config.activeProfile(); profile != nil {
+		return profile.token()
+	}
+
+	// A token command or the keychain comes first, with TODOIST_TOKEN as the fallback when they fail
+	token, err := configuredToken(config)
+	if token != "" {
+		return token, nil
+	}
+	if err != nil {
+		if os.Getenv("TODOIST_TOKEN") == "" {
+			return "", err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v, using TODOIST_TOKEN\n", err)
+	}
+
+	token = os.Getenv("TODOIST_TOKEN")
+	if token == "" && os.Getenv(todoistAPIURLEnv) != "" {
+		token = "mock"
+	}
+	if token == "" {
+		return "", fmt.Errorf("TODOIST_TOKEN environment variable is required")
+	}
+	return token, nil
+}
+
+// do sends an HTTP request, retrying when rate limited or on transient server errors
+func (c *TodoistClient) do(req *http.Request) (*http.Response, error) {
+	return c.sendWithRetries(req)
+}
+
+// send sends a single HTTP request through the client's middleware, which records it against the rate limit budget
+func (c *TodoistClient) send(req *http.Request) (*http.Response, error) {
+	return c.handler()(req)
+}
+
+// RateLimitBudget returns how many requests were sent in the current rate limit
+// window and how many remain before Todoist starts rejecting requests
+func (c *TodoistClient) RateLimitBudget() (used int, remaining int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pruneRequestTimes(time.Now())
+	used = len(c.requestTimes)
+	remaining = todoistRateLimit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return used, remaining
+}
+
+// pruneRequestTimes drops requests that have fallen out of the rate limit window
+// The times are in order, so the recent ones are the tail; c.mu must be held
+func (c *TodoistClient) pruneRequestTimes(now time.Time) {
+	cutoff := now.Add(-todoistRateLimitWindow)
+	old := 0
+	for old < len(c.requestTimes) && !c.requestTimes[old].After(cutoff) {
+		old++
+	}
+	if old > 0 {
+		c.requestTimes = append(c.requestTimes[:0], c.requestTimes[old:]...)
 	}
 }
 
 // GetTasks fetches all active tasks from the Todoist API
-func (c *TodoistClient) GetTasks() ([]TodoistTask, error) {
+func (c *TodoistClient) GetTasks(ctx context.Context) ([]TodoistTask, error) {
+	return c.getTasks(ctx, nil)
+}
+
+// GetProjectTasks fetches all active tasks in the given project from the Todoist API
+func (c *TodoistClient) GetProjectTasks(ctx context.Context, projectID string) ([]TodoistTask, error) {
+	return c.getTasks(ctx, url.Values{"project_id": {projectID}})
+}
+
+// GetTasksByLabel fetches the active tasks carrying a label, across all projects
+func (c *TodoistClient) GetTasksByLabel(ctx context.Context, label string) ([]TodoistTask, error) {
+	return c.getTasks(ctx, url.Values{"label": {label}})
+}
+
+// GetFilteredTasks fetches the active tasks matching a Todoist filter query, e.g. "today | overdue"
+// Todoist evaluates the filter, so tasks that don't match are never downloaded
+func (c *TodoistClient) GetFilteredTasks(ctx context.Context, filter string) ([]TodoistTask, error) {
+	return c.getTasks(ctx, url.Values{"filter": {filter}})
+}
+
+// GetTasksByIDs fetches the given active tasks from the Todoist API
+// Tasks that were completed or deleted are not returned
+func (c *TodoistClient) GetTasksByIDs(ctx context.Context, ids []string) ([]TodoistTask, error) {
+	return c.getTasks(ctx, url.Values{"ids": {strings.Join(ids, ",")}})
+}
+
+// getTasks fetches active tasks matching the given query parameters
+func (c *TodoistClient) getTasks(ctx context.Context, query url.Values) ([]TodoistTask, error) {
+	// Build the tasks endpoint URL with any filters
+	endpoint := c.apiBase + "/tasks"
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
 	// Create HTTP GET request for tasks endpoint
-	req, err := http.NewRequest("GET", todoistAPIBase+"/tasks", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -114,7 +280,7 @@ func (c *TodoistClient) GetTasks() ([]TodoistTask, error) {
 	req.Header.Set("Content-Type", "application/json")
 
 	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -122,12 +288,12 @@ func (c *TodoistClient) GetTasks() ([]TodoistTask, error) {
 
 	// Check if the API returned a success status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
-	// Parse the JSON response into TodoistTask structs
-	var tasks []TodoistTask
-	if err := json.NewDecoder(resp.Body).Decode(&tasks); err != nil {
+	// Parse the JSON response into TodoistTask structs, one task at a time
+	tasks := []TodoistTask{}
+	if err := decodeJSONArray(resp.Body, func(task TodoistTask) { tasks = append(tasks, task) }); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
@@ -135,9 +301,9 @@ func (c *TodoistClient) GetTasks() ([]TodoistTask, error) {
 }
 
 // GetProjects fetches all projects from the Todoist API
-func (c *TodoistClient) GetProjects() ([]TodoistProject, error) {
+func (c *TodoistClient) GetProjects(ctx context.Context) ([]TodoistProject, error) {
 	// Create HTTP GET request for projects endpoint
-	req, err := http.NewRequest("GET", todoistAPIBase+"/projects", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", c.apiBase+"/projects", nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -147,83 +313,81 @@ func (c *TodoistClient) GetProjects() ([]TodoistProject, error) {
 	req.Header.Set("Content-Type", "application/json")
 
 	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
+	// A conditional request is answered without data when nothing changed
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, errNotModified
+	}
+
 	// Check if the API returned a success status
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
-	// Parse the JSON response into TodoistProject structs
-	var projects []TodoistProject
-	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
+	// Parse the JSON response into TodoistProject structs, one project at a time
+	projects := []TodoistProject{}
+	if err := decodeJSONArray(resp.Body, func(project TodoistProject) { projects = append(projects, project) }); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	return projects, nil
 }
 
-// loadProjects loads project data into the cache if not already loaded
-func (c *TodoistClient) loadProjects() error {
-	// Skip loading if projects are already cached
-	if len(c.projects) > 0 {
-		return nil
-	}
-
-	// Fetch projects from the API
-	projects, err := c.GetProjects()
-	if err != nil {
-		return err
-	}
-
-	// Populate the cache with project ID to name mappings
-	for _, project := range projects {
-		c.projects[project.ID] = project.Name
-	}
-
-	return nil
-}
-
 // LoadProjectsFromCache populates the client's project cache from cached project data
 func (c *TodoistClient) LoadProjectsFromCache(projects []TodoistProject) {
-	// Clear existing project cache
-	c.projects = make(map[string]string)
-	
-	// Populate cache with project ID to name mappings
+	// Build a new snapshot sized for the projects, so readers never see a partly filled map
+	names := make(map[string]string, len(projects))
 	for _, project := range projects {
-		c.projects[project.ID] = project.Name
+		names[project.ID] = project.Name
 	}
+	c.projects.Store(&names)
 }
 
 // GetProjectName returns the project name for a given project ID
-// Returns "Unknown Project" if the project ID is not found in the cache
+// Returns the raw project ID while no projects are loaded, and "Unknown Project" if the ID is not found
 func (c *TodoistClient) GetProjectName(projectID string) string {
-	if name, exists := c.projects[projectID]; exists {
+	names := c.projects.Load()
+	if names == nil || len(*names) == 0 {
+		if projectID != "" {
+			return projectID
+		}
+		return "Unknown Project"
+	}
+	if name, exists := (*names)[projectID]; exists {
 		return name
 	}
 	return "Unknown Project"
 }
 
-// GetTodaysTasks fetches and filters tasks that are due today or overdue
-// Returns tasks sorted with overdue tasks first (oldest first), then today's tasks by priority
-func (c *TodoistClient) GetTodaysTasks() ([]TodoistTask, error) {
-	// Load project information first to enable project name lookups
-	if err := c.loadProjects(); err != nil {
-		return nil, fmt.Errorf("failed to load projects: %w", err)
-	}
+// todayFilter is the Todoist filter query for the tasks that can be due today or overdue
+// Tomorrow is included since Todoist's today follows the account's timezone, which can be a day behind the app's;
+// the tasks are trimmed with filterTodaysTasks once fetched
+const todayFilter = "overdue | today | tomorrow"
 
-	// Fetch all active tasks from the API
-	allTasks, err := c.GetTasks()
+// GetTodaysTasks fetches the tasks that are due today or overdue
+// Returns tasks sorted with overdue tasks first (oldest first), then today's tasks by priority
+// Project names aren't loaded here, so the caller can fetch the projects at the same time
+func (c *TodoistClient) GetTodaysTasks(ctx context.Context) ([]TodoistTask, error) {
+	// Only fetch the tasks due today or overdue, not every undated task in the account
+	tasks, err := c.GetFilteredTasks(ctx, todayFilter)
 	if err != nil {
 		return nil, err
 	}
 
+	// Filtered again, since Todoist's "today" follows the account's timezone rather than the app's
+	return filterTodaysTasks(tasks), nil
+}
+
+// filterTodaysTasks keeps only tasks that are due today or overdue
+// Returns tasks sorted with overdue tasks first (oldest first), then today's tasks by priority
+func filterTodaysTasks(allTasks []TodoistTask) []TodoistTask {
 	// Get today's date in YYYY-MM-DD format for comparison
-	today := time.Now().Format("2006-01-02")
+	today := dayNow().Format("2006-01-02")
 	var todaysTasks []TodoistTask
 
 	// Filter tasks to include only those due today or overdue
@@ -237,42 +401,10 @@ func (c *TodoistClient) GetTodaysTasks() ([]TodoistTask, error) {
 		}
 	}
 
-	// Sort tasks with smart ordering logic
-	sort.Slice(todaysTasks, func(i, j int) bool {
-		taskI := todaysTasks[i]
-		taskJ := todaysTasks[j]
-
-		// Determine if each task is overdue
-		isOverdueI := isOverdue(taskI.Due.Date, today)
-		isOverdueJ := isOverdue(taskJ.Due.Date, today)
-
-		// Prioritize overdue tasks over today's tasks
-		if isOverdueI && !isOverdueJ {
-			return true
-		}
-		if !isOverdueI && isOverdueJ {
-			return false
-		}
-
-		// Parse dates for comparison
-		dateI, errI := time.Parse("2006-01-02", taskI.Due.Date)
-		dateJ, errJ := time.Parse("2006-01-02", taskJ.Due.Date)
-
-		// Fallback to priority sorting if date parsing fails
-		if errI != nil || errJ != nil {
-			return taskI.Priority > taskJ.Priority
-		}
-
-		// For overdue tasks: sort by date (oldest first)
-		if isOverdueI && isOverdueJ {
-			return dateI.Before(dateJ)
-		}
-
-		// For today's tasks: sort by priority (higher priority first)
-		return taskI.Priority > taskJ.Priority
-	})
+	// Sort overdue tasks first, oldest first, then today's tasks by priority
+	sortTasks(todaysTasks, sortDue, nil)
 
-	return todaysTasks, nil
+	return todaysTasks
 }
 
 // isOverdue checks if a task date is before today's date
@@ -302,16 +434,20 @@ type NewTaskRequest struct {
 	Description string `json:"description,omitempty"`
 	// ProjectID is the ID of the project to add the task to (optional)
 	ProjectID string `json:"project_id,omitempty"`
+	// SectionID is the ID of the section to add the task to (optional)
+	SectionID string `json:"section_id,omitempty"`
 	// Priority is the priority level (1-4, where 4 is highest, optional)
 	Priority int `json:"priority,omitempty"`
 	// Labels is an array of label names (optional)
 	Labels []string `json:"labels,omitempty"`
 	// DueString is a human-readable due date string (optional)
 	DueString string `json:"due_string,omitempty"`
+	// AssigneeID is the user ID of a collaborator to assign the task to, in shared projects (optional)
+	AssigneeID string `json:"assignee_id,omitempty"`
 }
 
 // CreateTask creates a new task in Todoist
-func (c *TodoistClient) CreateTask(task NewTaskRequest) (*TodoistTask, error) {
+func (c *TodoistClient) CreateTask(ctx context.Context, task NewTaskRequest) (*TodoistTask, error) {
 	// Convert the task request to JSON
 	taskJSON, err := json.Marshal(task)
 	if err != nil {
@@ -319,7 +455,7 @@ func (c *TodoistClient) CreateTask(task NewTaskRequest) (*TodoistTask, error) {
 	}
 
 	// Create HTTP POST request for tasks endpoint
-	req, err := http.NewRequest("POST", todoistAPIBase+"/tasks",
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/tasks",
 		bytes.NewBuffer(taskJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -330,7 +466,7 @@ func (c *TodoistClient) CreateTask(task NewTaskRequest) (*TodoistTask, error) {
 	req.Header.Set("Content-Type", "application/json")
 
 	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to make request: %w", err)
 	}
@@ -338,7 +474,7 @@ func (c *TodoistClient) CreateTask(task NewTaskRequest) (*TodoistTask, error) {
 
 	// Check if the API returned a success status
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return nil, newAPIError(resp)
 	}
 
 	// Parse the JSON response into TodoistTask struct
@@ -350,10 +486,76 @@ func (c *TodoistClient) CreateTask(task NewTaskRequest) (*TodoistTask, error) {
 	return &createdTask, nil
 }
 
+// UpdateTaskRequest represents the fields that can be changed on an existing task
+// Zero values are left out so only the given fields are updated
+type UpdateTaskRequest struct {
+	// Content is the new task title/content (optional)
+	Content string `json:"content,omitempty"`
+	// Description is the new task description (optional)
+	Description string `json:"description,omitempty"`
+	// Priority is the new priority level (1-4, where 4 is highest, optional)
+	Priority int `json:"priority,omitempty"`
+	// Labels replaces the task's labels when set; an empty slice removes all labels (optional)
+	Labels *[]string `json:"labels,omitempty"`
+	// DueString is a human-readable due date string, "no date" clears it (optional)
+	DueString string `json:"due_string,omitempty"`
+}
+
+// UpdateTask updates an existing task in Todoist
+func (c *TodoistClient) UpdateTask(ctx context.Context, taskID string, update UpdateTaskRequest) (*TodoistTask, error) {
+	// Convert the update request to JSON
+	updateJSON, err := json.Marshal(update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal task update: %w", err)
+	}
+
+	// Create HTTP POST request for task endpoint
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/tasks/"+taskID,
+		bytes.NewBuffer(updateJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set required headers for Todoist API authentication
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Execute the HTTP request
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// Check if the API returned a success status
+	if resp.StatusCode != http.StatusOK {
+		return nil, newAPIError(resp)
+	}
+
+	// Parse the JSON response into TodoistTask struct
+	var updatedTask TodoistTask
+	if err := json.NewDecoder(resp.Body).Decode(&updatedTask); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &updatedTask, nil
+}
+
+// MoveTask moves a task to another project
+// The REST API cannot change a task's project, so this goes through the Sync API
+func (c *TodoistClient) MoveTask(ctx context.Context, taskID, projectID string) error {
+	return c.runSyncCommands(ctx, []syncCommand{
+		newSyncCommand("item_move", map[string]any{
+			"id":         taskID,
+			"project_id": projectID,
+		}),
+	})
+}
+
 // CompleteTask marks a task as completed in Todoist
-func (c *TodoistClient) CompleteTask(taskID string) error {
+func (c *TodoistClient) CompleteTask(ctx context.Context, taskID string) error {
 	// Create HTTP POST request for task close endpoint
-	req, err := http.NewRequest("POST", todoistAPIBase+"/tasks/"+taskID+"/close", nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", c.apiBase+"/tasks/"+taskID+"/close", nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -362,7 +564,7 @@ func (c *TodoistClient) CompleteTask(taskID string) error {
 	req.Header.Set("Authorization", "Bearer "+c.token)
 
 	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -370,16 +572,16 @@ func (c *TodoistClient) CompleteTask(taskID string) error {
 
 	// Check if the API returned a success status
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
 // DeleteTask permanently deletes a task from Todoist
-func (c *TodoistClient) DeleteTask(taskID string) error {
+func (c *TodoistClient) DeleteTask(ctx context.Context, taskID string) error {
 	// Create HTTP DELETE request for task endpoint
-	req, err := http.NewRequest("DELETE", todoistAPIBase+"/tasks/"+taskID, nil)
+	req, err := http.NewRequestWithContext(ctx, "DELETE", c.apiBase+"/tasks/"+taskID, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -388,7 +590,7 @@ func (c *TodoistClient) DeleteTask(taskID string) error {
 	req.Header.Set("Authorization", "Bearer "+c.token)
 
 	// Execute the HTTP request
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return fmt.Errorf("failed to make request: %w", err)
 	}
@@ -396,17 +598,17 @@ func (c *TodoistClient) DeleteTask(taskID string) error {
 
 	// Check if the API returned a success status
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
-		return fmt.Errorf("API request failed with status %d", resp.StatusCode)
+		return newAPIError(resp)
 	}
 
 	return nil
 }
 
 // completeTask creates a command that completes a task via Todoist API
-func completeTask(client *TodoistClient, taskID string) tea.Cmd {
+func completeTask(ctx context.Context, client *TodoistClient, taskID string) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
 		// Call the API to complete the task
-		err := client.CompleteTask(taskID)
+		err := client.CompleteTask(ctx, taskID)
 		if err != nil {
 			// Return error message if API call fails
 			return errorMsg(err)
@@ -416,42 +618,44 @@ func completeTask(client *TodoistClient, taskID string) tea.Cmd {
 	})
 }
 
-// createTaskWithDetails creates a command that creates a new task with detailed parameters
-func createTaskWithDetails(client *TodoistClient, content string, priority int, projectID, deadline string) tea.Cmd {
+// createTaskWithDetails creates a command that creates a new task with the details filled in the form
+// The answer names the placeholder row shown for the task meanwhile
+func createTaskWithDetails(ctx context.Context, client *TodoistClient, placeholderID string, taskRequest NewTaskRequest) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		// Create the task request with form data
-		taskRequest := NewTaskRequest{
-			Content:   content,
-			Priority:  priority,
-			DueString: deadline,
-		}
-
-		// Add project ID if specified
-		if projectID != "" {
-			taskRequest.ProjectID = projectID
-		}
-
 		// Call the API to create the task
-		createdTask, err := client.CreateTask(taskRequest)
+		createdTask, err := client.CreateTask(ctx, taskRequest)
 		if err != nil {
-			// Return error message if API call fails
-			return errorMsg(err)
+			return taskCreateSentMsg{placeholderID: placeholderID, err: err}
 		}
 		// Return created task on success
-		return taskCreatedMsg(*createdTask)
+		return taskCreateSentMsg{placeholderID: placeholderID, task: *createdTask}
 	})
 }
 
-// deleteTask creates a command that deletes a task via Todoist API
-func deleteTask(client *TodoistClient, taskID string) tea.Cmd {
+// updateTaskWithDetails creates a command that saves an edited task and moves it if its project or section changed
+func updateTaskWithDetails(ctx context.Context, client *TodoistClient, taskID string, update UpdateTaskRequest, projectID, sectionID string) tea.Cmd {
 	return tea.Cmd(func() tea.Msg {
-		// Call the API to delete the task
-		err := client.DeleteTask(taskID)
+		// Call the API to update the task fields
+		updatedTask, err := client.UpdateTask(ctx, taskID, update)
 		if err != nil {
 			// Return error message if API call fails
 			return errorMsg(err)
 		}
-		// Return deleted task ID on success
-		return taskDeletedMsg(taskID)
+
+		// Move the task if a different project or section was selected
+		if err := client.PlaceTask(ctx, updatedTask, projectID, sectionID); err != nil {
+			return errorMsg(err)
+		}
+
+		// Return updated task on success
+		return taskUpdatedMsg(*updatedTask)
+	})
+}
+
+// deleteTask creates a command that deletes a task via Todoist API
+func deleteTask(ctx context.Context, client *TodoistClient, taskID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		// Call the API to delete the task, reporting whether it worked so a failed delete can be undone
+		return taskDeleteSentMsg{taskID: taskID, err: client.DeleteTask(ctx, taskID)}
 	})
 }
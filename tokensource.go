@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// keychainService is the service name the API token is stored under in the OS keychain
+const keychainService = "todoist-tui"
+
+// keychainDefaultAccount is the keychain account holding the token when no profile is used
+const keychainDefaultAccount = "default"
+
+// runTokenCommand runs a command that prints an API token, e.g. ["pass", "show", "todoist"]
+// The command's errors are shown on the terminal, since it may ask for a passphrase
+func runTokenCommand(command []string) (string, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("%s printed no token", command[0])
+	}
+	return token, nil
+}
+
+// keychainToken reads the API token stored for an account in the OS keychain
+func keychainToken(account string) (string, error) {
+	output, err := readKeychain(keychainService, account)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up service %q, account %q: %w", keychainService, account, err)
+	}
+	token := strings.TrimSpace(output)
+	if token == "" {
+		return "", fmt.Errorf("no token for service %q, account %q", keychainService, account)
+	}
+	return token, nil
+}
+
+// configuredToken reads the token from the token command or keychain set in the config
+// Returns "" without an error when neither is set
+func configuredToken(config *Config) (string, error) {
+	if config == nil {
+		return "", nil
+	}
+	if len(config.TokenCommand) > 0 {
+		token, err := runTokenCommand(config.TokenCommand)
+		if err != nil {
+			return "", fmt.Errorf("failed to run token command: %w", err)
+		}
+		return token, nil
+	}
+	if config.Keychain {
+		token, err := keychainToken(keychainDefaultAccount)
+		if err != nil {
+			return "", fmt.Errorf("failed to read token from the keychain: %w", err)
+		}
+		return token, nil
+	}
+	return "", nil
+}
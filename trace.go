@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Kinds of timings recorded by --trace
+const (
+	// traceFrame is the time View took to render a frame
+	traceFrame = "frame"
+	// traceUpdate is the time Update took to handle a message
+	traceUpdate = "update"
+	// traceAPI is the time a single Todoist API request took, including reading the response headers
+	traceAPI = "api"
+)
+
+// traceKinds lists the kinds in the order the summary reports them
+var traceKinds = []string{traceFrame, traceUpdate, traceAPI}
+
+// perfTrace writes every timing to a trace file and keeps them for a summary on exit
+// A nil trace records nothing, so the hooks cost almost nothing when tracing is off
+type perfTrace struct {
+	start time.Time
+	file  *os.File
+	out   *bufio.Writer
+
+	// mu guards out and durations, which are written from concurrent commands
+	mu        sync.Mutex
+	durations map[string][]time.Duration
+}
+
+// tracer is the active trace, set by --trace
+var tracer *perfTrace
+
+// newPerfTrace creates the trace file and writes its header
+func newPerfTrace(path string) (*perfTrace, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace file: %w", err)
+	}
+	t := &perfTrace{
+		start:     time.Now(),
+		file:      file,
+		out:       bufio.NewWriter(file),
+		durations: make(map[string][]time.Duration),
+	}
+	_, _ = fmt.Fprintln(t.out, "# offset_ms\tkind\tname\tduration_ms")
+	return t, nil
+}
+
+// observe records how long something took since it started
+// Call it deferred with the start time, e.g. defer tracer.observe(traceFrame, "view", time.Now())
+func (t *perfTrace) observe(kind, name string, start time.Time) {
+	if t == nil {
+		return
+	}
+	elapsed := time.Since(start)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.durations[kind] = append(t.durations[kind], elapsed)
+	_, _ = fmt.Fprintf(t.out, "%.1f\t%s\t%s\t%.3f\n", milliseconds(start.Sub(t.start)), kind, name, milliseconds(elapsed))
+}
+
+// observeUpdate records how long Update took to handle a message, named after the message type
+func (t *perfTrace) observeUpdate(msg tea.Msg, start time.Time) {
+	if t == nil {
+		return
+	}
+	t.observe(traceUpdate, fmt.Sprintf("%T", msg), start)
+}
+
+// observeRequest records how long an API request took, named after its method and path
+func (t *perfTrace) observeRequest(req *http.Request, start time.Time) {
+	if t == nil {
+		return
+	}
+	t.observe(traceAPI, req.Method+" "+req.URL.Path, start)
+}
+
+// summary writes the count, median, 95th percentile and slowest time of each kind
+func (t *perfTrace) summary(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Trace summary:")
+	for _, kind := range traceKinds {
+		durations := append([]time.Duration(nil), t.durations[kind]...)
+		if len(durations) == 0 {
+			_, _ = fmt.Fprintf(w, "  %-7s none recorded\n", kind)
+			continue
+		}
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		_, _ = fmt.Fprintf(w, "  %-7s %6d  p50 %8.1fms  p95 %8.1fms  max %8.1fms\n", kind, len(durations),
+			milliseconds(percentile(durations, 50)), milliseconds(percentile(durations, 95)), milliseconds(durations[len(durations)-1]))
+	}
+}
+
+// close appends the summary to the trace file, closes it and prints the summary
+func (t *perfTrace) close(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The summary is kept in the file as comments so the timings stay easy to parse
+	var summary strings.Builder
+	t.summary(&summary)
+	for _, line := range strings.Split(strings.TrimSuffix(summary.String(), "\n"), "\n") {
+		_, _ = fmt.Fprintln(t.out, "# "+line)
+	}
+	if err := t.out.Flush(); err != nil {
+		_ = t.file.Close()
+		return fmt.Errorf("failed to write trace file: %w", err)
+	}
+	if err := t.file.Close(); err != nil {
+		return fmt.Errorf("failed to close trace file: %w", err)
+	}
+
+	t.summary(w)
+	_, _ = fmt.Fprintf(w, "Trace written to %s\n", t.file.Name())
+	return nil
+}
+
+// percentile returns the p-th percentile of sorted durations, using the nearest rank
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// milliseconds converts a duration to fractional milliseconds for display
+func milliseconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// urgentPriority is the API priority shown as P1
+const urgentPriority = 4
+
+// urgentTasksLoadedMsg is sent with all open P1 tasks from the cache
+type urgentTasksLoadedMsg []TodoistTask
+
+// urgentTasks returns the P1 tasks, soonest due first and undated ones last
+func urgentTasks(tasks []TodoistTask) []TodoistTask {
+	var urgent []TodoistTask
+	for _, task := range tasks {
+		if task.Priority == urgentPriority {
+			urgent = append(urgent, task)
+		}
+	}
+	sort.SliceStable(urgent, func(i, j int) bool {
+		if urgent[i].Due == nil || urgent[j].Due == nil {
+			return urgent[j].Due == nil && urgent[i].Due != nil
+		}
+		return urgent[i].Due.Date < urgent[j].Due.Date
+	})
+	return urgent
+}
+
+// loadUrgentTasks creates a command that counts the P1 tasks in the cache
+// Returns nil when no P1 limit is configured
+func loadUrgentTasks(cache *CacheDB, config *Config) tea.Cmd {
+	if cache == nil || config == nil || config.MaxUrgentTasks <= 0 {
+		return nil
+	}
+	return tea.Cmd(func() tea.Msg {
+		tasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to count P1 tasks: %w", err))
+		}
+		return urgentTasksLoadedMsg(urgentTasks(tasks))
+	})
+}
+
+// overUrgentLimit reports whether more tasks are P1 than the configured limit allows
+func (m model) overUrgentLimit() bool {
+	return m.config != nil && m.config.MaxUrgentTasks > 0 && len(m.urgentTasks) > m.config.MaxUrgentTasks
+}
+
+// renderUrgentWarning warns when too many tasks are P1
+func (m model) renderUrgentWarning() string {
+	if !m.overUrgentLimit() {
+		return ""
+	}
+	return staleStyle.Render(fmt.Sprintf("🚨 %d tasks are P1 (limit %d) — if everything is urgent, nothing is. Press ! to triage",
+		len(m.urgentTasks), m.config.MaxUrgentTasks))
+}
+
+// openTriage shows the triage screen listing the P1 tasks
+func (m *model) openTriage() {
+	m.showingTriage = true
+	m.triageIdx = 0
+}
+
+// renderTriageScreen creates the screen for demoting P1 tasks
+func (m model) renderTriageScreen() string {
+	var content strings.Builder
+
+	// Screen title
+	content.WriteString(popupTitleStyle.Render("🚨 Triage P1 Tasks"))
+	content.WriteString("\n\n")
+
+	// How far over the limit the P1 tasks are
+	limit := m.config.MaxUrgentTasks
+	if excess := len(m.urgentTasks) - limit; excess > 0 {
+		content.WriteString(staleStyle.MarginLeft(0).Render(fmt.Sprintf("%d P1 tasks, limit %d: demote %d more", len(m.urgentTasks), limit, excess)))
+	} else {
+		content.WriteString(fmt.Sprintf("%d P1 tasks, limit %d: within the limit", len(m.urgentTasks), limit))
+	}
+	content.WriteString("\n\n")
+
+	if len(m.urgentTasks) == 0 {
+		content.WriteString("No P1 tasks")
+		content.WriteString("\n")
+	} else {
+		selected := lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor)
+		for i, task := range m.urgentTasks {
+			due := "no date"
+			if task.Due != nil {
+				due = task.Due.Date
+			}
+			line := fmt.Sprintf("%-10s  %s (%s)", due, task.Content, m.client.GetProjectName(task.ProjectID))
+			if i == m.triageIdx {
+				content.WriteString(selected.Render("→ " + line))
+			} else {
+				content.WriteString("  " + line)
+			}
+			content.WriteString("\n")
+		}
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	content.WriteString("↑/↓: select • 2/3/4: demote to P2/P3/P4 • ESC: close")
+
+	// Calculate panel width
+	maxWidth := 80
+	if m.width < 90 {
+		maxWidth = m.width - 10
+	}
+
+	return lipgloss.NewStyle().MarginLeft(2).Render(popupStyle.Width(maxWidth).Render(content.String()))
+}
+
+// handleTriageInput handles keyboard input when the triage screen is visible
+func (m model) handleTriageInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape", "!":
+		// Close the triage screen
+		m.showingTriage = false
+	case "up", "k":
+		// Move selection up
+		if m.triageIdx > 0 {
+			m.triageIdx--
+		}
+	case "down", "j":
+		// Move selection down
+		if m.triageIdx < len(m.urgentTasks)-1 {
+			m.triageIdx++
+		}
+	case "2", "3", "4":
+		// Demote the selected task; P2 is API priority 3, P4 is API priority 1
+		if m.triageIdx < 0 || m.triageIdx >= len(m.urgentTasks) {
+			return m, nil
+		}
+		task := m.urgentTasks[m.triageIdx]
+		priority := 5 - int(msg.String()[0]-'0')
+
+		// Drop it from the list right away so the next one can be triaged
+		m.urgentTasks = append(m.urgentTasks[:m.triageIdx:m.triageIdx], m.urgentTasks[m.triageIdx+1:]...)
+		if m.triageIdx >= len(m.urgentTasks) && m.triageIdx > 0 {
+			m.triageIdx--
+		}
+
+		update := UpdateTaskRequest{Priority: priority}
+		op := newOperation(opUpdate, task.ID, task.Content, operationPayload{Update: &update})
+		return m, m.writeOrQueue(op, updateTaskWithDetails(m.requests.base(), m.client, task.ID, update, "", ""))
+	}
+	return m, nil
+}
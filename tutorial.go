@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tutorialCommand is the subcommand that walks new users through the interface with fake data
+const tutorialCommand = "tutorial"
+
+// tutorialStep is one thing the tutorial asks the user to try
+type tutorialStep struct {
+	// name titles the step, e.g. "Navigate"
+	name string
+	// prompt tells what to do, with the keys bound in keys
+	prompt func(keys *keymap) string
+	// done reports whether a message has completed the step, given the model before and after handling it
+	done func(before, after model, msg tea.Msg) bool
+}
+
+// tutorialSteps are the steps of the tutorial in order; the last one only says goodbye
+var tutorialSteps = []tutorialStep{
+	{
+		name: "Navigate",
+		prompt: func(keys *keymap) string {
+			return fmt.Sprintf("Welcome! Move through the list with %s and %s to select another task",
+				keys.keyFor(keyContextMain, actionDown), keys.keyFor(keyContextMain, actionUp))
+		},
+		done: func(before, after model, msg tea.Msg) bool {
+			_, key := msg.(tea.KeyMsg)
+			return key && !before.showingCreateTask && before.selectedIndex >= 0 && after.selectedIndex != before.selectedIndex
+		},
+	},
+	{
+		name: "Complete",
+		prompt: func(keys *keymap) string {
+			return fmt.Sprintf("Press %s to complete the selected task", keys.keyFor(keyContextMain, actionComplete))
+		},
+		done: func(before, after model, msg tea.Msg) bool {
+			_, completed := msg.(taskCompletedMsg)
+			return completed || len(after.completionQueue) > len(before.completionQueue)
+		},
+	},
+	{
+		name: "Quick add",
+		prompt: func(keys *keymap) string {
+			return fmt.Sprintf("Press %s, type \"Call the plumber #Home @errand tomorrow\" and press Enter: "+
+				"#Project, @label, p1-p4 and a date at the end are picked out of the text", keys.keyFor(keyContextMain, actionNewTask))
+		},
+		done: func(before, after model, msg tea.Msg) bool {
+			sent, ok := msg.(taskCreateSentMsg)
+			return ok && sent.err == nil
+		},
+	},
+	{
+		name: "Search",
+		prompt: func(keys *keymap) string {
+			return fmt.Sprintf("Press %s, type part of a task's name, pick it with ↑/↓ and press Enter to jump to it",
+				keys.keyFor(keyContextMain, actionSearch))
+		},
+		done: func(before, after model, msg tea.Msg) bool {
+			key, ok := msg.(tea.KeyMsg)
+			return ok && key.String() == "enter" && before.showingSearch && !after.showingSearch
+		},
+	},
+	{
+		name: "Done",
+		prompt: func(keys *keymap) string {
+			return fmt.Sprintf("That's the basics! Press %s to list every key, and Ctrl+C to leave. Nothing here touched your account",
+				keys.keyFor(keyContextMain, actionHelp))
+		},
+	},
+}
+
+// advanceTutorial moves the tutorial on when a message completed its current step
+func (m *model) advanceTutorial(before model, msg tea.Msg) tea.Cmd {
+	if !m.tutorial || m.tutorialStep >= len(tutorialSteps)-1 {
+		return nil
+	}
+	step := tutorialSteps[m.tutorialStep]
+	if !step.done(before, *m, msg) {
+		return nil
+	}
+	m.tutorialStep++
+	return m.notify(toastSuccess, fmt.Sprintf("%s done, %d of %d", step.name, m.tutorialStep, len(tutorialSteps)-1))
+}
+
+// renderTutorialPrompt renders the current step of the tutorial, shown on every screen so it stays in sight
+func (m model) renderTutorialPrompt() string {
+	if !m.tutorial {
+		return ""
+	}
+	step := tutorialSteps[m.tutorialStep]
+	title := fmt.Sprintf("🎓 Tutorial %d/%d · %s: ", min(m.tutorialStep+1, len(tutorialSteps)-1), len(tutorialSteps)-1, step.name)
+	if m.tutorialStep == len(tutorialSteps)-1 {
+		title = "🎓 Tutorial · "
+	}
+	// Wrap long prompts rather than letting the terminal cut them off
+	return staleStyle.Width(max(m.width-4, 20)).Render(title + step.prompt(m.keys))
+}
+
+// runTutorial runs the tutorial subcommand: the interface with the default config, against a mock server
+// with fake tasks on a free local port and a throwaway cache, so nothing reaches a Todoist account
+func runTutorial(args []string) error {
+	flags := flag.NewFlagSet(tutorialCommand, flag.ExitOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	server, err := newMockServer(time.Now())
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to start tutorial server: %w", err)
+	}
+	defer func() { _ = listener.Close() }()
+	// The mock server logs every request, which would draw over the interface
+	log.SetOutput(io.Discard)
+	go func() { _ = http.Serve(listener, server.handler()) }()
+	if err := os.Setenv(todoistAPIURLEnv, "http://"+listener.Addr().String()); err != nil {
+		return fmt.Errorf("failed to point the tutorial at its server: %w", err)
+	}
+
+	theme, err := loadTheme("", nil)
+	if err != nil {
+		return err
+	}
+	applyTheme(theme)
+	keys, err := newKeymap(nil, nil)
+	if err != nil {
+		return err
+	}
+	pomodoroLength, err := parsePomodoroLength("")
+	if err != nil {
+		return err
+	}
+
+	initial := initialModel(safeModeConfig(), []string{"priority", "task", "project", "due"}, false)
+	initial.pomodoroLength = pomodoroLength
+	initial.someday = newSomedaySettings(nil)
+	initial.staleSweep = staleSweep{months: defaultStaleMonths, label: initial.someday.label}
+	initial.keys = keys
+	initial.tutorial = true
+
+	finalModel, err := tea.NewProgram(initial).Run()
+	if err != nil {
+		return fmt.Errorf("failed to run tutorial: %w", err)
+	}
+	if final, ok := finalModel.(model); ok {
+		final.requests.stop()
+		if final.cache != nil {
+			_ = final.cache.Close()
+		}
+	}
+	return nil
+}
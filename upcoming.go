@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// upcomingDays is the number of days shown in the upcoming view, starting today
+const upcomingDays = 7
+
+// upcomingFilter is the Todoist filter query for the tasks in the upcoming view
+// Yesterday is included since the app's today can still be Todoist's yesterday, before the day_starts_at time
+var upcomingFilter = fmt.Sprintf("yesterday | today | next %d days", upcomingDays)
+
+// upcomingTasksLoadedMsg is sent when the tasks for the upcoming view have been loaded
+type upcomingTasksLoadedMsg struct {
+	tasks     []TodoistTask
+	fromCache bool // Whether the tasks came from the cache while offline
+}
+
+// filterUpcomingTasks returns the tasks due within the next upcomingDays days, ordered by date then priority
+func filterUpcomingTasks(allTasks []TodoistTask, now time.Time) []TodoistTask {
+	today := now.Format("2006-01-02")
+	last := now.AddDate(0, 0, upcomingDays-1).Format("2006-01-02")
+
+	var upcoming []TodoistTask
+	for _, task := range allTasks {
+		// Dates in YYYY-MM-DD format compare correctly as strings
+		if task.Due != nil && task.Due.Date >= today && task.Due.Date <= last {
+			upcoming = append(upcoming, task)
+		}
+	}
+
+	sort.SliceStable(upcoming, func(i, j int) bool {
+		if upcoming[i].Due.Date != upcoming[j].Due.Date {
+			return upcoming[i].Due.Date < upcoming[j].Due.Date
+		}
+		// Higher priority first within a day
+		return upcoming[i].Priority > upcoming[j].Priority
+	})
+	return upcoming
+}
+
+// loadUpcomingTasks creates a command that fetches the tasks for the upcoming view
+func loadUpcomingTasks(ctx context.Context, client *TodoistClient) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		// Only fetch the dated tasks the view shows; the days are trimmed again to the app's timezone
+		tasks, err := client.GetFilteredTasks(ctx, upcomingFilter)
+		if err != nil {
+			return readErrorMsg(err)
+		}
+		return upcomingTasksLoadedMsg{tasks: filterUpcomingTasks(tasks, dayNow())}
+	})
+}
+
+// loadCachedUpcomingTasks creates a command that loads the upcoming tasks from the cache
+func loadCachedUpcomingTasks(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		allTasks, err := cache.LoadTasks()
+		if err != nil {
+			return errorMsg(err)
+		}
+		return upcomingTasksLoadedMsg{tasks: filterUpcomingTasks(allTasks, dayNow()), fromCache: true}
+	})
+}
+
+// upcomingDates returns the dates shown in the upcoming view in YYYY-MM-DD format
+func upcomingDates(now time.Time) []string {
+	dates := make([]string, upcomingDays)
+	for i := range dates {
+		dates[i] = now.AddDate(0, 0, i).Format("2006-01-02")
+	}
+	return dates
+}
+
+// formatDayHeader renders a date as a day header such as "Today", "Tomorrow" or "Wednesday, Oct 21"
+func formatDayHeader(date string, now time.Time) string {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return date
+	}
+	switch date {
+	case now.Format("2006-01-02"):
+		return "Today, " + day.Format("Jan 2")
+	case now.AddDate(0, 0, 1).Format("2006-01-02"):
+		return "Tomorrow, " + day.Format("Jan 2")
+	default:
+		return day.Format("Monday, Jan 2")
+	}
+}
+
+// switchToUpcoming shows the upcoming view and loads its tasks
+func (m *model) switchToUpcoming() tea.Cmd {
+	m.view = viewUpcoming
+	m.viewProjectID = ""
+	m.tasks = nil
+	m.allTasks = nil
+	m.selectedIndex = -1
+	m.hScroll = 0
+	m.loading = true
+	return loadUpcomingTasks(m.requests.replace(requestGroupView), m.client)
+}
+
+// setUpcomingTasks stores the upcoming tasks in the view's sort order, keeping tasks of collapsed days out of navigation
+func (m *model) setUpcomingTasks(tasks []TodoistTask) {
+	m.tasks = m.sortForView(m.withPendingCreates(m.hideParked(tasks)))
+	m.allTasks = nil
+	for _, task := range m.tasks {
+		if !m.collapsedDays[task.Due.Date] && m.matchesFilter(task) {
+			m.allTasks = append(m.allTasks, task)
+		}
+	}
+
+	// Keep the selection within the visible tasks
+	if len(m.allTasks) == 0 {
+		m.selectedIndex = -1
+	} else if m.selectedIndex == -1 || m.selectedIndex >= len(m.allTasks) {
+		m.selectedIndex = 0
+	}
+}
+
+// toggleDay collapses or expands the day at the given position in the upcoming view
+func (m *model) toggleDay(position int) {
+	dates := upcomingDates(dayNow())
+	if position < 0 || position >= len(dates) {
+		return
+	}
+	if m.collapsedDays == nil {
+		m.collapsedDays = make(map[string]bool)
+	}
+	date := dates[position]
+	m.collapsedDays[date] = !m.collapsedDays[date]
+	m.setUpcomingTasks(m.tasks)
+}
+
+// renderUpcoming renders the upcoming view's tasks grouped under day headers
+func (m model) renderUpcoming(b *listBuilder) {
+	now := dayNow()
+
+	// Group the tasks matching the filter by due date
+	tasksByDate := make(map[string][]TodoistTask)
+	for _, task := range m.tasks {
+		if !m.matchesFilter(task) {
+			continue
+		}
+		tasksByDate[task.Due.Date] = append(tasksByDate[task.Due.Date], task)
+	}
+
+	// Keep track of task index for selection, counting only expanded days
+	taskIndex := 0
+	for i, date := range upcomingDates(now) {
+		dayTasks := tasksByDate[date]
+
+		// Day header with its number for collapsing
+		marker := "▾"
+		if m.collapsedDays[date] {
+			marker = "▸"
+		}
+		b.WriteString(titleStyle.Render(fmt.Sprintf("%s %d. %s (%d)", marker, i+1, formatDayHeader(date, now), len(dayTasks))))
+		b.WriteString("\n")
+
+		if m.collapsedDays[date] || len(dayTasks) == 0 {
+			continue
+		}
+
+		header, separator := m.generateHeaders()
+		b.WriteString(headerStyle.Render(header))
+		b.WriteString("\n")
+		b.WriteString(headerStyle.Render(separator))
+		b.WriteString("\n")
+		for _, task := range dayTasks {
+			m.renderTask(task, b, taskIndex)
+			taskIndex++
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minListPageHeight is the fewest list lines shown per page, even in a very short terminal
+const minListPageHeight = 3
+
+// renderBufferTasks is the number of tasks rendered beyond each end of the visible page
+const renderBufferTasks = 3
+
+// lineSpan is a range of lines in the rendered task list, from start up to but not including end
+type lineSpan struct {
+	start, end int
+}
+
+// taskRange is a range of task indexes, from first up to but not including end
+type taskRange struct {
+	first, end int
+}
+
+// listBuilder collects the rendered task list, keeping track of the lines each task occupies
+type listBuilder struct {
+	strings.Builder
+	// lines is the number of complete lines written so far
+	lines int
+	// tasks holds the lines of each task, indexed by task index
+	tasks []lineSpan
+	// rendered limits which tasks are rendered in full, or nil to render every task
+	// The others are written as blank lines of the same height, so long lists stay cheap to page through
+	rendered *taskRange
+}
+
+// measuringList returns a builder that only measures tasks, for finding the pages of the list
+func measuringList() *listBuilder {
+	return &listBuilder{rendered: &taskRange{}}
+}
+
+// renders reports whether the task with the given index is rendered in full
+func (b *listBuilder) renders(taskIndex int) bool {
+	return b.rendered == nil || (taskIndex >= b.rendered.first && taskIndex < b.rendered.end)
+}
+
+// skipLines writes blank lines standing in for a task that isn't rendered
+func (b *listBuilder) skipLines(count int) {
+	b.WriteString(strings.Repeat("\n", count))
+}
+
+// WriteString appends to the list, counting the lines written
+func (b *listBuilder) WriteString(s string) (int, error) {
+	b.lines += strings.Count(s, "\n")
+	return b.Builder.WriteString(s)
+}
+
+// startTask marks the start of the next task's lines
+func (b *listBuilder) startTask() {
+	b.tasks = append(b.tasks, lineSpan{start: b.lines, end: b.lines})
+}
+
+// endTask marks the end of the current task's lines
+func (b *listBuilder) endTask() {
+	b.tasks[len(b.tasks)-1].end = b.lines
+}
+
+// pageStarts returns the first line of each page of the list, never splitting a task across pages
+// A page starts where the previous task ended, so headers above a task stay on the same page as it
+func (b *listBuilder) pageStarts(height int) []int {
+	starts := []int{0}
+	for i, task := range b.tasks {
+		current := starts[len(starts)-1]
+		if i > 0 && task.end > current+height && b.tasks[i-1].end > current {
+			starts = append(starts, b.tasks[i-1].end)
+		}
+	}
+	return starts
+}
+
+// pageOf returns the index of the page showing the given task, or the first page when there is none
+func (b *listBuilder) pageOf(starts []int, taskIndex int) int {
+	if taskIndex < 0 || taskIndex >= len(b.tasks) {
+		return 0
+	}
+	page := 0
+	for i, start := range starts {
+		if start <= b.tasks[taskIndex].start {
+			page = i
+		}
+	}
+	return page
+}
+
+// firstTaskFrom returns the index of the first task starting at or after a line, or -1 if there is none
+func (b *listBuilder) firstTaskFrom(line int) int {
+	for i, task := range b.tasks {
+		if task.start >= line {
+			return i
+		}
+	}
+	return -1
+}
+
+// listPageHeight returns how many list lines fit on screen between the text above the list and the footer
+// One line is kept for the page indicator
+func (m model) listPageHeight(top, footer string) int {
+	return max(m.height-strings.Count(top, "\n")-lipgloss.Height(footer)-1, minListPageHeight)
+}
+
+// currentPageHeight returns the list page height for the main view as it is currently shown
+func (m model) currentPageHeight() int {
+	return m.listPageHeight(m.renderHeading()+m.renderStatusLines(), m.renderFooter())
+}
+
+// tasksBetween returns the tasks with lines between start and end, widened by renderBufferTasks on both sides
+func (b *listBuilder) tasksBetween(start, end int) taskRange {
+	r := taskRange{first: len(b.tasks), end: 0}
+	for i, task := range b.tasks {
+		if task.end > start && task.start < end {
+			r.first = min(r.first, i)
+			r.end = max(r.end, i+1)
+		}
+	}
+	if r.first > r.end {
+		return taskRange{}
+	}
+	return taskRange{first: max(0, r.first-renderBufferTasks), end: min(len(b.tasks), r.end+renderBufferTasks)}
+}
+
+// renderTaskWindow renders the page of the task list holding the selected task, followed by a page indicator
+// The list is measured first, and then only the tasks on that page are rendered; lists that fit on screen are rendered whole
+func (m model) renderTaskWindow(height int) string {
+	layout := m.renderTaskList(measuringList())
+	if layout.lines <= height {
+		return m.renderTaskList(&listBuilder{}).String()
+	}
+
+	starts := layout.pageStarts(height)
+	page := layout.pageOf(starts, m.selectedIndex)
+	end := layout.lines
+	if page+1 < len(starts) {
+		end = starts[page+1]
+	}
+	end = min(end, starts[page]+height)
+
+	visible := layout.tasksBetween(starts[page], end)
+	list := m.renderTaskList(&listBuilder{rendered: &visible})
+
+	lines := strings.Split(list.String(), "\n")
+	var b strings.Builder
+	for _, line := range lines[starts[page]:end] {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	// Pad short pages so the footer stays in place while paging
+	for i := end - starts[page]; i < height; i++ {
+		b.WriteString("\n")
+	}
+	b.WriteString(loadingStyle.Render(fmt.Sprintf("Page %d/%d • PgUp/PgDn: page • Home/End: first/last task", page+1, len(starts))))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// pageSelection moves the selection to the first task of the next or previous page
+// Moving past the last page selects the last task, and before the first page the first task
+func (m *model) pageSelection(delta int) {
+	if len(m.allTasks) == 0 {
+		return
+	}
+	list := m.renderTaskList(measuringList())
+	starts := list.pageStarts(m.currentPageHeight())
+	page := list.pageOf(starts, m.selectedIndex) + delta
+
+	m.hScroll = 0
+	switch {
+	case page < 0:
+		m.selectedIndex = 0
+	case page >= len(starts):
+		m.selectedIndex = len(m.allTasks) - 1
+	default:
+		if index := list.firstTaskFrom(starts[page]); index >= 0 {
+			m.selectedIndex = index
+		} else {
+			m.selectedIndex = len(m.allTasks) - 1
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// viewMode represents which set of tasks the main list is showing
+type viewMode int
+
+const (
+	// viewToday shows today's and overdue tasks
+	viewToday viewMode = iota
+	// viewProject shows all active tasks in a single project
+	viewProject
+	// viewUpcoming shows the tasks due in the next week, grouped by day
+	viewUpcoming
+	// viewLabel shows all active tasks carrying a single label
+	viewLabel
+	// viewSomeday shows the someday/maybe backlog: tasks with the someday label or in the backlog project
+	viewSomeday
+)
+
+// projectPickerVisibleRows is the number of projects shown at once in the project picker
+const projectPickerVisibleRows = 10
+
+// projectTasksLoadedMsg is sent when the tasks of a project have been loaded from the API
+type projectTasksLoadedMsg struct {
+	projectID string
+	tasks     []TodoistTask
+	fromCache bool // Whether the tasks came from the cache while offline
+}
+
+// loadProjectTasks creates a command that fetches a project's active tasks in the background
+func loadProjectTasks(ctx context.Context, client *TodoistClient, projectID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		// Call the API to get the project's tasks
+		tasks, err := client.GetProjectTasks(ctx, projectID)
+		if err != nil {
+			// Return error message if API call fails
+			return readErrorMsg(err)
+		}
+		// Return loaded tasks on success
+		return projectTasksLoadedMsg{projectID: projectID, tasks: tasks}
+	})
+}
+
+// reloadCurrentView returns a command that reloads the tasks for the active view
+func (m model) reloadCurrentView() tea.Cmd {
+	switch m.view {
+	case viewProject:
+		return loadProjectTasks(m.requests.replace(requestGroupView), m.client, m.viewProjectID)
+	case viewUpcoming:
+		return loadUpcomingTasks(m.requests.replace(requestGroupView), m.client)
+	case viewLabel:
+		return loadLabelTasks(m.requests.replace(requestGroupView), m.client, m.viewLabelName)
+	case viewSomeday:
+		return m.reloadSomeday()
+	default:
+		// Fetch the project names alongside the tasks if they're missing
+		if !m.client.HasProjectNames() {
+			return tea.Batch(loadTasks(m.requests.replace(requestGroupView), m.client), fetchProjects(m.requests.base(), m.client, m.cache))
+		}
+		return loadTasks(m.requests.replace(requestGroupView), m.client)
+	}
+}
+
+// viewTitle returns the main title for the active view
+func (m model) viewTitle() string {
+	switch m.view {
+	case viewProject:
+		return "📁 Project: " + m.client.GetProjectName(m.viewProjectID)
+	case viewUpcoming:
+		return "🗓️ Upcoming: Next 7 Days"
+	case viewLabel:
+		return "🏷️ Label: @" + m.viewLabelName
+	case viewSomeday:
+		return "💭 Someday/Maybe"
+	default:
+		return "📋 Today's Tasks & Overdue"
+	}
+}
+
+// escapeHint describes what the back key does in the main list for the footer
+func (m model) escapeHint() string {
+	back := m.keys.keyFor(keyContextMain, actionBack)
+	switch {
+	case back == "":
+		return "ctrl+c: quit"
+	case m.view != viewToday:
+		return back + ": back to today • ctrl+c: quit"
+	}
+	return back + "/ctrl+c: quit"
+}
+
+// switchToToday returns to the today view and reloads its tasks
+func (m *model) switchToToday() tea.Cmd {
+	m.view = viewToday
+	m.viewProjectID = ""
+	// Drop the previous view's tasks so they are not diffed against today's
+	m.tasks = nil
+	m.allTasks = nil
+	m.selectedIndex = -1
+	m.hScroll = 0
+	m.loading = true
+	return loadFromCacheWithCmd(m.requests.replace(requestGroupView), m.client, m.cache)
+}
+
+// openProjectPicker shows the project list for choosing a project view
+func (m *model) openProjectPicker() {
+	m.showingProjectPicker = true
+	m.projectPickerMove = false
+	m.projectPickerSearch = ""
+	m.projectPickerResults = m.projects
+	m.projectPickerIdx = 0
+}
+
+// renderProjectPicker creates the popup listing projects to browse
+func (m model) renderProjectPicker() string {
+	var content strings.Builder
+
+	// Picker title, depending on whether a project is being browsed or selected tasks moved
+	if m.projectPickerMove {
+		content.WriteString(popupTitleStyle.Render(fmt.Sprintf("📁 Move %d Task(s) to Project", len(m.markedTaskIDs()))))
+	} else {
+		content.WriteString(popupTitleStyle.Render("📁 Browse Project"))
+	}
+	content.WriteString("\n\n")
+
+	// Search query with cursor
+	content.WriteString(popupFieldStyle.Render("Search: "))
+	content.WriteString(m.projectPickerSearch + "│")
+	content.WriteString("\n\n")
+
+	// Show a window of projects around the selection
+	results := m.projectPickerResults
+	if len(results) == 0 {
+		content.WriteString("No matching projects")
+		content.WriteString("\n")
+	} else {
+		start := 0
+		if m.projectPickerIdx >= projectPickerVisibleRows {
+			start = m.projectPickerIdx - projectPickerVisibleRows + 1
+		}
+		end := start + projectPickerVisibleRows
+		if end > len(results) {
+			end = len(results)
+		}
+		for i := start; i < end; i++ {
+			if i == m.projectPickerIdx {
+				content.WriteString(lipgloss.NewStyle().Background(selectionBgColor).Foreground(selectionFgColor).Render("→ " + results[i].Name))
+			} else {
+				content.WriteString("  " + results[i].Name)
+			}
+			content.WriteString("\n")
+		}
+		content.WriteString(fmt.Sprintf("\n(%d/%d)\n", m.projectPickerIdx+1, len(results)))
+	}
+	content.WriteString("\n")
+
+	// Instructions
+	if m.projectPickerMove {
+		content.WriteString("Type: search • ↑/↓: select • Enter: move • ESC: cancel")
+	} else {
+		content.WriteString("Type: search • ↑/↓: select • Enter: open • ESC: cancel")
+	}
+
+	// Calculate popup size and position
+	maxWidth := 50
+	if m.width < 60 {
+		maxWidth = m.width - 10
+	}
+
+	// Apply popup styling with appropriate width
+	styledPopup := popupStyle.Width(maxWidth).Render(content.String())
+
+	// Center the popup on screen
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, styledPopup)
+}
+
+// handleProjectPickerInput handles keyboard input when the project picker is visible
+func (m model) handleProjectPickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "escape":
+		// Close the picker without changing views
+		m.showingProjectPicker = false
+	case "up":
+		// Move selection up with wrap around
+		if len(m.projectPickerResults) > 0 {
+			if m.projectPickerIdx <= 0 {
+				m.projectPickerIdx = len(m.projectPickerResults) - 1
+			} else {
+				m.projectPickerIdx--
+			}
+		}
+	case "down":
+		// Move selection down with wrap around
+		if len(m.projectPickerResults) > 0 {
+			if m.projectPickerIdx >= len(m.projectPickerResults)-1 {
+				m.projectPickerIdx = 0
+			} else {
+				m.projectPickerIdx++
+			}
+		}
+	case "backspace":
+		// Remove the last search character
+		if len(m.projectPickerSearch) > 0 {
+			m.projectPickerSearch = m.projectPickerSearch[:len(m.projectPickerSearch)-1]
+			m.projectPickerResults = fuzzySearchProjects(m.projects, m.projectPickerSearch)
+			m.projectPickerIdx = 0
+		}
+	case "enter":
+		// Switch to the selected project's view and fetch its tasks
+		if m.projectPickerIdx >= 0 && m.projectPickerIdx < len(m.projectPickerResults) {
+			project := m.projectPickerResults[m.projectPickerIdx]
+			m.showingProjectPicker = false
+			// Confirm moving the selected tasks there instead of opening it
+			if m.projectPickerMove {
+				m.confirmBulk(bulkMove, project.ID, project.Name)
+				return m, nil
+			}
+			m.view = viewProject
+			m.viewProjectID = project.ID
+			m.selectedIndex = -1
+			m.hScroll = 0
+			m.loading = true
+			return m, loadProjectTasks(m.requests.replace(requestGroupView), m.client, project.ID)
+		}
+	default:
+		// Add typed characters to the search
+		if len(msg.String()) == 1 && msg.String() != "\x1b" {
+			m.projectPickerSearch += msg.String()
+			m.projectPickerResults = fuzzySearchProjects(m.projects, m.projectPickerSearch)
+			m.projectPickerIdx = 0
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// watchSnapshot holds the last seen state of a watched task
+type watchSnapshot struct {
+	// TaskID is the ID of the watched task
+	TaskID string
+	// CommentCount is the number of comments when last checked
+	CommentCount int
+	// Assignee is the assignee user ID when last checked
+	Assignee string
+	// Due is the due date/datetime when last checked
+	Due string
+	// Changed indicates a change was detected that the user has not looked at yet
+	Changed bool
+}
+
+// watchesLoadedMsg is sent with the current watch state, mapping task IDs to unseen changes
+type watchesLoadedMsg map[string]bool
+
+// snapshotTask captures the watched fields of a task
+func snapshotTask(task TodoistTask) watchSnapshot {
+	snapshot := watchSnapshot{
+		TaskID:       task.ID,
+		CommentCount: task.CommentCount,
+		Assignee:     task.Assignee,
+	}
+	if task.Due != nil {
+		snapshot.Due = strings.TrimSpace(task.Due.Date + " " + task.Due.Datetime)
+	}
+	return snapshot
+}
+
+// describeWatchChanges lists what changed between a snapshot and the current task state
+func describeWatchChanges(before, after watchSnapshot) []string {
+	var changes []string
+	if after.CommentCount > before.CommentCount {
+		changes = append(changes, fmt.Sprintf("%d new comment(s)", after.CommentCount-before.CommentCount))
+	}
+	if after.Assignee != before.Assignee {
+		changes = append(changes, "assignee changed")
+	}
+	if after.Due != before.Due {
+		changes = append(changes, "due date changed")
+	}
+	return changes
+}
+
+// LoadWatches loads all watched task snapshots from the cache
+func (c *CacheDB) LoadWatches() ([]watchSnapshot, error) {
+	rows, err := c.db.Query("SELECT task_id, comment_count, assignee, due, changed FROM watched_tasks")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var watches []watchSnapshot
+	for rows.Next() {
+		var watch watchSnapshot
+		if err := rows.Scan(&watch.TaskID, &watch.CommentCount, &watch.Assignee, &watch.Due, &watch.Changed); err != nil {
+			return nil, err
+		}
+		watches = append(watches, watch)
+	}
+
+	return watches, rows.Err()
+}
+
+// SaveWatch stores or replaces the snapshot of a watched task
+func (c *CacheDB) SaveWatch(watch watchSnapshot) error {
+	_, err := c.db.Exec(`
+		INSERT INTO watched_tasks (task_id, comment_count, assignee, due, changed)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(task_id) DO UPDATE SET
+			comment_count = excluded.comment_count,
+			assignee = excluded.assignee,
+			due = excluded.due,
+			changed = excluded.changed
+	`, watch.TaskID, watch.CommentCount, watch.Assignee, watch.Due, watch.Changed)
+	return err
+}
+
+// RemoveWatch stops watching a task
+func (c *CacheDB) RemoveWatch(taskID string) error {
+	_, err := c.db.Exec("DELETE FROM watched_tasks WHERE task_id = ?", taskID)
+	return err
+}
+
+// MarkWatchSeen clears the unseen change flag of a watched task
+func (c *CacheDB) MarkWatchSeen(taskID string) error {
+	_, err := c.db.Exec("UPDATE watched_tasks SET changed = 0 WHERE task_id = ?", taskID)
+	return err
+}
+
+// watchState converts watch snapshots into the model's map of task IDs to unseen changes
+func watchState(watches []watchSnapshot) watchesLoadedMsg {
+	state := make(watchesLoadedMsg, len(watches))
+	for _, watch := range watches {
+		state[watch.TaskID] = watch.Changed
+	}
+	return state
+}
+
+// loadWatches creates a command that reads the watched tasks from the cache
+func loadWatches(cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		watches, err := cache.LoadWatches()
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to load watched tasks: %w", err))
+		}
+		return watchState(watches)
+	})
+}
+
+// toggleWatch creates a command that starts or stops watching a task
+func toggleWatch(cache *CacheDB, task TodoistTask, watch bool) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		var err error
+		if watch {
+			err = cache.SaveWatch(snapshotTask(task))
+		} else {
+			err = cache.RemoveWatch(task.ID)
+		}
+		if err != nil {
+			return errorMsg(fmt.Errorf("failed to update watched task: %w", err))
+		}
+		return loadWatches(cache)()
+	})
+}
+
+// markWatchSeen creates a command that clears a watched task's change badge
+func markWatchSeen(cache *CacheDB, taskID string) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		if err := cache.MarkWatchSeen(taskID); err != nil {
+			return errorMsg(fmt.Errorf("failed to update watched task: %w", err))
+		}
+		return nil
+	})
+}
+
+// checkWatchedTasks creates a command that fetches watched tasks and notifies about changes
+func checkWatchedTasks(ctx context.Context, client *TodoistClient, cache *CacheDB) tea.Cmd {
+	return tea.Cmd(func() tea.Msg {
+		watches, err := cache.LoadWatches()
+		if err != nil || len(watches) == 0 {
+			return nil
+		}
+
+		// Fetch the current state of every watched task in one request
+		ids := make([]string, len(watches))
+		for i, watch := range watches {
+			ids[i] = watch.TaskID
+		}
+		tasks, err := client.GetTasksByIDs(ctx, ids)
+		if err != nil {
+			return readErrorMsg(fmt.Errorf("failed to check watched tasks: %w", err))
+		}
+		tasksByID := make(map[string]TodoistTask, len(tasks))
+		for _, task := range tasks {
+			tasksByID[task.ID] = task
+		}
+
+		// Compare each watched task with its snapshot
+		for i, watch := range watches {
+			task, ok := tasksByID[watch.TaskID]
+			if !ok {
+				continue // Completed or deleted
+			}
+			current := snapshotTask(task)
+			changes := describeWatchChanges(watch, current)
+			if len(changes) == 0 {
+				continue
+			}
+
+			// Remember the new state and flag the change until it is seen
+			current.Changed = true
+			if err := cache.SaveWatch(current); err != nil {
+				return errorMsg(fmt.Errorf("failed to update watched task: %w", err))
+			}
+			watches[i] = current
+
+			_ = sendDesktopNotification("👁 "+task.Content, strings.Join(changes, ", "))
+		}
+
+		return watchState(watches)
+	})
+}